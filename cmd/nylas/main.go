@@ -2,7 +2,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/mqasimca/nylas/internal/air"
@@ -14,6 +13,7 @@ import (
 	"github.com/mqasimca/nylas/internal/cli/config"
 	"github.com/mqasimca/nylas/internal/cli/contacts"
 	"github.com/mqasimca/nylas/internal/cli/demo"
+	"github.com/mqasimca/nylas/internal/cli/dev"
 	"github.com/mqasimca/nylas/internal/cli/email"
 	"github.com/mqasimca/nylas/internal/cli/inbound"
 	"github.com/mqasimca/nylas/internal/cli/mcp"
@@ -49,13 +49,13 @@ func main() {
 	rootCmd.AddCommand(mcp.NewMCPCmd())
 	rootCmd.AddCommand(slack.NewSlackCmd())
 	rootCmd.AddCommand(demo.NewDemoCmd())
+	rootCmd.AddCommand(dev.NewDevCmd())
 	rootCmd.AddCommand(cli.NewTUICmd())
 	rootCmd.AddCommand(ui.NewUICmd())
 	rootCmd.AddCommand(air.NewAirCmd())
 	rootCmd.AddCommand(update.NewUpdateCmd())
 
 	if err := cli.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, "Error:", err)
-		os.Exit(1)
+		os.Exit(cli.ReportError(err))
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
 )
 
 // BaseClient provides common HTTP client functionality for AI providers.
@@ -32,9 +33,7 @@ func NewBaseClient(apiKey, model, baseURL string, timeout time.Duration) *BaseCl
 		apiKey:  apiKey,
 		model:   model,
 		baseURL: baseURL,
-		client: &http.Client{
-			Timeout: timeout,
-		},
+		client:  httputil.NewClient(timeout),
 	}
 }
 
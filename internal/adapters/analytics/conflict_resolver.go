@@ -24,8 +24,22 @@ func NewConflictResolver(client CalendarClient, patterns *domain.MeetingPattern)
 	}
 }
 
+// SetPatternStore lets the resolver fall back to stored patterns when none are passed in.
+func (cr *ConflictResolver) SetPatternStore(store domain.PatternStore) {
+	cr.learner.WithStore(store)
+}
+
 // DetectConflicts analyzes a proposed event for conflicts.
 func (cr *ConflictResolver) DetectConflicts(ctx context.Context, grantID string, proposed *domain.Event, patterns *domain.MeetingPattern) (*domain.ConflictAnalysis, error) {
+	// Stored patterns get their own scorer, so concurrent calls for other
+	// grants don't share it.
+	scorer := cr.scorer
+	if patterns == nil {
+		if patterns = cr.learner.StoredPatterns(grantID); patterns != nil {
+			scorer = NewMeetingScorer(patterns)
+		}
+	}
+
 	// Get existing events around the proposed time
 	startTime := time.Unix(proposed.When.StartTime, 0)
 	endTime := time.Unix(proposed.When.EndTime, 0)
@@ -66,7 +80,7 @@ func (cr *ConflictResolver) DetectConflicts(ctx context.Context, grantID string,
 	// Generate alternative times if there are conflicts
 	var alternatives []domain.RescheduleOption
 	if len(hardConflicts) > 0 || len(softConflicts) > 2 {
-		alternatives = cr.suggestAlternatives(ctx, grantID, proposed, allEvents, patterns)
+		alternatives = cr.suggestAlternatives(ctx, grantID, proposed, allEvents, patterns, scorer)
 	}
 
 	// Generate AI recommendation
@@ -269,7 +283,7 @@ func (cr *ConflictResolver) generateConflictRecommendations(hard, soft []domain.
 }
 
 // suggestAlternatives finds better times for the meeting.
-func (cr *ConflictResolver) suggestAlternatives(ctx context.Context, grantID string, proposed *domain.Event, existing []domain.Event, patterns *domain.MeetingPattern) []domain.RescheduleOption {
+func (cr *ConflictResolver) suggestAlternatives(ctx context.Context, grantID string, proposed *domain.Event, existing []domain.Event, patterns *domain.MeetingPattern, scorer *MeetingScorer) []domain.RescheduleOption {
 	var options []domain.RescheduleOption
 
 	duration := time.Unix(proposed.When.EndTime, 0).Sub(time.Unix(proposed.When.StartTime, 0))
@@ -278,7 +292,7 @@ func (cr *ConflictResolver) suggestAlternatives(ctx context.Context, grantID str
 	// Try same day, later times
 	for i := 1; i <= 4; i++ {
 		altTime := startTime.Add(time.Duration(i) * time.Hour)
-		option := cr.evaluateAlternative(altTime, duration, proposed, existing, patterns)
+		option := cr.evaluateAlternative(altTime, duration, proposed, existing, patterns, scorer)
 		if option != nil && option.Score > 50 {
 			options = append(options, *option)
 		}
@@ -286,16 +300,16 @@ func (cr *ConflictResolver) suggestAlternatives(ctx context.Context, grantID str
 
 	// Try next day, same time
 	nextDay := startTime.AddDate(0, 0, 1)
-	option := cr.evaluateAlternative(nextDay, duration, proposed, existing, patterns)
+	option := cr.evaluateAlternative(nextDay, duration, proposed, existing, patterns, scorer)
 	if option != nil && option.Score > 50 {
 		options = append(options, *option)
 	}
 
 	// Try best time from patterns
-	if patterns != nil && cr.scorer != nil {
+	if patterns != nil && scorer != nil {
 		bestTime := cr.findBestTimeFromPatterns(startTime, duration, patterns)
 		if bestTime != nil {
-			option := cr.evaluateAlternative(*bestTime, duration, proposed, existing, patterns)
+			option := cr.evaluateAlternative(*bestTime, duration, proposed, existing, patterns, scorer)
 			if option != nil && option.Score > 50 {
 				options = append(options, *option)
 			}
@@ -320,7 +334,7 @@ func (cr *ConflictResolver) suggestAlternatives(ctx context.Context, grantID str
 }
 
 // evaluateAlternative scores an alternative time slot.
-func (cr *ConflictResolver) evaluateAlternative(startTime time.Time, duration time.Duration, proposed *domain.Event, existing []domain.Event, patterns *domain.MeetingPattern) *domain.RescheduleOption {
+func (cr *ConflictResolver) evaluateAlternative(startTime time.Time, duration time.Duration, proposed *domain.Event, existing []domain.Event, patterns *domain.MeetingPattern, scorer *MeetingScorer) *domain.RescheduleOption {
 	endTime := startTime.Add(duration)
 
 	// Create temporary event for conflict checking
@@ -341,8 +355,8 @@ func (cr *ConflictResolver) evaluateAlternative(startTime time.Time, duration ti
 
 	// Score using meeting scorer
 	score := 70 // Base score
-	if cr.scorer != nil && patterns != nil {
-		meetingScore := cr.scorer.ScoreMeetingTime(startTime, []string{}, int(duration.Minutes()))
+	if scorer != nil && patterns != nil {
+		meetingScore := scorer.ScoreMeetingTime(startTime, []string{}, int(duration.Minutes()))
 		score = meetingScore.Score
 	}
 
@@ -27,6 +27,11 @@ func NewFocusOptimizer(nylasClient ports.NylasClient) *FocusOptimizer {
 	}
 }
 
+// SetPatternStore makes the optimizer reuse and update stored patterns.
+func (f *FocusOptimizer) SetPatternStore(store domain.PatternStore) {
+	f.patternLearner.WithStore(store)
+}
+
 // AnalyzeFocusTimePatterns analyzes productivity patterns and recommends focus time blocks.
 func (f *FocusOptimizer) AnalyzeFocusTimePatterns(ctx context.Context, grantID string, settings *domain.FocusTimeSettings) (*domain.FocusTimeAnalysis, error) {
 	// Analyze calendar history to learn patterns
@@ -306,13 +311,6 @@ func (f *FocusOptimizer) timesOverlap(start1, end1, start2, end2 string) bool {
 	return s1.Before(e2) && s2.Before(e1)
 }
 
-// calculateCurrentProtection calculates currently protected focus time hours per week.
-func (f *FocusOptimizer) calculateCurrentProtection(ctx context.Context, grantID string) float64 {
-	// This would query for existing focus time blocks in the calendar
-	// For now, return 0 as placeholder
-	return 0.0
-}
-
 // generateInsights generates AI insights about focus patterns.
 func (f *FocusOptimizer) generateInsights(patterns *domain.MeetingPattern, blocks []domain.FocusTimeBlock, settings *domain.FocusTimeSettings) []string {
 	var insights []string
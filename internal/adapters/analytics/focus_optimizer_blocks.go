@@ -8,6 +8,7 @@ import (
 	"github.com/mqasimca/nylas/internal/domain"
 )
 
+// CreateProtectedBlocks creates calendar events for recommended focus blocks.
 func (f *FocusOptimizer) CreateProtectedBlocks(ctx context.Context, grantID string, blocks []domain.FocusTimeBlock, settings *domain.FocusTimeSettings) ([]*domain.ProtectedBlock, error) {
 	// Get user's primary calendar
 	calendars, err := f.calendarClient.GetCalendars(ctx, grantID)
@@ -22,6 +23,18 @@ func (f *FocusOptimizer) CreateProtectedBlocks(ctx context.Context, grantID stri
 	// Use primary calendar (first calendar)
 	calendarID := calendars[0].ID
 
+	// Skip slots that already have a focus block from an earlier run
+	now := time.Now()
+	existing := make(map[string]bool)
+	focus, err := f.existingFocusBlocks(ctx, grantID, now, now.AddDate(0, 0, 8))
+	if err != nil {
+		return nil, fmt.Errorf("find existing focus blocks: %w", err)
+	}
+	for _, event := range focus {
+		day := time.Unix(event.When.StartTime, 0).Format("2006-01-02")
+		existing[day+" "+event.Metadata[domain.FocusBlockMetadataStart]] = true
+	}
+
 	var protectedBlocks []*domain.ProtectedBlock
 
 	for _, block := range blocks {
@@ -29,7 +42,11 @@ func (f *FocusOptimizer) CreateProtectedBlocks(ctx context.Context, grantID stri
 		startTime := f.nextOccurrence(block.DayOfWeek, block.StartTime)
 		endTime := f.nextOccurrence(block.DayOfWeek, block.EndTime)
 
-		// Create calendar event for focus time
+		if existing[startTime.Format("2006-01-02")+" "+block.StartTime] {
+			continue
+		}
+
+		// Create calendar event for focus time, tagged so later runs can find it
 		eventReq := &domain.CreateEventRequest{
 			Title:       "Focus Time",
 			Description: block.Reason,
@@ -37,7 +54,8 @@ func (f *FocusOptimizer) CreateProtectedBlocks(ctx context.Context, grantID stri
 				StartTime: startTime.Unix(),
 				EndTime:   endTime.Unix(),
 			},
-			Busy: true, // Show as busy
+			Busy:     true, // Show as busy
+			Metadata: focusBlockMetadata(block),
 		}
 
 		event, err := f.nylasClient.CreateEvent(ctx, grantID, calendarID, eventReq)
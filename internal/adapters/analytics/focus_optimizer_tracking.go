@@ -0,0 +1,383 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// focusReconcileDays is how far ahead focus blocks are reconciled.
+const focusReconcileDays = 14
+
+// defaultMinFocusMinutes is the smallest block kept when settings don't specify one.
+const defaultMinFocusMinutes = 60
+
+// focusEventsPageSize is how many events are requested per page.
+const focusEventsPageSize = 200
+
+// interval is a half-open time range.
+type interval struct {
+	start, end time.Time
+}
+
+func (i interval) duration() time.Duration {
+	return i.end.Sub(i.start)
+}
+
+func eventInterval(e *domain.Event) interval {
+	return interval{start: time.Unix(e.When.StartTime, 0), end: time.Unix(e.When.EndTime, 0)}
+}
+
+// focusBlockMetadata returns the metadata that tags an event as a focus block.
+func focusBlockMetadata(block domain.FocusTimeBlock) map[string]string {
+	return map[string]string{
+		domain.FocusBlockMetadataKey:   "true",
+		domain.FocusBlockMetadataDay:   block.DayOfWeek,
+		domain.FocusBlockMetadataStart: block.StartTime,
+		domain.FocusBlockMetadataEnd:   block.EndTime,
+	}
+}
+
+// fetchFocusEvents returns focus blocks and other busy, timed events in a range.
+func (f *FocusOptimizer) fetchFocusEvents(ctx context.Context, grantID string, start, end time.Time) (focus, busy []domain.Event, err error) {
+	calendars, err := f.calendarClient.GetCalendars(ctx, grantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get calendars: %w", err)
+	}
+
+	for _, calendar := range calendars {
+		events, err := f.listEvents(ctx, grantID, calendar.ID, start, end)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get events for calendar %s: %w", calendar.ID, err)
+		}
+		for _, event := range events {
+			if event.CalendarID == "" {
+				event.CalendarID = calendar.ID
+			}
+			if event.Status == "cancelled" || event.When.StartTime == 0 {
+				continue
+			}
+			switch {
+			case event.IsFocusBlock():
+				focus = append(focus, event)
+			case event.Busy:
+				busy = append(busy, event)
+			}
+		}
+	}
+
+	sort.Slice(focus, func(i, j int) bool { return focus[i].When.StartTime < focus[j].When.StartTime })
+	return focus, busy, nil
+}
+
+// listEvents pages through every event in a calendar between start and end.
+func (f *FocusOptimizer) listEvents(ctx context.Context, grantID, calendarID string, start, end time.Time) ([]domain.Event, error) {
+	params := &domain.EventQueryParams{
+		Start:           start.Unix(),
+		End:             end.Unix(),
+		Limit:           focusEventsPageSize,
+		ExpandRecurring: true,
+	}
+
+	var events []domain.Event
+	for {
+		page, err := f.nylasClient.GetEventsWithCursor(ctx, grantID, calendarID, params)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page.Data...)
+		if page.Pagination.NextCursor == "" || page.Pagination.NextCursor == params.PageToken {
+			return events, nil
+		}
+		params.PageToken = page.Pagination.NextCursor
+	}
+}
+
+// existingFocusBlocks returns focus blocks between start and end.
+func (f *FocusOptimizer) existingFocusBlocks(ctx context.Context, grantID string, start, end time.Time) ([]domain.Event, error) {
+	focus, _, err := f.fetchFocusEvents(ctx, grantID, start, end)
+	return focus, err
+}
+
+// weekBounds returns Monday 00:00 of the week containing t and the following Monday.
+func weekBounds(t time.Time) (time.Time, time.Time) {
+	offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+	start := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 0, 7)
+}
+
+// protectedTime returns how much of the focus blocks is not overlapped by meetings.
+func protectedTime(focus, busy []domain.Event) time.Duration {
+	var busyIntervals []interval
+	for i := range busy {
+		busyIntervals = append(busyIntervals, eventInterval(&busy[i]))
+	}
+
+	var total time.Duration
+	for i := range focus {
+		for _, free := range subtractIntervals(eventInterval(&focus[i]), busyIntervals) {
+			total += free.duration()
+		}
+	}
+	return total
+}
+
+// calculateCurrentProtection calculates currently protected focus time hours per week.
+func (f *FocusOptimizer) calculateCurrentProtection(ctx context.Context, grantID string) float64 {
+	start, end := weekBounds(time.Now())
+	focus, busy, err := f.fetchFocusEvents(ctx, grantID, start, end)
+	if err != nil {
+		return 0.0
+	}
+	return protectedTime(focus, busy).Hours()
+}
+
+// FocusTimeStatus reports this week's protected focus time, optionally
+// reconciling upcoming blocks first.
+func (f *FocusOptimizer) FocusTimeStatus(ctx context.Context, grantID string, settings *domain.FocusTimeSettings, reconcile, apply bool) (*domain.FocusTimeStatus, error) {
+	status := &domain.FocusTimeStatus{TargetHours: settings.TargetHoursPerWeek}
+
+	if reconcile {
+		changes, err := f.ReconcileFocusBlocks(ctx, grantID, settings, apply)
+		if err != nil {
+			return nil, err
+		}
+		status.Changes = changes
+	}
+
+	status.WeekStart, status.WeekEnd = weekBounds(time.Now())
+	focus, busy, err := f.fetchFocusEvents(ctx, grantID, status.WeekStart, status.WeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	status.ProtectedHours = protectedTime(focus, busy).Hours()
+	for i := range focus {
+		status.Blocks = append(status.Blocks, protectedBlockFromEvent(&focus[i]))
+	}
+	return status, nil
+}
+
+// protectedBlockFromEvent converts a tagged focus event to a ProtectedBlock.
+func protectedBlockFromEvent(event *domain.Event) domain.ProtectedBlock {
+	span := eventInterval(event)
+	return domain.ProtectedBlock{
+		ID:                event.ID,
+		CalendarEventID:   event.ID,
+		StartTime:         span.start,
+		EndTime:           span.end,
+		Duration:          int(span.duration().Minutes()),
+		RecurrencePattern: "weekly",
+		Priority:          domain.PriorityHigh,
+		Reason:            event.Description,
+		CreatedAt:         event.CreatedAt,
+		UpdatedAt:         event.UpdatedAt,
+	}
+}
+
+// ReconcileFocusBlocks checks upcoming focus blocks against the meetings
+// around them. Blocks are trimmed or moved when meetings land on them,
+// extended back toward their target slot when room frees up, and removed
+// when no usable time is left that day. Changes are only written to the
+// calendar when apply is true.
+func (f *FocusOptimizer) ReconcileFocusBlocks(ctx context.Context, grantID string, settings *domain.FocusTimeSettings, apply bool) ([]domain.FocusBlockChange, error) {
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	focus, busy, err := f.fetchFocusEvents(ctx, grantID, dayStart, dayStart.AddDate(0, 0, focusReconcileDays))
+	if err != nil {
+		return nil, err
+	}
+
+	minDuration := time.Duration(defaultMinFocusMinutes) * time.Minute
+	var workingHours *domain.WorkingHoursConfig
+	if settings != nil {
+		if settings.MinBlockDuration > 0 {
+			minDuration = time.Duration(settings.MinBlockDuration) * time.Minute
+		}
+		workingHours = settings.WorkingHours
+	}
+
+	var changes []domain.FocusBlockChange
+	for i := range focus {
+		block := &focus[i]
+		current := eventInterval(block)
+		if current.start.Before(now) {
+			continue // Don't move a block that has already started
+		}
+
+		// Other focus blocks count as busy when looking for room
+		var blocking []interval
+		for j := range busy {
+			blocking = append(blocking, eventInterval(&busy[j]))
+		}
+		for j := range focus {
+			if j != i {
+				blocking = append(blocking, eventInterval(&focus[j]))
+			}
+		}
+
+		change := planFocusBlock(block, current, focusTargetSlot(block, current),
+			workdaySlot(current.start, workingHours), blocking, minDuration, now)
+		if change.Action == domain.FocusBlockKept {
+			continue
+		}
+
+		if apply {
+			if err := f.applyFocusBlockChange(ctx, grantID, &change); err != nil {
+				return changes, err
+			}
+			if change.Action != domain.FocusBlockRemoved {
+				block.When.StartTime = change.NewStart.Unix()
+				block.When.EndTime = change.NewEnd.Unix()
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// focusTargetSlot returns the slot a block was originally created for on its day.
+func focusTargetSlot(block *domain.Event, current interval) interval {
+	start, errStart := time.Parse("15:04", block.Metadata[domain.FocusBlockMetadataStart])
+	end, errEnd := time.Parse("15:04", block.Metadata[domain.FocusBlockMetadataEnd])
+	if errStart != nil || errEnd != nil || !end.After(start) {
+		return current
+	}
+
+	day := current.start
+	return interval{
+		start: time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, day.Location()),
+		end:   time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, day.Location()),
+	}
+}
+
+// workdaySlot returns the working hours on day, or an empty interval when
+// day isn't a working day.
+func workdaySlot(day time.Time, hours *domain.WorkingHoursConfig) interval {
+	schedule := hours.GetScheduleForDay(day.Weekday().String())
+	if schedule == nil || !schedule.Enabled {
+		return interval{start: day, end: day}
+	}
+	start, errStart := time.Parse("15:04", schedule.Start)
+	end, errEnd := time.Parse("15:04", schedule.End)
+	if errStart != nil || errEnd != nil || !end.After(start) {
+		start, _ = time.Parse("15:04", domain.DefaultWorkingHours().Start)
+		end, _ = time.Parse("15:04", domain.DefaultWorkingHours().End)
+	}
+	return interval{
+		start: time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, day.Location()),
+		end:   time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, day.Location()),
+	}
+}
+
+// planFocusBlock decides how a single focus block should change. Time
+// before now counts as busy, so a block is never moved into the past.
+func planFocusBlock(block *domain.Event, current, target, workday interval, blocking []interval, minDuration time.Duration, now time.Time) domain.FocusBlockChange {
+	blocking = append(blocking[:len(blocking):len(blocking)], interval{end: now})
+
+	change := domain.FocusBlockChange{
+		EventID:    block.ID,
+		CalendarID: block.CalendarID,
+		Action:     domain.FocusBlockKept,
+		OldStart:   current.start,
+		OldEnd:     current.end,
+	}
+
+	// Prefer the largest free stretch of the original target slot
+	if best, ok := largestInterval(subtractIntervals(target, blocking)); ok && best.duration() >= minDuration {
+		if best.start.Equal(current.start) && best.end.Equal(current.end) {
+			return change
+		}
+		change.NewStart, change.NewEnd = best.start, best.end
+		if best.duration() > current.duration() {
+			change.Action = domain.FocusBlockExtended
+			change.Reason = "more of the target slot is free"
+		} else {
+			change.Action = domain.FocusBlockShrunk
+			change.Reason = "a meeting overlaps the focus block"
+		}
+		return change
+	}
+
+	// Otherwise look for room elsewhere in the working day
+	want := target.duration()
+	for _, free := range subtractIntervals(workday, blocking) {
+		if free.duration() < minDuration {
+			continue
+		}
+		length := min(want, free.duration())
+		change.Action = domain.FocusBlockMoved
+		change.NewStart, change.NewEnd = free.start, free.start.Add(length)
+		change.Reason = "meetings fill the original slot"
+		return change
+	}
+
+	change.Action = domain.FocusBlockRemoved
+	change.Reason = "no free time left for focus work that day"
+	return change
+}
+
+// applyFocusBlockChange writes a reconciliation decision to the calendar.
+func (f *FocusOptimizer) applyFocusBlockChange(ctx context.Context, grantID string, change *domain.FocusBlockChange) error {
+	if change.Action == domain.FocusBlockRemoved {
+		if err := f.nylasClient.DeleteEvent(ctx, grantID, change.CalendarID, change.EventID); err != nil {
+			return fmt.Errorf("remove focus block %s: %w", change.EventID, err)
+		}
+		change.Applied = true
+		return nil
+	}
+
+	req := &domain.UpdateEventRequest{
+		When: &domain.EventWhen{
+			StartTime: change.NewStart.Unix(),
+			EndTime:   change.NewEnd.Unix(),
+		},
+	}
+	if _, err := f.nylasClient.UpdateEvent(ctx, grantID, change.CalendarID, change.EventID, req); err != nil {
+		return fmt.Errorf("update focus block %s: %w", change.EventID, err)
+	}
+	change.Applied = true
+	return nil
+}
+
+// subtractIntervals returns the parts of base not covered by any of busy, in order.
+func subtractIntervals(base interval, busy []interval) []interval {
+	sorted := make([]interval, 0, len(busy))
+	for _, b := range busy {
+		if b.end.After(base.start) && b.start.Before(base.end) {
+			sorted = append(sorted, b)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+
+	var free []interval
+	cursor := base.start
+	for _, b := range sorted {
+		if b.start.After(cursor) {
+			free = append(free, interval{start: cursor, end: b.start})
+		}
+		if b.end.After(cursor) {
+			cursor = b.end
+		}
+	}
+	if base.end.After(cursor) {
+		free = append(free, interval{start: cursor, end: base.end})
+	}
+	return free
+}
+
+// largestInterval returns the longest interval, preferring the earliest on ties.
+func largestInterval(intervals []interval) (interval, bool) {
+	var best interval
+	found := false
+	for _, i := range intervals {
+		if !found || i.duration() > best.duration() {
+			best, found = i, true
+		}
+	}
+	return best, found
+}
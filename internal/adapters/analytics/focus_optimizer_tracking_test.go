@@ -0,0 +1,296 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// focusEvent builds a tagged focus block event.
+func focusEvent(id string, start, end time.Time, targetStart, targetEnd string) domain.Event {
+	return domain.Event{
+		ID:         id,
+		CalendarID: "cal-1",
+		Busy:       true,
+		When:       domain.EventWhen{StartTime: start.Unix(), EndTime: end.Unix()},
+		Metadata: map[string]string{
+			domain.FocusBlockMetadataKey:   "true",
+			domain.FocusBlockMetadataDay:   start.Weekday().String(),
+			domain.FocusBlockMetadataStart: targetStart,
+			domain.FocusBlockMetadataEnd:   targetEnd,
+		},
+	}
+}
+
+func busyEvent(id string, start, end time.Time) domain.Event {
+	return domain.Event{
+		ID:   id,
+		Busy: true,
+		When: domain.EventWhen{StartTime: start.Unix(), EndTime: end.Unix()},
+	}
+}
+
+// at returns hour:minute on the day of base.
+func at(base time.Time, hour, minute int) time.Time {
+	return time.Date(base.Year(), base.Month(), base.Day(), hour, minute, 0, 0, base.Location())
+}
+
+func TestSubtractIntervals(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	base := interval{start: at(day, 9, 0), end: at(day, 12, 0)}
+
+	free := subtractIntervals(base, []interval{
+		{start: at(day, 10, 0), end: at(day, 10, 30)},
+		{start: at(day, 10, 15), end: at(day, 11, 0)}, // overlaps the previous one
+		{start: at(day, 13, 0), end: at(day, 14, 0)},  // outside base
+	})
+
+	if len(free) != 2 {
+		t.Fatalf("subtractIntervals() returned %d intervals, want 2", len(free))
+	}
+	if free[0].duration() != time.Hour || free[1].duration() != time.Hour {
+		t.Errorf("subtractIntervals() = %v, want 9-10 and 11-12", free)
+	}
+}
+
+func TestProtectedTime(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	focus := []domain.Event{
+		focusEvent("f1", at(day, 9, 0), at(day, 11, 0), "09:00", "11:00"),
+	}
+	busy := []domain.Event{busyEvent("m1", at(day, 10, 0), at(day, 10, 30))}
+
+	if got := protectedTime(focus, busy); got != 90*time.Minute {
+		t.Errorf("protectedTime() = %v, want 1h30m", got)
+	}
+}
+
+func TestWeekBounds(t *testing.T) {
+	wednesday := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+	start, end := weekBounds(wednesday)
+
+	if start.Weekday() != time.Monday || start.Day() != 10 || start.Hour() != 0 {
+		t.Errorf("weekBounds() start = %v, want Monday Mar 10 00:00", start)
+	}
+	if end.Sub(start) != 7*24*time.Hour {
+		t.Errorf("weekBounds() span = %v, want 7 days", end.Sub(start))
+	}
+}
+
+func TestPlanFocusBlock(t *testing.T) {
+	day := time.Now().AddDate(0, 0, 2)
+	minDuration := time.Hour
+
+	tests := []struct {
+		name     string
+		current  interval
+		blocking []interval
+		want     domain.FocusBlockAction
+	}{
+		{
+			name:    "unchanged when slot is free",
+			current: interval{start: at(day, 9, 0), end: at(day, 11, 0)},
+			want:    domain.FocusBlockKept,
+		},
+		{
+			name:     "shrunk around a meeting",
+			current:  interval{start: at(day, 9, 0), end: at(day, 11, 0)},
+			blocking: []interval{{start: at(day, 9, 0), end: at(day, 9, 30)}},
+			want:     domain.FocusBlockShrunk,
+		},
+		{
+			name:    "extended back to target",
+			current: interval{start: at(day, 9, 30), end: at(day, 11, 0)},
+			want:    domain.FocusBlockExtended,
+		},
+		{
+			name:     "moved when target slot is full",
+			current:  interval{start: at(day, 9, 0), end: at(day, 11, 0)},
+			blocking: []interval{{start: at(day, 9, 0), end: at(day, 11, 0)}},
+			want:     domain.FocusBlockMoved,
+		},
+		{
+			name:     "removed when the day is full",
+			current:  interval{start: at(day, 9, 0), end: at(day, 11, 0)},
+			blocking: []interval{{start: at(day, 8, 0), end: at(day, 18, 0)}},
+			want:     domain.FocusBlockRemoved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := focusEvent("f1", tt.current.start, tt.current.end, "09:00", "11:00")
+			target := focusTargetSlot(&block, tt.current)
+
+			change := planFocusBlock(&block, tt.current, target, workdaySlot(day, nil), tt.blocking, minDuration, time.Now())
+			if change.Action != tt.want {
+				t.Errorf("planFocusBlock() action = %s, want %s (%s)", change.Action, tt.want, change.Reason)
+			}
+		})
+	}
+}
+
+func TestPlanFocusBlock_MovesWithinWorkingHoursAndNotIntoThePast(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local) // Monday
+	current := interval{start: at(day, 14, 0), end: at(day, 16, 0)}
+	block := focusEvent("f1", current.start, current.end, "14:00", "16:00")
+	blocking := []interval{{start: at(day, 14, 0), end: at(day, 16, 0)}}
+	hours := &domain.WorkingHoursConfig{Default: &domain.DaySchedule{Enabled: true, Start: "08:00", End: "18:00"}}
+
+	// At 12:30 the morning is gone, so the block moves to 12:30 rather than 8:00.
+	now := at(day, 12, 30)
+	change := planFocusBlock(&block, current, current, workdaySlot(day, hours), blocking, time.Hour, now)
+	if change.Action != domain.FocusBlockMoved || !change.NewStart.Equal(now) {
+		t.Errorf("planFocusBlock() = %s %v, want moved to %v", change.Action, change.NewStart, now)
+	}
+
+	// Later in the day, only the configured evening hour is left.
+	now = at(day, 13, 30)
+	blocking = append(blocking, interval{start: at(day, 13, 30), end: at(day, 14, 0)})
+	change = planFocusBlock(&block, current, current, workdaySlot(day, hours), blocking, time.Hour, now)
+	if change.Action != domain.FocusBlockMoved || !change.NewStart.Equal(at(day, 16, 0)) || !change.NewEnd.Equal(at(day, 18, 0)) {
+		t.Errorf("planFocusBlock() = %s %v-%v, want moved to 16:00-18:00", change.Action, change.NewStart, change.NewEnd)
+	}
+
+	// Non-working days leave nowhere to move to.
+	hours.Monday = &domain.DaySchedule{Enabled: false}
+	change = planFocusBlock(&block, current, current, workdaySlot(day, hours), blocking, time.Hour, now)
+	if change.Action != domain.FocusBlockRemoved {
+		t.Errorf("planFocusBlock() action = %s, want removed", change.Action)
+	}
+}
+
+func TestFocusOptimizer_FetchFocusEvents(t *testing.T) {
+	day := time.Now().AddDate(0, 0, 2)
+	client := nylas.NewMockClient()
+	client.GetCalendarsFunc = func(ctx context.Context, grantID string) ([]domain.Calendar, error) {
+		return []domain.Calendar{{ID: "cal-1"}}, nil
+	}
+
+	t.Run("pages through all events", func(t *testing.T) {
+		client.GetEventsWithCursorFunc = func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+			if params.PageToken == "" {
+				return &domain.EventListResponse{
+					Data:       []domain.Event{busyEvent("meeting-1", at(day, 9, 0), at(day, 10, 0))},
+					Pagination: domain.Pagination{NextCursor: "page-2", HasMore: true},
+				}, nil
+			}
+			return &domain.EventListResponse{
+				Data: []domain.Event{focusEvent("focus-1", at(day, 13, 0), at(day, 15, 0), "13:00", "15:00")},
+			}, nil
+		}
+
+		focus, busy, err := NewFocusOptimizer(client).fetchFocusEvents(context.Background(), "grant-1", day, day.AddDate(0, 0, 1))
+		if err != nil {
+			t.Fatalf("fetchFocusEvents() error = %v", err)
+		}
+		if len(focus) != 1 || len(busy) != 1 {
+			t.Errorf("fetchFocusEvents() = %d focus, %d busy; want 1 and 1", len(focus), len(busy))
+		}
+	})
+
+	t.Run("fails when a calendar can't be read", func(t *testing.T) {
+		client.GetEventsWithCursorFunc = func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+			return nil, errors.New("rate limited")
+		}
+
+		if _, err := NewFocusOptimizer(client).ReconcileFocusBlocks(context.Background(), "grant-1", nil, true); err == nil {
+			t.Error("ReconcileFocusBlocks() should fail when events can't be fetched")
+		}
+	})
+}
+
+func TestFocusOptimizer_ReconcileFocusBlocks(t *testing.T) {
+	day := time.Now().AddDate(0, 0, 2)
+	events := []domain.Event{
+		focusEvent("focus-1", at(day, 9, 0), at(day, 11, 0), "09:00", "11:00"),
+		busyEvent("meeting-1", at(day, 9, 0), at(day, 9, 30)),
+	}
+
+	client := nylas.NewMockClient()
+	client.GetCalendarsFunc = func(ctx context.Context, grantID string) ([]domain.Calendar, error) {
+		return []domain.Calendar{{ID: "cal-1"}}, nil
+	}
+	client.GetEventsFunc = func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error) {
+		return events, nil
+	}
+
+	var updated *domain.UpdateEventRequest
+	client.UpdateEventFunc = func(ctx context.Context, grantID, calendarID, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error) {
+		updated = req
+		return &domain.Event{ID: eventID}, nil
+	}
+
+	optimizer := NewFocusOptimizer(client)
+	settings := &domain.FocusTimeSettings{MinBlockDuration: 60}
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		changes, err := optimizer.ReconcileFocusBlocks(context.Background(), "grant-1", settings, false)
+		if err != nil {
+			t.Fatalf("ReconcileFocusBlocks() error = %v", err)
+		}
+		if len(changes) != 1 || changes[0].Applied {
+			t.Fatalf("ReconcileFocusBlocks() = %+v, want one unapplied change", changes)
+		}
+		if updated != nil {
+			t.Error("dry run should not update events")
+		}
+	})
+
+	t.Run("apply trims the block", func(t *testing.T) {
+		changes, err := optimizer.ReconcileFocusBlocks(context.Background(), "grant-1", settings, true)
+		if err != nil {
+			t.Fatalf("ReconcileFocusBlocks() error = %v", err)
+		}
+		if len(changes) != 1 || !changes[0].Applied || changes[0].Action != domain.FocusBlockShrunk {
+			t.Fatalf("ReconcileFocusBlocks() = %+v, want one applied shrink", changes)
+		}
+		if updated == nil || updated.When.StartTime != at(day, 9, 30).Unix() {
+			t.Errorf("expected block to start at 9:30, got %+v", updated)
+		}
+	})
+}
+
+func TestFocusOptimizer_CreateProtectedBlocks_TagsAndSkipsExisting(t *testing.T) {
+	client := nylas.NewMockClient()
+	optimizer := NewFocusOptimizer(client)
+
+	block := domain.FocusTimeBlock{DayOfWeek: "Tuesday", StartTime: "09:00", EndTime: "11:00", Duration: 120}
+	start := optimizer.nextOccurrence(block.DayOfWeek, block.StartTime)
+	end := optimizer.nextOccurrence(block.DayOfWeek, block.EndTime)
+
+	var existing []domain.Event
+	client.GetCalendarsFunc = func(ctx context.Context, grantID string) ([]domain.Calendar, error) {
+		return []domain.Calendar{{ID: "cal-1"}}, nil
+	}
+	client.GetEventsFunc = func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error) {
+		return existing, nil
+	}
+
+	var created []*domain.CreateEventRequest
+	client.CreateEventFunc = func(ctx context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error) {
+		created = append(created, req)
+		return &domain.Event{ID: "evt-new"}, nil
+	}
+
+	settings := &domain.FocusTimeSettings{}
+	if _, err := optimizer.CreateProtectedBlocks(context.Background(), "grant-1", []domain.FocusTimeBlock{block}, settings); err != nil {
+		t.Fatalf("CreateProtectedBlocks() error = %v", err)
+	}
+	if len(created) != 1 || created[0].Metadata[domain.FocusBlockMetadataKey] != "true" {
+		t.Fatalf("expected one tagged focus block, got %+v", created)
+	}
+
+	existing = []domain.Event{focusEvent("focus-1", start, end, "09:00", "11:00")}
+	blocks, err := optimizer.CreateProtectedBlocks(context.Background(), "grant-1", []domain.FocusTimeBlock{block}, settings)
+	if err != nil {
+		t.Fatalf("CreateProtectedBlocks() error = %v", err)
+	}
+	if len(blocks) != 0 || len(created) != 1 {
+		t.Errorf("existing block should not be recreated; created %d", len(created))
+	}
+}
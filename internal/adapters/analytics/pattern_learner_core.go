@@ -18,8 +18,16 @@ type CalendarClient interface {
 type PatternLearner struct {
 	client       CalendarClient
 	workingHours *domain.DaySchedule
+	store        domain.PatternStore
 }
 
+// patternSyncOverlap is how far before the last sync incremental fetches start,
+// so events edited or cancelled shortly before the sync are picked up again.
+const patternSyncOverlap = 24 * time.Hour
+
+// patternFreshness is how long stored patterns are reused without fetching new events.
+const patternFreshness = 6 * time.Hour
+
 // NewPatternLearner creates a new pattern learner.
 func NewPatternLearner(client CalendarClient) *PatternLearner {
 	return &PatternLearner{
@@ -35,6 +43,13 @@ func NewPatternLearnerWithWorkingHours(client CalendarClient, workingHours *doma
 	}
 }
 
+// WithStore enables persisting learned patterns in store and returns the learner.
+// With a store, AnalyzeHistory only fetches events added since the last run.
+func (p *PatternLearner) WithStore(store domain.PatternStore) *PatternLearner {
+	p.store = store
+	return p
+}
+
 // getWorkingHoursRange returns the start and end hours based on config or defaults.
 func (p *PatternLearner) getWorkingHoursRange() (startHour, endHour int) {
 	// Default working hours: 9-17
@@ -74,8 +89,8 @@ func (p *PatternLearner) AnalyzeHistory(ctx context.Context, grantID string, day
 	end := time.Now()
 	start := end.AddDate(0, 0, -days)
 
-	// Fetch events from the period
-	events, err := p.fetchEvents(ctx, grantID, start, end)
+	// Fetch events from the period, reusing stored samples where possible
+	events, err := p.syncEvents(ctx, grantID, start, end)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch events: %w", err)
 	}
@@ -90,6 +105,7 @@ func (p *PatternLearner) AnalyzeHistory(ctx context.Context, grantID string, day
 
 	// Learn patterns from events
 	patterns := p.learnPatterns(events, start, end)
+	p.saveProfile(grantID, start, end, patterns, events)
 
 	// Generate recommendations
 	recommendations := p.generateRecommendations(patterns, events)
@@ -108,6 +124,104 @@ func (p *PatternLearner) AnalyzeHistory(ctx context.Context, grantID string, day
 	return analysis, nil
 }
 
+// Patterns returns learned patterns for a grant, reusing stored patterns
+// that are recent enough and cover the requested period.
+func (p *PatternLearner) Patterns(ctx context.Context, grantID string, days int) (*domain.MeetingPattern, error) {
+	if profile := p.loadProfile(grantID); profile != nil && profile.Patterns != nil {
+		start := time.Now().AddDate(0, 0, -days)
+		if time.Since(profile.SyncedAt) < patternFreshness && !profile.CoveredFrom.After(start) {
+			return profile.Patterns, nil
+		}
+	}
+
+	analysis, err := p.AnalyzeHistory(ctx, grantID, days)
+	if err != nil {
+		return nil, err
+	}
+	return analysis.Patterns, nil
+}
+
+// StoredPatterns returns the stored patterns for a grant without fetching events.
+func (p *PatternLearner) StoredPatterns(grantID string) *domain.MeetingPattern {
+	if profile := p.loadProfile(grantID); profile != nil {
+		return profile.Patterns
+	}
+	return nil
+}
+
+// syncEvents returns the events between start and end. With a stored profile
+// covering the period, only events since the last sync are fetched and merged.
+func (p *PatternLearner) syncEvents(ctx context.Context, grantID string, start, end time.Time) ([]domain.Event, error) {
+	profile := p.loadProfile(grantID)
+	if profile == nil || profile.CoveredFrom.After(start) {
+		return p.fetchEvents(ctx, grantID, start, end)
+	}
+
+	fetchStart := profile.SyncedAt.Add(-patternSyncOverlap)
+	if fetchStart.Before(start) {
+		fetchStart = start
+	}
+	recent, err := p.fetchEvents(ctx, grantID, fetchStart, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep stored samples inside the window that predate the refetched range;
+	// anything newer is replaced by the fresh copy so deletions are honoured.
+	seen := make(map[string]bool, len(recent))
+	events := make([]domain.Event, 0, len(profile.Samples)+len(recent))
+	for _, event := range recent {
+		if event.ID != "" {
+			if seen[event.ID] {
+				continue
+			}
+			seen[event.ID] = true
+		}
+		events = append(events, event)
+	}
+	for _, sample := range profile.Samples {
+		startTime := time.Unix(sample.When.StartTime, 0)
+		if startTime.Before(start) || !startTime.Before(fetchStart) || seen[sample.ID] {
+			continue
+		}
+		events = append(events, sample)
+	}
+	return events, nil
+}
+
+// loadProfile returns the stored profile for a grant, or nil.
+func (p *PatternLearner) loadProfile(grantID string) *domain.PatternProfile {
+	if p.store == nil {
+		return nil
+	}
+	profile, err := p.store.LoadProfile(grantID)
+	if err != nil {
+		// A corrupt profile is rebuilt from the API
+		return nil
+	}
+	return profile
+}
+
+// saveProfile persists learned patterns and their samples. Failures are
+// non-fatal: the analysis is still valid, it just won't be reused.
+func (p *PatternLearner) saveProfile(grantID string, start, end time.Time, patterns *domain.MeetingPattern, events []domain.Event) {
+	if p.store == nil {
+		return
+	}
+	samples := make([]domain.Event, 0, len(events))
+	for _, event := range events {
+		samples = append(samples, compactEvent(event))
+	}
+	_ = p.store.SaveProfile(&domain.PatternProfile{
+		Version:     domain.PatternProfileVersion,
+		GrantID:     grantID,
+		CoveredFrom: start,
+		SyncedAt:    end,
+		Patterns:    patterns,
+		Samples:     samples,
+	})
+}
+
 // fetchEvents retrieves events for the specified period.
 func (p *PatternLearner) fetchEvents(ctx context.Context, grantID string, start, end time.Time) ([]domain.Event, error) {
 	// Get all calendars for the grant
@@ -0,0 +1,139 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// PatternFileStore stores pattern profiles as one JSON file per grant.
+type PatternFileStore struct {
+	dir string
+}
+
+// NewPatternFileStore creates a pattern store rooted at dir.
+func NewPatternFileStore(dir string) *PatternFileStore {
+	return &PatternFileStore{dir: dir}
+}
+
+// NewDefaultPatternFileStore creates a pattern store in the default location.
+func NewDefaultPatternFileStore() *PatternFileStore {
+	return NewPatternFileStore(DefaultPatternDir())
+}
+
+// DefaultPatternDir returns the default pattern profile directory.
+func DefaultPatternDir() string {
+	return filepath.Join(config.DefaultConfigDir(), "patterns")
+}
+
+// Dir returns the directory profiles are stored in.
+func (s *PatternFileStore) Dir() string {
+	return s.dir
+}
+
+// path returns the profile file for a grant.
+func (s *PatternFileStore) path(grantID string) string {
+	// Grant IDs are opaque; keep them from escaping the store directory.
+	name := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(grantID)
+	return filepath.Join(s.dir, name+".json")
+}
+
+// LoadProfile loads the profile for a grant. A missing or outdated profile yields nil, nil.
+func (s *PatternFileStore) LoadProfile(grantID string) (*domain.PatternProfile, error) {
+	// #nosec G304 -- path is derived from the pattern store directory
+	f, err := os.Open(s.path(grantID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening pattern profile: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	profile, err := ReadPatternProfile(f)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Version != domain.PatternProfileVersion {
+		return nil, nil
+	}
+	return profile, nil
+}
+
+// SaveProfile writes a profile atomically.
+func (s *PatternFileStore) SaveProfile(profile *domain.PatternProfile) error {
+	if profile == nil || profile.GrantID == "" {
+		return fmt.Errorf("pattern profile requires a grant ID")
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("creating pattern directory: %w", err)
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("marshaling pattern profile: %w", err)
+	}
+
+	path := s.path(profile.GrantID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("writing pattern profile: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("saving pattern profile: %w", err)
+	}
+	return nil
+}
+
+// DeleteProfile removes the profile for a grant.
+func (s *PatternFileStore) DeleteProfile(grantID string) error {
+	if err := os.Remove(s.path(grantID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("deleting pattern profile: %w", err)
+	}
+	return nil
+}
+
+// ReadPatternProfile decodes a profile, e.g. from an exported file.
+func ReadPatternProfile(r io.Reader) (*domain.PatternProfile, error) {
+	var profile domain.PatternProfile
+	if err := json.NewDecoder(r).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("parsing pattern profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// WritePatternProfile encodes a profile as indented JSON for export.
+func WritePatternProfile(w io.Writer, profile *domain.PatternProfile) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(profile)
+}
+
+// compactEvent keeps only the fields pattern learning reads.
+func compactEvent(event domain.Event) domain.Event {
+	compact := domain.Event{
+		ID:         event.ID,
+		CalendarID: event.CalendarID,
+		Status:     event.Status,
+		When: domain.EventWhen{
+			StartTime:     event.When.StartTime,
+			EndTime:       event.When.EndTime,
+			StartTimezone: event.When.StartTimezone,
+			EndTimezone:   event.When.EndTimezone,
+		},
+	}
+	for _, p := range event.Participants {
+		compact.Participants = append(compact.Participants, domain.Participant{
+			Person: domain.Person{Email: p.Email},
+			Status: p.Status,
+		})
+	}
+	return compact
+}
@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestPatternFileStore_RoundTrip(t *testing.T) {
+	store := NewPatternFileStore(t.TempDir())
+
+	profile, err := store.LoadProfile("grant-1")
+	if err != nil || profile != nil {
+		t.Fatalf("LoadProfile() on empty store = %v, %v; want nil, nil", profile, err)
+	}
+
+	saved := &domain.PatternProfile{
+		Version:  domain.PatternProfileVersion,
+		GrantID:  "grant-1",
+		SyncedAt: time.Now(),
+		Patterns: &domain.MeetingPattern{Acceptance: domain.AcceptancePatterns{Overall: 0.8}},
+		Samples:  []domain.Event{{ID: "evt-1"}},
+	}
+	if err := store.SaveProfile(saved); err != nil {
+		t.Fatalf("SaveProfile() error = %v", err)
+	}
+
+	loaded, err := store.LoadProfile("grant-1")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+	if loaded == nil || loaded.Patterns.Acceptance.Overall != 0.8 || len(loaded.Samples) != 1 {
+		t.Errorf("LoadProfile() = %+v, want saved profile", loaded)
+	}
+
+	if err := store.DeleteProfile("grant-1"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if loaded, _ := store.LoadProfile("grant-1"); loaded != nil {
+		t.Error("LoadProfile() after delete should return nil")
+	}
+}
+
+func TestPatternFileStore_IgnoresOtherVersions(t *testing.T) {
+	store := NewPatternFileStore(t.TempDir())
+	if err := store.SaveProfile(&domain.PatternProfile{Version: 99, GrantID: "grant-1"}); err != nil {
+		t.Fatalf("SaveProfile() error = %v", err)
+	}
+
+	profile, err := store.LoadProfile("grant-1")
+	if err != nil || profile != nil {
+		t.Errorf("LoadProfile() = %v, %v; want nil, nil for unknown version", profile, err)
+	}
+}
+
+func TestPatternFileStore_PathStaysInDir(t *testing.T) {
+	dir := t.TempDir()
+	store := NewPatternFileStore(dir)
+	if err := store.SaveProfile(&domain.PatternProfile{Version: domain.PatternProfileVersion, GrantID: "../escape"}); err != nil {
+		t.Fatalf("SaveProfile() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected profile inside store dir, found %d entries", len(entries))
+	}
+}
+
+func TestPatternProfile_ExportImport(t *testing.T) {
+	profile := &domain.PatternProfile{
+		Version:  domain.PatternProfileVersion,
+		GrantID:  "grant-1",
+		Patterns: &domain.MeetingPattern{UserEmail: "user@example.com"},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePatternProfile(&buf, profile); err != nil {
+		t.Fatalf("WritePatternProfile() error = %v", err)
+	}
+	read, err := ReadPatternProfile(&buf)
+	if err != nil {
+		t.Fatalf("ReadPatternProfile() error = %v", err)
+	}
+	if read.GrantID != "grant-1" || read.Patterns.UserEmail != "user@example.com" {
+		t.Errorf("ReadPatternProfile() = %+v, want exported profile", read)
+	}
+}
+
+func TestPatternLearner_IncrementalSync(t *testing.T) {
+	now := time.Now()
+	old := createTestEvent("old", now.AddDate(0, 0, -30), 30, "confirmed")
+	recent := createTestEvent("recent", now.Add(-2*time.Hour), 30, "confirmed")
+
+	var fetchStarts []int64
+	events := []domain.Event{old}
+	client := &testNylasClient{
+		getCalendarsFunc: func(ctx context.Context, grantID string) ([]domain.Calendar, error) {
+			return []domain.Calendar{{ID: "cal_1"}}, nil
+		},
+		getEventsFunc: func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error) {
+			fetchStarts = append(fetchStarts, params.Start)
+			var inRange []domain.Event
+			for _, e := range events {
+				if e.When.StartTime >= params.Start && e.When.StartTime <= params.End {
+					inRange = append(inRange, e)
+				}
+			}
+			return inRange, nil
+		},
+	}
+
+	store := NewPatternFileStore(t.TempDir())
+	learner := NewPatternLearner(client).WithStore(store)
+	ctx := context.Background()
+
+	first, err := learner.AnalyzeHistory(ctx, "grant-1", 90)
+	if err != nil {
+		t.Fatalf("first AnalyzeHistory() error = %v", err)
+	}
+	if first.TotalMeetings != 1 {
+		t.Fatalf("first run TotalMeetings = %d, want 1", first.TotalMeetings)
+	}
+
+	// A new event arrives; the second run should only fetch recent history
+	events = append(events, recent)
+	second, err := learner.AnalyzeHistory(ctx, "grant-1", 90)
+	if err != nil {
+		t.Fatalf("second AnalyzeHistory() error = %v", err)
+	}
+	if second.TotalMeetings != 2 {
+		t.Errorf("second run TotalMeetings = %d, want 2 (stored + new)", second.TotalMeetings)
+	}
+	if len(fetchStarts) != 2 {
+		t.Fatalf("expected 2 fetches, got %d", len(fetchStarts))
+	}
+	if fetchStarts[1] <= old.When.StartTime {
+		t.Error("second run should fetch only events since the last sync")
+	}
+
+	// Fresh stored patterns are reused without another fetch
+	patterns, err := learner.Patterns(ctx, "grant-1", 90)
+	if err != nil || patterns == nil {
+		t.Fatalf("Patterns() = %v, %v", patterns, err)
+	}
+	if len(fetchStarts) != 2 {
+		t.Errorf("Patterns() refetched events; fetches = %d, want 2", len(fetchStarts))
+	}
+
+	// A longer window than the stored profile covers forces a full fetch
+	if _, err := learner.AnalyzeHistory(ctx, "grant-1", 180); err != nil {
+		t.Fatalf("AnalyzeHistory(180) error = %v", err)
+	}
+	if fetchStarts[2] > now.AddDate(0, 0, -179).Unix() {
+		t.Error("wider window should refetch the full period")
+	}
+}
@@ -24,18 +24,14 @@ func NewDefaultFileStore() *FileStore {
 	return NewFileStore(DefaultConfigPath())
 }
 
-// DefaultConfigPath returns the default config file path.
+// DefaultConfigPath returns the config file path for the active profile.
 func DefaultConfigPath() string {
-	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
-		return filepath.Join(xdg, "nylas", "config.yaml")
-	}
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".config", "nylas", "config.yaml")
+	return filepath.Join(DefaultConfigDir(), "config.yaml")
 }
 
-// DefaultConfigDir returns the default config directory.
+// DefaultConfigDir returns the config directory for the active profile.
 func DefaultConfigDir() string {
-	return filepath.Dir(DefaultConfigPath())
+	return ProfileDir(ActiveProfile())
 }
 
 // Load loads the configuration from the file.
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is the profile used when none is selected. Its config and
+// secrets live at the original, top-level locations, so setups from before
+// profiles existed keep working.
+const DefaultProfile = "default"
+
+// currentProfileFile holds the name chosen with `nylas config profile use`.
+const currentProfileFile = "current_profile"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// profileOverride is set from the --profile flag.
+var profileOverride string
+
+// SetProfile selects the profile for this process, taking precedence over
+// NYLAS_PROFILE and the saved current profile.
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// ActiveProfile returns the profile in use: --profile, then NYLAS_PROFILE,
+// then the one saved with `nylas config profile use`.
+func ActiveProfile() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if env := os.Getenv("NYLAS_PROFILE"); env != "" {
+		return env
+	}
+	return CurrentProfile()
+}
+
+// CurrentProfile returns the saved current profile, or DefaultProfile.
+func CurrentProfile() string {
+	data, err := os.ReadFile(filepath.Join(BaseConfigDir(), currentProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	if name := strings.TrimSpace(string(data)); name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// SetCurrentProfile saves the profile used when neither --profile nor
+// NYLAS_PROFILE is set.
+func SetCurrentProfile(name string) error {
+	path := filepath.Join(BaseConfigDir(), currentProfileFile)
+	if name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(BaseConfigDir(), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(name+"\n"), 0600)
+}
+
+// BaseConfigDir returns the top-level config directory, which holds the
+// default profile and the profiles/ directory.
+func BaseConfigDir() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "nylas")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "nylas")
+}
+
+// ProfileDir returns the config directory for a profile.
+func ProfileDir(name string) string {
+	if name == "" || name == DefaultProfile {
+		return BaseConfigDir()
+	}
+	return filepath.Join(BaseConfigDir(), "profiles", name)
+}
+
+// NewProfileFileStore creates a FileStore for a profile's config file.
+func NewProfileFileStore(name string) *FileStore {
+	return NewFileStore(filepath.Join(ProfileDir(name), "config.yaml"))
+}
+
+// ValidateProfileName checks that a profile name is safe to use as a
+// directory and keyring service name.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ProfileExists reports whether a profile has been created. The default
+// profile always exists.
+func ProfileExists(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	if ValidateProfileName(name) != nil {
+		return false
+	}
+	info, err := os.Stat(ProfileDir(name))
+	return err == nil && info.IsDir()
+}
+
+// ListProfiles returns the default profile followed by every created
+// profile, sorted by name.
+func ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(BaseConfigDir(), "profiles"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil && entry.Name() != DefaultProfile {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// CreateProfile creates an empty profile directory.
+func CreateProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if ProfileExists(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	return os.MkdirAll(ProfileDir(name), 0700)
+}
+
+// DeleteProfile removes a profile's config directory. If it was the current
+// profile, the default profile becomes current.
+func DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return errors.New("the default profile can't be deleted")
+	}
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	if err := os.RemoveAll(ProfileDir(name)); err != nil {
+		return err
+	}
+	if CurrentProfile() == name {
+		return SetCurrentProfile(DefaultProfile)
+	}
+	return nil
+}
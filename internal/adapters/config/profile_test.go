@@ -0,0 +1,143 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// useTempConfigHome points the config directory at a temp dir and clears any
+// profile selection for the test.
+func useTempConfigHome(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("NYLAS_PROFILE", "")
+	SetProfile("")
+	t.Cleanup(func() { SetProfile("") })
+	return filepath.Join(dir, "nylas")
+}
+
+func TestActiveProfile_Precedence(t *testing.T) {
+	base := useTempConfigHome(t)
+
+	if got := ActiveProfile(); got != DefaultProfile {
+		t.Errorf("ActiveProfile() = %q, want %q", got, DefaultProfile)
+	}
+	if got := DefaultConfigPath(); got != filepath.Join(base, "config.yaml") {
+		t.Errorf("DefaultConfigPath() = %q, want the top-level config", got)
+	}
+
+	for _, name := range []string{"saved", "env", "flag"} {
+		if err := CreateProfile(name); err != nil {
+			t.Fatalf("CreateProfile(%q) error = %v", name, err)
+		}
+	}
+
+	if err := SetCurrentProfile("saved"); err != nil {
+		t.Fatalf("SetCurrentProfile() error = %v", err)
+	}
+	if got := ActiveProfile(); got != "saved" {
+		t.Errorf("ActiveProfile() = %q, want saved", got)
+	}
+
+	t.Setenv("NYLAS_PROFILE", "env")
+	if got := ActiveProfile(); got != "env" {
+		t.Errorf("ActiveProfile() = %q, want env", got)
+	}
+
+	SetProfile("flag")
+	if got := ActiveProfile(); got != "flag" {
+		t.Errorf("ActiveProfile() = %q, want flag", got)
+	}
+	if got := DefaultConfigDir(); got != filepath.Join(base, "profiles", "flag") {
+		t.Errorf("DefaultConfigDir() = %q, want the flag profile's directory", got)
+	}
+}
+
+func TestProfileStoresAreSeparate(t *testing.T) {
+	useTempConfigHome(t)
+
+	if err := NewDefaultFileStore().Save(&domain.Config{Region: "us", DefaultGrant: "prod-grant"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := CreateProfile("staging"); err != nil {
+		t.Fatalf("CreateProfile() error = %v", err)
+	}
+	if err := NewProfileFileStore("staging").Save(&domain.Config{Region: "eu", API: &domain.APIConfig{BaseURL: "https://staging.example.com"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	SetProfile("staging")
+	cfg, err := NewDefaultFileStore().Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Region != "eu" || cfg.DefaultGrant != "" || cfg.API == nil || cfg.API.BaseURL != "https://staging.example.com" {
+		t.Errorf("staging profile loaded %+v", cfg)
+	}
+
+	SetProfile(DefaultProfile)
+	cfg, err = NewDefaultFileStore().Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DefaultGrant != "prod-grant" {
+		t.Errorf("default profile DefaultGrant = %q, want prod-grant", cfg.DefaultGrant)
+	}
+}
+
+func TestListAndDeleteProfiles(t *testing.T) {
+	useTempConfigHome(t)
+
+	for _, name := range []string{"prod-eu", "staging"} {
+		if err := CreateProfile(name); err != nil {
+			t.Fatalf("CreateProfile(%q) error = %v", name, err)
+		}
+	}
+	if err := CreateProfile("staging"); err == nil {
+		t.Error("CreateProfile() of an existing profile should fail")
+	}
+
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if want := []string{"default", "prod-eu", "staging"}; !slices.Equal(names, want) {
+		t.Errorf("ListProfiles() = %v, want %v", names, want)
+	}
+
+	if err := SetCurrentProfile("staging"); err != nil {
+		t.Fatalf("SetCurrentProfile() error = %v", err)
+	}
+	if err := DeleteProfile("staging"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if ProfileExists("staging") {
+		t.Error("staging should no longer exist")
+	}
+	if got := CurrentProfile(); got != DefaultProfile {
+		t.Errorf("CurrentProfile() after deleting it = %q, want default", got)
+	}
+	if err := DeleteProfile(DefaultProfile); err == nil {
+		t.Error("DeleteProfile(default) should fail")
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"prod", "staging-eu", "team_2"} {
+		if err := ValidateProfileName(name); err != nil {
+			t.Errorf("ValidateProfileName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "Prod", "../etc", "a/b", "-x", "has space"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("ValidateProfileName(%q) should fail", name)
+		}
+		if ProfileExists(name) {
+			t.Errorf("ProfileExists(%q) should be false", name)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package keyring
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+)
+
+// DefaultAgentTTL is how long the unlock agent keeps a vault key.
+const DefaultAgentTTL = 8 * time.Hour
+
+// agentTimeout bounds a single request to the agent.
+const agentTimeout = 2 * time.Second
+
+// AgentSocketPath returns the unlock agent's socket: NYLAS_AGENT_SOCK, or
+// agent.sock in the top-level config directory.
+func AgentSocketPath() string {
+	if path := os.Getenv("NYLAS_AGENT_SOCK"); path != "" {
+		return path
+	}
+	return filepath.Join(config.BaseConfigDir(), "agent.sock")
+}
+
+// agentRequest is one request to the agent, sent as a JSON line.
+type agentRequest struct {
+	Op    string `json:"op"`              // get, put, lock, status or stop
+	Vault string `json:"vault,omitempty"` // Vault file the key unlocks
+	Key   []byte `json:"key,omitempty"`
+	TTL   int64  `json:"ttl,omitempty"` // Seconds; 0 uses the agent's default
+}
+
+// agentResponse is the agent's JSON line reply.
+type agentResponse struct {
+	Key    []byte `json:"key,omitempty"`
+	Vaults int    `json:"vaults,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// agentKey is an unlocked vault key held by the agent.
+type agentKey struct {
+	key     []byte
+	expires time.Time
+}
+
+// Agent holds unlocked vault keys in memory so a passphrase is asked for
+// once per session. It never sees the passphrase itself, and keys expire
+// after their TTL.
+type Agent struct {
+	ttl  time.Duration
+	mu   sync.Mutex
+	keys map[string]agentKey
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewAgent creates an agent that keeps keys for ttl unless a request asks
+// for less.
+func NewAgent(ttl time.Duration) *Agent {
+	if ttl <= 0 {
+		ttl = DefaultAgentTTL
+	}
+	return &Agent{ttl: ttl, keys: make(map[string]agentKey), stop: make(chan struct{})}
+}
+
+// ListenAgent listens on the agent socket. A socket left behind by an agent
+// that's no longer running is replaced; a live one is an error.
+func ListenAgent(path string) (net.Listener, error) {
+	if AgentRunning(path) {
+		return nil, fmt.Errorf("an agent is already running on %s", path)
+	}
+	_ = os.Remove(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	return listenSocket(path)
+}
+
+// Serve handles connections on l until ctx is done or a client asks the
+// agent to stop. Held keys are wiped on return.
+func (a *Agent) Serve(ctx context.Context, l net.Listener) error {
+	defer a.lockAll()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-a.stop:
+		}
+		_ = l.Close()
+	}()
+
+	purge := time.NewTicker(time.Minute)
+	defer purge.Stop()
+	go func() {
+		for {
+			select {
+			case <-purge.C:
+				a.purgeExpired()
+			case <-a.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-a.stop:
+				return nil
+			default:
+				return err
+			}
+		}
+		go a.serveConn(conn)
+	}
+}
+
+// serveConn answers one request.
+func (a *Agent) serveConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(agentTimeout))
+
+	var req agentRequest
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err == nil {
+		err = json.Unmarshal(line, &req)
+	}
+	resp := agentResponse{}
+	if err != nil {
+		resp.Error = "invalid request"
+	} else {
+		resp = a.handle(req)
+	}
+
+	data, _ := json.Marshal(resp)
+	_, _ = conn.Write(append(data, '\n'))
+}
+
+// handle carries out a request.
+func (a *Agent) handle(req agentRequest) agentResponse {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch req.Op {
+	case "get":
+		held, ok := a.keys[req.Vault]
+		if !ok || time.Now().After(held.expires) {
+			return agentResponse{Error: "locked"}
+		}
+		return agentResponse{Key: held.key}
+	case "put":
+		if req.Vault == "" || len(req.Key) == 0 {
+			return agentResponse{Error: "put needs a vault and key"}
+		}
+		ttl := a.ttl
+		if req.TTL > 0 {
+			ttl = time.Duration(req.TTL) * time.Second
+		}
+		a.keys[req.Vault] = agentKey{key: req.Key, expires: time.Now().Add(ttl)}
+		return agentResponse{}
+	case "lock":
+		for vault, held := range a.keys {
+			wipe(held.key)
+			delete(a.keys, vault)
+		}
+		return agentResponse{}
+	case "status":
+		return agentResponse{Vaults: len(a.keys)}
+	case "stop":
+		a.once.Do(func() { close(a.stop) })
+		return agentResponse{}
+	default:
+		return agentResponse{Error: fmt.Sprintf("unknown op %q", req.Op)}
+	}
+}
+
+// purgeExpired drops keys past their TTL.
+func (a *Agent) purgeExpired() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	for vault, held := range a.keys {
+		if now.After(held.expires) {
+			wipe(held.key)
+			delete(a.keys, vault)
+		}
+	}
+}
+
+// lockAll wipes every held key.
+func (a *Agent) lockAll() {
+	a.handle(agentRequest{Op: "lock"})
+}
+
+// wipe zeroes a key in memory.
+func wipe(key []byte) {
+	for i := range key {
+		key[i] = 0
+	}
+}
+
+// callAgent sends one request to the agent at path.
+func callAgent(path string, req agentRequest) (agentResponse, error) {
+	conn, err := net.DialTimeout("unix", path, agentTimeout)
+	if err != nil {
+		return agentResponse{}, err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(agentTimeout))
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return agentResponse{}, err
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return agentResponse{}, err
+	}
+
+	var resp agentResponse
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return agentResponse{}, err
+	}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return agentResponse{}, err
+	}
+	if resp.Error != "" {
+		return resp, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+// AgentRunning reports whether an agent answers on path.
+func AgentRunning(path string) bool {
+	_, err := callAgent(path, agentRequest{Op: "status"})
+	return err == nil
+}
+
+// AgentStatus returns how many vault keys the agent holds.
+func AgentStatus(path string) (int, error) {
+	resp, err := callAgent(path, agentRequest{Op: "status"})
+	return resp.Vaults, err
+}
+
+// AgentLock makes the agent forget every key.
+func AgentLock(path string) error {
+	_, err := callAgent(path, agentRequest{Op: "lock"})
+	return err
+}
+
+// AgentStop shuts the agent down.
+func AgentStop(path string) error {
+	_, err := callAgent(path, agentRequest{Op: "stop"})
+	return err
+}
+
+// agentGetKey returns the agent's key for a vault, or nil.
+func agentGetKey(path, vault string) []byte {
+	resp, err := callAgent(path, agentRequest{Op: "get", Vault: vault})
+	if err != nil {
+		return nil
+	}
+	return resp.Key
+}
+
+// agentPutKey hands a vault key to the agent for ttl (0 for its default).
+func agentPutKey(path, vault string, key []byte, ttl time.Duration) error {
+	_, err := callAgent(path, agentRequest{Op: "put", Vault: vault, Key: key, TTL: int64(ttl / time.Second)})
+	return err
+}
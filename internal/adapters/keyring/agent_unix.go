@@ -0,0 +1,17 @@
+//go:build !windows
+
+package keyring
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenSocket listens on a Unix socket that only the owner can connect
+// to. The umask is set before the socket exists, so there is no moment
+// when another user could connect.
+func listenSocket(path string) (net.Listener, error) {
+	old := syscall.Umask(0177)
+	defer syscall.Umask(old)
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package keyring
+
+import "net"
+
+// listenSocket listens on a Unix socket. Windows has no umask; the socket
+// inherits the ACL of the user's config directory.
+func listenSocket(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,133 @@
+package keyring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// Backends lists the secret store backends that can be selected.
+var Backends = []string{
+	domain.SecretBackendKeyring,
+	domain.SecretBackendFile,
+	domain.SecretBackendPassphrase,
+	domain.SecretBackendPass,
+	domain.SecretBackendHelper,
+}
+
+// BackendOptions configures NewBackend.
+type BackendOptions struct {
+	Profile   string
+	ConfigDir string
+	Secrets   *domain.SecretsConfig // pass prefix and helper settings; may be nil
+	Prompt    PassphrasePrompt      // Asks for the passphrase backend's passphrase; nil fails when locked
+}
+
+// NewBackend creates the named secret store backend.
+func NewBackend(name string, opts BackendOptions) (ports.SecretStore, error) {
+	secrets := opts.Secrets
+	if secrets == nil {
+		secrets = &domain.SecretsConfig{}
+	}
+
+	switch name {
+	case domain.SecretBackendKeyring:
+		return NewProfileKeyring(opts.Profile), nil
+	case domain.SecretBackendFile:
+		return NewEncryptedFileStore(opts.ConfigDir)
+	case domain.SecretBackendPassphrase:
+		return NewPassphraseStore(opts.ConfigDir, opts.Prompt), nil
+	case domain.SecretBackendPass:
+		prefix := secrets.PassPrefix
+		if prefix == "" {
+			profile := opts.Profile
+			if profile == "" {
+				profile = config.DefaultProfile
+			}
+			prefix = "nylas/" + profile
+		}
+		return NewPassStore(prefix), nil
+	case domain.SecretBackendHelper:
+		return NewHelperStore(secrets.Helper, opts.Profile)
+	default:
+		return nil, fmt.Errorf("unknown secret backend %q (valid: keyring, file, passphrase, pass, helper)", name)
+	}
+}
+
+// OpenProfileSecretStore opens a profile's secret store. The backend comes
+// from the profile's secrets.backend setting; when that's unset, the system
+// keyring is used if available, falling back to the encrypted file. prompt
+// asks for a vault passphrase and may be nil.
+func OpenProfileSecretStore(profile, configDir string, prompt PassphrasePrompt) (ports.SecretStore, error) {
+	cfg, err := config.NewFileStore(filepath.Join(configDir, "config.yaml")).Load()
+	if err == nil && cfg.Secrets != nil && cfg.Secrets.Backend != "" {
+		store, err := NewBackend(cfg.Secrets.Backend, BackendOptions{
+			Profile:   profile,
+			ConfigDir: configDir,
+			Secrets:   cfg.Secrets,
+			Prompt:    prompt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !store.IsAvailable() {
+			return nil, fmt.Errorf("%w: %s is not available on this system", domain.ErrSecretStoreFailed, store.Name())
+		}
+		return store, nil
+	}
+	return newAutoSecretStore(profile, configDir)
+}
+
+// SecretKeys returns the names of every secret the CLI keeps in store:
+// credentials, the grant list and each grant's token.
+func SecretKeys(store ports.SecretStore) []string {
+	keys := []string{ports.KeyAPIKey, ports.KeyClientID, ports.KeyClientSecret, ports.KeyOrgID, ports.KeySlackUserToken, grantsKey, defaultGrantKey}
+
+	data, err := store.Get(grantsKey)
+	if err != nil {
+		return keys
+	}
+	var grants []domain.GrantInfo
+	if json.Unmarshal([]byte(data), &grants) == nil {
+		for _, grant := range grants {
+			keys = append(keys, ports.GrantTokenKey(grant.ID))
+		}
+	}
+	return keys
+}
+
+// MigrateSecrets copies every secret from one store to another and returns
+// the keys copied. The source is left as it is; see DeleteSecrets.
+func MigrateSecrets(from, to ports.SecretStore) ([]string, error) {
+	var copied []string
+	for _, key := range SecretKeys(from) {
+		value, err := from.Get(key)
+		if errors.Is(err, domain.ErrSecretNotFound) || (err == nil && value == "") {
+			continue
+		}
+		if err != nil {
+			return copied, fmt.Errorf("reading %s from %s: %w", key, from.Name(), err)
+		}
+		if err := to.Set(key, value); err != nil {
+			return copied, fmt.Errorf("writing %s to %s: %w", key, to.Name(), err)
+		}
+		copied = append(copied, key)
+	}
+	return copied, nil
+}
+
+// DeleteSecrets removes keys from a store, such as the source of a
+// migration once the new backend is in use.
+func DeleteSecrets(store ports.SecretStore, keys []string) error {
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			return fmt.Errorf("removing %s from %s: %w", key, store.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package keyring_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/adapters/keyring"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// agentSocket returns a socket path short enough for Unix socket limits and
+// points NYLAS_AGENT_SOCK at it.
+func agentSocket(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "nyl")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "a.sock")
+	t.Setenv("NYLAS_AGENT_SOCK", path)
+	return path
+}
+
+func TestPassphraseStore(t *testing.T) {
+	agentSocket(t) // No agent running
+	dir := t.TempDir()
+	t.Setenv("NYLAS_PASSPHRASE", "correct horse")
+
+	store := keyring.NewPassphraseStore(dir, nil)
+	assert.False(t, store.Exists())
+
+	require.NoError(t, store.Set(ports.KeyAPIKey, "nyk_secret"))
+	assert.True(t, store.Exists())
+
+	value, err := keyring.NewPassphraseStore(dir, nil).Get(ports.KeyAPIKey)
+	require.NoError(t, err)
+	assert.Equal(t, "nyk_secret", value)
+
+	data, err := os.ReadFile(store.Path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "nyk_secret")
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		t.Setenv("NYLAS_PASSPHRASE", "wrong")
+		_, err := keyring.NewPassphraseStore(dir, nil).Get(ports.KeyAPIKey)
+		assert.ErrorIs(t, err, domain.ErrSecretStoreLocked)
+	})
+
+	t.Run("locked without passphrase or prompt", func(t *testing.T) {
+		t.Setenv("NYLAS_PASSPHRASE", "")
+		_, err := keyring.NewPassphraseStore(dir, nil).Get(ports.KeyAPIKey)
+		assert.ErrorIs(t, err, domain.ErrSecretStoreLocked)
+	})
+
+	t.Run("prompt", func(t *testing.T) {
+		t.Setenv("NYLAS_PASSPHRASE", "")
+		prompt := func(string) (string, error) { return "correct horse", nil }
+		value, err := keyring.NewPassphraseStore(dir, prompt).Get(ports.KeyAPIKey)
+		require.NoError(t, err)
+		assert.Equal(t, "nyk_secret", value)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ports.KeyAPIKey))
+		_, err := store.Get(ports.KeyAPIKey)
+		assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+	})
+}
+
+func TestAgent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix sockets")
+	}
+	path := agentSocket(t)
+	t.Setenv("NYLAS_PASSPHRASE", "")
+	dir := t.TempDir()
+
+	assert.False(t, keyring.AgentRunning(path))
+
+	l, err := keyring.ListenAgent(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "only the owner may connect to the agent")
+	done := make(chan error, 1)
+	go func() { done <- keyring.NewAgent(0).Serve(context.Background(), l) }()
+
+	require.True(t, keyring.AgentRunning(path))
+	_, err = keyring.ListenAgent(path)
+	assert.Error(t, err, "a second agent must not take over the socket")
+
+	// Unlocking creates the vault and hands its key to the agent.
+	require.NoError(t, keyring.NewPassphraseStore(dir, nil).Unlock("s3cret", 0))
+	vaults, err := keyring.AgentStatus(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, vaults)
+
+	// A fresh store with no passphrase source uses the agent's key.
+	require.NoError(t, keyring.NewPassphraseStore(dir, nil).Set(ports.KeyAPIKey, "nyk_agent"))
+	value, err := keyring.NewPassphraseStore(dir, nil).Get(ports.KeyAPIKey)
+	require.NoError(t, err)
+	assert.Equal(t, "nyk_agent", value)
+
+	assert.Error(t, keyring.NewPassphraseStore(dir, nil).Unlock("wrong", 0))
+
+	require.NoError(t, keyring.AgentLock(path))
+	_, err = keyring.NewPassphraseStore(dir, nil).Get(ports.KeyAPIKey)
+	assert.ErrorIs(t, err, domain.ErrSecretStoreLocked)
+
+	require.NoError(t, keyring.AgentStop(path))
+	require.NoError(t, <-done)
+	assert.False(t, keyring.AgentRunning(path))
+}
+
+// writeScript writes an executable shell script to dir.
+func writeScript(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700)) // #nosec G306 -- test helper script
+	return path
+}
+
+// testSecretStore runs the round trip every backend must support.
+func testSecretStore(t *testing.T, store ports.SecretStore) {
+	t.Helper()
+	require.True(t, store.IsAvailable())
+
+	_, err := store.Get(ports.KeyAPIKey)
+	assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+
+	require.NoError(t, store.Set(ports.KeyAPIKey, "nyk_value"))
+	value, err := store.Get(ports.KeyAPIKey)
+	require.NoError(t, err)
+	assert.Equal(t, "nyk_value", value)
+
+	require.NoError(t, store.Delete(ports.KeyAPIKey))
+	_, err = store.Get(ports.KeyAPIKey)
+	assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+}
+
+func TestHelperStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script helper")
+	}
+	dir := t.TempDir()
+	helper := writeScript(t, dir, "helper", `
+dir="$(dirname "$0")"
+while IFS= read -r line; do
+	[ -z "$line" ] && break
+	case "$line" in
+		profile=*) profile="${line#profile=}" ;;
+		key=*) key="${line#key=}" ;;
+		value=*) value="${line#value=}" ;;
+	esac
+done
+file="$dir/$profile.$key"
+case "$1" in
+	store) printf '%s' "$value" > "$file" ;;
+	get) [ -f "$file" ] && printf 'value=%s\n' "$(cat "$file")" ;;
+	erase) rm -f "$file" ;;
+esac
+exit 0
+`)
+
+	store, err := keyring.NewHelperStore(helper, "work")
+	require.NoError(t, err)
+	testSecretStore(t, store)
+
+	assert.Error(t, store.Set(ports.KeyAPIKey, "two\nlines"))
+
+	t.Run("failing helper", func(t *testing.T) {
+		failing := writeScript(t, dir, "failing", "echo 'vault offline' >&2\nexit 1\n")
+		store, err := keyring.NewHelperStore(failing, "work")
+		require.NoError(t, err)
+		_, err = store.Get(ports.KeyAPIKey)
+		assert.ErrorIs(t, err, domain.ErrSecretStoreFailed)
+		assert.Contains(t, err.Error(), "vault offline")
+	})
+
+	t.Run("bare name", func(t *testing.T) {
+		store, err := keyring.NewHelperStore("vault", "work")
+		require.NoError(t, err)
+		assert.Contains(t, store.Name(), "nylas-credential-vault")
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := keyring.NewHelperStore("", "work")
+		assert.Error(t, err)
+	})
+}
+
+func TestPassStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script pass")
+	}
+	dir := t.TempDir()
+	writeScript(t, dir, "pass", `
+dir="$(dirname "$0")/store"
+for entry; do :; done
+file="$dir/$(echo "$entry" | tr / _)"
+mkdir -p "$dir"
+case "$1" in
+	insert) cat > "$file" ;;
+	show|rm)
+		if [ ! -f "$file" ]; then
+			echo "Error: $entry is not in the password store." >&2
+			exit 1
+		fi
+		if [ "$1" = show ]; then cat "$file"; echo; else rm -f "$file"; fi ;;
+esac
+`)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	store := keyring.NewPassStore("/nylas/test/")
+	assert.Equal(t, "pass (nylas/test)", store.Name())
+	testSecretStore(t, store)
+	assert.NoError(t, store.Delete("missing"))
+}
+
+func TestNewBackend(t *testing.T) {
+	t.Setenv("NYLAS_DISABLE_KEYRING", "true")
+	dir := t.TempDir()
+
+	for _, name := range keyring.Backends {
+		opts := keyring.BackendOptions{Profile: "default", ConfigDir: dir, Secrets: &domain.SecretsConfig{Helper: "test"}}
+		store, err := keyring.NewBackend(name, opts)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, store.Name(), name)
+	}
+
+	pass, err := keyring.NewBackend(domain.SecretBackendPass, keyring.BackendOptions{Profile: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "pass (nylas/staging)", pass.Name())
+
+	_, err = keyring.NewBackend("vault", keyring.BackendOptions{ConfigDir: dir})
+	assert.Error(t, err)
+
+	_, err = keyring.NewBackend(domain.SecretBackendHelper, keyring.BackendOptions{ConfigDir: dir})
+	assert.Error(t, err, "helper backend needs secrets.helper")
+}
+
+func TestOpenProfileSecretStore(t *testing.T) {
+	t.Setenv("NYLAS_DISABLE_KEYRING", "true")
+
+	t.Run("configured backend", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("secrets:\n  backend: passphrase\n"), 0600))
+
+		store, err := keyring.OpenProfileSecretStore("default", dir, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "passphrase-encrypted file", store.Name())
+	})
+
+	t.Run("unavailable backend", func(t *testing.T) {
+		dir := t.TempDir()
+		config := "secrets:\n  backend: helper\n  helper: /nonexistent/helper\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0600))
+
+		_, err := keyring.OpenProfileSecretStore("default", dir, nil)
+		assert.ErrorIs(t, err, domain.ErrSecretStoreFailed)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		store, err := keyring.OpenProfileSecretStore("default", t.TempDir(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "encrypted file", store.Name())
+	})
+}
+
+func TestMigrateSecrets(t *testing.T) {
+	from, err := keyring.NewEncryptedFileStore(t.TempDir())
+	require.NoError(t, err)
+	to, err := keyring.NewEncryptedFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, from.Set(ports.KeyAPIKey, "nyk_migrate"))
+	require.NoError(t, from.Set(ports.KeySlackUserToken, "xoxp-migrate"))
+	grants := keyring.NewGrantStore(from)
+	require.NoError(t, grants.SaveGrant(domain.GrantInfo{ID: "grant-1", Email: "a@example.com"}))
+	require.NoError(t, from.Set(ports.GrantTokenKey("grant-1"), "token-1"))
+
+	copied, err := keyring.MigrateSecrets(from, to)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{ports.KeyAPIKey, ports.KeySlackUserToken, "grants", ports.GrantTokenKey("grant-1")}, copied)
+
+	slackToken, err := to.Get(ports.KeySlackUserToken)
+	require.NoError(t, err)
+	assert.Equal(t, "xoxp-migrate", slackToken)
+
+	value, err := to.Get(ports.GrantTokenKey("grant-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", value)
+
+	migrated, err := keyring.NewGrantStore(to).ListGrants()
+	require.NoError(t, err)
+	require.Len(t, migrated, 1)
+	assert.Equal(t, "grant-1", migrated[0].ID)
+
+	_, err = from.Get(ports.KeyAPIKey)
+	require.NoError(t, err, "migrating leaves the source alone")
+
+	require.NoError(t, keyring.DeleteSecrets(from, copied))
+	_, err = from.Get(ports.KeyAPIKey)
+	assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+	_, err = from.Get(ports.KeySlackUserToken)
+	assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+}
@@ -154,7 +154,25 @@ func (f *EncryptedFileStore) saveSecrets(secrets map[string]string) error {
 
 // encrypt encrypts plaintext using AES-256-GCM.
 func (f *EncryptedFileStore) encrypt(plaintext []byte) ([]byte, error) {
-	block, err := aes.NewCipher(f.key)
+	ciphertext, err := sealGCM(f.key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(ciphertext)), nil
+}
+
+// decrypt decrypts ciphertext using AES-256-GCM.
+func (f *EncryptedFileStore) decrypt(data []byte) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return openGCM(f.key, ciphertext)
+}
+
+// sealGCM encrypts plaintext with AES-256-GCM, prefixing the random nonce.
+func sealGCM(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -169,18 +187,12 @@ func (f *EncryptedFileStore) encrypt(plaintext []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
-	return []byte(base64.StdEncoding.EncodeToString(ciphertext)), nil
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
 }
 
-// decrypt decrypts ciphertext using AES-256-GCM.
-func (f *EncryptedFileStore) decrypt(data []byte) ([]byte, error) {
-	ciphertext, err := base64.StdEncoding.DecodeString(string(data))
-	if err != nil {
-		return nil, err
-	}
-
-	block, err := aes.NewCipher(f.key)
+// openGCM decrypts a nonce-prefixed AES-256-GCM ciphertext from sealGCM.
+func openGCM(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
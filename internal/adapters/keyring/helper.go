@@ -0,0 +1,115 @@
+package keyring
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// HelperStore implements SecretStore by running an external credential
+// helper, in the style of git's credential helpers. The helper is run as
+//
+//	<helper> get|store|erase
+//
+// with key=value lines on stdin, ended by a blank line:
+//
+//	protocol=nylas
+//	profile=<profile>
+//	key=<secret name>
+//	value=<secret>        (store only)
+//
+// For get, it prints value=<secret>, or nothing when the secret isn't
+// stored. A non-zero exit is an error; its stderr is shown to the user.
+type HelperStore struct {
+	command []string
+	profile string
+}
+
+// NewHelperStore creates a HelperStore. helper is either a name, which runs
+// nylas-credential-<name> from PATH, or a path; either can be followed by
+// arguments.
+func NewHelperStore(helper, profile string) (*HelperStore, error) {
+	command := strings.Fields(helper)
+	if len(command) == 0 {
+		return nil, fmt.Errorf("%w: no credential helper configured (set secrets.helper)", domain.ErrSecretStoreFailed)
+	}
+	if !strings.ContainsRune(command[0], filepath.Separator) && !strings.Contains(command[0], "/") {
+		command[0] = "nylas-credential-" + command[0]
+	}
+	return &HelperStore{command: command, profile: profile}, nil
+}
+
+// Set stores a secret value for the given key.
+func (h *HelperStore) Set(key, value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("%w: credential helpers can't store values containing newlines", domain.ErrSecretStoreFailed)
+	}
+	_, err := h.run("store", key, value)
+	return err
+}
+
+// Get retrieves a secret value for the given key.
+func (h *HelperStore) Get(key string) (string, error) {
+	out, err := h.run("get", key, "")
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "value="); ok {
+			return value, nil
+		}
+	}
+	return "", domain.ErrSecretNotFound
+}
+
+// Delete removes a secret for the given key.
+func (h *HelperStore) Delete(key string) error {
+	_, err := h.run("erase", key, "")
+	return err
+}
+
+// IsAvailable checks that the helper can be found.
+func (h *HelperStore) IsAvailable() bool {
+	_, err := exec.LookPath(h.command[0])
+	return err == nil
+}
+
+// Name returns the name of the secret store backend.
+func (h *HelperStore) Name() string {
+	return "credential helper (" + filepath.Base(h.command[0]) + ")"
+}
+
+// run invokes the helper for one operation.
+func (h *HelperStore) run(op, key, value string) (string, error) {
+	var input strings.Builder
+	input.WriteString("protocol=nylas\n")
+	input.WriteString("profile=" + h.profile + "\n")
+	input.WriteString("key=" + key + "\n")
+	if op == "store" {
+		input.WriteString("value=" + value + "\n")
+	}
+	input.WriteString("\n")
+
+	args := append(append([]string{}, h.command[1:]...), op)
+	cmd := exec.Command(h.command[0], args...) // #nosec G204 -- the helper is the user's own configured executable
+	cmd.Stdin = strings.NewReader(input.String())
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("%w: credential helper %s: %s", domain.ErrSecretStoreFailed, op, msg)
+	}
+	return stdout.String(), nil
+}
@@ -4,6 +4,7 @@ package keyring
 import (
 	"os"
 
+	"github.com/mqasimca/nylas/internal/adapters/config"
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/mqasimca/nylas/internal/ports"
 	"github.com/zalando/go-keyring"
@@ -12,21 +13,38 @@ import (
 const serviceName = "nylas"
 
 // SystemKeyring implements SecretStore using the system keychain.
-type SystemKeyring struct{}
+type SystemKeyring struct {
+	service string
+}
 
-// NewSystemKeyring creates a new SystemKeyring instance.
+// NewSystemKeyring creates a SystemKeyring for the active profile.
 func NewSystemKeyring() *SystemKeyring {
-	return &SystemKeyring{}
+	return NewProfileKeyring(config.ActiveProfile())
+}
+
+// NewProfileKeyring creates a SystemKeyring holding a profile's secrets.
+// Each profile has its own keychain service; the default profile keeps the
+// original "nylas" service.
+func NewProfileKeyring(profile string) *SystemKeyring {
+	return &SystemKeyring{service: serviceNameFor(profile)}
+}
+
+// serviceNameFor returns the keychain service for a profile.
+func serviceNameFor(profile string) string {
+	if profile == "" || profile == config.DefaultProfile {
+		return serviceName
+	}
+	return serviceName + ":" + profile
 }
 
 // Set stores a secret value for the given key.
 func (k *SystemKeyring) Set(key, value string) error {
-	return keyring.Set(serviceName, key, value)
+	return keyring.Set(k.service, key, value)
 }
 
 // Get retrieves a secret value for the given key.
 func (k *SystemKeyring) Get(key string) (string, error) {
-	value, err := keyring.Get(serviceName, key)
+	value, err := keyring.Get(k.service, key)
 	if err == keyring.ErrNotFound {
 		return "", domain.ErrSecretNotFound
 	}
@@ -35,7 +53,7 @@ func (k *SystemKeyring) Get(key string) (string, error) {
 
 // Delete removes a secret for the given key.
 func (k *SystemKeyring) Delete(key string) error {
-	err := keyring.Delete(serviceName, key)
+	err := keyring.Delete(k.service, key)
 	if err == keyring.ErrNotFound {
 		return nil // Already deleted
 	}
@@ -45,11 +63,11 @@ func (k *SystemKeyring) Delete(key string) error {
 // IsAvailable checks if the system keychain is available.
 func (k *SystemKeyring) IsAvailable() bool {
 	testKey := "__nylas_keyring_test__"
-	err := keyring.Set(serviceName, testKey, "test")
+	err := keyring.Set(k.service, testKey, "test")
 	if err != nil {
 		return false
 	}
-	_ = keyring.Delete(serviceName, testKey)
+	_ = keyring.Delete(k.service, testKey)
 	return true
 }
 
@@ -58,16 +76,29 @@ func (k *SystemKeyring) Name() string {
 	return "system keyring"
 }
 
-// NewSecretStore creates a SecretStore, preferring system keyring with file fallback.
+// NewSecretStore creates a SecretStore for the active profile, using the backend set in
+// its config (secrets.backend) or, by default, the system keyring with file fallback.
+// A passphrase-protected store asks for its passphrase on the terminal when locked.
+func NewSecretStore(configDir string) (ports.SecretStore, error) {
+	return NewProfileSecretStore(config.ActiveProfile(), configDir)
+}
+
+// NewProfileSecretStore is NewSecretStore for a given profile, whose
+// config and file stores live in configDir.
+func NewProfileSecretStore(profile, configDir string) (ports.SecretStore, error) {
+	return OpenProfileSecretStore(profile, configDir, TerminalPrompt)
+}
+
+// newAutoSecretStore prefers the system keyring with file fallback.
 // If the system keyring is available but empty, and the encrypted file has credentials,
 // it will migrate the credentials to the system keyring.
-func NewSecretStore(configDir string) (ports.SecretStore, error) {
+func newAutoSecretStore(profile, configDir string) (ports.SecretStore, error) {
 	// Check if keyring is disabled via environment variable (useful for testing)
 	if os.Getenv("NYLAS_DISABLE_KEYRING") == "true" {
 		return NewEncryptedFileStore(configDir)
 	}
 
-	kr := NewSystemKeyring()
+	kr := NewProfileKeyring(profile)
 	if !kr.IsAvailable() {
 		return NewEncryptedFileStore(configDir)
 	}
@@ -114,3 +145,25 @@ func NewSecretStore(configDir string) (ports.SecretStore, error) {
 
 	return kr, nil
 }
+
+// DeleteProfileKeyring removes a profile's credentials, grants and grant
+// tokens from the system keychain. The default profile's keychain entries
+// are never touched.
+func DeleteProfileKeyring(profile string) {
+	if profile == "" || profile == config.DefaultProfile {
+		return
+	}
+	kr := NewProfileKeyring(profile)
+	if !kr.IsAvailable() {
+		return
+	}
+
+	if grants, err := NewGrantStore(kr).ListGrants(); err == nil {
+		for _, grant := range grants {
+			_ = kr.Delete(ports.GrantTokenKey(grant.ID))
+		}
+	}
+	for _, key := range []string{ports.KeyAPIKey, ports.KeyClientID, ports.KeyClientSecret, ports.KeyOrgID, grantsKey, defaultGrantKey} {
+		_ = kr.Delete(key)
+	}
+}
@@ -0,0 +1,85 @@
+package keyring
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// PassStore implements SecretStore with pass, the standard Unix password
+// manager, so secrets are encrypted to the user's GPG key. Each secret is
+// an entry under prefix, e.g. nylas/default/api_key.
+type PassStore struct {
+	bin    string
+	prefix string
+}
+
+// NewPassStore creates a PassStore keeping entries under prefix.
+func NewPassStore(prefix string) *PassStore {
+	return &PassStore{bin: "pass", prefix: strings.Trim(prefix, "/")}
+}
+
+// entry returns the pass entry name for a key.
+func (p *PassStore) entry(key string) string {
+	return p.prefix + "/" + key
+}
+
+// Set stores a secret value for the given key.
+func (p *PassStore) Set(key, value string) error {
+	_, err := p.run(value, "insert", "--multiline", "--force", p.entry(key))
+	return err
+}
+
+// Get retrieves a secret value for the given key.
+func (p *PassStore) Get(key string) (string, error) {
+	out, err := p.run("", "show", p.entry(key))
+	if err != nil {
+		if strings.Contains(err.Error(), "is not in the password store") {
+			return "", domain.ErrSecretNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// Delete removes a secret for the given key.
+func (p *PassStore) Delete(key string) error {
+	_, err := p.run("", "rm", "--force", p.entry(key))
+	if err != nil && strings.Contains(err.Error(), "is not in the password store") {
+		return nil
+	}
+	return err
+}
+
+// IsAvailable checks that pass is installed.
+func (p *PassStore) IsAvailable() bool {
+	_, err := exec.LookPath(p.bin)
+	return err == nil
+}
+
+// Name returns the name of the secret store backend.
+func (p *PassStore) Name() string {
+	return "pass (" + p.prefix + ")"
+}
+
+// run runs pass with stdin, returning its output. Failures include pass's
+// own error message.
+func (p *PassStore) run(stdin string, args ...string) (string, error) {
+	cmd := exec.Command(p.bin, args...) // #nosec G204 -- fixed binary, arguments are entry names
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("%w: pass %s: %s", domain.ErrSecretStoreFailed, args[0], msg)
+	}
+	return stdout.String(), nil
+}
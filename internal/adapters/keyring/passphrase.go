@@ -0,0 +1,335 @@
+package keyring
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/term"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// Argon2id parameters for new vaults (the second recommended option in
+// RFC 9106: 3 passes over 64 MiB).
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // KiB
+	argonThreads = 4
+	argonKeyLen  = 32
+	argonSaltLen = 16
+)
+
+// vaultFile is the on-disk format of a PassphraseStore. The KDF parameters
+// are stored so they can be raised later without breaking old vaults.
+type vaultFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Data    []byte `json:"data"` // Nonce-prefixed AES-256-GCM ciphertext of the secrets map
+}
+
+// deriveKey derives the vault key from a passphrase.
+func (v *vaultFile) deriveKey(passphrase string) []byte {
+	return argon2.IDKey([]byte(passphrase), v.Salt, v.Time, v.Memory, v.Threads, argonKeyLen)
+}
+
+// PassphrasePrompt asks the user for a passphrase.
+type PassphrasePrompt func(prompt string) (string, error)
+
+// TerminalPrompt reads a passphrase from the terminal without echoing it.
+func TerminalPrompt(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd()) // #nosec G115 -- file descriptors fit in int
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("%w: no terminal to ask for the passphrase; run 'nylas auth unlock' or set NYLAS_PASSPHRASE", domain.ErrSecretStoreLocked)
+	}
+	_, _ = fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(os.Stderr)
+	return string(passphrase), err
+}
+
+// PassphraseStore implements SecretStore using a file encrypted with a key
+// derived from a passphrase with Argon2id. Unlike EncryptedFileStore, a copy
+// of the file is useless without the passphrase, even on the same machine.
+//
+// The passphrase comes from NYLAS_PASSPHRASE or the prompt. The derived key
+// is handed to the unlock agent when one is running (nylas auth unlock), so
+// later commands in the session don't ask again.
+type PassphraseStore struct {
+	path   string
+	agent  string
+	prompt PassphrasePrompt // nil fails with ErrSecretStoreLocked instead of asking
+	mu     sync.Mutex
+	key    []byte
+}
+
+// NewPassphraseStore creates a PassphraseStore in configDir.
+func NewPassphraseStore(configDir string, prompt PassphrasePrompt) *PassphraseStore {
+	return &PassphraseStore{
+		path:   filepath.Join(configDir, ".secrets.vault"),
+		agent:  AgentSocketPath(),
+		prompt: prompt,
+	}
+}
+
+// Path returns the vault file path.
+func (s *PassphraseStore) Path() string {
+	return s.path
+}
+
+// Set stores a secret value for the given key.
+func (s *PassphraseStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vault, secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if vault == nil {
+		if vault, err = s.create(); err != nil {
+			return err
+		}
+		secrets = make(map[string]string)
+	}
+
+	secrets[key] = value
+	return s.save(vault, secrets)
+}
+
+// Get retrieves a secret value for the given key.
+func (s *PassphraseStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vault, secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[key]
+	if vault == nil || !ok {
+		return "", domain.ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// Delete removes a secret for the given key.
+func (s *PassphraseStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vault, secrets, err := s.load()
+	if err != nil || vault == nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+	delete(secrets, key)
+	return s.save(vault, secrets)
+}
+
+// IsAvailable always returns true for file-based storage.
+func (s *PassphraseStore) IsAvailable() bool {
+	return true
+}
+
+// Name returns the name of the secret store backend.
+func (s *PassphraseStore) Name() string {
+	return "passphrase-encrypted file"
+}
+
+// Unlock checks passphrase against the vault, creating the vault if there
+// isn't one yet, and hands the key to the agent for ttl (0 for its default).
+// It fails if no agent is running.
+func (s *PassphraseStore) Unlock(passphrase string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vault, err := s.readVault()
+	if err != nil {
+		return err
+	}
+	if vault == nil {
+		if vault, err = newVault(); err != nil {
+			return err
+		}
+		s.key = vault.deriveKey(passphrase)
+		if err := s.save(vault, map[string]string{}); err != nil {
+			return err
+		}
+	} else {
+		key := vault.deriveKey(passphrase)
+		if _, err := s.decrypt(vault, key); err != nil {
+			return err
+		}
+		s.key = key
+	}
+	return agentPutKey(s.agent, s.path, s.key, ttl)
+}
+
+// Exists reports whether the vault has been created.
+func (s *PassphraseStore) Exists() bool {
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+// readVault reads the vault file, returning nil if it doesn't exist yet.
+func (s *PassphraseStore) readVault() (*vaultFile, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrSecretStoreFailed, err)
+	}
+	var vault vaultFile
+	if err := json.Unmarshal(data, &vault); err != nil {
+		return nil, fmt.Errorf("%w: %s is not a valid vault: %v", domain.ErrSecretStoreFailed, s.path, err)
+	}
+	if vault.KDF != "argon2id" {
+		return nil, fmt.Errorf("%w: unsupported key derivation %q", domain.ErrSecretStoreFailed, vault.KDF)
+	}
+	return &vault, nil
+}
+
+// load reads and decrypts the vault, unlocking it if needed. A vault that
+// doesn't exist yet is returned as nil without asking for a passphrase.
+func (s *PassphraseStore) load() (*vaultFile, map[string]string, error) {
+	vault, err := s.readVault()
+	if err != nil || vault == nil {
+		return nil, nil, err
+	}
+
+	if s.key == nil {
+		if key := agentGetKey(s.agent, s.path); key != nil {
+			if _, err := s.decrypt(vault, key); err == nil {
+				s.key = key
+			}
+		}
+	}
+	if s.key == nil {
+		passphrase, err := s.passphrase("Passphrase for " + s.path + ": ")
+		if err != nil {
+			return nil, nil, err
+		}
+		key := vault.deriveKey(passphrase)
+		if _, err := s.decrypt(vault, key); err != nil {
+			return nil, nil, err
+		}
+		s.key = key
+		_ = agentPutKey(s.agent, s.path, key, 0) // Best effort: only if an agent is running
+	}
+
+	secrets, err := s.decrypt(vault, s.key)
+	return vault, secrets, err
+}
+
+// create starts a new vault, asking for its passphrase twice.
+func (s *PassphraseStore) create() (*vaultFile, error) {
+	passphrase, err := s.passphrase("New passphrase for " + s.path + ": ")
+	if err != nil {
+		return nil, err
+	}
+	if os.Getenv("NYLAS_PASSPHRASE") == "" {
+		again, err := s.prompt("Repeat passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if again != passphrase {
+			return nil, errors.New("passphrases don't match")
+		}
+	}
+
+	vault, err := newVault()
+	if err != nil {
+		return nil, err
+	}
+	s.key = vault.deriveKey(passphrase)
+	_ = agentPutKey(s.agent, s.path, s.key, 0)
+	return vault, nil
+}
+
+// passphrase returns NYLAS_PASSPHRASE or asks for one.
+func (s *PassphraseStore) passphrase(prompt string) (string, error) {
+	if env := os.Getenv("NYLAS_PASSPHRASE"); env != "" {
+		return env, nil
+	}
+	if s.prompt == nil {
+		return "", fmt.Errorf("%w: run 'nylas auth unlock' or set NYLAS_PASSPHRASE", domain.ErrSecretStoreLocked)
+	}
+	passphrase, err := s.prompt(prompt)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase can't be empty")
+	}
+	return passphrase, nil
+}
+
+// decrypt opens the vault's secrets with key.
+func (s *PassphraseStore) decrypt(vault *vaultFile, key []byte) (map[string]string, error) {
+	plaintext, err := openGCM(key, vault.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrong passphrase for %s", domain.ErrSecretStoreLocked, s.path)
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrSecretStoreFailed, err)
+	}
+	if secrets == nil {
+		secrets = make(map[string]string)
+	}
+	return secrets, nil
+}
+
+// save encrypts secrets with the unlocked key and writes the vault.
+func (s *PassphraseStore) save(vault *vaultFile, secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	if vault.Data, err = sealGCM(s.key, plaintext); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSecretStoreFailed, err)
+	}
+
+	data, err := json.MarshalIndent(vault, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSecretStoreFailed, err)
+	}
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSecretStoreFailed, err)
+	}
+	return nil
+}
+
+// newVault returns an empty vault header with a fresh salt.
+func newVault() (*vaultFile, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	return &vaultFile{
+		Version: 1,
+		KDF:     "argon2id",
+		Salt:    salt,
+		Time:    argonTime,
+		Memory:  argonMemory,
+		Threads: argonThreads,
+	}, nil
+}
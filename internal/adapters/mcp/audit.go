@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Audit outcomes recorded for tools/call requests.
+const (
+	AuditOutcomeOK                  = "ok"
+	AuditOutcomeError               = "error"
+	AuditOutcomeDenied              = "denied"
+	AuditOutcomeConfirmationPending = "confirmation_required"
+	AuditOutcomeLocal               = "local"
+)
+
+// AuditEntry is a single tools/call record in the audit log.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Tool      string    `json:"tool"`
+	ArgsHash  string    `json:"args_hash"`
+	Grant     string    `json:"grant,omitempty"`
+	Outcome   string    `json:"outcome"`
+	LatencyMS int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditLog appends tool call records to a JSON Lines file.
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLog creates an audit log writing to path.
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// Path returns the audit log file path.
+func (a *AuditLog) Path() string {
+	return a.path
+}
+
+// Record appends an entry to the log.
+func (a *AuditLog) Record(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling audit entry: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		return fmt.Errorf("creating audit directory: %w", err)
+	}
+	// #nosec G304 -- path is the configured audit log location
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// ReadAuditLog reads entries from an audit log file, oldest first.
+// If limit is positive, only the most recent limit entries are returned.
+func ReadAuditLog(path string, limit int) ([]AuditEntry, error) {
+	// #nosec G304 -- path is the configured audit log location
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // Skip corrupt lines
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,451 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+const (
+	// HTTPEndpointPath is the path of the Streamable HTTP MCP endpoint.
+	HTTPEndpointPath = "/mcp"
+
+	// sessionHeader carries the MCP session ID between client and server.
+	sessionHeader = "Mcp-Session-Id"
+
+	// sessionIdleTimeout is how long an unused session is kept.
+	sessionIdleTimeout = 30 * time.Minute
+
+	// sseKeepAlive is the interval between SSE keep-alive comments.
+	sseKeepAlive = 15 * time.Second
+
+	// maxRequestBody limits the size of a single HTTP request body.
+	maxRequestBody = 4 << 20
+)
+
+// httpSession is a client session with its own upstream MCP session.
+type httpSession struct {
+	proxy    *Proxy
+	lastSeen time.Time
+}
+
+// HTTPServer serves the proxy over the MCP Streamable HTTP transport.
+// Each client session gets its own upstream session, so concurrent
+// clients do not share state.
+type HTTPServer struct {
+	proxy    *Proxy
+	token    string
+	sessions map[string]*httpSession
+	mu       sync.Mutex
+	now      func() time.Time
+}
+
+// NewHTTPServer creates a Streamable HTTP server for the proxy.
+// Requests must carry "Authorization: Bearer <token>"; an empty token disables auth.
+func NewHTTPServer(proxy *Proxy, token string) *HTTPServer {
+	return &HTTPServer{
+		proxy:    proxy,
+		token:    token,
+		sessions: make(map[string]*httpSession),
+		now:      time.Now,
+	}
+}
+
+// GenerateToken returns a random bearer token for local clients.
+func GenerateToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Handler returns the HTTP handler for the MCP endpoint.
+func (s *HTTPServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HTTPEndpointPath, s.handleMCP)
+	return mux
+}
+
+// ListenAndServe serves on addr until ctx is cancelled.
+// An address without a host (":8080") binds to localhost only.
+func (s *HTTPServer) ListenAndServe(ctx context.Context, addr string) error {
+	if strings.HasPrefix(addr, ":") {
+		addr = "127.0.0.1" + addr
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: domain.HTTPReadHeaderTimeout,
+		ReadTimeout:       domain.HTTPReadTimeout,
+		IdleTimeout:       domain.HTTPIdleTimeout,
+		// No WriteTimeout: tool calls stream over SSE and may outlive it
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// SessionCount returns the number of active sessions.
+func (s *HTTPServer) SessionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// handleMCP dispatches Streamable HTTP requests.
+func (s *HTTPServer) handleMCP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="nylas-mcp"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !allowedOrigin(r) {
+		http.Error(w, "forbidden origin", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		s.handlePost(w, r)
+	case http.MethodDelete:
+		s.handleDelete(w, r)
+	default:
+		// No server-initiated messages, so GET streams are not offered
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// authorized checks the bearer token in constant time.
+func (s *HTTPServer) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// allowedOrigin rejects browser requests from non-local origins (DNS rebinding protection).
+func allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// handleDelete terminates a session.
+func (s *HTTPServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.Header.Get(sessionHeader)
+	s.mu.Lock()
+	_, ok := s.sessions[id]
+	delete(s.sessions, id)
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePost processes one JSON-RPC message or a batch.
+func (s *HTTPServer) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+	if err != nil {
+		http.Error(w, "reading request body", http.StatusBadRequest)
+		return
+	}
+	body = bytes.TrimSpace(body)
+
+	messages, isBatch, err := splitMessages(body)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(rpcError(nil, -32700, "parse error"))
+		return
+	}
+
+	session, sessionID, status := s.resolveSession(r.Header.Get(sessionHeader), messages)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	if sessionID != "" {
+		w.Header().Set(sessionHeader, sessionID)
+	}
+
+	// Notifications and responses only: process and acknowledge
+	if !containsRequest(messages) {
+		for _, msg := range messages {
+			_ = session.proxy.handleMessage(r.Context(), msg)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		s.streamResponses(w, r, session.proxy, messages)
+		return
+	}
+
+	responses := processConcurrently(r.Context(), session.proxy, messages, nil)
+	w.Header().Set("Content-Type", "application/json")
+	if isBatch {
+		batch := make([]json.RawMessage, len(responses))
+		for i, resp := range responses {
+			batch[i] = resp
+		}
+		_ = json.NewEncoder(w).Encode(batch)
+		return
+	}
+	if len(responses) > 0 {
+		_, _ = w.Write(responses[0])
+	}
+}
+
+// resolveSession finds the session for a request, creating one on initialize.
+// Returns the session, the session ID to echo, and an HTTP status.
+func (s *HTTPServer) resolveSession(id string, messages []json.RawMessage) (*httpSession, string, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, sess := range s.sessions {
+		if now.Sub(sess.lastSeen) > sessionIdleTimeout {
+			delete(s.sessions, key)
+		}
+	}
+
+	if id != "" {
+		sess, ok := s.sessions[id]
+		if !ok {
+			return nil, "", http.StatusNotFound
+		}
+		sess.lastSeen = now
+		return sess, id, http.StatusOK
+	}
+
+	if !containsMethod(messages, "initialize") {
+		return nil, "", http.StatusBadRequest
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", http.StatusInternalServerError
+	}
+	id = hex.EncodeToString(buf)
+	sess := &httpSession{proxy: s.proxy.newSession(), lastSeen: now}
+	s.sessions[id] = sess
+	return sess, id, http.StatusOK
+}
+
+// streamResponses writes responses as SSE events as each request completes,
+// with keep-alive comments while requests are outstanding.
+func (s *HTTPServer) streamResponses(w http.ResponseWriter, r *http.Request, proxy *Proxy, messages []json.RawMessage) {
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	results := make(chan []byte, len(messages))
+	done := make(chan struct{})
+	go func() {
+		processConcurrently(r.Context(), proxy, messages, results)
+		close(done)
+	}()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case resp := <-results:
+			writeSSEMessage(w, resp)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case <-ticker.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case <-done:
+			// Drain anything that arrived with the final result
+			for {
+				select {
+				case resp := <-results:
+					writeSSEMessage(w, resp)
+				default:
+					if flusher != nil {
+						flusher.Flush()
+					}
+					return
+				}
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeSSEMessage writes a response as one SSE message event. A newline in
+// the payload would end the data field early, so JSON is compacted onto one
+// line, and anything else is sent as one data line per line.
+func writeSSEMessage(w io.Writer, resp []byte) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, resp); err == nil {
+		resp = compact.Bytes()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("event: message\n")
+	for _, line := range bytes.Split(bytes.TrimRight(resp, "\r\n"), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, _ = w.Write(buf.Bytes())
+}
+
+// processConcurrently handles messages in parallel. Responses are sent to
+// results as they complete (if non-nil) and also returned in input order.
+func processConcurrently(ctx context.Context, proxy *Proxy, messages []json.RawMessage, results chan<- []byte) [][]byte {
+	ordered := make([][]byte, len(messages))
+	var wg sync.WaitGroup
+	for i, msg := range messages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := proxy.handleMessage(ctx, msg)
+			ordered[i] = resp
+			if results != nil && len(resp) > 0 {
+				results <- resp
+			}
+		}()
+	}
+	wg.Wait()
+
+	responses := make([][]byte, 0, len(ordered))
+	for _, resp := range ordered {
+		if len(resp) > 0 {
+			responses = append(responses, resp)
+		}
+	}
+	return responses
+}
+
+// splitMessages splits a request body into individual JSON-RPC messages.
+func splitMessages(body []byte) ([]json.RawMessage, bool, error) {
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, true, err
+		}
+		if len(batch) == 0 {
+			return nil, true, errors.New("empty batch")
+		}
+		return batch, true, nil
+	}
+	if !json.Valid(body) {
+		return nil, false, errors.New("invalid JSON")
+	}
+	return []json.RawMessage{body}, false, nil
+}
+
+// rpcEnvelope holds the fields used to classify a JSON-RPC message.
+type rpcEnvelope struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// containsRequest reports whether any message is a request (method and id).
+func containsRequest(messages []json.RawMessage) bool {
+	for _, msg := range messages {
+		var env rpcEnvelope
+		if json.Unmarshal(msg, &env) == nil && env.Method != "" && len(env.ID) > 0 && string(env.ID) != "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// containsMethod reports whether any message calls the given method.
+func containsMethod(messages []json.RawMessage, method string) bool {
+	for _, msg := range messages {
+		var env rpcEnvelope
+		if json.Unmarshal(msg, &env) == nil && env.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
+// newSession returns a copy of the proxy configuration with its own upstream session.
+func (p *Proxy) newSession() *Proxy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return &Proxy{
+		endpoint:      p.endpoint,
+		apiKey:        p.apiKey,
+		authHeader:    p.authHeader,
+		defaultGrant:  p.defaultGrant,
+		grantStore:    p.grantStore,
+		templateStore: p.templateStore,
+		messageCache:  p.messageCache,
+		httpClient:    p.httpClient,
+		policy:        p.policy,
+		auditLog:      p.auditLog,
+		confirms:      newConfirmationStore(),
+	}
+}
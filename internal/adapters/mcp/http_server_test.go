@@ -0,0 +1,244 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestHTTPServer(t *testing.T) (*HTTPServer, *httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var upstreamSessions atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req rpcRequest
+		_ = json.Unmarshal(body, &req)
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == "initialize" {
+			upstreamSessions.Add(1)
+			w.Header().Set("Mcp-Session-Id", "upstream-session")
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"capabilities":{}}}`))
+			return
+		}
+		if req.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if r.Header.Get("Mcp-Session-Id") != "upstream-session" {
+			t.Errorf("expected upstream session header, got %q", r.Header.Get("Mcp-Session-Id"))
+		}
+		resp, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": map[string]any{"method": req.Method}})
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(upstream.Close)
+
+	proxy := NewProxy("test-api-key", "us")
+	proxy.endpoint = upstream.URL
+
+	srv := NewHTTPServer(proxy, "secret")
+	local := httptest.NewServer(srv.Handler())
+	t.Cleanup(local.Close)
+	return srv, local, &upstreamSessions
+}
+
+func postMCP(t *testing.T, url, session, accept, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, url+HTTPEndpointPath, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	if session != "" {
+		req.Header.Set(sessionHeader, session)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestHTTPServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	_, local, _ := newTestHTTPServer(t)
+
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, local.URL+HTTPEndpointPath, strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPServer_OriginRejected(t *testing.T) {
+	t.Parallel()
+
+	_, local, _ := newTestHTTPServer(t)
+
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, local.URL+HTTPEndpointPath, strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Origin", "https://evil.example.com")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTPServer_SessionLifecycle(t *testing.T) {
+	t.Parallel()
+
+	srv, local, upstreamSessions := newTestHTTPServer(t)
+
+	// Requests before initialize are rejected
+	resp := postMCP(t, local.URL, "", "application/json", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 without session, got %d", resp.StatusCode)
+	}
+
+	// Two clients initialize independent sessions
+	var sessions []string
+	for range 2 {
+		resp = postMCP(t, local.URL, "", "application/json", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("initialize failed: %d", resp.StatusCode)
+		}
+		id := resp.Header.Get(sessionHeader)
+		if id == "" {
+			t.Fatal("expected session ID header")
+		}
+		sessions = append(sessions, id)
+	}
+	if sessions[0] == sessions[1] {
+		t.Error("expected distinct sessions")
+	}
+	if srv.SessionCount() != 2 || upstreamSessions.Load() != 2 {
+		t.Errorf("expected 2 local and upstream sessions, got %d and %d", srv.SessionCount(), upstreamSessions.Load())
+	}
+
+	// Notifications are acknowledged with 202
+	resp = postMCP(t, local.URL, sessions[0], "application/json", `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected 202 for notification, got %d", resp.StatusCode)
+	}
+
+	// Unknown session is 404
+	resp = postMCP(t, local.URL, "nope", "application/json", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown session, got %d", resp.StatusCode)
+	}
+
+	// DELETE terminates a session
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodDelete, local.URL+HTTPEndpointPath, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set(sessionHeader, sessions[0])
+	delResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = delResp.Body.Close()
+	if delResp.StatusCode != http.StatusNoContent || srv.SessionCount() != 1 {
+		t.Errorf("expected session deleted, status %d, count %d", delResp.StatusCode, srv.SessionCount())
+	}
+}
+
+func TestHTTPServer_BatchJSONAndSSE(t *testing.T) {
+	t.Parallel()
+
+	_, local, _ := newTestHTTPServer(t)
+
+	resp := postMCP(t, local.URL, "", "application/json", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+	session := resp.Header.Get(sessionHeader)
+
+	batch := `[{"jsonrpc":"2.0","id":2,"method":"tools/list"},{"jsonrpc":"2.0","id":3,"method":"ping"}]`
+
+	resp = postMCP(t, local.URL, session, "application/json", batch)
+	var results []map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatalf("failed to decode batch: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 batch responses, got %d", len(results))
+	}
+
+	resp = postMCP(t, local.URL, session, "application/json, text/event-stream", batch)
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("expected SSE response, got %q", ct)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if n := strings.Count(string(body), "data: "); n != 2 {
+		t.Errorf("expected 2 SSE events, got %d: %s", n, body)
+	}
+}
+
+func TestWriteSSEMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp string
+		want string
+	}{
+		{"single line", `{"id":1}`, "event: message\ndata: {\"id\":1}\n\n"},
+		{"indented JSON is compacted", "{\n  \"id\": 1\n}\n", "event: message\ndata: {\"id\":1}\n\n"},
+		{"other text keeps its lines", "line one\r\nline two", "event: message\ndata: line one\ndata: line two\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeSSEMessage(&buf, []byte(tt.resp))
+			if buf.String() != tt.want {
+				t.Errorf("writeSSEMessage(%q) = %q, want %q", tt.resp, buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPServer_InjectsDefaultGrant(t *testing.T) {
+	t.Parallel()
+
+	var gotGrant atomic.Value
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Method == "tools/call" {
+			gotGrant.Store(req.Params.Arguments["grant_id"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	}))
+	defer upstream.Close()
+
+	proxy := NewProxy("test-api-key", "us")
+	proxy.endpoint = upstream.URL
+	proxy.SetDefaultGrant("grant-default")
+
+	local := httptest.NewServer(NewHTTPServer(proxy, "secret").Handler())
+	defer local.Close()
+
+	resp := postMCP(t, local.URL, "", "application/json", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+	session := resp.Header.Get(sessionHeader)
+	postMCP(t, local.URL, session, "application/json", `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_messages","arguments":{}}}`)
+
+	if gotGrant.Load() != "grant-default" {
+		t.Errorf("expected default grant to be injected, got %v", gotGrant.Load())
+	}
+}
@@ -0,0 +1,239 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tool policy actions.
+const (
+	PolicyAllow = "allow"
+	PolicyDeny  = "deny"
+	PolicyHide  = "hide"
+)
+
+// confirmationTokenArg is the tool argument used to pass a confirmation token.
+const confirmationTokenArg = "confirmation_token"
+
+// confirmationTTL is how long a confirmation token stays valid.
+const confirmationTTL = 5 * time.Minute
+
+// writeToolPrefixes identifies tools that modify mailbox or calendar data.
+var writeToolPrefixes = []string{"create_", "update_", "delete_", "send_", "confirm_send_", "move_"}
+
+// Policy controls which MCP tools an assistant may see and call.
+//
+// Example policy file:
+//
+//	default: allow
+//	read_only: false
+//	tools:
+//	  send_message:
+//	    confirm: true
+//	  list_messages:
+//	    grants: [grant-123]
+//	  update_event:
+//	    action: hide
+type Policy struct {
+	Default  string                `yaml:"default,omitempty"`   // allow (default) or deny
+	ReadOnly bool                  `yaml:"read_only,omitempty"` // hide and block all write tools
+	Tools    map[string]ToolPolicy `yaml:"tools,omitempty"`
+}
+
+// ToolPolicy holds per-tool rules.
+type ToolPolicy struct {
+	Action  string   `yaml:"action,omitempty"`  // allow, deny or hide
+	Grants  []string `yaml:"grants,omitempty"`  // if set, tool may only run against these grants (by grant_id or identifier)
+	Confirm bool     `yaml:"confirm,omitempty"` // require a confirmation token before running
+}
+
+// PolicyDecision is the result of evaluating a tool call against a policy.
+type PolicyDecision struct {
+	Allowed bool
+	Reason  string
+}
+
+// LoadPolicy reads a policy file. A missing file yields a nil policy and no error.
+func LoadPolicy(path string) (*Policy, error) {
+	// #nosec G304 -- path comes from the --policy flag or the default config dir
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate checks that the policy only uses known actions.
+func (p *Policy) Validate() error {
+	switch p.Default {
+	case "", PolicyAllow, PolicyDeny:
+	default:
+		return fmt.Errorf("invalid policy default %q (valid: allow, deny)", p.Default)
+	}
+	for name, rule := range p.Tools {
+		switch rule.Action {
+		case "", PolicyAllow, PolicyDeny, PolicyHide:
+		default:
+			return fmt.Errorf("invalid action %q for tool %s (valid: allow, deny, hide)", rule.Action, name)
+		}
+	}
+	return nil
+}
+
+// IsWriteTool reports whether a tool modifies data.
+func IsWriteTool(name string) bool {
+	for _, prefix := range writeToolPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// action returns the effective action for a tool.
+func (p *Policy) action(tool string) string {
+	if p.ReadOnly && IsWriteTool(tool) {
+		return PolicyHide
+	}
+	if rule, ok := p.Tools[tool]; ok && rule.Action != "" {
+		return rule.Action
+	}
+	if p.Default == PolicyDeny {
+		// Tools listed without an action are implicitly allowed.
+		if _, ok := p.Tools[tool]; ok {
+			return PolicyAllow
+		}
+		return PolicyDeny
+	}
+	return PolicyAllow
+}
+
+// IsHidden reports whether a tool should be removed from tools/list.
+func (p *Policy) IsHidden(tool string) bool {
+	if p == nil {
+		return false
+	}
+	a := p.action(tool)
+	return a == PolicyHide || a == PolicyDeny
+}
+
+// RequiresConfirmation reports whether a tool needs a confirmation token.
+func (p *Policy) RequiresConfirmation(tool string) bool {
+	if p == nil {
+		return false
+	}
+	return p.Tools[tool].Confirm
+}
+
+// Evaluate checks whether a tool call is permitted for the given grant.
+// Confirmation is handled separately by the proxy.
+func (p *Policy) Evaluate(tool, grantID string) PolicyDecision {
+	if p == nil {
+		return PolicyDecision{Allowed: true}
+	}
+
+	switch p.action(tool) {
+	case PolicyDeny, PolicyHide:
+		if p.ReadOnly && IsWriteTool(tool) {
+			return PolicyDecision{Reason: fmt.Sprintf("tool %s is blocked: MCP policy is read-only", tool)}
+		}
+		return PolicyDecision{Reason: fmt.Sprintf("tool %s is not allowed by MCP policy", tool)}
+	}
+
+	if grants := p.Tools[tool].Grants; len(grants) > 0 {
+		if grantID == "" {
+			return PolicyDecision{Reason: fmt.Sprintf("tool %s requires an explicit grant_id", tool)}
+		}
+		if !slices.Contains(grants, grantID) {
+			return PolicyDecision{Reason: fmt.Sprintf("tool %s is not allowed for grant %s", tool, grantID)}
+		}
+	}
+
+	return PolicyDecision{Allowed: true}
+}
+
+// HashArguments returns a short, stable hash of tool arguments.
+// The confirmation token is excluded so confirmed retries hash the same.
+func HashArguments(args map[string]any) string {
+	clean := make(map[string]any, len(args))
+	for k, v := range args {
+		if k != confirmationTokenArg {
+			clean[k] = v
+		}
+	}
+	// json.Marshal sorts map keys, giving a canonical encoding
+	data, err := json.Marshal(clean)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// pendingConfirmation records a tool call awaiting confirmation.
+type pendingConfirmation struct {
+	tool     string
+	argsHash string
+	expires  time.Time
+}
+
+// confirmationStore tracks issued confirmation tokens.
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{pending: make(map[string]pendingConfirmation)}
+}
+
+// issue creates a token bound to the tool and argument hash.
+func (c *confirmationStore) issue(tool, argsHash string) string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	token := hex.EncodeToString(buf)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, v := range c.pending {
+		if now.After(v.expires) {
+			delete(c.pending, k)
+		}
+	}
+	c.pending[token] = pendingConfirmation{tool: tool, argsHash: argsHash, expires: now.Add(confirmationTTL)}
+	return token
+}
+
+// redeem consumes a token if it matches the tool call.
+func (c *confirmationStore) redeem(token, tool, argsHash string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending, ok := c.pending[token]
+	if !ok {
+		return false
+	}
+	delete(c.pending, token)
+	return pending.tool == tool && pending.argsHash == argsHash && time.Now().Before(pending.expires)
+}
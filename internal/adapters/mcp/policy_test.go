@@ -0,0 +1,336 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file returns nil policy", func(t *testing.T) {
+		policy, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if policy != nil {
+			t.Errorf("expected nil policy, got %+v", policy)
+		}
+	})
+
+	t.Run("parses tools", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		content := "read_only: true\ntools:\n  list_messages:\n    grants: [g1]\n  send_message:\n    confirm: true\n"
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		policy, err := LoadPolicy(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !policy.ReadOnly {
+			t.Error("expected read_only to be true")
+		}
+		if got := policy.Tools["list_messages"].Grants; len(got) != 1 || got[0] != "g1" {
+			t.Errorf("unexpected grants: %v", got)
+		}
+		if !policy.RequiresConfirmation("send_message") {
+			t.Error("expected send_message to require confirmation")
+		}
+	})
+
+	t.Run("rejects unknown action", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		if err := os.WriteFile(path, []byte("tools:\n  send_message:\n    action: maybe\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPolicy(path); err == nil {
+			t.Error("expected error for unknown action")
+		}
+	})
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  *Policy
+		tool    string
+		grant   string
+		allowed bool
+	}{
+		{"nil policy allows", nil, "send_message", "", true},
+		{"default allow", &Policy{}, "list_messages", "", true},
+		{"default deny blocks unlisted", &Policy{Default: PolicyDeny}, "list_messages", "", false},
+		{"default deny allows listed", &Policy{Default: PolicyDeny, Tools: map[string]ToolPolicy{"list_messages": {}}}, "list_messages", "", true},
+		{"explicit deny", &Policy{Tools: map[string]ToolPolicy{"send_message": {Action: PolicyDeny}}}, "send_message", "", false},
+		{"hidden tool blocked", &Policy{Tools: map[string]ToolPolicy{"update_event": {Action: PolicyHide}}}, "update_event", "", false},
+		{"read-only blocks writes", &Policy{ReadOnly: true}, "create_draft", "", false},
+		{"read-only allows reads", &Policy{ReadOnly: true}, "list_events", "", true},
+		{"grant scope allows listed grant", &Policy{Tools: map[string]ToolPolicy{"list_messages": {Grants: []string{"g1"}}}}, "list_messages", "g1", true},
+		{"grant scope blocks other grant", &Policy{Tools: map[string]ToolPolicy{"list_messages": {Grants: []string{"g1"}}}}, "list_messages", "g2", false},
+		{"grant scope requires grant", &Policy{Tools: map[string]ToolPolicy{"list_messages": {Grants: []string{"g1"}}}}, "list_messages", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Evaluate(tt.tool, tt.grant)
+			if got.Allowed != tt.allowed {
+				t.Errorf("Evaluate(%q, %q) allowed = %v, want %v (reason: %s)", tt.tool, tt.grant, got.Allowed, tt.allowed, got.Reason)
+			}
+			if !got.Allowed && got.Reason == "" {
+				t.Error("expected a reason for denial")
+			}
+		})
+	}
+}
+
+func TestHashArguments_IgnoresConfirmationToken(t *testing.T) {
+	t.Parallel()
+
+	a := HashArguments(map[string]any{"to": "a@example.com", "subject": "hi"})
+	b := HashArguments(map[string]any{"subject": "hi", "to": "a@example.com", confirmationTokenArg: "abc"})
+	if a != b {
+		t.Errorf("hashes differ: %s vs %s", a, b)
+	}
+	if a == HashArguments(map[string]any{"to": "b@example.com", "subject": "hi"}) {
+		t.Error("different arguments should hash differently")
+	}
+}
+
+func TestProxy_modifyToolsListResponse_AppliesPolicy(t *testing.T) {
+	t.Parallel()
+
+	proxy := NewProxy("test-api-key", "us")
+	proxy.SetPolicy(&Policy{
+		ReadOnly: true,
+		Tools: map[string]ToolPolicy{
+			"list_threads":  {Action: PolicyHide},
+			"list_messages": {Confirm: true},
+		},
+	})
+
+	response := []byte(`{"jsonrpc":"2.0","id":1,"result":{"tools":[
+		{"name":"list_messages","description":"List","inputSchema":{"type":"object","properties":{}}},
+		{"name":"list_threads","description":"Threads","inputSchema":{}},
+		{"name":"send_message","description":"Send","inputSchema":{}},
+		{"name":"current_time","description":"Time","inputSchema":{}}
+	]}}`)
+
+	var resp struct {
+		Result struct {
+			Tools []struct {
+				Name        string         `json:"name"`
+				Description string         `json:"description"`
+				InputSchema map[string]any `json:"inputSchema"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(proxy.modifyToolsListResponse(response), &resp); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	var names []string
+	for _, tool := range resp.Result.Tools {
+		names = append(names, tool.Name)
+	}
+	if strings.Join(names, ",") != "list_messages,current_time" {
+		t.Fatalf("unexpected tools: %v", names)
+	}
+
+	props, _ := resp.Result.Tools[0].InputSchema["properties"].(map[string]any)
+	if _, ok := props[confirmationTokenArg]; !ok {
+		t.Error("expected confirmation_token property on confirmed tool")
+	}
+}
+
+func TestProxy_handleToolCall_PolicyAndAudit(t *testing.T) {
+	t.Parallel()
+
+	var upstreamCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCalls++
+		var req rpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if _, ok := req.Params.Arguments[confirmationTokenArg]; ok {
+			t.Error("confirmation token should not be forwarded upstream")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"sent"}]}}`))
+	}))
+	defer server.Close()
+
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	proxy := NewProxy("test-api-key", "us")
+	proxy.endpoint = server.URL
+	proxy.SetDefaultGrant("grant-1")
+	proxy.SetAuditLog(NewAuditLog(auditPath))
+	proxy.SetPolicy(&Policy{Tools: map[string]ToolPolicy{
+		"send_message": {Confirm: true},
+		"create_event": {Action: PolicyDeny},
+	}})
+
+	call := func(line string) map[string]any {
+		t.Helper()
+		var resp map[string]any
+		if err := json.Unmarshal(proxy.handleMessage(t.Context(), []byte(line)), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return resp
+	}
+
+	// Denied tool never reaches upstream
+	call(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"create_event","arguments":{}}}`)
+
+	// First send returns a confirmation token
+	resp := call(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"send_message","arguments":{"to":"a@example.com"}}}`)
+	text := resp["result"].(map[string]any)["content"].([]any)[0].(map[string]any)["text"].(string)
+	idx := strings.Index(text, `"confirmation_token": "`)
+	if idx == -1 {
+		t.Fatalf("expected confirmation token in response, got %q", text)
+	}
+	token := text[idx+len(`"confirmation_token": "`):]
+	token = token[:strings.Index(token, `"`)]
+
+	// Confirmed retry is forwarded
+	call(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"send_message","arguments":{"to":"a@example.com","confirmation_token":"` + token + `"}}}`)
+
+	if upstreamCalls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", upstreamCalls)
+	}
+
+	entries, err := ReadAuditLog(auditPath, 0)
+	if err != nil {
+		t.Fatalf("ReadAuditLog failed: %v", err)
+	}
+	wantOutcomes := []string{AuditOutcomeDenied, AuditOutcomeConfirmationPending, AuditOutcomeOK}
+	if len(entries) != len(wantOutcomes) {
+		t.Fatalf("expected %d audit entries, got %d", len(wantOutcomes), len(entries))
+	}
+	for i, want := range wantOutcomes {
+		if entries[i].Outcome != want {
+			t.Errorf("entry %d outcome = %q, want %q", i, entries[i].Outcome, want)
+		}
+	}
+	if entries[2].Grant != "grant-1" {
+		t.Errorf("expected default grant in audit entry, got %q", entries[2].Grant)
+	}
+	if entries[1].ArgsHash != entries[2].ArgsHash {
+		t.Error("confirmed retry should have the same args hash")
+	}
+}
+
+func TestProxy_handleToolCall_GrantScopeCoversIdentifier(t *testing.T) {
+	t.Parallel()
+
+	var forwarded []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		forwarded = append(forwarded, req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"ok"}]}}`))
+	}))
+	defer server.Close()
+
+	proxy := NewProxy("test-api-key", "us")
+	proxy.endpoint = server.URL
+	proxy.SetDefaultGrant("grant-work")
+	proxy.SetGrantStore(&mockGrantStore{grants: []domain.GrantInfo{
+		{ID: "grant-work", Email: "work@example.com"},
+		{ID: "grant-home", Email: "home@example.com"},
+	}})
+	proxy.SetPolicy(&Policy{Tools: map[string]ToolPolicy{
+		"list_messages": {Grants: []string{"grant-work"}},
+	}})
+
+	isDenied := func(line string) bool {
+		t.Helper()
+		outcome, _ := toolResponseOutcome(proxy.handleMessage(t.Context(), []byte(line)))
+		return outcome == AuditOutcomeError
+	}
+
+	tests := []struct {
+		name   string
+		args   string
+		denied bool
+	}{
+		{"allowed email", `{"identifier":"work@example.com"}`, false},
+		{"other account's email", `{"identifier":"home@example.com"}`, true},
+		{"unknown email", `{"identifier":"someone@example.com"}`, true},
+		{"other account's grant ID", `{"identifier":"grant-home"}`, true},
+		{"allowed grant_id with other identifier", `{"grant_id":"grant-work","identifier":"home@example.com"}`, true},
+	}
+	for _, tt := range tests {
+		line := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_messages","arguments":` + tt.args + `}}`
+		if got := isDenied(line); got != tt.denied {
+			t.Errorf("%s: denied = %v, want %v", tt.name, got, tt.denied)
+		}
+	}
+	if len(forwarded) != 1 {
+		t.Errorf("expected 1 upstream call, got %d", len(forwarded))
+	}
+}
+
+func TestProxy_handleToolCall_KeepsParamsMeta(t *testing.T) {
+	t.Parallel()
+
+	var forwarded map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&forwarded)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"sent"}]}}`))
+	}))
+	defer server.Close()
+
+	proxy := NewProxy("test-api-key", "us")
+	proxy.endpoint = server.URL
+	proxy.SetDefaultGrant("grant-1")
+	proxy.SetPolicy(&Policy{Tools: map[string]ToolPolicy{"send_message": {Confirm: true}}})
+
+	argsHash := HashArguments(map[string]any{"to": "a@example.com"})
+	token := proxy.confirms.issue("send_message", argsHash)
+	line := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"send_message",` +
+		`"arguments":{"to":"a@example.com","confirmation_token":"` + token + `"},"_meta":{"progressToken":7}}}`
+	proxy.handleMessage(t.Context(), []byte(line))
+
+	params, _ := forwarded["params"].(map[string]any)
+	if meta, _ := params["_meta"].(map[string]any); meta["progressToken"] != float64(7) {
+		t.Errorf("params._meta = %v, want progressToken 7", params["_meta"])
+	}
+	args, _ := params["arguments"].(map[string]any)
+	if _, ok := args[confirmationTokenArg]; ok {
+		t.Error("confirmation token should not be forwarded upstream")
+	}
+	if args["grant_id"] != "grant-1" || args["to"] != "a@example.com" {
+		t.Errorf("arguments = %v", args)
+	}
+}
+
+func TestReadAuditLog_Limit(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log := NewAuditLog(path)
+	for _, tool := range []string{"a", "b", "c"} {
+		if err := log.Record(AuditEntry{Tool: tool, Outcome: AuditOutcomeOK}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := ReadAuditLog(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Tool != "b" || entries[1].Tool != "c" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
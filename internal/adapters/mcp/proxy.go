@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
 	"github.com/mqasimca/nylas/internal/ports"
 )
 
@@ -46,19 +47,25 @@ type rpcRequest struct {
 	Params  struct {
 		Name      string         `json:"name"`
 		Arguments map[string]any `json:"arguments"`
+		URI       string         `json:"uri,omitempty"`
 	} `json:"params"`
 }
 
 // Proxy forwards MCP requests from STDIO to the Nylas MCP server.
 type Proxy struct {
-	endpoint     string
-	apiKey       string
-	authHeader   string // Cached "Bearer <apiKey>" value
-	defaultGrant string
-	grantStore   ports.GrantStore
-	httpClient   *http.Client
-	sessionID    string
-	mu           sync.RWMutex
+	endpoint      string
+	apiKey        string
+	authHeader    string // Cached "Bearer <apiKey>" value
+	defaultGrant  string
+	grantStore    ports.GrantStore
+	templateStore ports.TemplateStore
+	messageCache  MessageCache
+	httpClient    *http.Client
+	sessionID     string
+	policy        *Policy
+	auditLog      *AuditLog
+	confirms      *confirmationStore
+	mu            sync.RWMutex
 }
 
 // NewProxy creates a new MCP proxy with the given API key and region.
@@ -67,9 +74,8 @@ func NewProxy(apiKey, region string) *Proxy {
 		endpoint:   GetMCPEndpoint(region),
 		apiKey:     apiKey,
 		authHeader: "Bearer " + apiKey, // Cache auth header
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
+		httpClient: httputil.NewClient(DefaultTimeout),
+		confirms:   newConfirmationStore(),
 	}
 }
 
@@ -90,6 +96,21 @@ func (p *Proxy) SetGrantStore(store ports.GrantStore) {
 	p.grantStore = store
 }
 
+// SetPolicy sets the tool policy applied to tools/list and tools/call.
+// A nil policy allows every tool.
+func (p *Proxy) SetPolicy(policy *Policy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policy = policy
+}
+
+// SetAuditLog sets the log that records every tools/call request.
+func (p *Proxy) SetAuditLog(log *AuditLog) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.auditLog = log
+}
+
 // Run starts the proxy, reading from stdin and writing to stdout.
 func (p *Proxy) Run(ctx context.Context) error {
 	reader := bufio.NewReader(os.Stdin)
@@ -117,48 +138,7 @@ func (p *Proxy) Run(ctx context.Context) error {
 			continue
 		}
 
-		// Parse JSON once for all operations
-		var req rpcRequest
-		if err := json.Unmarshal(line, &req); err != nil {
-			// Not valid JSON - forward as-is, let server handle error
-			response, fwdErr := p.forward(ctx, line, nil)
-			if fwdErr != nil {
-				errorResp := p.createErrorResponse(nil, fwdErr)
-				_, _ = writer.Write(append(errorResp, '\n'))
-				_ = writer.Flush()
-				continue
-			}
-			if len(response) > 0 {
-				_, _ = writer.Write(append(response, '\n'))
-				_ = writer.Flush()
-			}
-			continue
-		}
-
-		// Try to handle locally first (for get_grant without email)
-		if localResponse, handled := p.handleLocalToolCall(&req); handled {
-			if len(localResponse) > 0 {
-				if _, err := writer.Write(append(localResponse, '\n')); err != nil {
-					return fmt.Errorf("writing local response: %w", err)
-				}
-				_ = writer.Flush()
-			}
-			continue
-		}
-
-		// Forward to Nylas MCP server
-		response, err := p.forward(ctx, line, &req)
-		if err != nil {
-			// Write error response
-			errorResp := p.createErrorResponse(&req, err)
-			if _, writeErr := writer.Write(append(errorResp, '\n')); writeErr != nil {
-				return fmt.Errorf("writing error response: %w", writeErr)
-			}
-			_ = writer.Flush()
-			continue
-		}
-
-		// Write response
+		response := p.handleMessage(ctx, line)
 		if len(response) > 0 {
 			if _, err := writer.Write(append(response, '\n')); err != nil {
 				return fmt.Errorf("writing response: %w", err)
@@ -168,6 +148,38 @@ func (p *Proxy) Run(ctx context.Context) error {
 	}
 }
 
+// handleMessage processes a single JSON-RPC message and returns the response
+// to send back to the client, or nil if there is nothing to send.
+func (p *Proxy) handleMessage(ctx context.Context, line []byte) []byte {
+	// Parse JSON once for all operations
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		// Not valid JSON - forward as-is, let server handle error
+		response, fwdErr := p.forward(ctx, line, nil)
+		if fwdErr != nil {
+			return p.createErrorResponse(nil, fwdErr)
+		}
+		return response
+	}
+
+	// Tool calls go through policy checks and the audit log
+	if req.Method == "tools/call" {
+		return p.handleToolCall(ctx, line, &req)
+	}
+
+	// Serve local resources and prompts, merged with upstream ones
+	if response, handled := p.handleResourcesAndPrompts(ctx, line, &req); handled {
+		return response
+	}
+
+	// Forward to Nylas MCP server
+	response, err := p.forward(ctx, line, &req)
+	if err != nil {
+		return p.createErrorResponse(&req, err)
+	}
+	return response
+}
+
 // forward sends a request to the Nylas MCP server and returns the response.
 // The parsed rpcRequest is optional - if nil, request is forwarded as-is.
 func (p *Proxy) forward(ctx context.Context, request []byte, parsed *rpcRequest) ([]byte, error) {
@@ -366,11 +378,50 @@ func (p *Proxy) injectDefaultGrant(request []byte, parsed *rpcRequest) []byte {
 	// Inject the default grant_id
 	req.Params.Arguments["grant_id"] = defaultGrant
 
-	// Re-marshal the request
-	modified, err := json.Marshal(req)
+	grantJSON, err := json.Marshal(defaultGrant)
+	if err != nil {
+		return request
+	}
+	modified, err := editArguments(request, func(args map[string]json.RawMessage) {
+		args["grant_id"] = grantJSON
+	})
 	if err != nil {
-		return request // Marshal failed, use original
+		return request // Edit failed, use original
 	}
 
 	return modified
 }
+
+// editArguments applies edit to the arguments of a tools/call request. Only
+// the arguments are re-encoded, so other fields (such as params._meta) pass
+// through untouched.
+func editArguments(request []byte, edit func(args map[string]json.RawMessage)) ([]byte, error) {
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(request, &msg); err != nil {
+		return nil, err
+	}
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(msg["params"], &params); err != nil {
+		return nil, err
+	}
+	var args map[string]json.RawMessage
+	if raw, ok := params["arguments"]; ok {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+	}
+	if args == nil {
+		args = make(map[string]json.RawMessage)
+	}
+
+	edit(args)
+
+	var err error
+	if params["arguments"], err = json.Marshal(args); err != nil {
+		return nil, err
+	}
+	if msg["params"], err = json.Marshal(params); err != nil {
+		return nil, err
+	}
+	return json.Marshal(msg)
+}
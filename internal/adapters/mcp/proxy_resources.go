@@ -0,0 +1,455 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/templates"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// Local resource URIs served by the proxy without a network round trip.
+const (
+	resourceScheme       = "nylas://"
+	resourceGrants       = "nylas://grants"
+	resourceTemplates    = "nylas://templates"
+	resourceThreads      = "nylas://threads"
+	templatePromptPrefix = "template-"
+
+	// threadResourceLimit caps how many cached threads nylas://threads lists.
+	threadResourceLimit = 50
+)
+
+// Tools whose policy rules also govern the local resources, so a policy
+// can't be sidestepped by reading a resource instead of calling a tool.
+// Templates have no upstream tool; list_templates names their rule.
+const (
+	grantsResourceTool    = "get_grant"
+	threadsResourceTool   = "list_threads"
+	templatesResourceTool = "list_templates"
+)
+
+// CachedThread summarizes a locally cached email thread.
+type CachedThread struct {
+	ID           string    `json:"id"`
+	Subject      string    `json:"subject"`
+	Participants []string  `json:"participants"`
+	MessageCount int       `json:"message_count"`
+	Unread       bool      `json:"unread"`
+	LastMessage  time.Time `json:"last_message"`
+}
+
+// CachedMessage is a locally cached email message.
+type CachedMessage struct {
+	ID       string    `json:"id"`
+	ThreadID string    `json:"thread_id"`
+	From     string    `json:"from"`
+	To       []string  `json:"to,omitempty"`
+	Subject  string    `json:"subject"`
+	Date     time.Time `json:"date"`
+	Body     string    `json:"body"`
+}
+
+// MessageCache provides access to locally cached messages (such as the Air cache).
+type MessageCache interface {
+	// RecentThreads returns the most recently active cached threads for an account.
+	RecentThreads(email string, limit int) ([]CachedThread, error)
+
+	// ThreadMessages returns the cached messages in a thread, oldest first.
+	ThreadMessages(email, threadID string) ([]CachedMessage, error)
+}
+
+// SetTemplateStore sets the store used for nylas://templates resources and template prompts.
+func (p *Proxy) SetTemplateStore(store ports.TemplateStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.templateStore = store
+}
+
+// SetMessageCache sets the cache used for nylas://threads resources.
+func (p *Proxy) SetMessageCache(cache MessageCache) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messageCache = cache
+}
+
+// hasLocalResources reports whether the proxy can serve any local resources or prompts.
+func (p *Proxy) hasLocalResources() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.grantStore != nil || p.templateStore != nil || p.messageCache != nil
+}
+
+// rpcResult builds a JSON-RPC success response.
+func rpcResult(id any, result any) []byte {
+	resp, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  result,
+	})
+	if err != nil {
+		return fallbackErrorResponse
+	}
+	return resp
+}
+
+// rpcError builds a JSON-RPC error response with the given code.
+func rpcError(id any, code int, message string) []byte {
+	resp, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+		},
+	})
+	if err != nil {
+		return fallbackErrorResponse
+	}
+	return resp
+}
+
+// handleResourcesAndPrompts serves resources/* and prompts/* requests, merging local
+// entries with whatever the upstream server advertises.
+// Returns false if the request is not a resource or prompt request.
+func (p *Proxy) handleResourcesAndPrompts(ctx context.Context, line []byte, req *rpcRequest) ([]byte, bool) {
+	switch req.Method {
+	case "resources/list":
+		return p.mergeList(ctx, line, req, "resources", p.localResources(ctx)), true
+	case "resources/templates/list":
+		return p.mergeList(ctx, line, req, "resourceTemplates", p.localResourceTemplates()), true
+	case "prompts/list":
+		return p.mergeList(ctx, line, req, "prompts", p.localPrompts(ctx)), true
+	case "resources/read":
+		if strings.HasPrefix(req.Params.URI, resourceScheme) {
+			return p.readLocalResource(ctx, req), true
+		}
+	case "prompts/get":
+		if strings.HasPrefix(req.Params.Name, templatePromptPrefix) {
+			return p.getTemplatePrompt(ctx, req), true
+		}
+	}
+	return nil, false
+}
+
+// mergeList forwards a list request upstream and prepends local entries to the result.
+// If the upstream server does not support the method, only local entries are returned.
+func (p *Proxy) mergeList(ctx context.Context, line []byte, req *rpcRequest, key string, local []map[string]any) []byte {
+	result := map[string]any{}
+	merged := make([]any, 0, len(local))
+	for _, item := range local {
+		merged = append(merged, item)
+	}
+
+	if upstream, err := p.forward(ctx, line, req); err == nil && len(upstream) > 0 {
+		var resp struct {
+			Result map[string]any `json:"result"`
+		}
+		if json.Unmarshal(upstream, &resp) == nil && resp.Result != nil {
+			result = resp.Result
+			if items, ok := resp.Result[key].([]any); ok {
+				merged = append(merged, items...)
+			}
+		}
+	}
+
+	result[key] = merged
+	return rpcResult(req.ID, result)
+}
+
+// localResources lists the concrete local resources.
+func (p *Proxy) localResources(ctx context.Context) []map[string]any {
+	grantStore, templateStore, messageCache := p.allowedLocalSources()
+
+	var resources []map[string]any
+	if grantStore != nil {
+		resources = append(resources, map[string]any{
+			"uri":         resourceGrants,
+			"name":        "Authenticated grants",
+			"description": "Email accounts authenticated in the Nylas CLI, including the default grant",
+			"mimeType":    "application/json",
+		})
+	}
+	if templateStore != nil {
+		resources = append(resources, map[string]any{
+			"uri":         resourceTemplates,
+			"name":        "Email templates",
+			"description": "Locally stored email templates",
+			"mimeType":    "application/json",
+		})
+		if list, err := templateStore.List(ctx, ""); err == nil {
+			for _, t := range list {
+				resources = append(resources, map[string]any{
+					"uri":         resourceTemplates + "/" + t.ID,
+					"name":        "Template: " + t.Name,
+					"description": t.Subject,
+					"mimeType":    "application/json",
+				})
+			}
+		}
+	}
+	if messageCache != nil {
+		resources = append(resources, map[string]any{
+			"uri":         resourceThreads,
+			"name":        "Cached threads",
+			"description": "Recent email threads from the local Air cache for the default account",
+			"mimeType":    "application/json",
+		})
+	}
+	return resources
+}
+
+// localResourceTemplates lists the parameterized local resources.
+func (p *Proxy) localResourceTemplates() []map[string]any {
+	_, templateStore, messageCache := p.allowedLocalSources()
+
+	var list []map[string]any
+	if templateStore != nil {
+		list = append(list, map[string]any{
+			"uriTemplate": resourceTemplates + "/{id}",
+			"name":        "Email template",
+			"description": "A locally stored email template by ID",
+			"mimeType":    "application/json",
+		})
+	}
+	if messageCache != nil {
+		list = append(list, map[string]any{
+			"uriTemplate": resourceThreads + "/{id}",
+			"name":        "Cached thread",
+			"description": "All cached messages in an email thread, oldest first",
+			"mimeType":    "application/json",
+		})
+	}
+	return list
+}
+
+// readLocalResource serves resources/read for nylas:// URIs.
+func (p *Proxy) readLocalResource(ctx context.Context, req *rpcRequest) []byte {
+	uri := req.Params.URI
+
+	data, err := p.localResourceData(ctx, uri)
+	if err != nil {
+		return rpcError(req.ID, -32002, err.Error())
+	}
+
+	text, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return rpcError(req.ID, -32603, fmt.Sprintf("encoding resource: %v", err))
+	}
+
+	return rpcResult(req.ID, map[string]any{
+		"contents": []map[string]any{
+			{
+				"uri":      uri,
+				"mimeType": "application/json",
+				"text":     string(text),
+			},
+		},
+	})
+}
+
+// allowedLocalSources returns the stores behind the local resources, with
+// the ones the policy hides set to nil.
+func (p *Proxy) allowedLocalSources() (ports.GrantStore, ports.TemplateStore, MessageCache) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	grantStore, templateStore, messageCache := p.grantStore, p.templateStore, p.messageCache
+	if p.policy.IsHidden(grantsResourceTool) {
+		grantStore = nil
+	}
+	if p.policy.IsHidden(templatesResourceTool) {
+		templateStore = nil
+	}
+	if p.policy.IsHidden(threadsResourceTool) {
+		messageCache = nil
+	}
+	return grantStore, templateStore, messageCache
+}
+
+// localResourceData resolves a nylas:// URI to the data it represents.
+func (p *Proxy) localResourceData(ctx context.Context, uri string) (any, error) {
+	p.mu.RLock()
+	grantStore, templateStore, messageCache := p.grantStore, p.templateStore, p.messageCache
+	defaultGrant, policy := p.defaultGrant, p.policy
+	p.mu.RUnlock()
+
+	switch {
+	case uri == resourceGrants && grantStore != nil:
+		if policy.IsHidden(grantsResourceTool) {
+			break
+		}
+		grants, err := grantStore.ListGrants()
+		if err != nil {
+			return nil, fmt.Errorf("listing grants: %w", err)
+		}
+		list := make([]map[string]any, 0, len(grants))
+		for _, g := range grants {
+			// Only list the grants the assistant may use
+			if !policy.Evaluate(grantsResourceTool, g.ID).Allowed {
+				continue
+			}
+			list = append(list, map[string]any{
+				"grant_id": g.ID,
+				"email":    g.Email,
+				"provider": string(g.Provider),
+				"default":  g.ID == defaultGrant,
+			})
+		}
+		return list, nil
+
+	case (uri == resourceTemplates || strings.HasPrefix(uri, resourceTemplates+"/")) && templateStore != nil:
+		if policy.IsHidden(templatesResourceTool) {
+			break
+		}
+		if uri == resourceTemplates {
+			return templateStore.List(ctx, "")
+		}
+		return templateStore.Get(ctx, strings.TrimPrefix(uri, resourceTemplates+"/"))
+
+	case (uri == resourceThreads || strings.HasPrefix(uri, resourceThreads+"/")) && messageCache != nil:
+		if policy.IsHidden(threadsResourceTool) {
+			break
+		}
+		grant := p.defaultGrantInfo()
+		if grant == nil {
+			return nil, fmt.Errorf("no default account for cached threads")
+		}
+		if decision := policy.Evaluate(threadsResourceTool, grant.ID); !decision.Allowed {
+			return nil, fmt.Errorf("%s", decision.Reason)
+		}
+		if uri == resourceThreads {
+			return messageCache.RecentThreads(grant.Email, threadResourceLimit)
+		}
+		messages, err := messageCache.ThreadMessages(grant.Email, strings.TrimPrefix(uri, resourceThreads+"/"))
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) == 0 {
+			return nil, fmt.Errorf("thread not found in local cache: %s", uri)
+		}
+		return messages, nil
+	}
+
+	return nil, fmt.Errorf("resource not found: %s", uri)
+}
+
+// defaultGrantInfo returns the default grant, or the first grant.
+func (p *Proxy) defaultGrantInfo() *domain.GrantInfo {
+	p.mu.RLock()
+	grantStore, defaultGrant := p.grantStore, p.defaultGrant
+	p.mu.RUnlock()
+
+	if grantStore == nil {
+		return nil
+	}
+	if defaultGrant != "" {
+		if info, err := grantStore.GetGrant(defaultGrant); err == nil {
+			return info
+		}
+	}
+	if grants, err := grantStore.ListGrants(); err == nil && len(grants) > 0 {
+		return &grants[0]
+	}
+	return nil
+}
+
+// localPrompts builds one prompt per stored email template.
+func (p *Proxy) localPrompts(ctx context.Context) []map[string]any {
+	_, templateStore, _ := p.allowedLocalSources()
+
+	if templateStore == nil {
+		return nil
+	}
+	list, err := templateStore.List(ctx, "")
+	if err != nil {
+		return nil
+	}
+
+	prompts := make([]map[string]any, 0, len(list))
+	for _, t := range list {
+		args := make([]map[string]any, 0, len(t.Variables))
+		for _, v := range t.Variables {
+			args = append(args, map[string]any{
+				"name":        v,
+				"description": fmt.Sprintf("Value for {{%s}}", v),
+				"required":    false,
+			})
+		}
+		prompts = append(prompts, map[string]any{
+			"name":        templatePromptPrefix + t.ID,
+			"description": fmt.Sprintf("Draft an email from the %q template", t.Name),
+			"arguments":   args,
+		})
+	}
+	return prompts
+}
+
+// getTemplatePrompt serves prompts/get for template prompts.
+func (p *Proxy) getTemplatePrompt(ctx context.Context, req *rpcRequest) []byte {
+	_, templateStore, _ := p.allowedLocalSources()
+
+	if templateStore == nil {
+		return rpcError(req.ID, -32602, "prompt not found: "+req.Params.Name)
+	}
+
+	tpl, err := templateStore.Get(ctx, strings.TrimPrefix(req.Params.Name, templatePromptPrefix))
+	if err != nil {
+		return rpcError(req.ID, -32602, "prompt not found: "+req.Params.Name)
+	}
+
+	return rpcResult(req.ID, map[string]any{
+		"description": fmt.Sprintf("Draft an email from the %q template", tpl.Name),
+		"messages": []map[string]any{
+			{
+				"role": "user",
+				"content": map[string]any{
+					"type": "text",
+					"text": buildTemplatePrompt(tpl, req.Params.Arguments),
+				},
+			},
+		},
+	})
+}
+
+// buildTemplatePrompt renders a template into instructions for the assistant.
+func buildTemplatePrompt(tpl *domain.EmailTemplate, arguments map[string]any) string {
+	vars := make(map[string]string, len(arguments))
+	for k, v := range arguments {
+		if s, ok := v.(string); ok {
+			vars[k] = s
+		}
+	}
+
+	body := tpl.TextBody
+	if body == "" {
+		body = tpl.HTMLBody
+	}
+	subject, missingSubject := templates.ExpandVariables(tpl.Subject, vars)
+	body, missingBody := templates.ExpandVariables(body, vars)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Draft an email using the %q template.\n\n", tpl.Name)
+	fmt.Fprintf(&sb, "Subject: %s\n\n%s\n", subject, body)
+	if missing := append(missingSubject, missingBody...); len(missing) > 0 {
+		fmt.Fprintf(&sb, "\nAsk the user for values of these placeholders before sending: %s\n", strings.Join(uniqueStrings(missing), ", "))
+	}
+	return sb.String()
+}
+
+// uniqueStrings returns values with duplicates removed, preserving order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
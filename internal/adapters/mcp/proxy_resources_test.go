@@ -0,0 +1,293 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/templates"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+type mockMessageCache struct {
+	threads  []CachedThread
+	messages map[string][]CachedMessage
+}
+
+func (m *mockMessageCache) RecentThreads(email string, limit int) ([]CachedThread, error) {
+	return m.threads, nil
+}
+
+func (m *mockMessageCache) ThreadMessages(email, threadID string) ([]CachedMessage, error) {
+	return m.messages[threadID], nil
+}
+
+func newResourceTestProxy(t *testing.T, upstream http.HandlerFunc) (*Proxy, *domain.EmailTemplate) {
+	t.Helper()
+
+	server := httptest.NewServer(upstream)
+	t.Cleanup(server.Close)
+
+	store := templates.NewFileStore(filepath.Join(t.TempDir(), "templates.json"))
+	tpl, err := store.Create(t.Context(), &domain.EmailTemplate{
+		Name:     "Welcome",
+		Subject:  "Welcome {{name}}",
+		HTMLBody: "Hi {{name}}, welcome to {{company}}.",
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+
+	proxy := NewProxy("test-api-key", "us")
+	proxy.endpoint = server.URL
+	proxy.SetDefaultGrant("grant-1")
+	proxy.SetGrantStore(&mockGrantStore{grants: []domain.GrantInfo{
+		{ID: "grant-1", Email: "user@example.com", Provider: "google"},
+		{ID: "grant-2", Email: "other@example.com", Provider: "microsoft"},
+	}})
+	proxy.SetTemplateStore(store)
+	proxy.SetMessageCache(&mockMessageCache{
+		threads: []CachedThread{{ID: "thread-1", Subject: "Hello", MessageCount: 2, LastMessage: time.Now()}},
+		messages: map[string][]CachedMessage{
+			"thread-1": {{ID: "m1", ThreadID: "thread-1", Subject: "Hello", Body: "first"}},
+		},
+	})
+	return proxy, tpl
+}
+
+func methodNotFound(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`))
+}
+
+func TestProxy_ResourcesList_MergesUpstream(t *testing.T) {
+	t.Parallel()
+
+	proxy, tpl := newResourceTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"resources":[{"uri":"https://upstream/doc","name":"Docs"}]}}`))
+	})
+
+	resp := proxy.handleMessage(t.Context(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+
+	var parsed struct {
+		Result struct {
+			Resources []struct {
+				URI string `json:"uri"`
+			} `json:"resources"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	var uris []string
+	for _, r := range parsed.Result.Resources {
+		uris = append(uris, r.URI)
+	}
+	joined := strings.Join(uris, ",")
+	for _, want := range []string{resourceGrants, resourceTemplates + "/" + tpl.ID, resourceThreads, "https://upstream/doc"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected %s in resources, got %v", want, uris)
+		}
+	}
+}
+
+func TestProxy_ResourcesRead(t *testing.T) {
+	t.Parallel()
+
+	proxy, tpl := newResourceTestProxy(t, methodNotFound)
+
+	tests := []struct {
+		name     string
+		uri      string
+		contains string
+		wantErr  bool
+	}{
+		{"grants", resourceGrants, `"default": true`, false},
+		{"template by id", resourceTemplates + "/" + tpl.ID, "Welcome {{name}}", false},
+		{"cached threads", resourceThreads, "thread-1", false},
+		{"cached thread", resourceThreads + "/thread-1", "first", false},
+		{"unknown thread", resourceThreads + "/missing", "", true},
+		{"unknown resource", "nylas://nothing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"` + tt.uri + `"}}`
+			var parsed struct {
+				Error  *struct{ Message string } `json:"error"`
+				Result struct {
+					Contents []struct {
+						Text string `json:"text"`
+					} `json:"contents"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(proxy.handleMessage(t.Context(), []byte(req)), &parsed); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if tt.wantErr {
+				if parsed.Error == nil {
+					t.Error("expected error response")
+				}
+				return
+			}
+			if parsed.Error != nil {
+				t.Fatalf("unexpected error: %s", parsed.Error.Message)
+			}
+			if len(parsed.Result.Contents) != 1 || !strings.Contains(parsed.Result.Contents[0].Text, tt.contains) {
+				t.Errorf("expected contents to contain %q, got %+v", tt.contains, parsed.Result.Contents)
+			}
+		})
+	}
+}
+
+func TestProxy_ResourcesRead_AppliesPolicy(t *testing.T) {
+	t.Parallel()
+
+	read := func(proxy *Proxy, uri string) (string, bool) {
+		t.Helper()
+		req := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"` + uri + `"}}`
+		var parsed struct {
+			Error  *struct{ Message string } `json:"error"`
+			Result struct {
+				Contents []struct {
+					Text string `json:"text"`
+				} `json:"contents"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(proxy.handleMessage(t.Context(), []byte(req)), &parsed); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+		if parsed.Error != nil || len(parsed.Result.Contents) == 0 {
+			return "", false
+		}
+		return parsed.Result.Contents[0].Text, true
+	}
+
+	t.Run("grants are filtered to the allowed ones", func(t *testing.T) {
+		proxy, _ := newResourceTestProxy(t, methodNotFound)
+		proxy.SetPolicy(&Policy{Tools: map[string]ToolPolicy{
+			grantsResourceTool: {Grants: []string{"grant-2"}},
+		}})
+
+		text, ok := read(proxy, resourceGrants)
+		if !ok || strings.Contains(text, "grant-1") || !strings.Contains(text, "grant-2") {
+			t.Errorf("grants = %s (ok %v), want only grant-2", text, ok)
+		}
+	})
+
+	t.Run("threads are denied when the default grant isn't allowed", func(t *testing.T) {
+		proxy, _ := newResourceTestProxy(t, methodNotFound)
+		proxy.SetPolicy(&Policy{Tools: map[string]ToolPolicy{
+			threadsResourceTool: {Grants: []string{"grant-2"}},
+		}})
+
+		if _, ok := read(proxy, resourceThreads); ok {
+			t.Error("expected nylas://threads to be denied")
+		}
+		if _, ok := read(proxy, resourceThreads+"/thread-1"); ok {
+			t.Error("expected nylas://threads/thread-1 to be denied")
+		}
+	})
+
+	t.Run("hidden resources are neither listed nor read", func(t *testing.T) {
+		proxy, tpl := newResourceTestProxy(t, methodNotFound)
+		proxy.SetPolicy(&Policy{Default: PolicyDeny, Tools: map[string]ToolPolicy{
+			threadsResourceTool: {},
+		}})
+
+		for _, uri := range []string{resourceGrants, resourceTemplates, resourceTemplates + "/" + tpl.ID} {
+			if _, ok := read(proxy, uri); ok {
+				t.Errorf("expected %s to be denied", uri)
+			}
+		}
+		if _, ok := read(proxy, resourceThreads); !ok {
+			t.Error("expected nylas://threads to be allowed")
+		}
+
+		var uris []string
+		for _, r := range proxy.localResources(t.Context()) {
+			uris = append(uris, r["uri"].(string))
+		}
+		if strings.Join(uris, ",") != resourceThreads {
+			t.Errorf("resources = %v, want only %s", uris, resourceThreads)
+		}
+		if prompts := proxy.localPrompts(t.Context()); len(prompts) != 0 {
+			t.Errorf("expected no template prompts, got %d", len(prompts))
+		}
+	})
+}
+
+func TestProxy_Prompts(t *testing.T) {
+	t.Parallel()
+
+	proxy, tpl := newResourceTestProxy(t, methodNotFound)
+
+	// prompts/list falls back to local prompts when upstream lacks support
+	var list struct {
+		Result struct {
+			Prompts []struct {
+				Name      string `json:"name"`
+				Arguments []struct {
+					Name string `json:"name"`
+				} `json:"arguments"`
+			} `json:"prompts"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(proxy.handleMessage(t.Context(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`)), &list); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(list.Result.Prompts) != 1 || list.Result.Prompts[0].Name != templatePromptPrefix+tpl.ID {
+		t.Fatalf("unexpected prompts: %+v", list.Result.Prompts)
+	}
+	if len(list.Result.Prompts[0].Arguments) != 2 {
+		t.Errorf("expected 2 prompt arguments, got %d", len(list.Result.Prompts[0].Arguments))
+	}
+
+	req := `{"jsonrpc":"2.0","id":2,"method":"prompts/get","params":{"name":"` + templatePromptPrefix + tpl.ID + `","arguments":{"name":"Ada"}}}`
+	var get struct {
+		Result struct {
+			Messages []struct {
+				Content struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"messages"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(proxy.handleMessage(t.Context(), []byte(req)), &get); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(get.Result.Messages) != 1 {
+		t.Fatalf("expected one prompt message, got %d", len(get.Result.Messages))
+	}
+	text := get.Result.Messages[0].Content.Text
+	if !strings.Contains(text, "Welcome Ada") || !strings.Contains(text, "company") {
+		t.Errorf("unexpected prompt text: %q", text)
+	}
+}
+
+func TestProxy_modifyInitializeResponse_AdvertisesCapabilities(t *testing.T) {
+	t.Parallel()
+
+	proxy, _ := newResourceTestProxy(t, methodNotFound)
+	resp := proxy.modifyInitializeResponse([]byte(`{"jsonrpc":"2.0","id":1,"result":{"capabilities":{"tools":{}}}}`))
+
+	var parsed struct {
+		Result struct {
+			Capabilities map[string]any `json:"capabilities"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	for _, c := range []string{"tools", "resources", "prompts"} {
+		if _, ok := parsed.Result.Capabilities[c]; !ok {
+			t.Errorf("expected %s capability", c)
+		}
+	}
+}
@@ -148,8 +148,9 @@ func (p *Proxy) createErrorResponse(req *rpcRequest, originalErr error) []byte {
 	return respBytes
 }
 
-// modifyToolsListResponse modifies the tools/list response to make get_grant email optional.
-// This allows AI assistants to call get_grant without providing an email,
+// modifyToolsListResponse modifies the tools/list response to make get_grant email optional
+// and to apply the tool policy (hidden tools are removed, confirmed tools gain a token argument).
+// Making email optional allows AI assistants to call get_grant without providing an email,
 // which triggers the local grant lookup in handleLocalToolCall.
 func (p *Proxy) modifyToolsListResponse(response []byte) []byte {
 	// Parse the JSON-RPC response
@@ -169,43 +170,35 @@ func (p *Proxy) modifyToolsListResponse(response []byte) []byte {
 		return response
 	}
 
-	// Find and modify the get_grant tool
+	p.mu.RLock()
+	policy := p.policy
+	p.mu.RUnlock()
+
+	filtered := make([]any, 0, len(tools))
 	for _, tool := range tools {
 		toolMap, ok := tool.(map[string]any)
 		if !ok {
+			filtered = append(filtered, tool)
 			continue
 		}
 
-		name, ok := toolMap["name"].(string)
-		if !ok || name != "get_grant" {
-			continue
-		}
+		name, _ := toolMap["name"].(string)
 
-		// Found get_grant - modify its inputSchema to make email optional
-		inputSchema, ok := toolMap["inputSchema"].(map[string]any)
-		if !ok {
+		// Drop tools the policy hides or denies
+		if policy.IsHidden(name) {
 			continue
 		}
+		filtered = append(filtered, tool)
 
-		// Remove "email" from required array
-		required, ok := inputSchema["required"].([]any)
-		if ok {
-			newRequired := make([]any, 0, len(required))
-			for _, r := range required {
-				if r != "email" {
-					newRequired = append(newRequired, r)
-				}
-			}
-			inputSchema["required"] = newRequired
+		if policy.RequiresConfirmation(name) {
+			addConfirmationToken(toolMap)
 		}
 
-		// Update the description to indicate email is optional
-		if desc, ok := toolMap["description"].(string); ok {
-			toolMap["description"] = desc + " If email is not provided, returns the default authenticated grant."
+		if name == "get_grant" {
+			makeGrantEmailOptional(toolMap)
 		}
-
-		break
 	}
+	result["tools"] = filtered
 
 	// Re-marshal the modified response
 	modified, err := json.Marshal(rpcResp)
@@ -216,8 +209,56 @@ func (p *Proxy) modifyToolsListResponse(response []byte) []byte {
 	return modified
 }
 
+// makeGrantEmailOptional modifies the get_grant inputSchema to make email optional.
+func makeGrantEmailOptional(toolMap map[string]any) {
+	inputSchema, ok := toolMap["inputSchema"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	// Remove "email" from required array
+	required, ok := inputSchema["required"].([]any)
+	if ok {
+		newRequired := make([]any, 0, len(required))
+		for _, r := range required {
+			if r != "email" {
+				newRequired = append(newRequired, r)
+			}
+		}
+		inputSchema["required"] = newRequired
+	}
+
+	// Update the description to indicate email is optional
+	if desc, ok := toolMap["description"].(string); ok {
+		toolMap["description"] = desc + " If email is not provided, returns the default authenticated grant."
+	}
+}
+
+// addConfirmationToken advertises the confirmation_token argument on tools
+// that the policy requires to be confirmed.
+func addConfirmationToken(toolMap map[string]any) {
+	if desc, ok := toolMap["description"].(string); ok {
+		toolMap["description"] = desc + " Requires user confirmation: the first call returns a confirmation_token to pass on the confirmed retry."
+	}
+
+	inputSchema, ok := toolMap["inputSchema"].(map[string]any)
+	if !ok {
+		return
+	}
+	properties, ok := inputSchema["properties"].(map[string]any)
+	if !ok {
+		properties = make(map[string]any)
+		inputSchema["properties"] = properties
+	}
+	properties[confirmationTokenArg] = map[string]any{
+		"type":        "string",
+		"description": "Token returned by the first call, passed once the user has confirmed the action.",
+	}
+}
+
 // modifyInitializeResponse enhances the initialize response with timezone guidance.
 // This ensures AI assistants display all timestamps consistently in the user's timezone.
+// It also advertises the resources and prompts capabilities served locally.
 func (p *Proxy) modifyInitializeResponse(response []byte) []byte {
 	// Parse the JSON-RPC response
 	var rpcResp map[string]any
@@ -252,6 +293,21 @@ When displaying ANY timestamps to users (from emails, events, availability, etc.
 3. Format times clearly (e.g., "2:00 PM %s")`, tzName, localZone, tzName, localZone, localZone)
 
 	result["instructions"] = instructions + timezoneGuidance
+
+	// Advertise resources and prompts when the proxy serves local ones
+	if p.hasLocalResources() {
+		capabilities, ok := result["capabilities"].(map[string]any)
+		if !ok {
+			capabilities = make(map[string]any)
+			result["capabilities"] = capabilities
+		}
+		for _, capability := range []string{"resources", "prompts"} {
+			if _, exists := capabilities[capability]; !exists {
+				capabilities[capability] = map[string]any{}
+			}
+		}
+	}
+
 	rpcResp["result"] = result
 
 	// Re-marshal the modified response
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// handleToolCall applies the tool policy, runs the call locally or upstream,
+// and records the outcome in the audit log.
+func (p *Proxy) handleToolCall(ctx context.Context, line []byte, req *rpcRequest) []byte {
+	start := time.Now()
+	tool := req.Params.Name
+	argsHash := HashArguments(req.Params.Arguments)
+	grant := p.effectiveGrant(req)
+
+	response, outcome, errMsg := p.executeToolCall(ctx, line, req, grant, argsHash)
+
+	p.mu.RLock()
+	auditLog := p.auditLog
+	p.mu.RUnlock()
+	if auditLog != nil {
+		entry := AuditEntry{
+			Time:      start.UTC(),
+			Tool:      tool,
+			ArgsHash:  argsHash,
+			Grant:     grant,
+			Outcome:   outcome,
+			LatencyMS: time.Since(start).Milliseconds(),
+			Error:     errMsg,
+		}
+		if err := auditLog.Record(entry); err != nil {
+			log.Printf("mcp: failed to write audit log: %v", err)
+		}
+	}
+
+	return response
+}
+
+// executeToolCall runs a tool call and returns the response, audit outcome and error text.
+func (p *Proxy) executeToolCall(ctx context.Context, line []byte, req *rpcRequest, grant, argsHash string) ([]byte, string, string) {
+	p.mu.RLock()
+	policy := p.policy
+	p.mu.RUnlock()
+
+	tool := req.Params.Name
+
+	if decision := policy.Evaluate(tool, grant); !decision.Allowed {
+		return p.createToolErrorResponse(req.ID, decision.Reason), AuditOutcomeDenied, decision.Reason
+	}
+	// A call may name a second account by identifier; it must be allowed too.
+	if identifier, _ := req.Params.Arguments["identifier"].(string); identifier != "" {
+		if other := p.resolveIdentifier(identifier); other != grant {
+			if decision := policy.Evaluate(tool, other); !decision.Allowed {
+				return p.createToolErrorResponse(req.ID, decision.Reason), AuditOutcomeDenied, decision.Reason
+			}
+		}
+	}
+
+	if policy.RequiresConfirmation(tool) {
+		token, _ := req.Params.Arguments[confirmationTokenArg].(string)
+		if token == "" || !p.confirms.redeem(token, tool, argsHash) {
+			newToken := p.confirms.issue(tool, argsHash)
+			msg := fmt.Sprintf("Tool %s requires confirmation. Show the user exactly what will happen and, "+
+				"once they approve, call %s again with the same arguments plus %q: %q.",
+				tool, tool, confirmationTokenArg, newToken)
+			return p.createToolErrorResponse(req.ID, msg), AuditOutcomeConfirmationPending, ""
+		}
+
+		// Strip the token so the upstream server never sees it
+		delete(req.Params.Arguments, confirmationTokenArg)
+		if stripped, err := editArguments(line, func(args map[string]json.RawMessage) {
+			delete(args, confirmationTokenArg)
+		}); err == nil {
+			line = stripped
+		}
+	}
+
+	if localResponse, handled := p.handleLocalToolCall(req); handled {
+		if outcome, errMsg := toolResponseOutcome(localResponse); outcome == AuditOutcomeError {
+			return localResponse, outcome, errMsg
+		}
+		return localResponse, AuditOutcomeLocal, ""
+	}
+
+	response, err := p.forward(ctx, line, req)
+	if err != nil {
+		return p.createErrorResponse(req, err), AuditOutcomeError, err.Error()
+	}
+	outcome, errMsg := toolResponseOutcome(response)
+	return response, outcome, errMsg
+}
+
+// effectiveGrant returns the grant a tool call will run against.
+func (p *Proxy) effectiveGrant(req *rpcRequest) string {
+	if grantID, ok := req.Params.Arguments["grant_id"].(string); ok && grantID != "" {
+		return grantID
+	}
+	if identifier, ok := req.Params.Arguments["identifier"].(string); ok && identifier != "" {
+		return p.resolveIdentifier(identifier)
+	}
+	if !toolsRequiringGrant[req.Params.Name] {
+		return ""
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.defaultGrant
+}
+
+// resolveIdentifier returns the grant ID for an identifier argument, which
+// may be a grant ID or an email address. Identifiers the grant store doesn't
+// know are returned as given, so they never match a policy's grant list.
+func (p *Proxy) resolveIdentifier(identifier string) string {
+	p.mu.RLock()
+	store := p.grantStore
+	p.mu.RUnlock()
+	if store == nil {
+		return identifier
+	}
+
+	if info, err := store.GetGrant(identifier); err == nil {
+		return info.ID
+	}
+	if info, err := store.GetGrantByEmail(identifier); err == nil {
+		return info.ID
+	}
+	return identifier
+}
+
+// toolResponseOutcome classifies a tools/call response for the audit log.
+func toolResponseOutcome(response []byte) (string, string) {
+	var resp struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Result struct {
+			IsError bool `json:"isError"`
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(response, &resp); err != nil {
+		return AuditOutcomeOK, ""
+	}
+	if resp.Error != nil {
+		return AuditOutcomeError, resp.Error.Message
+	}
+	if resp.Result.IsError {
+		msg := ""
+		if len(resp.Result.Content) > 0 {
+			msg = resp.Result.Content[0].Text
+		}
+		return AuditOutcomeError, msg
+	}
+	return AuditOutcomeOK, ""
+}
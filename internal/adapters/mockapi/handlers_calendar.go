@@ -0,0 +1,216 @@
+package mockapi
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func (s *Server) listCalendars(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	page, next, ok := paginate(w, r, data.calendars.list(nil))
+	if ok {
+		writeList(w, page, next)
+	}
+}
+
+func (s *Server) getCalendar(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	c, found := data.calendars.get(id)
+	if !found {
+		writeNotFound(w, "calendar", id)
+		return
+	}
+	writeData(w, http.StatusOK, c)
+}
+
+// eventFilter returns a matcher for the event list query parameters.
+func eventFilter(query url.Values) func(domain.Event) bool {
+	start, _ := strconv.ParseInt(query.Get("start"), 10, 64)
+	end, _ := strconv.ParseInt(query.Get("end"), 10, 64)
+
+	return func(e domain.Event) bool {
+		switch {
+		case query.Get("calendar_id") != "" && e.CalendarID != query.Get("calendar_id"):
+			return false
+		case e.Status == "cancelled" && query.Get("show_cancelled") != "true":
+			return false
+		case start > 0 && e.When.EndDateTime().Unix() < start, end > 0 && e.When.StartDateTime().Unix() > end:
+			return false
+		case !containsFold(e.Title, query.Get("title")), !containsFold(e.Location, query.Get("location")):
+			return false
+		case query.Get("busy") != "" && strconv.FormatBool(e.Busy) != query.Get("busy"):
+			return false
+		}
+		return true
+	}
+}
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	events := data.events.list(eventFilter(r.URL.Query()))
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].When.StartDateTime().Before(events[j].When.StartDateTime())
+	})
+	page, next, ok := paginate(w, r, events)
+	if ok {
+		writeList(w, toWire(page, toWireEvent), next)
+	}
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	e, found := data.events.get(id)
+	if !found {
+		writeNotFound(w, "event", id)
+		return
+	}
+	writeData(w, http.StatusOK, toWireEvent(e))
+}
+
+func (s *Server) createEvent(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateEventRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	calendarID := r.URL.Query().Get("calendar_id")
+	if calendarID == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "calendar_id is required")
+		return
+	}
+	if req.When == (domain.EventWhen{}) {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "when is required")
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	if _, found := data.calendars.get(calendarID); !found {
+		writeNotFound(w, "calendar", calendarID)
+		return
+	}
+	t := now()
+	e := domain.Event{
+		ID:           s.nextID("event"),
+		GrantID:      r.PathValue("grant"),
+		CalendarID:   calendarID,
+		Title:        req.Title,
+		Description:  req.Description,
+		Location:     req.Location,
+		When:         req.When,
+		Participants: req.Participants,
+		Status:       "confirmed",
+		Busy:         req.Busy,
+		Visibility:   req.Visibility,
+		Recurrence:   req.Recurrence,
+		Conferencing: req.Conferencing,
+		Reminders:    req.Reminders,
+		Metadata:     req.Metadata,
+		CreatedAt:    t,
+		UpdatedAt:    t,
+		Object:       "event",
+	}
+	data.events.add(e.ID, e, false)
+	s.emit(domain.TriggerEventCreated, toWireEvent(e))
+	writeData(w, http.StatusOK, toWireEvent(e))
+}
+
+func (s *Server) updateEvent(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpdateEventRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	e, found := data.events.get(id)
+	if !found {
+		writeNotFound(w, "event", id)
+		return
+	}
+	if req.Title != nil {
+		e.Title = *req.Title
+	}
+	if req.Description != nil {
+		e.Description = *req.Description
+	}
+	if req.Location != nil {
+		e.Location = *req.Location
+	}
+	if req.When != nil {
+		e.When = *req.When
+	}
+	if len(req.Participants) > 0 {
+		e.Participants = req.Participants
+	}
+	if req.Busy != nil {
+		e.Busy = *req.Busy
+	}
+	if req.Visibility != nil {
+		e.Visibility = *req.Visibility
+	}
+	if len(req.Recurrence) > 0 {
+		e.Recurrence = req.Recurrence
+	}
+	if req.Conferencing != nil {
+		e.Conferencing = req.Conferencing
+	}
+	if req.Reminders != nil {
+		e.Reminders = req.Reminders
+	}
+	if len(req.Metadata) > 0 {
+		e.Metadata = req.Metadata
+	}
+	e.UpdatedAt = now()
+	data.events.put(id, e)
+	s.emit(domain.TriggerEventUpdated, toWireEvent(e))
+	writeData(w, http.StatusOK, toWireEvent(e))
+}
+
+func (s *Server) deleteEvent(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !data.events.remove(id) {
+		writeNotFound(w, "event", id)
+		return
+	}
+	s.emit(domain.TriggerEventDeleted, deletedObject{ID: id, GrantID: r.PathValue("grant"), Object: "event"})
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
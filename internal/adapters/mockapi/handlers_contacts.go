@@ -0,0 +1,158 @@
+package mockapi
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// contactFilter returns a matcher for the contact list query parameters.
+func contactFilter(r *http.Request) func(domain.Contact) bool {
+	email := r.URL.Query().Get("email")
+	return func(c domain.Contact) bool {
+		return email == "" || slices.ContainsFunc(c.Emails, func(e domain.ContactEmail) bool {
+			return strings.EqualFold(e.Email, email)
+		})
+	}
+}
+
+func (s *Server) listContacts(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	page, next, ok := paginate(w, r, data.contacts.list(contactFilter(r)))
+	if ok {
+		writeList(w, page, next)
+	}
+}
+
+func (s *Server) getContact(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	c, found := data.contacts.get(id)
+	if !found {
+		writeNotFound(w, "contact", id)
+		return
+	}
+	writeData(w, http.StatusOK, c)
+}
+
+func (s *Server) createContact(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateContactRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	c := domain.Contact{
+		ID:                s.nextID("contact"),
+		GrantID:           r.PathValue("grant"),
+		Object:            "contact",
+		GivenName:         req.GivenName,
+		MiddleName:        req.MiddleName,
+		Surname:           req.Surname,
+		Suffix:            req.Suffix,
+		Nickname:          req.Nickname,
+		Birthday:          req.Birthday,
+		CompanyName:       req.CompanyName,
+		JobTitle:          req.JobTitle,
+		ManagerName:       req.ManagerName,
+		Notes:             req.Notes,
+		Emails:            req.Emails,
+		PhoneNumbers:      req.PhoneNumbers,
+		WebPages:          req.WebPages,
+		IMAddresses:       req.IMAddresses,
+		PhysicalAddresses: req.PhysicalAddresses,
+		Groups:            req.Groups,
+		Source:            "address_book",
+	}
+	data.contacts.add(c.ID, c, false)
+	s.emit(domain.TriggerContactCreated, c)
+	writeData(w, http.StatusOK, c)
+}
+
+func (s *Server) updateContact(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpdateContactRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	c, found := data.contacts.get(id)
+	if !found {
+		writeNotFound(w, "contact", id)
+		return
+	}
+	for field, value := range map[*string]*string{
+		&c.GivenName:   req.GivenName,
+		&c.MiddleName:  req.MiddleName,
+		&c.Surname:     req.Surname,
+		&c.Suffix:      req.Suffix,
+		&c.Nickname:    req.Nickname,
+		&c.Birthday:    req.Birthday,
+		&c.CompanyName: req.CompanyName,
+		&c.JobTitle:    req.JobTitle,
+		&c.ManagerName: req.ManagerName,
+		&c.Notes:       req.Notes,
+	} {
+		if value != nil {
+			*field = *value
+		}
+	}
+	if req.Emails != nil {
+		c.Emails = req.Emails
+	}
+	if req.PhoneNumbers != nil {
+		c.PhoneNumbers = req.PhoneNumbers
+	}
+	if req.WebPages != nil {
+		c.WebPages = req.WebPages
+	}
+	if req.IMAddresses != nil {
+		c.IMAddresses = req.IMAddresses
+	}
+	if req.PhysicalAddresses != nil {
+		c.PhysicalAddresses = req.PhysicalAddresses
+	}
+	if req.Groups != nil {
+		c.Groups = req.Groups
+	}
+	data.contacts.put(id, c)
+	s.emit(domain.TriggerContactUpdated, c)
+	writeData(w, http.StatusOK, c)
+}
+
+func (s *Server) deleteContact(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !data.contacts.remove(id) {
+		writeNotFound(w, "contact", id)
+		return
+	}
+	s.emit(domain.TriggerContactDeleted, deletedObject{ID: id, GrantID: r.PathValue("grant"), Object: "contact"})
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
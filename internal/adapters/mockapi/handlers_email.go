@@ -0,0 +1,468 @@
+package mockapi
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// defaultSender is the From address of sent messages that don't set one.
+var defaultSender = []domain.EmailParticipant{{Name: "Mock User", Email: "me@example.com"}}
+
+// messageFilter returns a matcher for the message list query parameters.
+func messageFilter(query url.Values) func(domain.Message) bool {
+	var in []string
+	for _, value := range query["in"] {
+		in = append(in, strings.Split(value, ",")...)
+	}
+	before, _ := strconv.ParseInt(query.Get("received_before"), 10, 64)
+	after, _ := strconv.ParseInt(query.Get("received_after"), 10, 64)
+
+	return func(m domain.Message) bool {
+		switch {
+		case len(in) > 0 && !slices.ContainsFunc(m.Folders, func(folder string) bool { return containsEqualFold(in, folder) }):
+			return false
+		case query.Get("unread") != "" && strconv.FormatBool(m.Unread) != query.Get("unread"):
+			return false
+		case query.Get("starred") != "" && strconv.FormatBool(m.Starred) != query.Get("starred"):
+			return false
+		case query.Get("has_attachment") != "" && strconv.FormatBool(len(m.Attachments) > 0) != query.Get("has_attachment"):
+			return false
+		case query.Get("thread_id") != "" && m.ThreadID != query.Get("thread_id"):
+			return false
+		case !containsFold(m.Subject, query.Get("subject")):
+			return false
+		case query.Get("from") != "" && !hasParticipant(m.From, query.Get("from")):
+			return false
+		case query.Get("to") != "" && !hasParticipant(m.To, query.Get("to")):
+			return false
+		case before > 0 && m.Date.Unix() >= before, after > 0 && m.Date.Unix() <= after:
+			return false
+		case query.Get("q") != "":
+			q := query.Get("q")
+			return containsFold(m.Subject, q) || containsFold(m.Snippet, q) || containsFold(m.Body, q)
+		}
+		return true
+	}
+}
+
+// containsFold reports whether s contains substr, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// containsEqualFold reports whether values includes s, ignoring case, so
+// provider-style folder IDs such as INBOX match.
+func containsEqualFold(values []string, s string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, s) })
+}
+
+// hasParticipant reports whether an address list includes email.
+func hasParticipant(participants []domain.EmailParticipant, email string) bool {
+	return slices.ContainsFunc(participants, func(p domain.EmailParticipant) bool {
+		return strings.EqualFold(p.Email, email)
+	})
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	page, next, ok := paginate(w, r, data.messages.list(messageFilter(r.URL.Query())))
+	if ok {
+		writeList(w, toWire(page, toWireMessage), next)
+	}
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	m, found := data.messages.get(id)
+	if !found {
+		writeNotFound(w, "message", id)
+		return
+	}
+	writeData(w, http.StatusOK, toWireMessage(m))
+}
+
+func (s *Server) updateMessage(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpdateMessageRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	m, found := data.messages.get(id)
+	if !found {
+		writeNotFound(w, "message", id)
+		return
+	}
+	if req.Unread != nil {
+		m.Unread = *req.Unread
+	}
+	if req.Starred != nil {
+		m.Starred = *req.Starred
+	}
+	if len(req.Folders) > 0 {
+		m.Folders = req.Folders
+	}
+	data.messages.put(id, m)
+	s.emit(domain.TriggerMessageUpdated, toWireMessage(m))
+	writeData(w, http.StatusOK, toWireMessage(m))
+}
+
+func (s *Server) deleteMessage(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !data.messages.remove(id) {
+		writeNotFound(w, "message", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	var req domain.SendMessageRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if len(req.To) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "to is required")
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	m := s.newSentMessage(data, r.PathValue("grant"), req.Subject, req.Body, req.ReplyToMsgID)
+	if len(req.From) > 0 {
+		m.From = req.From
+	}
+	m.To, m.Cc, m.Bcc, m.ReplyTo, m.Metadata = req.To, req.Cc, req.Bcc, req.ReplyTo, req.Metadata
+	data.messages.add(m.ID, m, false)
+	s.emit(domain.TriggerMessageCreated, toWireMessage(m))
+	writeData(w, http.StatusOK, toWireMessage(m))
+}
+
+// newSentMessage builds a message in the sent folder, threaded with the
+// message it replies to. Callers hold s.mu.
+func (s *Server) newSentMessage(data *grantData, grantID, subject, body, replyToID string) domain.Message {
+	t := now()
+	threadID := ""
+	if original, ok := data.messages.get(replyToID); ok {
+		threadID = original.ThreadID
+	}
+	if threadID == "" {
+		threadID = s.nextID("thread")
+	}
+	return domain.Message{
+		ID:        s.nextID("message"),
+		GrantID:   grantID,
+		ThreadID:  threadID,
+		Subject:   subject,
+		From:      defaultSender,
+		Body:      body,
+		Snippet:   snippet(body),
+		Date:      t,
+		Folders:   []string{"sent"},
+		CreatedAt: t,
+		Object:    "message",
+	}
+}
+
+func (s *Server) listDrafts(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	page, next, ok := paginate(w, r, data.drafts.list(nil))
+	if ok {
+		writeList(w, toWire(page, toWireDraft), next)
+	}
+}
+
+func (s *Server) getDraft(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	d, found := data.drafts.get(id)
+	if !found {
+		writeNotFound(w, "draft", id)
+		return
+	}
+	writeData(w, http.StatusOK, toWireDraft(d))
+}
+
+// readDraftRequest reads a JSON draft, or the message field of the
+// multipart form used for drafts with attachments.
+func readDraftRequest(w http.ResponseWriter, r *http.Request, req *domain.CreateDraftRequest) bool {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		return readJSON(w, r, req)
+	}
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "Invalid multipart body: "+err.Error())
+		return false
+	}
+	r.Body = http.NoBody
+	if message := r.FormValue("message"); message != "" {
+		r.Body = io.NopCloser(strings.NewReader(message))
+	}
+	if !readJSON(w, r, req) {
+		return false
+	}
+	for _, files := range r.MultipartForm.File {
+		for _, file := range files {
+			req.Attachments = append(req.Attachments, domain.Attachment{
+				Filename:    file.Filename,
+				ContentType: file.Header.Get("Content-Type"),
+				Size:        file.Size,
+			})
+		}
+	}
+	return true
+}
+
+// applyDraft copies a draft request onto a draft.
+func applyDraft(d *domain.Draft, req domain.CreateDraftRequest) {
+	d.Subject, d.Body = req.Subject, req.Body
+	if len(req.To) > 0 {
+		d.To = req.To
+	}
+	if len(req.Cc) > 0 {
+		d.Cc = req.Cc
+	}
+	if len(req.Bcc) > 0 {
+		d.Bcc = req.Bcc
+	}
+	if len(req.ReplyTo) > 0 {
+		d.ReplyTo = req.ReplyTo
+	}
+	if req.ReplyToMsgID != "" {
+		d.ReplyToMsgID = req.ReplyToMsgID
+	}
+	if len(req.Attachments) > 0 {
+		d.Attachments = req.Attachments
+	}
+	d.UpdatedAt = now()
+}
+
+func (s *Server) createDraft(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateDraftRequest
+	if !readDraftRequest(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	d := domain.Draft{ID: s.nextID("draft"), GrantID: r.PathValue("grant"), From: defaultSender, CreatedAt: now()}
+	applyDraft(&d, req)
+	for i := range d.Attachments {
+		d.Attachments[i].ID = s.nextID("attachment")
+	}
+	data.drafts.add(d.ID, d, false)
+	writeData(w, http.StatusOK, toWireDraft(d))
+}
+
+func (s *Server) updateDraft(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateDraftRequest
+	if !readDraftRequest(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	d, found := data.drafts.get(id)
+	if !found {
+		writeNotFound(w, "draft", id)
+		return
+	}
+	applyDraft(&d, req)
+	data.drafts.put(id, d)
+	writeData(w, http.StatusOK, toWireDraft(d))
+}
+
+func (s *Server) sendDraft(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	d, found := data.drafts.get(id)
+	if !found {
+		writeNotFound(w, "draft", id)
+		return
+	}
+	if len(d.To) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "draft has no recipients")
+		return
+	}
+
+	m := s.newSentMessage(data, r.PathValue("grant"), d.Subject, d.Body, d.ReplyToMsgID)
+	m.From, m.To, m.Cc, m.Bcc, m.ReplyTo, m.Attachments = d.From, d.To, d.Cc, d.Bcc, d.ReplyTo, d.Attachments
+	data.drafts.remove(id)
+	data.messages.add(m.ID, m, false)
+	s.emit(domain.TriggerMessageCreated, toWireMessage(m))
+	writeData(w, http.StatusOK, toWireMessage(m))
+}
+
+func (s *Server) deleteDraft(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !data.drafts.remove(id) {
+		writeNotFound(w, "draft", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) listFolders(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	page, next, ok := paginate(w, r, data.folders.list(nil))
+	if ok {
+		writeList(w, toWire(page, toWireFolder), next)
+	}
+}
+
+func (s *Server) getFolder(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	f, found := data.folders.get(id)
+	if !found {
+		writeNotFound(w, "folder", id)
+		return
+	}
+	writeData(w, http.StatusOK, toWireFolder(f))
+}
+
+func (s *Server) createFolder(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateFolderRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "name is required")
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	f := domain.Folder{
+		ID:              s.nextID("folder"),
+		GrantID:         r.PathValue("grant"),
+		Name:            req.Name,
+		ParentID:        req.ParentID,
+		BackgroundColor: req.BackgroundColor,
+		TextColor:       req.TextColor,
+	}
+	data.folders.add(f.ID, f, true)
+	s.emit(domain.TriggerFolderCreated, toWireFolder(f))
+	writeData(w, http.StatusOK, toWireFolder(f))
+}
+
+func (s *Server) updateFolder(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpdateFolderRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	f, found := data.folders.get(id)
+	if !found {
+		writeNotFound(w, "folder", id)
+		return
+	}
+	if req.Name != "" {
+		f.Name = req.Name
+	}
+	if req.ParentID != "" {
+		f.ParentID = req.ParentID
+	}
+	if req.BackgroundColor != "" {
+		f.BackgroundColor = req.BackgroundColor
+	}
+	if req.TextColor != "" {
+		f.TextColor = req.TextColor
+	}
+	data.folders.put(id, f)
+	s.emit(domain.TriggerFolderUpdated, toWireFolder(f))
+	writeData(w, http.StatusOK, toWireFolder(f))
+}
+
+func (s *Server) deleteFolder(w http.ResponseWriter, r *http.Request) {
+	data, ok := s.grantData(w, r)
+	if !ok {
+		return
+	}
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !data.folders.remove(id) {
+		writeNotFound(w, "folder", id)
+		return
+	}
+	s.emit(domain.TriggerFolderDeleted, deletedObject{ID: id, GrantID: r.PathValue("grant"), Object: "folder"})
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
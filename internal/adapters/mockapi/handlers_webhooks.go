@@ -0,0 +1,140 @@
+package mockapi
+
+import (
+	"net/http"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, next, ok := paginate(w, r, s.webhooks.list(nil))
+	if ok {
+		writeList(w, toWire(page, toWireWebhook), next)
+	}
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	webhook, found := s.webhooks.get(id)
+	if !found {
+		writeNotFound(w, "webhook", id)
+		return
+	}
+	writeData(w, http.StatusOK, toWireWebhook(webhook))
+}
+
+// createWebhook registers a subscription. Unlike seeded ones, it receives
+// notifications.
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateWebhookRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.WebhookURL == "" || len(req.TriggerTypes) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "webhook_url and trigger_types are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := now()
+	webhook := mockWebhook{
+		Webhook: domain.Webhook{
+			ID:                         s.nextID("webhook"),
+			Description:                req.Description,
+			TriggerTypes:               req.TriggerTypes,
+			WebhookURL:                 req.WebhookURL,
+			WebhookSecret:              newSecret(),
+			Status:                     "active",
+			NotificationEmailAddresses: req.NotificationEmailAddresses,
+			StatusUpdatedAt:            t,
+			CreatedAt:                  t,
+			UpdatedAt:                  t,
+		},
+		deliver: true,
+	}
+	s.webhooks.add(webhook.ID, webhook, false)
+	writeData(w, http.StatusOK, toWireWebhook(webhook))
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpdateWebhookRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	webhook, found := s.webhooks.get(id)
+	if !found {
+		writeNotFound(w, "webhook", id)
+		return
+	}
+	t := now()
+	if len(req.TriggerTypes) > 0 {
+		webhook.TriggerTypes = req.TriggerTypes
+	}
+	if req.WebhookURL != "" {
+		webhook.WebhookURL = req.WebhookURL
+	}
+	if req.Description != "" {
+		webhook.Description = req.Description
+	}
+	if len(req.NotificationEmailAddresses) > 0 {
+		webhook.NotificationEmailAddresses = req.NotificationEmailAddresses
+	}
+	if req.Status != "" && req.Status != webhook.Status {
+		webhook.Status = req.Status
+		webhook.StatusUpdatedAt = t
+	}
+	webhook.UpdatedAt = t
+	s.webhooks.put(id, webhook)
+	writeData(w, http.StatusOK, toWireWebhook(webhook))
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !s.webhooks.remove(id) {
+		writeNotFound(w, "webhook", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+// sendTestEvent delivers a test notification to any URL, signed with the
+// secret of a subscription for that URL if there is one.
+func (s *Server) sendTestEvent(w http.ResponseWriter, r *http.Request) {
+	var req domain.WebhookTestRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.WebhookURL == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "webhook_url is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secret := ""
+	for _, webhook := range s.webhooks.list(nil) {
+		if webhook.WebhookURL == req.WebhookURL {
+			secret = webhook.WebhookSecret
+			break
+		}
+	}
+	s.deliver(req.WebhookURL, secret, s.newNotification("webhook.test", map[string]string{"message": "Test event from the Nylas mock server"}))
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
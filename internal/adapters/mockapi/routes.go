@@ -0,0 +1,72 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// routes registers the API endpoints.
+func (s *Server) routes(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.api(handler))
+	}
+
+	handle("GET /v3/grants/{grant}/messages", s.listMessages)
+	handle("POST /v3/grants/{grant}/messages/send", s.sendMessage)
+	handle("GET /v3/grants/{grant}/messages/{id}", s.getMessage)
+	handle("PUT /v3/grants/{grant}/messages/{id}", s.updateMessage)
+	handle("DELETE /v3/grants/{grant}/messages/{id}", s.deleteMessage)
+
+	handle("GET /v3/grants/{grant}/drafts", s.listDrafts)
+	handle("POST /v3/grants/{grant}/drafts", s.createDraft)
+	handle("GET /v3/grants/{grant}/drafts/{id}", s.getDraft)
+	handle("PUT /v3/grants/{grant}/drafts/{id}", s.updateDraft)
+	handle("POST /v3/grants/{grant}/drafts/{id}", s.sendDraft)
+	handle("DELETE /v3/grants/{grant}/drafts/{id}", s.deleteDraft)
+
+	handle("GET /v3/grants/{grant}/folders", s.listFolders)
+	handle("POST /v3/grants/{grant}/folders", s.createFolder)
+	handle("GET /v3/grants/{grant}/folders/{id}", s.getFolder)
+	handle("PUT /v3/grants/{grant}/folders/{id}", s.updateFolder)
+	handle("DELETE /v3/grants/{grant}/folders/{id}", s.deleteFolder)
+
+	handle("GET /v3/grants/{grant}/calendars", s.listCalendars)
+	handle("GET /v3/grants/{grant}/calendars/{id}", s.getCalendar)
+
+	handle("GET /v3/grants/{grant}/events", s.listEvents)
+	handle("POST /v3/grants/{grant}/events", s.createEvent)
+	handle("GET /v3/grants/{grant}/events/{id}", s.getEvent)
+	handle("PUT /v3/grants/{grant}/events/{id}", s.updateEvent)
+	handle("DELETE /v3/grants/{grant}/events/{id}", s.deleteEvent)
+
+	handle("GET /v3/grants/{grant}/contacts", s.listContacts)
+	handle("POST /v3/grants/{grant}/contacts", s.createContact)
+	handle("GET /v3/grants/{grant}/contacts/{id}", s.getContact)
+	handle("PUT /v3/grants/{grant}/contacts/{id}", s.updateContact)
+	handle("DELETE /v3/grants/{grant}/contacts/{id}", s.deleteContact)
+
+	handle("GET /v3/webhooks", s.listWebhooks)
+	handle("POST /v3/webhooks", s.createWebhook)
+	handle("POST /v3/webhooks/send-test-event", s.sendTestEvent)
+	handle("GET /v3/webhooks/{id}", s.getWebhook)
+	handle("PUT /v3/webhooks/{id}", s.updateWebhook)
+	handle("DELETE /v3/webhooks/{id}", s.deleteWebhook)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found_error",
+			fmt.Sprintf("%s %s is not supported by the mock server", r.Method, r.URL.Path))
+	})
+}
+
+// grantData locks the server and returns the request's grant data. The
+// caller must call s.mu.Unlock when ok.
+func (s *Server) grantData(w http.ResponseWriter, r *http.Request) (*grantData, bool) {
+	s.mu.Lock()
+	data, err := s.grant(r.Context(), r.PathValue("grant"))
+	if err != nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,302 @@
+// Package mockapi provides a local stand-in for the Nylas v3 REST API,
+// serving an in-memory dataset for application development and tests.
+package mockapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// Config configures the mock server.
+type Config struct {
+	Latency     time.Duration // Added to every API response
+	ErrorRate   float64       // Fraction of API requests, 0 to 1, that fail
+	ErrorStatus int           // Status of injected failures; defaults to 500
+
+	WebhookURL    string // Registered at startup to receive every trigger
+	WebhookSecret string // Signs deliveries to WebhookURL; generated when empty
+
+	Log io.Writer // Logs requests and webhook deliveries when set
+}
+
+// Server serves the Nylas v3 REST endpoints the CLI uses from an in-memory
+// dataset copied from a seed client. Changes last until the server stops.
+type Server struct {
+	config Config
+	seed   ports.NylasClient
+	client *http.Client
+	server *http.Server
+
+	mu       sync.Mutex
+	grants   map[string]*grantData
+	webhooks *collection[mockWebhook]
+	seq      int
+
+	deliveries sync.WaitGroup
+}
+
+// NewServer creates a server whose grants each start with seed's data,
+// such as nylas.NewDemoClient().
+func NewServer(config Config, seed ports.NylasClient) (*Server, error) {
+	if config.ErrorStatus == 0 {
+		config.ErrorStatus = http.StatusInternalServerError
+	}
+	if config.ErrorRate < 0 || config.ErrorRate > 1 {
+		return nil, fmt.Errorf("error rate must be between 0 and 1, got %g", config.ErrorRate)
+	}
+	if config.ErrorStatus < 400 || config.ErrorStatus > 599 {
+		return nil, fmt.Errorf("error status must be a 4xx or 5xx code, got %d", config.ErrorStatus)
+	}
+
+	webhooks, err := seedWebhooks(context.Background(), seed)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		config:   config,
+		seed:     seed,
+		client:   httputil.NewClient(10 * time.Second),
+		grants:   make(map[string]*grantData),
+		webhooks: webhooks,
+	}
+
+	if config.WebhookURL != "" {
+		secret := config.WebhookSecret
+		if secret == "" {
+			secret = newSecret()
+		}
+		t := now()
+		s.webhooks.add("webhook-mock", mockWebhook{
+			Webhook: domain.Webhook{
+				ID:              "webhook-mock",
+				Description:     "Registered by the mock server",
+				TriggerTypes:    deliveredTriggers,
+				WebhookURL:      config.WebhookURL,
+				WebhookSecret:   secret,
+				Status:          "active",
+				StatusUpdatedAt: t,
+				CreatedAt:       t,
+				UpdatedAt:       t,
+			},
+			deliver: true,
+		}, false)
+	}
+	return s, nil
+}
+
+// Handler returns the server's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	s.routes(mux)
+	return s.logRequests(mux)
+}
+
+// Serve accepts connections on l until ctx is done, then waits for
+// in-flight webhook deliveries.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	s.server = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.server.Serve(l) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := s.server.Shutdown(shutdownCtx)
+		s.deliveries.Wait()
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// grant returns a grant's data, seeding it on first use. Callers hold s.mu.
+func (s *Server) grant(ctx context.Context, grantID string) (*grantData, error) {
+	if data, ok := s.grants[grantID]; ok {
+		return data, nil
+	}
+	data, err := seedGrant(ctx, s.seed, grantID)
+	if err != nil {
+		return nil, err
+	}
+	s.grants[grantID] = data
+	return data, nil
+}
+
+// nextID returns a new object ID. Callers hold s.mu.
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-mock-%d", prefix, s.seq)
+}
+
+// statusRecorder captures the status a handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests adds a request ID to every response and logs each request.
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	var requests uint64
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		requestID := fmt.Sprintf("mock-req-%d", requests)
+		mu.Unlock()
+		w.Header().Set("X-Request-Id", requestID)
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.logf("%s %s → %d (%s)", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond))
+	})
+}
+
+// api wraps an API handler with authentication, latency and error
+// injection.
+func (s *Server) api(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Missing Authorization bearer token")
+			return
+		}
+
+		if s.config.Latency > 0 {
+			select {
+			case <-time.After(s.config.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if s.config.ErrorRate > 0 && rand.Float64() < s.config.ErrorRate { // #nosec G404 -- fault injection, not security
+			if s.config.ErrorStatus == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			writeError(w, s.config.ErrorStatus, "mock_injected_error", "Error injected by the mock server")
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// logf writes a line to the log, if any.
+func (s *Server) logf(format string, args ...any) {
+	if s.config.Log != nil {
+		_, _ = fmt.Fprintf(s.config.Log, format+"\n", args...)
+	}
+}
+
+// writeJSON writes an API response envelope.
+func writeJSON(w http.ResponseWriter, status int, body map[string]any) {
+	body["request_id"] = w.Header().Get("X-Request-Id")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body) // Ignore encode error - response already started
+}
+
+// writeData writes a single object.
+func writeData(w http.ResponseWriter, status int, data any) {
+	writeJSON(w, status, map[string]any{"data": data})
+}
+
+// writeList writes one page of a list.
+func writeList(w http.ResponseWriter, data any, nextCursor string) {
+	body := map[string]any{"data": data}
+	if nextCursor != "" {
+		body["next_cursor"] = nextCursor
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// writeError writes an API error.
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]string{"type": errType, "message": message},
+	})
+}
+
+// writeNotFound writes the API's error for a missing object.
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "not_found_error", fmt.Sprintf("%s %s not found", kind, id))
+}
+
+// readJSON decodes a request body into v.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(io.LimitReader(r.Body, 10<<20)).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// paginate returns the page of items selected by the limit and page_token
+// query parameters, and the cursor for the next page.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) ([]T, string, bool) {
+	query := r.URL.Query()
+
+	limit := defaultLimit
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "limit must be a positive integer")
+			return nil, "", false
+		}
+		limit = min(n, maxLimit)
+	}
+
+	offset := 0
+	if token := query.Get("page_token"); token != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(token)
+		n, convErr := strconv.Atoi(strings.TrimPrefix(string(decoded), "offset:"))
+		if err != nil || convErr != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "Invalid page_token")
+			return nil, "", false
+		}
+		offset = n
+	}
+
+	if offset >= len(items) {
+		return []T{}, "", true
+	}
+	end := min(offset+limit, len(items))
+	next := ""
+	if end < len(items) {
+		next = base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(end)))
+	}
+	return items[offset:end], next, true
+}
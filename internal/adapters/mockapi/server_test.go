@@ -0,0 +1,255 @@
+package mockapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
+)
+
+// newTestServer starts a mock server and returns an API client for it.
+func newTestServer(t *testing.T, config Config) (*Server, *nylas.HTTPClient) {
+	t.Helper()
+	server, err := NewServer(config, nylas.NewDemoClient())
+	if err != nil {
+		t.Fatalf("NewServer() error: %v", err)
+	}
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(ts.Close)
+
+	client := nylas.NewHTTPClient()
+	client.SetBaseURL(ts.URL)
+	client.SetCredentials("", "", "test-key")
+	client.SetMaxRetries(0)
+	return server, client
+}
+
+func TestServer_Messages(t *testing.T) {
+	_, client := newTestServer(t, Config{})
+	ctx := context.Background()
+
+	all, err := client.GetMessages(ctx, "grant-1", 200)
+	if err != nil {
+		t.Fatalf("GetMessages() error: %v", err)
+	}
+	if len(all) == 0 || all[0].Date.IsZero() || all[0].GrantID != "grant-1" {
+		t.Fatalf("messages = %+v", all)
+	}
+
+	// Cursor pagination walks every message exactly once.
+	var seen []string
+	params := &domain.MessageQueryParams{Limit: 2}
+	for {
+		page, err := client.GetMessagesWithCursor(ctx, "grant-1", params)
+		if err != nil {
+			t.Fatalf("GetMessagesWithCursor() error: %v", err)
+		}
+		for _, m := range page.Data {
+			seen = append(seen, m.ID)
+		}
+		if !page.Pagination.HasMore {
+			break
+		}
+		params.PageToken = page.Pagination.NextCursor
+	}
+	if len(seen) != len(all) || seen[0] != all[0].ID {
+		t.Errorf("paged through %v, want %d messages", seen, len(all))
+	}
+
+	// Changes persist and stay within the grant.
+	unread := true
+	if _, err := client.UpdateMessage(ctx, "grant-1", all[0].ID, &domain.UpdateMessageRequest{Unread: &unread}); err != nil {
+		t.Fatalf("UpdateMessage() error: %v", err)
+	}
+	sent, err := client.SendMessage(ctx, "grant-1", &domain.SendMessageRequest{
+		Subject: "Hello", Body: "Hi there", To: []domain.EmailParticipant{{Email: "a@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("SendMessage() error: %v", err)
+	}
+	got, err := client.GetMessage(ctx, "grant-1", sent.ID)
+	if err != nil || got.Subject != "Hello" || got.Folders[0] != "sent" {
+		t.Errorf("GetMessage() = %+v, %v", got, err)
+	}
+	if err := client.DeleteMessage(ctx, "grant-1", all[0].ID); err != nil {
+		t.Fatalf("DeleteMessage() error: %v", err)
+	}
+	if _, err := client.GetMessage(ctx, "grant-1", all[0].ID); !strings.Contains(err.Error(), "not found") {
+		t.Errorf("deleted message: err = %v", err)
+	}
+	if other, _ := client.GetMessages(ctx, "grant-2", 200); len(other) != len(all) {
+		t.Errorf("grant-2 has %d messages, want the untouched %d", len(other), len(all))
+	}
+
+	unreadOnly, err := client.GetMessagesWithParams(ctx, "grant-2", &domain.MessageQueryParams{Limit: 50, Unread: &unread})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range unreadOnly {
+		if !m.Unread {
+			t.Errorf("unread filter returned read message %s", m.ID)
+		}
+	}
+}
+
+func TestServer_DraftsEventsContactsFolders(t *testing.T) {
+	_, client := newTestServer(t, Config{})
+	ctx := context.Background()
+
+	draft, err := client.CreateDraft(ctx, "g", &domain.CreateDraftRequest{Subject: "Draft", To: []domain.EmailParticipant{{Email: "b@example.com"}}})
+	if err != nil {
+		t.Fatalf("CreateDraft() error: %v", err)
+	}
+	message, err := client.SendDraft(ctx, "g", draft.ID)
+	if err != nil || message.Subject != "Draft" {
+		t.Fatalf("SendDraft() = %+v, %v", message, err)
+	}
+	if _, err := client.GetDraft(ctx, "g", draft.ID); err == nil {
+		t.Error("sent draft should be gone")
+	}
+
+	start := time.Now().Add(time.Hour).Unix()
+	event, err := client.CreateEvent(ctx, "g", "primary", &domain.CreateEventRequest{
+		Title: "Planning", When: domain.EventWhen{StartTime: start, EndTime: start + 1800},
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error: %v", err)
+	}
+	title := "Replanning"
+	if updated, err := client.UpdateEvent(ctx, "g", "primary", event.ID, &domain.UpdateEventRequest{Title: &title}); err != nil || updated.Title != title {
+		t.Errorf("UpdateEvent() = %+v, %v", updated, err)
+	}
+	if _, err := client.CreateEvent(ctx, "g", "missing", &domain.CreateEventRequest{Title: "x", When: domain.EventWhen{StartTime: start}}); err == nil {
+		t.Error("expected an error for an unknown calendar")
+	}
+
+	contact, err := client.CreateContact(ctx, "g", &domain.CreateContactRequest{GivenName: "Ada", Emails: []domain.ContactEmail{{Email: "ada@example.com"}}})
+	if err != nil {
+		t.Fatalf("CreateContact() error: %v", err)
+	}
+	found, err := client.GetContacts(ctx, "g", &domain.ContactQueryParams{Email: "ada@example.com"})
+	if err != nil || len(found) != 1 || found[0].ID != contact.ID {
+		t.Errorf("GetContacts(email) = %+v, %v", found, err)
+	}
+
+	folder, err := client.CreateFolder(ctx, "g", &domain.CreateFolderRequest{Name: "Receipts"})
+	if err != nil {
+		t.Fatalf("CreateFolder() error: %v", err)
+	}
+	if err := client.DeleteFolder(ctx, "g", folder.ID); err != nil {
+		t.Errorf("DeleteFolder() error: %v", err)
+	}
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer receiver.Close()
+
+	_, client := newTestServer(t, Config{WebhookURL: receiver.URL, WebhookSecret: "s3cret"})
+	ctx := context.Background()
+
+	webhooks, err := client.ListWebhooks(ctx)
+	if err != nil || len(webhooks) == 0 || webhooks[0].WebhookURL != receiver.URL {
+		t.Fatalf("ListWebhooks() = %+v, %v", webhooks, err)
+	}
+
+	start := time.Now().Unix()
+	event, err := client.CreateEvent(ctx, "g", "primary", &domain.CreateEventRequest{Title: "Sync", When: domain.EventWhen{StartTime: start, EndTime: start + 60}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case r := <-received:
+		body := <-bodies
+		if got := r.Header.Get("X-Nylas-Signature"); got != httputil.SignHMAC("s3cret", body) {
+			t.Errorf("signature = %q", got)
+		}
+		var n struct {
+			Type string `json:"type"`
+			Data struct {
+				Object struct {
+					ID string `json:"id"`
+				} `json:"object"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &n); err != nil || n.Type != domain.TriggerEventCreated || n.Data.Object.ID != event.ID {
+			t.Errorf("notification = %s", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook delivered")
+	}
+
+	// Webhooks created through the API receive only their triggers.
+	created, err := client.CreateWebhook(ctx, &domain.CreateWebhookRequest{WebhookURL: receiver.URL + "/contacts", TriggerTypes: []string{domain.TriggerContactCreated}})
+	if err != nil || created.WebhookSecret == "" {
+		t.Fatalf("CreateWebhook() = %+v, %v", created, err)
+	}
+	if _, err := client.CreateContact(ctx, "g", &domain.CreateContactRequest{GivenName: "Lin"}); err != nil {
+		t.Fatal(err)
+	}
+	paths := map[string]bool{}
+	for range 2 {
+		select {
+		case r := <-received:
+			<-bodies
+			paths[r.URL.Path] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got deliveries to %v, want / and /contacts", paths)
+		}
+	}
+	if !paths["/"] || !paths["/contacts"] {
+		t.Errorf("deliveries went to %v", paths)
+	}
+}
+
+func TestServer_FaultInjection(t *testing.T) {
+	_, client := newTestServer(t, Config{ErrorRate: 1, ErrorStatus: http.StatusTooManyRequests})
+	_, err := client.GetMessages(context.Background(), "g", 10)
+	if err == nil || !strings.Contains(err.Error(), "Error injected") {
+		t.Errorf("GetMessages() error = %v, want the injected error", err)
+	}
+
+	for name, config := range map[string]Config{
+		"rate":   {ErrorRate: 1.5},
+		"status": {ErrorStatus: 200},
+	} {
+		if _, err := NewServer(config, nylas.NewDemoClient()); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	server, err := NewServer(Config{}, nylas.NewDemoClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v3/grants/g/messages", nil))
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("X-Request-Id") == "" {
+		t.Errorf("unauthenticated request = %d, headers %v", rec.Code, rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v3/grants/g/messages?page_token=bogus", nil)
+	req.Header.Set("Authorization", "Bearer k")
+	server.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad page token = %d", rec.Code)
+	}
+}
@@ -0,0 +1,193 @@
+package mockapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// collection keeps items in insertion order, newest first.
+type collection[T any] struct {
+	ids   []string
+	items map[string]T
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{items: make(map[string]T)}
+}
+
+// add stores an item ahead of the existing ones, or at the end when seeding.
+func (c *collection[T]) add(id string, item T, atEnd bool) {
+	if _, ok := c.items[id]; !ok {
+		if atEnd {
+			c.ids = append(c.ids, id)
+		} else {
+			c.ids = append([]string{id}, c.ids...)
+		}
+	}
+	c.items[id] = item
+}
+
+func (c *collection[T]) get(id string) (T, bool) {
+	item, ok := c.items[id]
+	return item, ok
+}
+
+// put replaces an existing item.
+func (c *collection[T]) put(id string, item T) {
+	if _, ok := c.items[id]; ok {
+		c.items[id] = item
+	}
+}
+
+func (c *collection[T]) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// list returns the items match accepts, in order.
+func (c *collection[T]) list(match func(T) bool) []T {
+	items := make([]T, 0, len(c.ids))
+	for _, id := range c.ids {
+		if item := c.items[id]; match == nil || match(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// grantData is one grant's mailbox, calendar and address book.
+type grantData struct {
+	messages  *collection[domain.Message]
+	drafts    *collection[domain.Draft]
+	folders   *collection[domain.Folder]
+	calendars *collection[domain.Calendar]
+	events    *collection[domain.Event]
+	contacts  *collection[domain.Contact]
+}
+
+// mockWebhook is a webhook subscription. Only subscriptions registered with
+// the server receive deliveries; seeded ones point at example URLs.
+type mockWebhook struct {
+	domain.Webhook
+	deliver bool
+}
+
+// seedGrant copies the seed client's dataset for a grant, so every grant
+// starts with the same data and changes independently.
+func seedGrant(ctx context.Context, seed ports.NylasClient, grantID string) (*grantData, error) {
+	data := &grantData{
+		messages:  newCollection[domain.Message](),
+		drafts:    newCollection[domain.Draft](),
+		folders:   newCollection[domain.Folder](),
+		calendars: newCollection[domain.Calendar](),
+		events:    newCollection[domain.Event](),
+		contacts:  newCollection[domain.Contact](),
+	}
+
+	messages, err := seed.GetMessages(ctx, grantID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("seeding messages: %w", err)
+	}
+	for _, m := range messages {
+		m.GrantID = grantID
+		m.Object = "message"
+		if len(m.Folders) == 0 {
+			m.Folders = []string{"inbox"}
+		}
+		data.messages.add(m.ID, m, true)
+	}
+
+	drafts, err := seed.GetDrafts(ctx, grantID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("seeding drafts: %w", err)
+	}
+	for _, d := range drafts {
+		d.GrantID = grantID
+		data.drafts.add(d.ID, d, true)
+	}
+
+	folders, err := seed.GetFolders(ctx, grantID)
+	if err != nil {
+		return nil, fmt.Errorf("seeding folders: %w", err)
+	}
+	for _, f := range folders {
+		f.GrantID = grantID
+		data.folders.add(f.ID, f, true)
+	}
+
+	calendars, err := seed.GetCalendars(ctx, grantID)
+	if err != nil {
+		return nil, fmt.Errorf("seeding calendars: %w", err)
+	}
+	for _, c := range calendars {
+		c.GrantID = grantID
+		c.Object = "calendar"
+		data.calendars.add(c.ID, c, true)
+	}
+
+	events, err := seed.GetEvents(ctx, grantID, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("seeding events: %w", err)
+	}
+	for _, e := range events {
+		e.GrantID = grantID
+		e.Object = "event"
+		if e.CalendarID == "" {
+			e.CalendarID = "primary"
+		}
+		data.events.add(e.ID, e, true)
+	}
+
+	contacts, err := seed.GetContacts(ctx, grantID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("seeding contacts: %w", err)
+	}
+	for _, c := range contacts {
+		c.GrantID = grantID
+		c.Object = "contact"
+		data.contacts.add(c.ID, c, true)
+	}
+
+	return data, nil
+}
+
+// seedWebhooks copies the seed client's webhook subscriptions.
+func seedWebhooks(ctx context.Context, seed ports.NylasClient) (*collection[mockWebhook], error) {
+	webhooks := newCollection[mockWebhook]()
+	seeded, err := seed.ListWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("seeding webhooks: %w", err)
+	}
+	for _, w := range seeded {
+		webhooks.add(w.ID, mockWebhook{Webhook: w}, true)
+	}
+	return webhooks, nil
+}
+
+// snippet shortens a body for a message preview.
+func snippet(body string) string {
+	const maxSnippet = 100
+	runes := []rune(body)
+	if len(runes) > maxSnippet {
+		return string(runes[:maxSnippet])
+	}
+	return body
+}
+
+// now is the server clock, truncated to the second like API timestamps.
+func now() time.Time {
+	return time.Now().Truncate(time.Second)
+}
@@ -0,0 +1,114 @@
+package mockapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
+)
+
+// deliveredTriggers are the triggers the server emits.
+var deliveredTriggers = []string{
+	domain.TriggerMessageCreated, domain.TriggerMessageUpdated,
+	domain.TriggerEventCreated, domain.TriggerEventUpdated, domain.TriggerEventDeleted,
+	domain.TriggerContactCreated, domain.TriggerContactUpdated, domain.TriggerContactDeleted,
+	domain.TriggerFolderCreated, domain.TriggerFolderUpdated, domain.TriggerFolderDeleted,
+}
+
+// notification is a webhook delivery body, in the API's CloudEvents format.
+type notification struct {
+	SpecVersion     string           `json:"specversion"`
+	Type            string           `json:"type"`
+	Source          string           `json:"source"`
+	ID              string           `json:"id"`
+	Time            int64            `json:"time"`
+	DeliveryAttempt int              `json:"webhook_delivery_attempt"`
+	Data            notificationData `json:"data"`
+}
+
+type notificationData struct {
+	ApplicationID string `json:"application_id"`
+	Object        any    `json:"object"`
+}
+
+// deletedObject is the object sent with *.deleted triggers.
+type deletedObject struct {
+	ID      string `json:"id"`
+	GrantID string `json:"grant_id"`
+	Object  string `json:"object"`
+}
+
+// emit delivers a trigger to every registered webhook subscribed to it.
+// Deliveries run in the background. Callers hold s.mu.
+func (s *Server) emit(trigger string, object any) {
+	for _, webhook := range s.webhooks.list(nil) {
+		if !webhook.deliver || webhook.Status != "active" || !slices.Contains(webhook.TriggerTypes, trigger) {
+			continue
+		}
+		s.deliver(webhook.WebhookURL, webhook.WebhookSecret, s.newNotification(trigger, object))
+	}
+}
+
+// newNotification builds a notification. Callers hold s.mu.
+func (s *Server) newNotification(trigger string, object any) notification {
+	s.seq++
+	return notification{
+		SpecVersion:     "1.0",
+		Type:            trigger,
+		Source:          "/nylas/mock-server",
+		ID:              fmt.Sprintf("notification-mock-%d", s.seq),
+		Time:            time.Now().Unix(),
+		DeliveryAttempt: 1,
+		Data:            notificationData{ApplicationID: "mock-application", Object: object},
+	}
+}
+
+// deliver posts a notification in the background, signing it with
+// X-Nylas-Signature when there is a secret.
+func (s *Server) deliver(url, secret string, n notification) {
+	body, err := json.Marshal(n)
+	if err != nil {
+		s.logf("webhook %s → %s: %v", n.Type, url, err)
+		return
+	}
+
+	s.deliveries.Add(1)
+	go func() {
+		defer s.deliveries.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			s.logf("webhook %s → %s: %v", n.Type, url, err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set("X-Nylas-Signature", httputil.SignHMAC(secret, body))
+		}
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			s.logf("webhook %s → %s: %v", n.Type, url, err)
+			return
+		}
+		_ = resp.Body.Close()
+		s.logf("webhook %s → %s: %s", n.Type, url, resp.Status)
+	}()
+}
+
+// newSecret returns a random webhook secret.
+func newSecret() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
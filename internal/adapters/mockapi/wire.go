@@ -0,0 +1,84 @@
+package mockapi
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// The domain types mostly match the API's JSON already; the wire types
+// below override the fields that differ, chiefly timestamps, which the API
+// sends as Unix seconds.
+
+type wireMessage struct {
+	domain.Message
+	Date      int64 `json:"date"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+type wireDraft struct {
+	domain.Draft
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+	Object    string `json:"object"`
+}
+
+type wireEvent struct {
+	domain.Event
+	CreatedAt int64 `json:"created_at,omitempty"`
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+}
+
+type wireFolder struct {
+	domain.Folder
+	Object string `json:"object"`
+}
+
+type wireWebhook struct {
+	domain.Webhook
+	StatusUpdatedAt int64 `json:"status_updated_at,omitempty"`
+	CreatedAt       int64 `json:"created_at,omitempty"`
+	UpdatedAt       int64 `json:"updated_at,omitempty"`
+}
+
+func toWireMessage(m domain.Message) wireMessage {
+	return wireMessage{Message: m, Date: unix(m.Date), CreatedAt: unix(m.CreatedAt)}
+}
+
+func toWireDraft(d domain.Draft) wireDraft {
+	return wireDraft{Draft: d, CreatedAt: unix(d.CreatedAt), UpdatedAt: unix(d.UpdatedAt), Object: "draft"}
+}
+
+func toWireEvent(e domain.Event) wireEvent {
+	return wireEvent{Event: e, CreatedAt: unix(e.CreatedAt), UpdatedAt: unix(e.UpdatedAt)}
+}
+
+func toWireFolder(f domain.Folder) wireFolder {
+	return wireFolder{Folder: f, Object: "folder"}
+}
+
+func toWireWebhook(w mockWebhook) wireWebhook {
+	return wireWebhook{
+		Webhook:         w.Webhook,
+		StatusUpdatedAt: unix(w.StatusUpdatedAt),
+		CreatedAt:       unix(w.CreatedAt),
+		UpdatedAt:       unix(w.UpdatedAt),
+	}
+}
+
+// toWire converts each item in a list.
+func toWire[T, W any](items []T, convert func(T) W) []W {
+	wire := make([]W, len(items))
+	for i, item := range items {
+		wire[i] = convert(item)
+	}
+	return wire
+}
+
+// unix returns t in Unix seconds, or 0 for the zero time.
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,66 @@
+// Package notify delivers notifications to the desktop and to webhooks.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// windowsToast shows a toast notification. The title and message come from
+// the environment so they never need quoting inside the script.
+const windowsToast = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text.Item(0).AppendChild($xml.CreateTextNode($env:NYLAS_NOTIFY_TITLE)) | Out-Null
+$text.Item(1).AppendChild($xml.CreateTextNode($env:NYLAS_NOTIFY_MESSAGE)) | Out-Null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Nylas CLI').Show([Windows.UI.Notifications.ToastNotification]::new($xml))`
+
+// Desktop shows notifications with the operating system's notifier:
+// notify-send on Linux, osascript on macOS and a PowerShell toast on Windows.
+type Desktop struct{}
+
+// NewDesktop creates a new Desktop notifier.
+func NewDesktop() *Desktop {
+	return &Desktop{}
+}
+
+// Notify shows the notification's title and message.
+func (d *Desktop) Notify(ctx context.Context, n ports.Notification) error {
+	cmd := createCommand(ctx, runtime.GOOS, n.Title, n.Message)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("desktop notification failed: %s", msg)
+		}
+		return fmt.Errorf("desktop notification failed: %w", err)
+	}
+	return nil
+}
+
+// createCommand creates the command that shows a notification on goos.
+func createCommand(ctx context.Context, goos, title, message string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return exec.CommandContext(ctx, "osascript", "-e", script)
+	case "windows":
+		cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToast)
+		cmd.Env = append(os.Environ(), "NYLAS_NOTIFY_TITLE="+title, "NYLAS_NOTIFY_MESSAGE="+message)
+		return cmd
+	default:
+		// #nosec G204 -- arguments are passed directly, not through a shell
+		return exec.CommandContext(ctx, "notify-send", "--app-name=Nylas CLI", "--", title, message)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
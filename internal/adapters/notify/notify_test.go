@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/httputil"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+func TestCreateCommand(t *testing.T) {
+	ctx := context.Background()
+
+	linux := createCommand(ctx, "linux", "Grant invalid", "-rf")
+	if !slices.Equal(linux.Args[len(linux.Args)-3:], []string{"--", "Grant invalid", "-rf"}) {
+		t.Errorf("linux args = %q", linux.Args)
+	}
+
+	darwin := createCommand(ctx, "darwin", `Say "hi"`, `a\b`)
+	want := `display notification "a\\b" with title "Say \"hi\""`
+	if darwin.Args[len(darwin.Args)-1] != want {
+		t.Errorf("darwin script = %q, want %q", darwin.Args[len(darwin.Args)-1], want)
+	}
+
+	windows := createCommand(ctx, "windows", "Title", "Message")
+	if !slices.Contains(windows.Env, "NYLAS_NOTIFY_TITLE=Title") || !slices.Contains(windows.Env, "NYLAS_NOTIFY_MESSAGE=Message") {
+		t.Error("windows command should pass the text through the environment")
+	}
+	if strings.Contains(strings.Join(windows.Args, " "), "Message") {
+		t.Error("windows command should not put the text in the script")
+	}
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	var gotBody []byte
+	var gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(SignatureHeader)
+	}))
+	defer server.Close()
+
+	n := ports.Notification{
+		Event:   "grant.degraded",
+		Title:   "Grant invalid",
+		Message: "user@example.com needs to sign in again",
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Data:    map[string]string{"id": "grant-1"},
+	}
+	if err := NewWebhook(server.URL, "s3cret").Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+
+	var got ports.Notification
+	if err := json.Unmarshal(gotBody, &got); err != nil || got.Event != n.Event || got.Title != n.Title || !got.Time.Equal(n.Time) {
+		t.Errorf("body = %s", gotBody)
+	}
+	if gotSignature != httputil.SignHMAC("s3cret", gotBody) {
+		t.Errorf("signature = %q", gotSignature)
+	}
+
+	if err := NewWebhook(server.URL, "").Notify(context.Background(), n); err != nil || gotSignature != "" {
+		t.Errorf("unsigned Notify() = %v, signature %q", err, gotSignature)
+	}
+}
+
+func TestWebhook_NotifyFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, "").Notify(context.Background(), ports.Notification{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("Notify() error = %v, want the status", err)
+	}
+}
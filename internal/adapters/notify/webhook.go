@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/httputil"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the body when the webhook
+// has a secret, the same scheme Nylas uses for its own webhooks.
+const SignatureHeader = "X-Nylas-Signature"
+
+// Webhook posts notifications as JSON to a URL.
+type Webhook struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhook creates a notifier that posts to url, signing each body with
+// secret when it isn't empty.
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{
+		url:    url,
+		secret: secret,
+		client: httputil.NewClient(30 * time.Second),
+	}
+}
+
+// Notify posts the notification. Any 2xx response counts as delivered.
+func (w *Webhook) Notify(ctx context.Context, n ports.Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		req.Header.Set(SignatureHeader, httputil.SignHMAC(w.secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook notification failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook notification failed: %s", resp.Status)
+	}
+	return nil
+}
@@ -299,18 +299,46 @@ func (c *HTTPClient) DeleteCredential(ctx context.Context, credentialID string)
 
 // Admin Grant Operations
 
-// ListAllGrants retrieves all grants with optional filtering.
+// grantsPageSize is the largest page the grants endpoint returns.
+const grantsPageSize = 200
+
+// ListAllGrants retrieves grants with optional filtering. With no limit it
+// pages through every grant; with one it returns that single page.
 func (c *HTTPClient) ListAllGrants(ctx context.Context, params *domain.GrantsQueryParams) ([]domain.Grant, error) {
-	baseURL := fmt.Sprintf("%s/v3/grants", c.baseURL)
+	if params == nil {
+		params = &domain.GrantsQueryParams{}
+	}
+	if params.Limit > 0 {
+		return c.listGrantsPage(ctx, params)
+	}
 
-	qb := NewQueryBuilder()
-	if params != nil {
-		qb.AddInt("limit", params.Limit).
-			AddInt("offset", params.Offset).
-			Add("connector_id", params.ConnectorID).
-			Add("status", params.Status)
+	page := *params
+	page.Limit = grantsPageSize
+	var all []domain.Grant
+	for {
+		grants, err := c.listGrantsPage(ctx, &page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, grants...)
+		if len(grants) < page.Limit {
+			return all, nil
+		}
+		page.Offset += len(grants)
 	}
-	queryURL := qb.BuildURL(baseURL)
+}
+
+// listGrantsPage retrieves one page of grants.
+func (c *HTTPClient) listGrantsPage(ctx context.Context, params *domain.GrantsQueryParams) ([]domain.Grant, error) {
+	baseURL := fmt.Sprintf("%s/v3/grants", c.baseURL)
+
+	queryURL := NewQueryBuilder().
+		AddInt("limit", params.Limit).
+		AddInt("offset", params.Offset).
+		Add("connector_id", params.ConnectorID).
+		Add("provider", params.Provider).
+		Add("grant_status", params.Status).
+		BuildURL(baseURL)
 
 	var result struct {
 		Data []domain.Grant `json:"data"`
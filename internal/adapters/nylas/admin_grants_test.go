@@ -3,8 +3,10 @@ package nylas_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/mqasimca/nylas/internal/adapters/nylas"
@@ -94,6 +96,37 @@ func TestHTTPClient_ListAllGrants_WithParams(t *testing.T) {
 	assert.Equal(t, "google", string(grants[0].Provider))
 }
 
+func TestHTTPClient_ListAllGrants_Paginates(t *testing.T) {
+	const total = 450
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "200", query.Get("limit"))
+		assert.Equal(t, "google", query.Get("provider"))
+		offsets = append(offsets, query.Get("offset"))
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		data := []map[string]interface{}{}
+		for i := offset; i < total && i < offset+200; i++ {
+			data = append(data, map[string]interface{}{"id": fmt.Sprintf("grant-%d", i), "provider": "google"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+
+	grants, err := client.ListAllGrants(context.Background(), &domain.GrantsQueryParams{Provider: "google"})
+
+	require.NoError(t, err)
+	assert.Len(t, grants, total)
+	assert.Equal(t, "grant-449", grants[total-1].ID)
+	assert.Equal(t, []string{"", "200", "400"}, offsets)
+}
+
 func TestHTTPClient_GetGrantStats(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/v3/grants", r.URL.Path)
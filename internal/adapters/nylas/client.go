@@ -9,11 +9,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/mqasimca/nylas/internal/adapters/providers"
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
 	"github.com/mqasimca/nylas/internal/ports"
 	"golang.org/x/time/rate"
 )
@@ -71,11 +73,8 @@ type HTTPClient struct {
 // Retry logic handles transient errors with exponential backoff and Retry-After header support.
 func NewHTTPClient() *HTTPClient {
 	return &HTTPClient{
-		httpClient: &http.Client{
-			// Remove global timeout since we use per-request context timeouts
-			Timeout: 0,
-		},
-		baseURL: baseURLUS,
+		httpClient: newAPIHTTPClient(),
+		baseURL:    baseURLUS,
 		// Create token bucket rate limiter: 10 requests/second, burst of 20
 		rateLimiter:    rate.NewLimiter(rate.Limit(defaultRateLimit), defaultRateLimit*2),
 		requestTimeout: domain.TimeoutAPI,
@@ -84,6 +83,30 @@ func NewHTTPClient() *HTTPClient {
 	}
 }
 
+// newAPIHTTPClient returns the http.Client for API calls. NYLAS_REPLAY
+// answers them from a cassette directory with no network, and NYLAS_RECORD
+// saves them to one; see httputil.Recorder.
+func newAPIHTTPClient() *http.Client {
+	// No global timeout since we use per-request context timeouts
+	if dir := os.Getenv("NYLAS_REPLAY"); dir != "" {
+		return &http.Client{Transport: httputil.NewReplayer(dir, false)}
+	}
+	if dir := os.Getenv("NYLAS_RECORD"); dir != "" {
+		return &http.Client{Transport: httputil.NewRecorder(dir, httputil.Transport())}
+	}
+	return httputil.NewClient(0)
+}
+
+// NewReplayClient creates a client that answers every call from the
+// cassette in dir. With loose matching, calls match recordings on method
+// and path alone, so they needn't use the recorded query parameters.
+func NewReplayClient(dir string, loose bool) *HTTPClient {
+	c := NewHTTPClient()
+	c.httpClient = &http.Client{Transport: httputil.NewReplayer(dir, loose)}
+	c.maxRetries = 0
+	return c
+}
+
 // SetRegion sets the API region (us or eu).
 func (c *HTTPClient) SetRegion(region string) {
 	if region == "eu" {
@@ -117,37 +140,56 @@ func (c *HTTPClient) setAuthHeader(req *http.Request) {
 	}
 }
 
-// parseError parses an error response from the API.
+// parseError parses an error response from the API into a *domain.APIError.
 // Uses streaming decoder with size limit to avoid large allocations.
 func (c *HTTPClient) parseError(resp *http.Response) error {
+	apiErr := &domain.APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  getRequestID(resp),
+	}
+	apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"))
+
 	// Limit error response body to 10KB to prevent memory issues
 	limitedReader := io.LimitReader(resp.Body, 10*1024)
 
 	var errResp struct {
-		Error struct {
+		RequestID string `json:"request_id"`
+		Error     struct {
 			Message string `json:"message"`
 			Type    string `json:"type"`
 		} `json:"error"`
 	}
 
 	// Use streaming decoder instead of ReadAll + Unmarshal
-	if err := json.NewDecoder(limitedReader).Decode(&errResp); err == nil && errResp.Error.Message != "" {
-		return fmt.Errorf("%w: %s", domain.ErrAPIError, errResp.Error.Message)
+	if err := json.NewDecoder(limitedReader).Decode(&errResp); err == nil {
+		apiErr.Message = errResp.Error.Message
+		apiErr.Type = errResp.Error.Type
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = errResp.RequestID
+		}
 	}
 
-	return fmt.Errorf("%w: status %d", domain.ErrAPIError, resp.StatusCode)
+	return apiErr
 }
 
-// getRequestID extracts the request ID from response headers.
-func getRequestID(resp *http.Response) string {
-	if resp == nil {
-		return ""
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
 	}
-	// Nylas uses X-Request-Id header
-	if id := resp.Header.Get("X-Request-Id"); id != "" {
-		return id
+	if when, err := http.ParseTime(value); err == nil {
+		return max(time.Until(when), 0), true
 	}
-	return resp.Header.Get("Request-Id")
+	return 0, false
+}
+
+// getRequestID extracts the request ID from response headers.
+func getRequestID(resp *http.Response) string {
+	return httputil.RequestID(resp)
 }
 
 // ensureContext ensures a context has a timeout.
@@ -260,15 +302,8 @@ func (c *HTTPClient) shouldRetryStatus(statusCode int) bool {
 func (c *HTTPClient) calculateBackoff(attempt int, resp *http.Response) time.Duration {
 	// Check Retry-After header if response available
 	if resp != nil {
-		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-			// Try parsing as seconds
-			if seconds, err := strconv.Atoi(retryAfter); err == nil {
-				delay := time.Duration(seconds) * time.Second
-				if delay <= maxRetryDelay {
-					return delay
-				}
-				return maxRetryDelay
-			}
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(delay, maxRetryDelay)
 		}
 	}
 
@@ -0,0 +1,80 @@
+package nylas
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestParseError(t *testing.T) {
+	t.Run("nylas error body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Retry-After", "7")
+		rec.WriteHeader(http.StatusTooManyRequests)
+		_, _ = rec.WriteString(`{"request_id":"body-id","error":{"type":"rate_limit_error","message":"Too many requests"}}`)
+
+		err := NewHTTPClient().parseError(rec.Result())
+
+		var apiErr *domain.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("parseError() = %T, want *domain.APIError", err)
+		}
+		if apiErr.StatusCode != 429 || apiErr.Type != "rate_limit_error" || apiErr.Message != "Too many requests" {
+			t.Errorf("parseError() = %+v", apiErr)
+		}
+		if apiErr.RequestID != "body-id" {
+			t.Errorf("RequestID = %q, want the body's request_id", apiErr.RequestID)
+		}
+		if apiErr.RetryAfter != 7*time.Second {
+			t.Errorf("RetryAfter = %v, want 7s", apiErr.RetryAfter)
+		}
+		if !errors.Is(err, domain.ErrAPIRateLimited) {
+			t.Error("expected ErrAPIRateLimited")
+		}
+	})
+
+	t.Run("header request ID and empty body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("X-Request-Id", "header-id")
+		rec.WriteHeader(http.StatusBadGateway)
+
+		err := NewHTTPClient().parseError(rec.Result())
+
+		var apiErr *domain.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("parseError() = %T, want *domain.APIError", err)
+		}
+		if apiErr.RequestID != "header-id" {
+			t.Errorf("RequestID = %q, want header-id", apiErr.RequestID)
+		}
+		if err.Error() != "nylas API error: status 502" {
+			t.Errorf("Error() = %q", err.Error())
+		}
+		if !errors.Is(err, domain.ErrAPIServerError) {
+			t.Error("expected ErrAPIServerError")
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("30"); !ok || d != 30*time.Second {
+		t.Errorf("seconds: got %v, %v", d, ok)
+	}
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(future); !ok || d <= 0 || d > time.Minute {
+		t.Errorf("date: got %v, %v", d, ok)
+	}
+	past := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(past); !ok || d != 0 {
+		t.Errorf("past date: got %v, %v", d, ok)
+	}
+	for _, bad := range []string{"", "soon", "-5"} {
+		if _, ok := parseRetryAfter(bad); ok {
+			t.Errorf("parseRetryAfter(%q) should fail", bad)
+		}
+	}
+}
@@ -57,6 +57,7 @@ type MockClient struct {
 	RevokeGrantFunc           func(ctx context.Context, grantID string) error
 	GetMessagesFunc           func(ctx context.Context, grantID string, limit int) ([]domain.Message, error)
 	GetMessagesWithParamsFunc func(ctx context.Context, grantID string, params *domain.MessageQueryParams) ([]domain.Message, error)
+	GetMessagesWithCursorFunc func(ctx context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error)
 	GetMessageFunc            func(ctx context.Context, grantID, messageID string) (*domain.Message, error)
 	SendMessageFunc           func(ctx context.Context, grantID string, req *domain.SendMessageRequest) (*domain.Message, error)
 	UpdateMessageFunc         func(ctx context.Context, grantID, messageID string, req *domain.UpdateMessageRequest) (*domain.Message, error)
@@ -81,12 +82,13 @@ type MockClient struct {
 	DownloadAttachmentFunc    func(ctx context.Context, grantID, messageID, attachmentID string) (io.ReadCloser, error)
 
 	// Calendar functions
-	GetCalendarsFunc func(ctx context.Context, grantID string) ([]domain.Calendar, error)
-	GetEventsFunc    func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error)
-	GetEventFunc     func(ctx context.Context, grantID, calendarID, eventID string) (*domain.Event, error)
-	CreateEventFunc  func(ctx context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error)
-	UpdateEventFunc  func(ctx context.Context, grantID, calendarID, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error)
-	DeleteEventFunc  func(ctx context.Context, grantID, calendarID, eventID string) error
+	GetCalendarsFunc        func(ctx context.Context, grantID string) ([]domain.Calendar, error)
+	GetEventsFunc           func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error)
+	GetEventsWithCursorFunc func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error)
+	GetEventFunc            func(ctx context.Context, grantID, calendarID, eventID string) (*domain.Event, error)
+	CreateEventFunc         func(ctx context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error)
+	UpdateEventFunc         func(ctx context.Context, grantID, calendarID, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error)
+	DeleteEventFunc         func(ctx context.Context, grantID, calendarID, eventID string) error
 }
 
 // NewMockClient creates a new MockClient.
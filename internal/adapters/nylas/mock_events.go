@@ -13,9 +13,17 @@ func (m *MockClient) GetEvents(ctx context.Context, grantID, calendarID string,
 	return []domain.Event{}, nil
 }
 
-// GetEventsWithCursor retrieves events with pagination.
+// GetEventsWithCursor retrieves events with pagination. Without its own
+// func, it returns what GetEvents does as a single page.
 func (m *MockClient) GetEventsWithCursor(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
-	return &domain.EventListResponse{Data: []domain.Event{}}, nil
+	if m.GetEventsWithCursorFunc != nil {
+		return m.GetEventsWithCursorFunc(ctx, grantID, calendarID, params)
+	}
+	events, err := m.GetEvents(ctx, grantID, calendarID, params)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EventListResponse{Data: events}, nil
 }
 
 // GetEvent retrieves a single event.
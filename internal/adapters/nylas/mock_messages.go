@@ -29,6 +29,9 @@ func (m *MockClient) GetMessagesWithParams(ctx context.Context, grantID string,
 func (m *MockClient) GetMessagesWithCursor(ctx context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
 	m.GetMessagesWithParamsCalled = true
 	m.LastGrantID = grantID
+	if m.GetMessagesWithCursorFunc != nil {
+		return m.GetMessagesWithCursorFunc(ctx, grantID, params)
+	}
 	if m.GetMessagesWithParamsFunc != nil {
 		msgs, err := m.GetMessagesWithParamsFunc(ctx, grantID, params)
 		return &domain.MessageListResponse{Data: msgs}, err
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// CSVWriter outputs lists as CSV with a header row.
+type CSVWriter struct {
+	w io.Writer
+}
+
+// NewCSVWriter creates a new CSV writer.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: w}
+}
+
+// Write outputs a list, or a single object as a one-row table, with a
+// column per exported field.
+func (cw *CSVWriter) Write(data any) error {
+	if data == nil {
+		return nil
+	}
+	v, ok := listValue(data)
+	if !ok {
+		rows := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(data)), 0, 1)
+		rows = reflect.Append(rows, reflect.ValueOf(data))
+		return cw.WriteList(rows.Interface(), nil)
+	}
+	return cw.WriteList(v.Interface(), nil)
+}
+
+// WriteList outputs a list as CSV. Without columns, every exported field is a column.
+func (cw *CSVWriter) WriteList(data any, columns []ports.Column) error {
+	if _, ok := listValue(data); !ok {
+		return cw.Write(data)
+	}
+	stream := cw.StreamList(columns)
+	if err := stream.WriteRows(data); err != nil {
+		return err
+	}
+	return stream.Close()
+}
+
+// StreamList starts a CSV list. The header is written with the first rows.
+func (cw *CSVWriter) StreamList(columns []ports.Column) ports.ListStream {
+	return &csvStream{w: csv.NewWriter(cw.w), columns: columns}
+}
+
+// csvStream writes CSV records as rows arrive.
+type csvStream struct {
+	w       *csv.Writer
+	columns []ports.Column
+	header  bool
+}
+
+// WriteRows outputs rows as records, preceded by the header the first time.
+// Without columns, the first rows' exported fields become the columns.
+func (s *csvStream) WriteRows(rows any) error {
+	v, ok := listValue(rows)
+	if !ok {
+		rv := reflect.ValueOf(rows)
+		v = reflect.Append(reflect.MakeSlice(reflect.SliceOf(rv.Type()), 0, 1), rv)
+	}
+	if !s.header && len(s.columns) == 0 {
+		s.columns = structColumns(elemType(v))
+	}
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+
+	for i := range v.Len() {
+		row := v.Index(i)
+		record := make([]string, len(s.columns))
+		for j, col := range s.columns {
+			record[j] = plainValue(rowValue(row, col.Field))
+		}
+		if err := s.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// Close writes the header if no rows were written.
+func (s *csvStream) Close() error {
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+func (s *csvStream) writeHeader() error {
+	if s.header || len(s.columns) == 0 {
+		return nil
+	}
+	s.header = true
+	header := make([]string, len(s.columns))
+	for i, col := range s.columns {
+		header[i] = col.Header
+	}
+	return s.w.Write(header)
+}
+
+// WriteError outputs an error as a one-column CSV.
+func (cw *CSVWriter) WriteError(err error) error {
+	w := csv.NewWriter(cw.w)
+	_ = w.Write([]string{"error"})
+	_ = w.Write([]string{err.Error()})
+	w.Flush()
+	return w.Error()
+}
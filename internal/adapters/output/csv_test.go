@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type csvItem struct {
+	ID      string    `json:"id"`
+	Subject string    `json:"subject"`
+	Date    time.Time `json:"date"`
+	Tags    []string  `json:"tags,omitempty"`
+	hidden  string
+}
+
+func TestCSVWriter_WriteList(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+
+	date := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := []csvItem{
+		{ID: "1", Subject: "Hello, world", Date: date},
+		{ID: "2", Subject: `Say "hi"`},
+	}
+	columns := []ports.Column{
+		{Header: "ID", Field: "ID"},
+		{Header: "Subject", Field: "Subject"},
+		{Header: "Date", Field: "Date"},
+	}
+
+	require.NoError(t, w.WriteList(data, columns))
+	assert.Equal(t, "ID,Subject,Date\n1,\"Hello, world\",2026-01-02T03:04:05Z\n2,\"Say \"\"hi\"\"\",\n", buf.String())
+}
+
+func TestCSVWriter_WriteDerivesColumns(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+
+	require.NoError(t, w.Write([]csvItem{{ID: "1", Subject: "Hi", Tags: []string{"a", "b"}}}))
+	assert.Equal(t, "id,subject,date,tags\n1,Hi,,\"a, b\"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, w.Write(csvItem{ID: "9"}))
+	assert.Equal(t, "id,subject,date,tags\n9,,,\n", buf.String())
+}
+
+func TestCSVWriter_EmbeddedStruct(t *testing.T) {
+	type item struct {
+		ID      string `json:"id"`
+		Subject string `json:"subject"`
+	}
+	type accountItem struct {
+		Account string `json:"account"`
+		item
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatCSV})
+	require.NoError(t, w.WriteList([]accountItem{{Account: "a@example.com", item: item{ID: "1", Subject: "Hi"}}}, nil))
+	assert.Equal(t, "account,id,subject\na@example.com,1,Hi\n", buf.String())
+
+	buf.Reset()
+	w = NewWriter(&buf, ports.OutputOptions{Format: ports.FormatCSV, Columns: []string{"subject", "account"}})
+	require.NoError(t, w.WriteList([]accountItem{{Account: "a@example.com", item: item{ID: "1", Subject: "Hi"}}}, nil))
+	assert.Equal(t, "subject,account\nHi,a@example.com\n", buf.String())
+}
+
+func TestPlainValue(t *testing.T) {
+	type nested struct {
+		Name string `json:"name"`
+	}
+	str := "ptr"
+
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"string", "abc", "abc"},
+		{"nil", nil, ""},
+		{"pointer", &str, "ptr"},
+		{"nil pointer", (*string)(nil), ""},
+		{"bool", true, "true"},
+		{"int", 42, "42"},
+		{"zero time", time.Time{}, ""},
+		{"struct as JSON", nested{Name: "Ann"}, `{"name":"Ann"}`},
+		{"empty slice", []nested{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, plainValue(tt.input))
+		})
+	}
+}
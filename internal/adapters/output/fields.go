@@ -0,0 +1,153 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// normalizeName lowercases a column or field name and drops separators, so
+// "received_at", "Received-At" and "ReceivedAt" all match.
+func normalizeName(name string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "", ".", "").Replace(strings.ToLower(name))
+}
+
+// matchesField reports whether a struct field answers to name, by Go name
+// or JSON tag.
+func matchesField(f reflect.StructField, name string) bool {
+	want := normalizeName(name)
+	if normalizeName(f.Name) == want {
+		return true
+	}
+	tag := jsonTag(f)
+	return tag != "" && tag != "-" && normalizeName(tag) == want
+}
+
+// jsonTag returns the name in a field's JSON tag, if any.
+func jsonTag(f reflect.StructField) string {
+	tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return tag
+}
+
+// listValue returns the slice behind data, dereferencing pointers.
+func listValue(data any) (reflect.Value, bool) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// elemType returns the struct type of a list's elements, if it has one.
+func elemType(v reflect.Value) reflect.Type {
+	t := v.Type().Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// structColumns derives columns from a struct's exported fields, for lists
+// written without explicit columns.
+func structColumns(t reflect.Type) []ports.Column {
+	if t.Kind() != reflect.Struct {
+		return []ports.Column{{Header: "Value", Field: ""}}
+	}
+	var cols []ports.Column
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && jsonTag(f) == "" {
+			// Embedded structs are flattened, as in their JSON
+			cols = append(cols, structColumns(f.Type)...)
+			continue
+		}
+		if f.PkgPath != "" || f.Anonymous {
+			continue
+		}
+		header := jsonTag(f)
+		if header == "-" {
+			continue
+		}
+		if header == "" {
+			header = f.Name
+		}
+		cols = append(cols, ports.Column{Header: header, Field: f.Name, Width: -1})
+	}
+	return cols
+}
+
+// selectColumns picks columns by name from the defaults, matching header or
+// field. Names that aren't default columns become new columns read from the
+// field of that name.
+func selectColumns(defaults []ports.Column, names []string) []ports.Column {
+	if len(names) == 0 {
+		return defaults
+	}
+	cols := make([]ports.Column, 0, len(names))
+	for _, name := range names {
+		col := ports.Column{Header: name, Field: name, Width: -1}
+		for _, d := range defaults {
+			if normalizeName(d.Header) == normalizeName(name) || normalizeName(d.Field) == normalizeName(name) {
+				col = d
+				break
+			}
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// rowValue reads a column from a row. An empty field means the row itself.
+func rowValue(row reflect.Value, field string) any {
+	if field == "" {
+		return row.Interface()
+	}
+	return getFieldValue(row, field)
+}
+
+// plainValue converts a value to text for machine-readable output: times are
+// RFC 3339 and nested values are JSON.
+func plainValue(v any) string {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+		v = rv.Interface()
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	case fmt.Stringer:
+		return val.String()
+	case []string:
+		return strings.Join(val, ", ")
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		if (rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.Len() == 0 {
+			return ""
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
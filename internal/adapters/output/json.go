@@ -28,6 +28,11 @@ func (jw *JSONWriter) WriteList(data any, _ []ports.Column) error {
 	return jw.encode(data)
 }
 
+// StreamList starts a JSON array written element by element (columns are ignored).
+func (jw *JSONWriter) StreamList(_ []ports.Column) ports.ListStream {
+	return &jsonStream{w: jw.w}
+}
+
 // WriteError outputs an error as JSON.
 func (jw *JSONWriter) WriteError(err error) error {
 	errObj := map[string]string{
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// NDJSONWriter outputs one compact JSON object per line, so lists can be
+// streamed and processed line by line.
+type NDJSONWriter struct {
+	enc *json.Encoder
+}
+
+// NewNDJSONWriter creates a new NDJSON writer.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{enc: json.NewEncoder(w)}
+}
+
+// Write outputs an object as one line, or a list as one line per item.
+func (nw *NDJSONWriter) Write(data any) error {
+	v, ok := listValue(data)
+	if !ok {
+		return nw.WriteRow(data)
+	}
+	for i := range v.Len() {
+		if err := nw.WriteRow(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteList outputs a list as one line per item (columns are ignored).
+func (nw *NDJSONWriter) WriteList(data any, _ []ports.Column) error {
+	return nw.Write(data)
+}
+
+// StreamList starts a list written one line per row (columns are ignored).
+func (nw *NDJSONWriter) StreamList(_ []ports.Column) ports.ListStream {
+	return rowStream{writeRow: nw.WriteRow}
+}
+
+// WriteRow outputs a single list item.
+func (nw *NDJSONWriter) WriteRow(row any) error {
+	if err := nw.enc.Encode(row); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// WriteError outputs an error as a JSON line.
+func (nw *NDJSONWriter) WriteError(err error) error {
+	return nw.WriteRow(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONWriter_Write(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+
+	data := []map[string]string{{"id": "1"}, {"id": "2"}}
+	require.NoError(t, w.WriteList(data, nil))
+	assert.Equal(t, "{\"id\":\"1\"}\n{\"id\":\"2\"}\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, w.Write(map[string]int{"count": 3}))
+	assert.Equal(t, "{\"count\":3}\n", buf.String())
+}
+
+func TestNDJSONWriter_WriteRowAndError(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+
+	require.NoError(t, w.WriteRow(map[string]string{"id": "1"}))
+	require.NoError(t, w.WriteError(errors.New("boom")))
+	assert.Equal(t, "{\"id\":\"1\"}\n{\"error\":\"boom\"}\n", buf.String())
+}
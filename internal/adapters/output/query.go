@@ -0,0 +1,138 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// queryWriter applies a JMESPath expression to the data before the format
+// writer renders it. The expression sees the JSON form of the data, so field
+// names are the ones --json prints.
+type queryWriter struct {
+	inner ports.OutputWriter
+	query *jmespath.JMESPath
+	err   error // Compile error, reported on first write
+}
+
+func newQueryWriter(inner ports.OutputWriter, expr string) *queryWriter {
+	qw := &queryWriter{inner: inner}
+	qw.query, qw.err = jmespath.Compile(expr)
+	if qw.err != nil {
+		qw.err = fmt.Errorf("invalid --query %q: %w", expr, qw.err)
+	}
+	return qw
+}
+
+// Write queries an object or list and writes the result.
+func (qw *queryWriter) Write(data any) error {
+	return qw.WriteList(data, nil)
+}
+
+// WriteList queries a list and writes the result. Columns that still exist
+// in the result keep their header and order; other keys follow.
+func (qw *queryWriter) WriteList(data any, columns []ports.Column) error {
+	result, err := qw.apply(data)
+	if err != nil {
+		return err
+	}
+
+	v, ok := listValue(result)
+	if !ok || result == nil {
+		return qw.inner.Write(result)
+	}
+	return qw.inner.WriteList(result, queryColumns(v, data, columns))
+}
+
+// WriteError writes an error through the underlying writer.
+func (qw *queryWriter) WriteError(err error) error {
+	return qw.inner.WriteError(err)
+}
+
+// apply runs the expression over the JSON form of data.
+func (qw *queryWriter) apply(data any) (any, error) {
+	if qw.err != nil {
+		return nil, qw.err
+	}
+	doc, err := toJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	result, err := qw.query.Search(doc)
+	if err != nil {
+		return nil, fmt.Errorf("--query failed: %w", err)
+	}
+	return result, nil
+}
+
+// toJSONValue converts data to the maps, slices and scalars of its JSON form.
+func toJSONValue(data any) (any, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data for --query: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode data for --query: %w", err)
+	}
+	return doc, nil
+}
+
+// queryColumns derives columns for a query result. Lists of objects get a
+// column per key, with the command's own columns first when their JSON name
+// survived the query; lists of scalars get a single value column.
+func queryColumns(result reflect.Value, data any, columns []ports.Column) []ports.Column {
+	keys := make(map[string]bool)
+	for i := range result.Len() {
+		row, ok := result.Index(i).Interface().(map[string]any)
+		if !ok {
+			return []ports.Column{{Header: "Value", Field: "", Width: -1}}
+		}
+		for k := range row {
+			keys[k] = true
+		}
+	}
+
+	var cols []ports.Column
+	if src, ok := listValue(data); ok && src.Len() > 0 {
+		for _, col := range columns {
+			key := jsonName(elemType(src), col.Field)
+			if keys[key] {
+				cols = append(cols, ports.Column{Header: col.Header, Field: key, Width: col.Width})
+				delete(keys, key)
+			}
+		}
+	}
+
+	rest := make([]string, 0, len(keys))
+	for k := range keys {
+		rest = append(rest, k)
+	}
+	sort.Strings(rest)
+	for _, k := range rest {
+		cols = append(cols, ports.Column{Header: k, Field: k})
+	}
+	return cols
+}
+
+// jsonName returns the JSON key a struct field is encoded under.
+func jsonName(t reflect.Type, field string) string {
+	if t.Kind() != reflect.Struct {
+		return field
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.PkgPath != "" || !matchesField(f, field) {
+			continue
+		}
+		if tag := jsonTag(f); tag != "" && tag != "-" {
+			return tag
+		}
+		return f.Name
+	}
+	return field
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type queryItem struct {
+	ID      string `json:"id"`
+	Subject string `json:"subject"`
+	Unread  bool   `json:"unread"`
+}
+
+var queryItems = []queryItem{
+	{ID: "1", Subject: "Hello", Unread: true},
+	{ID: "2", Subject: "Read me", Unread: false},
+	{ID: "3", Subject: "Again", Unread: true},
+}
+
+var queryItemColumns = []ports.Column{
+	{Header: "Subject", Field: "Subject"},
+	{Header: "ID", Field: "ID"},
+}
+
+func TestQueryWriter_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatNDJSON, Query: "[?unread].{id:id,subject:subject}"})
+
+	require.NoError(t, w.WriteList(queryItems, queryItemColumns))
+	assert.Equal(t, "{\"id\":\"1\",\"subject\":\"Hello\"}\n{\"id\":\"3\",\"subject\":\"Again\"}\n", buf.String())
+
+	_, streams := w.(ports.ListStreamer)
+	assert.False(t, streams, "queries need the whole result, so they can't stream")
+}
+
+func TestQueryWriter_TableKeepsColumnOrder(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatTable, NoColor: true, Query: "[?unread].{id:id,subject:subject,flag:unread}"})
+
+	require.NoError(t, w.WriteList(queryItems, queryItemColumns))
+	assert.Equal(t, "SUBJECT  ID  FLAG\nHello    1   Yes\nAgain    3   Yes\n", buf.String())
+}
+
+func TestQueryWriter_Scalars(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatTable, NoColor: true, Query: "length(@)"})
+	require.NoError(t, w.Write(queryItems))
+	assert.Equal(t, "3\n", buf.String())
+
+	buf.Reset()
+	w = NewWriter(&buf, ports.OutputOptions{Format: ports.FormatCSV, Query: "[].subject"})
+	require.NoError(t, w.Write(queryItems))
+	assert.Equal(t, "Value\nHello\nRead me\nAgain\n", buf.String())
+
+	buf.Reset()
+	w = NewWriter(&buf, ports.OutputOptions{Format: ports.FormatTable, Query: "[0].{id:id,subject:subject}"})
+	require.NoError(t, w.Write(queryItems))
+	assert.Equal(t, "id:       1\nsubject:  Hello\n", buf.String())
+}
+
+func TestQueryWriter_WithSortAndQuiet(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatQuiet, Query: "[?unread]", Sort: "-subject"})
+
+	require.NoError(t, w.WriteList(queryItems, queryItemColumns))
+	assert.Equal(t, "1\n3\n", buf.String())
+}
+
+func TestQueryWriter_InvalidExpression(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, ports.OutputOptions{Format: ports.FormatJSON, Query: "[?unread"})
+	err := w.Write(queryItems)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --query")
+}
@@ -49,6 +49,11 @@ func (qw *QuietWriter) WriteList(data any, _ []ports.Column) error {
 	return nil
 }
 
+// StreamList starts a list whose IDs are written as rows arrive.
+func (qw *QuietWriter) StreamList(_ []ports.Column) ports.ListStream {
+	return rowStream{writeRow: qw.Write}
+}
+
 // WriteError outputs nothing in quiet mode (errors go to stderr in CLI).
 func (qw *QuietWriter) WriteError(_ error) error {
 	// In quiet mode, we don't output errors to stdout
@@ -63,6 +68,14 @@ func extractQuietField(data any) string {
 		return qf.QuietField()
 	}
 
+	// Query results are JSON objects
+	if m, ok := data.(map[string]any); ok {
+		if id, ok := m["id"]; ok {
+			return fmt.Sprintf("%v", id)
+		}
+		return ""
+	}
+
 	v := reflect.ValueOf(data)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -0,0 +1,279 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"gopkg.in/yaml.v3"
+)
+
+// shapedWriter applies --columns and --sort before handing lists to the
+// underlying writer.
+type shapedWriter struct {
+	inner   ports.OutputWriter
+	format  ports.OutputFormat
+	columns []string
+	sort    string
+}
+
+// Write outputs a single object, or a sorted and column-selected list.
+func (sw *shapedWriter) Write(data any) error {
+	v, ok := listValue(data)
+	if !ok {
+		return sw.inner.Write(data)
+	}
+	if len(sw.columns) == 0 {
+		if sw.sort != "" {
+			v = sortRows(v, sw.sort)
+		}
+		return sw.inner.Write(v.Interface())
+	}
+	return sw.WriteList(data, nil)
+}
+
+// WriteList sorts the list, then selects columns. Column-less formats get
+// each row reduced to the selected fields.
+func (sw *shapedWriter) WriteList(data any, columns []ports.Column) error {
+	v, ok := listValue(data)
+	if !ok {
+		return sw.inner.WriteList(data, columns)
+	}
+	if sw.sort != "" {
+		v = sortRows(v, resolveField(sw.sort, columns))
+	}
+
+	if len(sw.columns) == 0 {
+		return sw.inner.WriteList(v.Interface(), columns)
+	}
+	if len(columns) == 0 && v.Len() > 0 {
+		columns = structColumns(elemType(v))
+	}
+	selected := selectColumns(columns, sw.columns)
+
+	if sw.projects() {
+		rows := make([]orderedRow, v.Len())
+		for i := range v.Len() {
+			rows[i] = projectRow(v.Index(i), selected)
+		}
+		return sw.inner.WriteList(rows, selected)
+	}
+	return sw.inner.WriteList(v.Interface(), selected)
+}
+
+// WriteError outputs an error through the underlying writer.
+func (sw *shapedWriter) WriteError(err error) error {
+	return sw.inner.WriteError(err)
+}
+
+// projects reports whether the format ignores columns, so --columns has to
+// trim the rows themselves.
+func (sw *shapedWriter) projects() bool {
+	switch sw.format {
+	case ports.FormatJSON, ports.FormatYAML, ports.FormatNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// streamingShapedWriter is a shapedWriter over a list streamer. It only
+// exists without --sort, since sorting needs every row first.
+type streamingShapedWriter struct {
+	*shapedWriter
+	streamer ports.ListStreamer
+}
+
+// StreamList starts a list whose rows are reduced to the selected columns.
+func (sw *streamingShapedWriter) StreamList(columns []ports.Column) ports.ListStream {
+	if len(sw.columns) == 0 {
+		return sw.streamer.StreamList(columns)
+	}
+	return &shapedStream{sw: sw, columns: columns}
+}
+
+// shapedStream selects columns once the first rows show the row type.
+type shapedStream struct {
+	sw       *streamingShapedWriter
+	columns  []ports.Column // The command's default columns
+	selected []ports.Column
+	inner    ports.ListStream // Started with the first rows
+}
+
+// WriteRows outputs rows, projected to the selected columns for column-less formats.
+func (s *shapedStream) WriteRows(rows any) error {
+	v, ok := listValue(rows)
+	if !ok {
+		return s.start(reflect.Value{}).WriteRows(rows)
+	}
+	inner := s.start(v)
+	if !s.sw.projects() {
+		return inner.WriteRows(rows)
+	}
+	projected := make([]orderedRow, v.Len())
+	for i := range v.Len() {
+		projected[i] = projectRow(v.Index(i), s.selected)
+	}
+	return inner.WriteRows(projected)
+}
+
+// Close finishes the underlying list.
+func (s *shapedStream) Close() error {
+	return s.start(reflect.Value{}).Close()
+}
+
+// start picks the columns and starts the underlying list on first use.
+func (s *shapedStream) start(rows reflect.Value) ports.ListStream {
+	if s.inner != nil {
+		return s.inner
+	}
+	defaults := s.columns
+	if len(defaults) == 0 && rows.IsValid() {
+		defaults = structColumns(elemType(rows))
+	}
+	s.selected = selectColumns(defaults, s.sw.columns)
+	s.inner = s.sw.streamer.StreamList(s.selected)
+	return s.inner
+}
+
+// resolveField maps a sort name to a field, preferring a column's field.
+func resolveField(name string, columns []ports.Column) string {
+	prefix := ""
+	if strings.HasPrefix(name, "-") {
+		prefix, name = "-", name[1:]
+	}
+	for _, col := range columns {
+		if normalizeName(col.Header) == normalizeName(name) {
+			return prefix + col.Field
+		}
+	}
+	return prefix + name
+}
+
+// sortRows returns a sorted copy of a list. A leading "-" on field sorts descending.
+func sortRows(v reflect.Value, field string) reflect.Value {
+	desc := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(field, "-")
+
+	sorted := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+	reflect.Copy(sorted, v)
+
+	sort.SliceStable(sorted.Interface(), func(i, j int) bool {
+		c := compareValues(getFieldValue(sorted.Index(i), field), getFieldValue(sorted.Index(j), field))
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	return sorted
+}
+
+// compareValues orders two field values of the same kind.
+func compareValues(a, b any) int {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	for av.IsValid() && av.Kind() == reflect.Ptr && !av.IsNil() {
+		av = av.Elem()
+	}
+	for bv.IsValid() && bv.Kind() == reflect.Ptr && !bv.IsNil() {
+		bv = bv.Elem()
+	}
+	if !av.IsValid() || !bv.IsValid() || av.Kind() != bv.Kind() {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+
+	if at, ok := av.Interface().(time.Time); ok {
+		return at.Compare(bv.Interface().(time.Time))
+	}
+
+	switch av.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmpOrdered(av.Int(), bv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmpOrdered(av.Uint(), bv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmpOrdered(av.Float(), bv.Float())
+	case reflect.Bool:
+		return cmpOrdered(boolInt(av.Bool()), boolInt(bv.Bool()))
+	case reflect.String:
+		return strings.Compare(strings.ToLower(av.String()), strings.ToLower(bv.String()))
+	default:
+		return strings.Compare(plainValue(av.Interface()), plainValue(bv.Interface()))
+	}
+}
+
+func cmpOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// orderedRow is a row reduced to selected columns. It keeps the column order
+// when encoded as JSON or YAML.
+type orderedRow struct {
+	keys   []string
+	values map[string]any
+}
+
+// projectRow reduces a row to the selected columns, keyed by column header.
+func projectRow(row reflect.Value, columns []ports.Column) orderedRow {
+	r := orderedRow{values: make(map[string]any, len(columns))}
+	for _, col := range columns {
+		key := strings.ToLower(col.Header)
+		if _, dup := r.values[key]; !dup {
+			r.keys = append(r.keys, key)
+		}
+		r.values[key] = rowValue(row, col.Field)
+	}
+	return r
+}
+
+// MarshalJSON encodes the row as an object in column order.
+func (r orderedRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(r.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML encodes the row as a mapping in column order.
+func (r orderedRow) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range r.keys {
+		var value yaml.Node
+		if err := value.Encode(r.values[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &value)
+	}
+	return node, nil
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type shapeItem struct {
+	ID       string    `json:"id"`
+	Subject  string    `json:"subject"`
+	Size     int       `json:"size"`
+	Received time.Time `json:"received_at"`
+}
+
+func shapeItems() []shapeItem {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []shapeItem{
+		{ID: "b", Subject: "beta", Size: 20, Received: base.Add(2 * time.Hour)},
+		{ID: "a", Subject: "Alpha", Size: 300, Received: base.Add(time.Hour)},
+		{ID: "c", Subject: "gamma", Size: 1, Received: base.Add(3 * time.Hour)},
+	}
+}
+
+var shapeColumns = []ports.Column{
+	{Header: "ID", Field: "ID"},
+	{Header: "Subject", Field: "Subject"},
+}
+
+func TestNewWriter_ColumnsAndSortCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{
+		Format:  ports.FormatCSV,
+		Columns: []string{"subject", "received_at"},
+		Sort:    "-size",
+	})
+
+	require.NoError(t, w.WriteList(shapeItems(), shapeColumns))
+	assert.Equal(t, "Subject,received_at\n"+
+		"Alpha,2026-01-01T01:00:00Z\n"+
+		"beta,2026-01-01T02:00:00Z\n"+
+		"gamma,2026-01-01T03:00:00Z\n", buf.String())
+}
+
+func TestNewWriter_SortByColumnHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatQuiet, Sort: "Subject"})
+
+	require.NoError(t, w.WriteList(shapeItems(), shapeColumns))
+	assert.Equal(t, "a\nb\nc\n", buf.String())
+
+	buf.Reset()
+	w = NewWriter(&buf, ports.OutputOptions{Format: ports.FormatTemplate, Template: "{{.ID}}", Sort: "-received_at"})
+	require.NoError(t, w.Write(shapeItems()))
+	assert.Equal(t, "c\nb\na\n", buf.String(), "Write should sort lists too")
+}
+
+func TestNewWriter_ColumnsProjectJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatNDJSON, Columns: []string{"subject", "id"}})
+
+	require.NoError(t, w.Write(shapeItems()[:1]))
+	assert.Equal(t, "{\"subject\":\"beta\",\"id\":\"b\"}\n", buf.String())
+
+	streamer, ok := w.(ports.ListStreamer)
+	require.True(t, ok, "NDJSON without --sort should stream")
+	buf.Reset()
+	list := streamer.StreamList(shapeColumns)
+	require.NoError(t, list.WriteRows(shapeItems()[1:2]))
+	require.NoError(t, list.Close())
+	assert.Equal(t, "{\"subject\":\"Alpha\",\"id\":\"a\"}\n", buf.String())
+}
+
+func TestNewWriter_ColumnsProjectYAML(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatYAML, Columns: []string{"size", "id"}})
+
+	require.NoError(t, w.WriteList(shapeItems()[:1], shapeColumns))
+	assert.Equal(t, "- size: 20\n  id: b\n", buf.String())
+}
+
+func TestNewWriter_SortDisablesStreaming(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, ports.OutputOptions{Format: ports.FormatNDJSON, Sort: "id"})
+	_, ok := w.(ports.ListStreamer)
+	assert.False(t, ok)
+}
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+	assert.Negative(t, compareValues(1, 2))
+	assert.Positive(t, compareValues("b", "A"))
+	assert.Zero(t, compareValues(true, true))
+	assert.Negative(t, compareValues(now, now.Add(time.Second)))
+	assert.Negative(t, compareValues(1.5, 2.5))
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// forEachRow calls fn for each item of a list, or once for a single object.
+func forEachRow(rows any, fn func(row any) error) error {
+	v, ok := listValue(rows)
+	if !ok {
+		return fn(rows)
+	}
+	for i := range v.Len() {
+		if err := fn(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowStream streams a list for formats without list framing (NDJSON,
+// templates, quiet) by writing each row as it comes.
+type rowStream struct {
+	writeRow func(row any) error
+}
+
+// WriteRows outputs each row.
+func (s rowStream) WriteRows(rows any) error {
+	return forEachRow(rows, s.writeRow)
+}
+
+// Close does nothing; there's no list framing to finish.
+func (rowStream) Close() error { return nil }
+
+// jsonStream writes a pretty-printed JSON array one element at a time.
+type jsonStream struct {
+	w io.Writer
+	n int
+}
+
+// WriteRows outputs rows as array elements, opening the array on the first one.
+func (s *jsonStream) WriteRows(rows any) error {
+	return forEachRow(rows, func(row any) error {
+		b, err := json.MarshalIndent(row, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		sep := ",\n  "
+		if s.n == 0 {
+			sep = "[\n  "
+		}
+		s.n++
+		_, err = fmt.Fprintf(s.w, "%s%s", sep, b)
+		return err
+	})
+}
+
+// Close closes the array, or writes an empty one if there were no rows.
+func (s *jsonStream) Close() error {
+	if s.n == 0 {
+		_, err := fmt.Fprintln(s.w, "[]")
+		return err
+	}
+	_, err := fmt.Fprint(s.w, "\n]\n")
+	return err
+}
+
+// yamlStream writes a YAML sequence one item at a time.
+type yamlStream struct {
+	yw *YAMLWriter
+	n  int
+}
+
+// WriteRows outputs each row as a sequence item.
+func (s *yamlStream) WriteRows(rows any) error {
+	return forEachRow(rows, func(row any) error {
+		s.n++
+		return s.yw.encode([]any{row})
+	})
+}
+
+// Close writes an empty sequence if there were no rows.
+func (s *yamlStream) Close() error {
+	if s.n == 0 {
+		return s.yw.encode([]any{})
+	}
+	return nil
+}
+
+var (
+	_ ports.ListStream = rowStream{}
+	_ ports.ListStream = (*jsonStream)(nil)
+	_ ports.ListStream = (*yamlStream)(nil)
+)
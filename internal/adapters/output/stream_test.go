@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamPages writes items through a list stream in pages of size n.
+func streamPages(t *testing.T, w ports.OutputWriter, columns []ports.Column, items []shapeItem, n int) {
+	t.Helper()
+	streamer, ok := w.(ports.ListStreamer)
+	require.True(t, ok, "%T should stream lists", w)
+
+	list := streamer.StreamList(columns)
+	for len(items) > 0 {
+		page := items[:min(n, len(items))]
+		items = items[len(page):]
+		require.NoError(t, list.WriteRows(page))
+	}
+	require.NoError(t, list.Close())
+}
+
+func TestStreamList_MatchesWriteList(t *testing.T) {
+	formats := []ports.OutputFormat{ports.FormatJSON, ports.FormatYAML, ports.FormatCSV, ports.FormatNDJSON, ports.FormatQuiet}
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			var want, got bytes.Buffer
+			require.NoError(t, NewWriter(&want, ports.OutputOptions{Format: format}).WriteList(shapeItems(), shapeColumns))
+			streamPages(t, NewWriter(&got, ports.OutputOptions{Format: format}), shapeColumns, shapeItems(), 2)
+			assert.Equal(t, want.String(), got.String())
+		})
+	}
+}
+
+func TestStreamList_EmptyJSONArray(t *testing.T) {
+	var buf bytes.Buffer
+	streamPages(t, NewJSONWriter(&buf), nil, nil, 1)
+
+	var items []shapeItem
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestStreamList_Table(t *testing.T) {
+	var buf bytes.Buffer
+	items := shapeItems()
+	items[2].Subject = "a much longer subject"
+	streamPages(t, NewTableWriter(&buf, false), []ports.Column{
+		{Header: "Subject", Field: "Subject"},
+		{Header: "ID", Field: "ID"},
+	}, items, 2)
+
+	assert.Equal(t, "SUBJECT  ID\n"+
+		"beta     b\n"+
+		"Alpha    a\n"+
+		"a mu...  c\n", buf.String(), "later pages keep the first page's widths")
+}
+
+func TestStreamList_ColumnsAndTimes(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, ports.OutputOptions{Format: ports.FormatCSV, Columns: []string{"id", "received_at"}})
+	streamPages(t, w, shapeColumns, shapeItems()[:2], 1)
+
+	assert.Equal(t, "ID,received_at\n"+
+		"b,"+shapeItems()[0].Received.Format(time.RFC3339)+"\n"+
+		"a,"+shapeItems()[1].Received.Format(time.RFC3339)+"\n", buf.String())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -32,11 +33,17 @@ func NewTableWriter(w io.Writer, colored bool) *TableWriter {
 // Write outputs a single object
 func (tw *TableWriter) Write(data any) error {
 	// For single objects, output as key: value pairs
+	if data == nil {
+		return nil
+	}
 	v := reflect.ValueOf(data)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 
+	if m, ok := data.(map[string]any); ok {
+		return tw.writeMap(m)
+	}
 	if v.Kind() != reflect.Struct {
 		_, _ = fmt.Fprintf(tw.w, "%v\n", data)
 		return nil
@@ -66,6 +73,23 @@ func (tw *TableWriter) Write(data any) error {
 	return nil
 }
 
+// writeMap outputs a map as key: value pairs, sorted by key
+func (tw *TableWriter) writeMap(m map[string]any) error {
+	keys := make([]string, 0, len(m))
+	maxKeyLen := 0
+	for k := range m {
+		keys = append(keys, k)
+		if len(k) > maxKeyLen {
+			maxKeyLen = len(k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		_, _ = fmt.Fprintf(tw.w, "%-*s  %v\n", maxKeyLen+1, k+":", formatValue(m[k]))
+	}
+	return nil
+}
+
 // WriteList outputs a list of objects as a table
 func (tw *TableWriter) WriteList(data any, columns []ports.Column) error {
 	v := reflect.ValueOf(data)
@@ -84,33 +108,11 @@ func (tw *TableWriter) WriteList(data any, columns []ports.Column) error {
 	tabW := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
 
 	// Write header
-	headers := make([]string, len(columns))
-	for i, col := range columns {
-		headers[i] = strings.ToUpper(col.Header)
-	}
-	_, _ = fmt.Fprintln(tabW, strings.Join(headers, "\t"))
+	_, _ = fmt.Fprintln(tabW, strings.Join(tableHeaders(columns), "\t"))
 
 	// Write rows
 	for i := range v.Len() {
-		row := v.Index(i)
-		if row.Kind() == reflect.Ptr {
-			row = row.Elem()
-		}
-
-		values := make([]string, len(columns))
-		for j, col := range columns {
-			val := getFieldValue(row, col.Field)
-			formatted := formatValue(val)
-			// Width: -1 = no truncation, 0 = auto (maxColumnWidth), >0 = fixed width
-			if col.Width > 0 && utf8.RuneCountInString(formatted) > col.Width {
-				formatted = truncate(formatted, col.Width)
-			} else if col.Width == 0 && utf8.RuneCountInString(formatted) > maxColumnWidth {
-				formatted = truncate(formatted, maxColumnWidth)
-			}
-			// Width == -1: no truncation
-			values[j] = formatted
-		}
-		_, _ = fmt.Fprintln(tabW, strings.Join(values, "\t"))
+		_, _ = fmt.Fprintln(tabW, strings.Join(formatRow(v.Index(i), columns), "\t"))
 	}
 
 	if err := tabW.Flush(); err != nil {
@@ -132,6 +134,104 @@ func (tw *TableWriter) WriteList(data any, columns []ports.Column) error {
 	return nil
 }
 
+// StreamList starts a table whose rows are printed as they arrive. Column
+// widths are set by the first rows; longer values in later rows are truncated.
+func (tw *TableWriter) StreamList(columns []ports.Column) ports.ListStream {
+	return &tableStream{tw: tw, columns: columns}
+}
+
+// tableStream prints table rows as they arrive.
+type tableStream struct {
+	tw      *TableWriter
+	columns []ports.Column
+	widths  []int // Set when the header is printed
+}
+
+// WriteRows prints rows, preceded by the header the first time.
+func (s *tableStream) WriteRows(rows any) error {
+	v, ok := listValue(rows)
+	if !ok || v.Len() == 0 {
+		return nil
+	}
+	if len(s.columns) == 0 {
+		s.columns = structColumns(elemType(v))
+	}
+
+	lines := make([][]string, v.Len())
+	for i := range v.Len() {
+		lines[i] = formatRow(v.Index(i), s.columns)
+	}
+
+	if s.widths == nil {
+		headers := tableHeaders(s.columns)
+		s.widths = make([]int, len(headers))
+		for j, h := range headers {
+			s.widths[j] = utf8.RuneCountInString(h)
+			for _, line := range lines {
+				s.widths[j] = max(s.widths[j], utf8.RuneCountInString(line[j]))
+			}
+		}
+		header := s.pad(headers)
+		if s.tw.colored {
+			header = "\033[1m" + header + "\033[0m"
+		}
+		_, _ = fmt.Fprintln(s.tw.w, header)
+	}
+
+	for _, line := range lines {
+		_, _ = fmt.Fprintln(s.tw.w, s.pad(line))
+	}
+	return nil
+}
+
+// Close does nothing; rows are printed as they arrive.
+func (s *tableStream) Close() error { return nil }
+
+// pad aligns cells to the column widths, like tabwriter does for WriteList.
+// The last column isn't padded or cut to width.
+func (s *tableStream) pad(cells []string) string {
+	var b strings.Builder
+	for j, cell := range cells {
+		if j == len(cells)-1 {
+			b.WriteString(cell)
+			break
+		}
+		cell = truncate(cell, s.widths[j])
+		b.WriteString(cell)
+		b.WriteString(strings.Repeat(" ", s.widths[j]-utf8.RuneCountInString(cell)+2))
+	}
+	return b.String()
+}
+
+// tableHeaders returns the upper-cased column headers.
+func tableHeaders(columns []ports.Column) []string {
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = strings.ToUpper(col.Header)
+	}
+	return headers
+}
+
+// formatRow formats a row's cells, truncated to their column widths.
+func formatRow(row reflect.Value, columns []ports.Column) []string {
+	if row.Kind() == reflect.Ptr {
+		row = row.Elem()
+	}
+	values := make([]string, len(columns))
+	for j, col := range columns {
+		formatted := formatValue(getFieldValue(row, col.Field))
+		// Width: -1 = no truncation, 0 = auto (maxColumnWidth), >0 = fixed width
+		if col.Width > 0 && utf8.RuneCountInString(formatted) > col.Width {
+			formatted = truncate(formatted, col.Width)
+		} else if col.Width == 0 && utf8.RuneCountInString(formatted) > maxColumnWidth {
+			formatted = truncate(formatted, maxColumnWidth)
+		}
+		// Width == -1: no truncation
+		values[j] = formatted
+	}
+	return values
+}
+
 // WriteError outputs an error message
 func (tw *TableWriter) WriteError(err error) error {
 	if tw.colored {
@@ -144,7 +244,7 @@ func (tw *TableWriter) WriteError(err error) error {
 
 // getFieldValue extracts a field value from a struct or map
 func getFieldValue(v reflect.Value, field string) any {
-	if v.Kind() == reflect.Ptr {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
 		v = v.Elem()
 	}
 
@@ -154,13 +254,20 @@ func getFieldValue(v reflect.Value, field string) any {
 		if f.IsValid() {
 			return f.Interface()
 		}
-		// Try case-insensitive match
+		// Try case-insensitive, then JSON tag and separator-insensitive matches
 		t := v.Type()
 		for i := range t.NumField() {
 			if strings.EqualFold(t.Field(i).Name, field) {
 				return v.Field(i).Interface()
 			}
 		}
+		for _, f := range reflect.VisibleFields(t) {
+			if f.PkgPath == "" && !f.Anonymous && matchesField(f, field) {
+				if fv, err := v.FieldByIndexErr(f.Index); err == nil {
+					return fv.Interface()
+				}
+			}
+		}
 	case reflect.Map:
 		key := reflect.ValueOf(field)
 		val := v.MapIndex(key)
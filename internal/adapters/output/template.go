@@ -0,0 +1,101 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// templateFuncs are available in --template output.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join":  func(sep string, v []string) string { return strings.Join(v, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"date": func(layout string, v any) string {
+		switch t := v.(type) {
+		case time.Time:
+			return t.Local().Format(layout)
+		case int64:
+			return time.Unix(t, 0).Local().Format(layout)
+		default:
+			return fmt.Sprintf("%v", v)
+		}
+	},
+}
+
+// TemplateWriter outputs each object through a Go text/template.
+type TemplateWriter struct {
+	w    io.Writer
+	tmpl *template.Template
+	err  error // Parse error, reported on first write
+}
+
+// NewTemplateWriter creates a template writer. Backslash escapes \t and \n
+// in text are expanded, and each row ends with a newline.
+func NewTemplateWriter(w io.Writer, text string) *TemplateWriter {
+	tw := &TemplateWriter{w: w}
+	if strings.TrimSpace(text) == "" {
+		tw.err = fmt.Errorf("template output needs --template, e.g. --template '{{.ID}}\\t{{.Subject}}'")
+		return tw
+	}
+
+	text = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(text)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	tw.tmpl, tw.err = template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if tw.err != nil {
+		tw.err = fmt.Errorf("invalid template: %w", tw.err)
+	}
+	return tw
+}
+
+// Write executes the template for an object, or for each item of a list.
+func (tw *TemplateWriter) Write(data any) error {
+	v, ok := listValue(data)
+	if !ok {
+		return tw.WriteRow(data)
+	}
+	for i := range v.Len() {
+		if err := tw.WriteRow(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteList executes the template for each item (columns are ignored).
+func (tw *TemplateWriter) WriteList(data any, _ []ports.Column) error {
+	return tw.Write(data)
+}
+
+// StreamList starts a list whose rows are templated as they arrive.
+func (tw *TemplateWriter) StreamList(_ []ports.Column) ports.ListStream {
+	return rowStream{writeRow: tw.WriteRow}
+}
+
+// WriteRow executes the template for a single item.
+func (tw *TemplateWriter) WriteRow(row any) error {
+	if tw.err != nil {
+		return tw.err
+	}
+	if err := tw.tmpl.Execute(tw.w, row); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// WriteError outputs an error message.
+func (tw *TemplateWriter) WriteError(err error) error {
+	_, _ = fmt.Fprintf(tw.w, "Error: %s\n", err.Error())
+	return nil
+}
@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateWriter_Write(t *testing.T) {
+	type item struct {
+		ID      string
+		Subject string
+		Tags    []string
+		Date    time.Time
+	}
+
+	var buf bytes.Buffer
+	w := NewTemplateWriter(&buf, `{{.ID}}\t{{upper .Subject}} [{{join "," .Tags}}] {{date "2006-01-02" .Date}}`)
+
+	data := []item{
+		{ID: "1", Subject: "hello", Tags: []string{"a", "b"}, Date: time.Date(2026, 5, 1, 12, 0, 0, 0, time.Local)},
+		{ID: "2", Subject: "bye"},
+	}
+	require.NoError(t, w.Write(data))
+	assert.Equal(t, "1\tHELLO [a,b] 2026-05-01\n2\tBYE [] 0001-01-01\n", buf.String())
+}
+
+func TestTemplateWriter_Errors(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := NewTemplateWriter(&buf, "").Write(map[string]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--template")
+
+	err = NewTemplateWriter(&buf, "{{.ID").Write(map[string]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid template")
+}
@@ -7,8 +7,30 @@ import (
 	"github.com/mqasimca/nylas/internal/ports"
 )
 
-// NewWriter creates an output writer for the specified format.
+// NewWriter creates an output writer for the specified format. A query, then
+// column selection and sorting from opts, wrap the format's writer.
 func NewWriter(w io.Writer, opts ports.OutputOptions) ports.OutputWriter {
+	shaped := newShapedWriter(w, opts)
+	if opts.Query != "" {
+		return newQueryWriter(shaped, opts.Query)
+	}
+	return shaped
+}
+
+func newShapedWriter(w io.Writer, opts ports.OutputOptions) ports.OutputWriter {
+	inner := newFormatWriter(w, opts)
+	if len(opts.Columns) == 0 && opts.Sort == "" {
+		return inner
+	}
+
+	shaped := &shapedWriter{inner: inner, format: opts.Format, columns: opts.Columns, sort: opts.Sort}
+	if streamer, ok := inner.(ports.ListStreamer); ok && opts.Sort == "" {
+		return &streamingShapedWriter{shapedWriter: shaped, streamer: streamer}
+	}
+	return shaped
+}
+
+func newFormatWriter(w io.Writer, opts ports.OutputOptions) ports.OutputWriter {
 	switch opts.Format {
 	case ports.FormatJSON:
 		return NewJSONWriter(w)
@@ -16,6 +38,12 @@ func NewWriter(w io.Writer, opts ports.OutputOptions) ports.OutputWriter {
 		return NewYAMLWriter(w)
 	case ports.FormatQuiet:
 		return NewQuietWriter(w)
+	case ports.FormatCSV:
+		return NewCSVWriter(w)
+	case ports.FormatNDJSON:
+		return NewNDJSONWriter(w)
+	case ports.FormatTemplate:
+		return NewTemplateWriter(w, opts.Template)
 	default:
 		return NewTableWriter(w, !opts.NoColor)
 	}
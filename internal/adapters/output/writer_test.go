@@ -20,6 +20,9 @@ func TestNewWriter(t *testing.T) {
 		{"json format", ports.FormatJSON, "*output.JSONWriter"},
 		{"yaml format", ports.FormatYAML, "*output.YAMLWriter"},
 		{"quiet format", ports.FormatQuiet, "*output.QuietWriter"},
+		{"csv format", ports.FormatCSV, "*output.CSVWriter"},
+		{"ndjson format", ports.FormatNDJSON, "*output.NDJSONWriter"},
+		{"template format", ports.FormatTemplate, "*output.TemplateWriter"},
 		{"empty format defaults to table", "", "*output.TableWriter"},
 	}
 
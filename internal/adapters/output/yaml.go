@@ -28,6 +28,11 @@ func (yw *YAMLWriter) WriteList(data any, _ []ports.Column) error {
 	return yw.encode(data)
 }
 
+// StreamList starts a YAML sequence written item by item (columns are ignored).
+func (yw *YAMLWriter) StreamList(_ []ports.Column) ports.ListStream {
+	return &yamlStream{yw: yw}
+}
+
 // WriteError outputs an error as YAML.
 func (yw *YAMLWriter) WriteError(err error) error {
 	errObj := map[string]string{
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
 	"github.com/mqasimca/nylas/internal/ports"
 )
 
@@ -76,7 +77,7 @@ func NewClient(config *ClientConfig) (*Client, error) {
 		config.UserCacheTTL = DefaultConfig().UserCacheTTL
 	}
 
-	options := []slack.Option{}
+	options := []slack.Option{slack.OptionHTTPClient(httputil.NewClient(0))}
 	if config.Debug {
 		options = append(options, slack.OptionDebug(true))
 	}
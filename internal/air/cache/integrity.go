@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ftsTables are the full-text indexes that triggers keep in sync with their
+// content tables.
+var ftsTables = []string{"emails_fts", "events_fts", "contacts_fts"}
+
+// CheckIntegrity runs SQLite's integrity check on a cache database and
+// verifies that each full-text index matches its content table.
+func CheckIntegrity(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("integrity check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("database is corrupt: %s", strings.Join(problems, "; "))
+	}
+
+	// A rank of 1 also compares the index with the external content table.
+	for _, table := range ftsTables {
+		query := fmt.Sprintf("INSERT INTO %s(%s, rank) VALUES('integrity-check', 1)", table, table)
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("%s does not match its table: %w", table, err)
+		}
+	}
+
+	return nil
+}
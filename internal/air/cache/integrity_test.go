@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	db := setupTestDB(t)
+
+	if _, err := db.Exec(`INSERT INTO emails (id, subject, body_text) VALUES ('m1', 'Quarterly report', 'Numbers attached')`); err != nil {
+		t.Fatalf("insert email: %v", err)
+	}
+	if err := CheckIntegrity(db); err != nil {
+		t.Fatalf("CheckIntegrity() on a healthy cache: %v", err)
+	}
+
+	// Without the trigger the next row never reaches the index.
+	if _, err := db.Exec(`DROP TRIGGER emails_ai`); err != nil {
+		t.Fatalf("drop trigger: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO emails (id, subject, body_text) VALUES ('m2', 'Unindexed', 'Missing from search')`); err != nil {
+		t.Fatalf("insert email: %v", err)
+	}
+	err := CheckIntegrity(db)
+	if err == nil || !strings.Contains(err.Error(), "emails_fts") {
+		t.Errorf("CheckIntegrity() = %v, want an emails_fts error", err)
+	}
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// CheckHealth checks every stored grant against the API. A grant is flagged
+// when Nylas reports it invalid or expired, when it no longer exists, or when
+// it has lost scopes it had when it was last seen healthy.
+func (s *GrantService) CheckHealth(ctx context.Context) ([]domain.GrantHealth, error) {
+	localGrants, err := s.grantStore.ListGrants()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultGrant, _ := s.grantStore.GetDefaultGrant()
+
+	result := make([]domain.GrantHealth, 0, len(localGrants))
+	for _, g := range localGrants {
+		health := s.checkGrant(ctx, g)
+		health.IsDefault = g.ID == defaultGrant
+		result = append(result, health)
+	}
+	return result, nil
+}
+
+// checkGrant checks one stored grant, recording its scopes as the baseline
+// while it is healthy.
+func (s *GrantService) checkGrant(ctx context.Context, info domain.GrantInfo) domain.GrantHealth {
+	health := domain.GrantHealth{
+		ID:       info.ID,
+		Email:    info.Email,
+		Provider: info.Provider,
+	}
+
+	grant, err := s.client.GetGrant(ctx, info.ID)
+	switch {
+	case errors.Is(err, domain.ErrGrantNotFound):
+		health.Health = domain.GrantRevoked
+		return health
+	case err != nil:
+		health.Health = domain.GrantCheckError
+		health.Error = err.Error()
+		return health
+	}
+
+	health.GrantStatus = grant.GrantStatus
+	if grant.Provider != "" {
+		health.Provider = grant.Provider
+	}
+
+	switch {
+	case grant.GrantStatus == domain.GrantExpired:
+		health.Health = domain.GrantExpired
+		return health
+	case !grant.IsValid():
+		health.Health = domain.GrantInvalid
+		return health
+	}
+
+	health.MissingScopes = missingScopes(info.Scopes, grant.Scope)
+	if len(health.MissingScopes) > 0 {
+		health.Health = domain.GrantScopeDrift
+		return health
+	}
+	health.Health = domain.GrantHealthy
+
+	// Widen the baseline when the grant gains scopes; narrowing it would
+	// hide the drift.
+	if (len(grant.Scope) > 0 && !slices.Equal(info.Scopes, grant.Scope)) || info.Provider != health.Provider {
+		info.Scopes = grant.Scope
+		info.Provider = health.Provider
+		_ = s.grantStore.SaveGrant(info)
+	}
+	return health
+}
+
+// missingScopes returns the baseline scopes that current lacks.
+func missingScopes(baseline, current []string) []string {
+	var missing []string
+	for _, scope := range baseline {
+		if !slices.Contains(current, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantService_CheckHealth(t *testing.T) {
+	grantStore := newMockGrantStore()
+	grantStore.grants["ok"] = domain.GrantInfo{ID: "ok", Email: "ok@example.com", Provider: domain.ProviderGoogle}
+	grantStore.grants["invalid"] = domain.GrantInfo{ID: "invalid", Email: "invalid@example.com"}
+	grantStore.grants["expired"] = domain.GrantInfo{ID: "expired", Email: "expired@example.com"}
+	grantStore.grants["gone"] = domain.GrantInfo{ID: "gone", Email: "gone@example.com"}
+	grantStore.grants["drift"] = domain.GrantInfo{ID: "drift", Email: "drift@example.com", Scopes: []string{"email", "calendar"}}
+	grantStore.grants["down"] = domain.GrantInfo{ID: "down", Email: "down@example.com"}
+	grantStore.defaultGrant = "ok"
+
+	client := nylas.NewMockClient()
+	client.GetGrantFunc = func(ctx context.Context, grantID string) (*domain.Grant, error) {
+		switch grantID {
+		case "ok":
+			return &domain.Grant{ID: grantID, GrantStatus: "valid", Provider: domain.ProviderGoogle, Scope: []string{"email", "contacts"}}, nil
+		case "invalid":
+			return &domain.Grant{ID: grantID, GrantStatus: "invalid"}, nil
+		case "expired":
+			return &domain.Grant{ID: grantID, GrantStatus: "expired"}, nil
+		case "gone":
+			return nil, domain.ErrGrantNotFound
+		case "drift":
+			return &domain.Grant{ID: grantID, GrantStatus: "valid", Scope: []string{"email"}}, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	svc := NewGrantService(client, grantStore, newMockConfigStore())
+	report, err := svc.CheckHealth(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report, 6)
+
+	byID := map[string]domain.GrantHealth{}
+	for _, h := range report {
+		byID[h.ID] = h
+	}
+
+	assert.Equal(t, domain.GrantHealthy, byID["ok"].Health)
+	assert.True(t, byID["ok"].IsDefault)
+	assert.Equal(t, domain.GrantInvalid, byID["invalid"].Health)
+	assert.Equal(t, domain.GrantExpired, byID["expired"].Health)
+	assert.Equal(t, domain.GrantRevoked, byID["gone"].Health)
+	assert.Equal(t, domain.GrantScopeDrift, byID["drift"].Health)
+	assert.Equal(t, []string{"calendar"}, byID["drift"].MissingScopes)
+	assert.Equal(t, domain.GrantCheckError, byID["down"].Health)
+	assert.Contains(t, byID["down"].Error, "connection refused")
+	assert.False(t, byID["down"].NeedsReauth())
+	assert.True(t, byID["drift"].NeedsReauth())
+
+	// Healthy grants record their scopes as the baseline; drifted ones keep
+	// the old baseline so the drift stays visible.
+	assert.Equal(t, []string{"email", "contacts"}, grantStore.grants["ok"].Scopes)
+	assert.Equal(t, []string{"email", "calendar"}, grantStore.grants["drift"].Scopes)
+}
+
+func TestService_Reauthenticate(t *testing.T) {
+	newService := func(grant *domain.Grant) (*Service, *mockGrantStore, *mockConfigStore) {
+		client := nylas.NewMockClient()
+		client.ExchangeCodeFunc = func(ctx context.Context, code, redirectURI string) (*domain.Grant, error) {
+			return grant, nil
+		}
+
+		grantStore := newMockGrantStore()
+		grantStore.grants["old"] = domain.GrantInfo{ID: "old", Email: "user@example.com", Provider: domain.ProviderMicrosoft}
+		grantStore.grants["other"] = domain.GrantInfo{ID: "other", Email: "other@example.com"}
+		grantStore.defaultGrant = "old"
+
+		configStore := newMockConfigStore()
+		configStore.config.Grants = []domain.GrantInfo{grantStore.grants["old"], grantStore.grants["other"]}
+		configStore.config.DefaultGrant = "old"
+
+		server := &mockOAuthServer{redirectURI: "http://localhost:8080/callback", code: "code"}
+		return NewService(client, grantStore, configStore, server, &mockBrowser{}), grantStore, configStore
+	}
+
+	t.Run("new grant ID replaces the old grant", func(t *testing.T) {
+		svc, grantStore, configStore := newService(&domain.Grant{
+			ID: "new", Email: "User@example.com", Provider: domain.ProviderMicrosoft, Scope: []string{"Mail.Read"},
+		})
+
+		grant, err := svc.Reauthenticate(context.Background(), "old")
+		require.NoError(t, err)
+		assert.Equal(t, "new", grant.ID)
+
+		_, err = grantStore.GetGrant("old")
+		assert.ErrorIs(t, err, domain.ErrGrantNotFound)
+		assert.Equal(t, []string{"Mail.Read"}, grantStore.grants["new"].Scopes)
+		assert.Equal(t, "new", grantStore.defaultGrant)
+
+		assert.Equal(t, "new", configStore.config.DefaultGrant)
+		require.Len(t, configStore.config.Grants, 2)
+		assert.Equal(t, "new", configStore.config.Grants[0].ID)
+	})
+
+	t.Run("same grant ID is updated in place", func(t *testing.T) {
+		svc, grantStore, configStore := newService(&domain.Grant{
+			ID: "old", Email: "user@example.com", Scope: []string{"Mail.Read"},
+		})
+
+		_, err := svc.Reauthenticate(context.Background(), "old")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProviderMicrosoft, grantStore.grants["old"].Provider)
+		assert.Equal(t, []string{"Mail.Read"}, grantStore.grants["old"].Scopes)
+		assert.Len(t, configStore.config.Grants, 2)
+	})
+
+	t.Run("signing in to another account is rejected", func(t *testing.T) {
+		svc, grantStore, _ := newService(&domain.Grant{ID: "new", Email: "someone@example.com"})
+
+		_, err := svc.Reauthenticate(context.Background(), "old")
+		require.ErrorIs(t, err, domain.ErrReauthMismatch)
+		_, err = grantStore.GetGrant("old")
+		assert.NoError(t, err)
+		_, err = grantStore.GetGrant("new")
+		assert.ErrorIs(t, err, domain.ErrGrantNotFound)
+	})
+
+	t.Run("unknown grant", func(t *testing.T) {
+		svc, _, _ := newService(&domain.Grant{ID: "new"})
+
+		_, err := svc.Reauthenticate(context.Background(), "missing")
+		assert.ErrorIs(t, err, domain.ErrGrantNotFound)
+	})
+}
@@ -3,6 +3,8 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/mqasimca/nylas/internal/ports"
@@ -36,6 +38,43 @@ func NewService(
 
 // Login performs OAuth login with the specified provider.
 func (s *Service) Login(ctx context.Context, provider domain.Provider) (*domain.Grant, error) {
+	grant, err := s.authorize(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save grant info
+	grantInfo := domain.GrantInfo{
+		ID:       grant.ID,
+		Email:    grant.Email,
+		Provider: grant.Provider,
+		Scopes:   grant.Scope,
+	}
+	if err := s.grantStore.SaveGrant(grantInfo); err != nil {
+		return nil, err
+	}
+
+	// Set as default if no default exists or this is the first grant
+	isFirstGrant := false
+	if _, err := s.grantStore.GetDefaultGrant(); err == domain.ErrNoDefaultGrant {
+		_ = s.grantStore.SetDefaultGrant(grant.ID)
+		isFirstGrant = true
+	}
+
+	// Update config with grant (only update default if this is the first grant)
+	cfg, _ := s.config.Load()
+	cfg.Grants = append(cfg.Grants, grantInfo)
+	if isFirstGrant {
+		cfg.DefaultGrant = grant.ID
+	}
+	_ = s.config.Save(cfg)
+
+	return grant, nil
+}
+
+// authorize runs the OAuth flow: it starts the callback server, opens the
+// provider's consent page and exchanges the returned code for a grant.
+func (s *Service) authorize(ctx context.Context, provider domain.Provider) (*domain.Grant, error) {
 	// Start callback server
 	if err := s.server.Start(); err != nil {
 		return nil, err
@@ -55,32 +94,62 @@ func (s *Service) Login(ctx context.Context, provider domain.Provider) (*domain.
 	}
 
 	// Exchange code for tokens
-	grant, err := s.client.ExchangeCode(ctx, code, s.server.GetRedirectURI())
+	return s.client.ExchangeCode(ctx, code, s.server.GetRedirectURI())
+}
+
+// Reauthenticate re-runs the OAuth flow for a stored grant. The account must
+// sign in as the same email address. If Nylas issues a new grant ID, it
+// replaces the old one, keeping its place as the default.
+func (s *Service) Reauthenticate(ctx context.Context, grantID string) (*domain.Grant, error) {
+	info, err := s.grantStore.GetGrant(grantID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Save grant info
+	grant, err := s.authorize(ctx, info.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(grant.Email, info.Email) {
+		return nil, fmt.Errorf("%w: signed in as %s instead of %s", domain.ErrReauthMismatch, grant.Email, info.Email)
+	}
+
 	grantInfo := domain.GrantInfo{
 		ID:       grant.ID,
 		Email:    grant.Email,
 		Provider: grant.Provider,
+		Scopes:   grant.Scope,
+	}
+	if grantInfo.Provider == "" {
+		grantInfo.Provider = info.Provider
 	}
 	if err := s.grantStore.SaveGrant(grantInfo); err != nil {
 		return nil, err
 	}
 
-	// Set as default if no default exists or this is the first grant
-	isFirstGrant := false
-	if _, err := s.grantStore.GetDefaultGrant(); err == domain.ErrNoDefaultGrant {
-		_ = s.grantStore.SetDefaultGrant(grant.ID)
-		isFirstGrant = true
+	defaultID, _ := s.grantStore.GetDefaultGrant()
+	if grant.ID != grantID {
+		if err := s.grantStore.DeleteGrant(grantID); err != nil {
+			return nil, err
+		}
+		if defaultID == grantID {
+			_ = s.grantStore.SetDefaultGrant(grant.ID)
+		}
 	}
 
-	// Update config with grant (only update default if this is the first grant)
+	// Replace the grant in config, keeping its position
 	cfg, _ := s.config.Load()
-	cfg.Grants = append(cfg.Grants, grantInfo)
-	if isFirstGrant {
+	replaced := false
+	for i, g := range cfg.Grants {
+		if g.ID == grantID {
+			cfg.Grants[i] = grantInfo
+			replaced = true
+		}
+	}
+	if !replaced {
+		cfg.Grants = append(cfg.Grants, grantInfo)
+	}
+	if cfg.DefaultGrant == grantID {
 		cfg.DefaultGrant = grant.ID
 	}
 	_ = s.config.Save(cfg)
@@ -450,7 +450,7 @@ func TestNewGrantsCmd(t *testing.T) {
 	})
 
 	t.Run("has_subcommands", func(t *testing.T) {
-		expectedCmds := []string{"list", "stats"}
+		expectedCmds := []string{"list", "stats", "revoke", "reauth", "export", "diff"}
 
 		cmdMap := make(map[string]bool)
 		for _, sub := range cmd.Commands() {
@@ -477,6 +477,8 @@ func TestGrantListCmd(t *testing.T) {
 	t.Run("has_filter_flags", func(t *testing.T) {
 		assert.NotNil(t, cmd.Flags().Lookup("connector-id"))
 		assert.NotNil(t, cmd.Flags().Lookup("status"))
+		assert.NotNil(t, cmd.Flags().Lookup("provider"))
+		assert.NotNil(t, cmd.Flags().Lookup("stale"))
 		assert.NotNil(t, cmd.Flags().Lookup("limit"))
 		assert.NotNil(t, cmd.Flags().Lookup("offset"))
 	})
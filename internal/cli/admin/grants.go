@@ -4,9 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/mqasimca/nylas/internal/cli/common"
-	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/mqasimca/nylas/internal/ports"
 	"github.com/spf13/cobra"
 )
@@ -16,22 +16,29 @@ func newGrantsCmd() *cobra.Command {
 		Use:     "grants",
 		Aliases: []string{"grant"},
 		Short:   "Manage grants",
-		Long:    "View and manage grants across all applications.",
+		Long: `View and manage grants across all applications.
+
+list, revoke, reauth and export page through every grant and share filters:
+--provider, --status, --connector-id and --stale (no sync within a duration).
+export writes a snapshot that diff compares against a later one.`,
 	}
 
 	cmd.AddCommand(newGrantListCmd())
 	cmd.AddCommand(newGrantStatsCmd())
+	cmd.AddCommand(newGrantRevokeCmd())
+	cmd.AddCommand(newGrantReauthCmd())
+	cmd.AddCommand(newGrantExportCmd())
+	cmd.AddCommand(newGrantDiffCmd())
 
 	return cmd
 }
 
 func newGrantListCmd() *cobra.Command {
 	var (
-		limit       int
-		offset      int
-		connectorID string
-		status      string
-		jsonOutput  bool
+		limit      int
+		offset     int
+		filter     grantFilter
+		jsonOutput bool
 	)
 
 	cmd := &cobra.Command{
@@ -39,20 +46,26 @@ func newGrantListCmd() *cobra.Command {
 		Aliases: []string{"ls"},
 		Short:   "List grants",
 		Long:    "List all grants with optional filters.",
+		Example: `  # Invalid Microsoft grants
+  nylas admin grants list --provider microsoft --status invalid
+
+  # Grants that haven't synced for three days
+  nylas admin grants list --stale 72h --limit 0`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 || offset < 0 {
+				return common.NewUserError("--limit and --offset can't be negative", "use 0 or more")
+			}
 			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
-				params := &domain.GrantsQueryParams{
-					Limit:       limit,
-					Offset:      offset,
-					ConnectorID: connectorID,
-					Status:      status,
-				}
-
-				grants, err := client.ListAllGrants(ctx, params)
+				grants, err := listGrants(ctx, client, filter)
 				if err != nil {
 					return struct{}{}, common.WrapListError("grants", err)
 				}
 
+				grants = grants[min(offset, len(grants)):]
+				if limit > 0 && len(grants) > limit {
+					grants = grants[:limit]
+				}
+
 				if jsonOutput {
 					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(grants)
 				}
@@ -64,24 +77,15 @@ func newGrantListCmd() *cobra.Command {
 
 				fmt.Printf("Found %d grant(s):\n\n", len(grants))
 
-				table := common.NewTable("EMAIL", "ID", "PROVIDER", "STATUS")
+				now := time.Now()
+				table := common.NewTable("EMAIL", "ID", "PROVIDER", "STATUS", "LAST SYNC")
 				for _, grant := range grants {
 					email := grant.Email
 					if email == "" {
 						email = "-"
 					}
 
-					status := grant.GrantStatus
-					switch grant.GrantStatus {
-					case "valid":
-						status = common.Green.Sprint(status)
-					case "invalid":
-						status = common.Red.Sprint(status)
-					default:
-						status = common.Yellow.Sprint(status)
-					}
-
-					table.AddRow(common.Cyan.Sprint(email), grant.ID, string(grant.Provider), status)
+					table.AddRow(common.Cyan.Sprint(email), grant.ID, string(grant.Provider), colorGrantStatus(grant.GrantStatus), grantAge(grant, now))
 				}
 				table.Render()
 
@@ -91,15 +95,26 @@ func newGrantListCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of grants to return")
-	cmd.Flags().IntVar(&offset, "offset", 0, "Offset for pagination")
-	cmd.Flags().StringVar(&connectorID, "connector-id", "", "Filter by connector ID")
-	cmd.Flags().StringVar(&status, "status", "", "Filter by status (valid, invalid)")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of grants to show (0 for all)")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Number of matching grants to skip")
+	filter.addFlags(cmd)
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	return cmd
 }
 
+// colorGrantStatus colors a grant status for a table.
+func colorGrantStatus(status string) string {
+	switch status {
+	case "valid":
+		return common.Green.Sprint(status)
+	case "invalid":
+		return common.Red.Sprint(status)
+	default:
+		return common.Yellow.Sprint(status)
+	}
+}
+
 func newGrantStatsCmd() *cobra.Command {
 	var jsonOutput bool
 
@@ -0,0 +1,205 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+func newGrantRevokeCmd() *cobra.Command {
+	var (
+		filter grantFilter
+		dryRun bool
+		yes    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revoke [grant-id...]",
+		Short: "Revoke grants in bulk",
+		Long: `Permanently revoke the named grants, or every grant matching the filters.
+
+At least one grant ID or filter is required. Use --dry-run to see which
+grants would be revoked.`,
+		Example: `  # Revoke every invalid grant that hasn't synced for 30 days
+  nylas admin grants revoke --status invalid --stale 720h
+
+  # Revoke two grants without asking
+  nylas admin grants revoke grant-1 grant-2 --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutBulkOperation)
+			defer cancel()
+
+			grants, err := selectGrants(ctx, client, filter, args)
+			if err != nil {
+				return common.WrapError(err)
+			}
+			if len(grants) == 0 {
+				common.PrintEmptyState("matching grants")
+				return nil
+			}
+
+			printGrantSelection(grants)
+			if dryRun {
+				common.PrintInfo("Dry run: %d grant(s) would be revoked", len(grants))
+				return nil
+			}
+			if !yes && !common.Confirm(fmt.Sprintf("Permanently revoke %d grant(s)?", len(grants)), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+
+			failed := revokeGrants(ctx, client, grants)
+			if failed > 0 {
+				return fmt.Errorf("%d of %d grant(s) could not be revoked", failed, len(grants))
+			}
+			common.PrintSuccess("Revoked %d grant(s)", len(grants))
+			return nil
+		},
+	}
+
+	filter.addFlags(cmd)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the grants that would be revoked")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+// revokeGrants revokes each grant, reporting failures as it goes, and
+// returns how many failed.
+func revokeGrants(ctx context.Context, client ports.NylasClient, grants []domain.Grant) int {
+	failed := 0
+	for _, g := range grants {
+		err := client.RevokeGrant(ctx, g.ID)
+		if errors.Is(err, domain.ErrGrantNotFound) {
+			err = nil // already gone
+		}
+		if err != nil {
+			common.PrintError("%s (%s): %v", g.ID, g.Email, err)
+			failed++
+			continue
+		}
+		_, _ = common.Green.Printf("✓ %s (%s)\n", g.ID, g.Email)
+	}
+	return failed
+}
+
+// grantReauthLink is a hosted authentication link for one grant.
+type grantReauthLink struct {
+	GrantID  string `json:"grant_id"`
+	Email    string `json:"email"`
+	Provider string `json:"provider"`
+	Status   string `json:"status"`
+	URL      string `json:"url"`
+}
+
+func newGrantReauthCmd() *cobra.Command {
+	var (
+		filter      grantFilter
+		redirectURI string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reauth [grant-id...]",
+		Short: "Create re-authentication links for grants in bulk",
+		Long: `Create a hosted authentication link for each named grant, or every grant
+matching the filters, to send to its owner.
+
+Each link opens the provider's consent page for the grant's account. When
+the owner signs in, Nylas re-authenticates the existing grant and redirects
+to --redirect-uri, which must be registered with your application.`,
+		Example: `  # Links for every invalid grant, as CSV for a mail merge
+  nylas admin grants reauth --status invalid \
+    --redirect-uri https://example.com/oauth/callback --format csv > reauth.csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutBulkOperation)
+			defer cancel()
+
+			grants, err := selectGrants(ctx, client, filter, args)
+			if err != nil {
+				return common.WrapError(err)
+			}
+
+			links := make([]grantReauthLink, 0, len(grants))
+			for _, g := range grants {
+				authURL, err := reauthURL(client, g, redirectURI)
+				if err != nil {
+					return err
+				}
+				links = append(links, grantReauthLink{
+					GrantID:  g.ID,
+					Email:    g.Email,
+					Provider: string(g.Provider),
+					Status:   g.GrantStatus,
+					URL:      authURL,
+				})
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(links)
+			}
+
+			if len(links) == 0 {
+				common.PrintEmptyState("matching grants")
+				return nil
+			}
+			for _, l := range links {
+				_, _ = common.Cyan.Printf("%s", l.Email)
+				_, _ = common.Dim.Printf(" (%s, %s)\n", l.Provider, l.Status)
+				fmt.Printf("  %s\n\n", l.URL)
+			}
+			return nil
+		},
+	}
+
+	filter.addFlags(cmd)
+	cmd.Flags().StringVar(&redirectURI, "redirect-uri", "", "Redirect URI registered with your application (required)")
+	_ = cmd.MarkFlagRequired("redirect-uri")
+
+	return cmd
+}
+
+// reauthURL builds a hosted authentication URL for a grant, with the
+// grant's email as the login hint.
+func reauthURL(client ports.NylasClient, g domain.Grant, redirectURI string) (string, error) {
+	u, err := url.Parse(client.BuildAuthURL(g.Provider, redirectURI))
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	if query.Get("client_id") == "" {
+		return "", common.NewUserError("client ID not configured", "run 'nylas auth config' or set NYLAS_CLIENT_ID")
+	}
+	if g.Email != "" {
+		query.Set("login_hint", g.Email)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// printGrantSelection lists the grants a bulk operation applies to.
+func printGrantSelection(grants []domain.Grant) {
+	table := common.NewTable("EMAIL", "ID", "PROVIDER", "STATUS")
+	for _, g := range grants {
+		table.AddRow(common.Cyan.Sprint(g.Email), g.ID, string(g.Provider), colorGrantStatus(g.GrantStatus))
+	}
+	table.Render()
+	fmt.Println()
+}
@@ -0,0 +1,312 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/adapters/output"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// grantInventoryRow is a grant flattened into a CSV inventory row.
+type grantInventoryRow struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	Provider    string `json:"provider"`
+	GrantStatus string `json:"grant_status"`
+	Scopes      string `json:"scopes"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func newGrantExportCmd() *cobra.Command {
+	var (
+		filter     grantFilter
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a grant inventory",
+		Long: `Export every grant, or the ones matching the filters, as JSON, or in the
+format chosen with --format (csv, yaml, ndjson).
+
+Exports are snapshots: compare two of them with 'nylas admin grants diff'.`,
+		Example: `  # Snapshot all grants
+  nylas admin grants export --output grants-$(date +%F).json
+
+  # Google grants as CSV
+  nylas admin grants export --provider google --format csv --output google.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutBulkOperation)
+			defer cancel()
+
+			grants, err := listGrants(ctx, client, filter)
+			if err != nil {
+				return common.WrapListError("grants", err)
+			}
+			for i := range grants {
+				grants[i].AccessToken, grants[i].RefreshToken = "", ""
+			}
+
+			if outputFile == "" {
+				return writeGrantInventory(cmd, cmd.OutOrStdout(), grants)
+			}
+			var buf bytes.Buffer
+			if err := writeGrantInventory(cmd, &buf, grants); err != nil {
+				return err
+			}
+			// Grant inventories list users' addresses, so keep them private.
+			if err := os.WriteFile(outputFile, buf.Bytes(), 0600); err != nil {
+				return common.WrapWriteError("grant inventory", err)
+			}
+			common.PrintSuccess("Exported %d grant(s) to %s", len(grants), outputFile)
+			return nil
+		},
+	}
+
+	filter.addFlags(cmd)
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write to this file instead of stdout")
+
+	return cmd
+}
+
+// writeGrantInventory writes grants in the command's output format, JSON
+// unless another is chosen. CSV rows are flattened so 'grants diff' can
+// read them back.
+func writeGrantInventory(cmd *cobra.Command, w io.Writer, grants []domain.Grant) error {
+	opts := common.GetOutputOptions(cmd, w)
+	if opts.Format == ports.FormatTable {
+		opts.Format = ports.FormatJSON
+	}
+	out := output.NewWriter(w, opts)
+	if opts.Format != ports.FormatCSV {
+		return out.Write(grants)
+	}
+
+	rows := make([]grantInventoryRow, 0, len(grants))
+	for _, g := range grants {
+		rows = append(rows, grantInventoryRow{
+			ID:          g.ID,
+			Email:       g.Email,
+			Provider:    string(g.Provider),
+			GrantStatus: g.GrantStatus,
+			Scopes:      strings.Join(g.Scope, " "),
+			CreatedAt:   formatGrantTime(g.CreatedAt),
+			UpdatedAt:   formatGrantTime(g.UpdatedAt),
+		})
+	}
+	return out.Write(rows)
+}
+
+func formatGrantTime(t domain.UnixTime) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// readGrantSnapshot reads grants from a JSON or CSV export, or from the
+// output of 'nylas admin grants list --json'.
+func readGrantSnapshot(path string) ([]domain.Grant, error) {
+	//nolint:gosec // G304: the user names the snapshot files to compare
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("[")) {
+		var grants []domain.Grant
+		if err := json.Unmarshal(trimmed, &grants); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return grants, nil
+	}
+
+	grants, err := readGrantsCSV(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return grants, nil
+}
+
+// readGrantsCSV reads a CSV inventory. Columns are found by name, so extra
+// or reordered columns are fine.
+func readGrantsCSV(r io.Reader) ([]domain.Grant, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	col := map[string]int{}
+	for i, name := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := col["id"]; !ok {
+		return nil, fmt.Errorf("not a grant inventory: no id column")
+	}
+	get := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	grants := make([]domain.Grant, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		g := domain.Grant{
+			ID:          get(row, "id"),
+			Email:       get(row, "email"),
+			Provider:    domain.Provider(get(row, "provider")),
+			GrantStatus: get(row, "grant_status"),
+			Scope:       strings.Fields(get(row, "scopes")),
+		}
+		if t, err := time.Parse(time.RFC3339, get(row, "created_at")); err == nil {
+			g.CreatedAt = domain.UnixTime{Time: t}
+		}
+		if t, err := time.Parse(time.RFC3339, get(row, "updated_at")); err == nil {
+			g.UpdatedAt = domain.UnixTime{Time: t}
+		}
+		grants = append(grants, g)
+	}
+	return grants, nil
+}
+
+// grantStatusChange is a grant whose status differs between two snapshots.
+type grantStatusChange struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Provider string `json:"provider"`
+	Old      string `json:"old_status"`
+	New      string `json:"new_status"`
+}
+
+// grantDiff is the difference between two grant snapshots.
+type grantDiff struct {
+	Added         []domain.Grant      `json:"added"`
+	Removed       []domain.Grant      `json:"removed"`
+	StatusChanged []grantStatusChange `json:"status_changed"`
+}
+
+// diffGrants compares two snapshots, keeping each list in the order of the
+// snapshot it comes from.
+func diffGrants(before, after []domain.Grant) grantDiff {
+	diff := grantDiff{
+		Added:         []domain.Grant{},
+		Removed:       []domain.Grant{},
+		StatusChanged: []grantStatusChange{},
+	}
+
+	old := make(map[string]domain.Grant, len(before))
+	for _, g := range before {
+		old[g.ID] = g
+	}
+	seen := make(map[string]bool, len(after))
+
+	for _, g := range after {
+		seen[g.ID] = true
+		prev, ok := old[g.ID]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, g)
+		case prev.GrantStatus != g.GrantStatus:
+			diff.StatusChanged = append(diff.StatusChanged, grantStatusChange{
+				ID:       g.ID,
+				Email:    g.Email,
+				Provider: string(g.Provider),
+				Old:      prev.GrantStatus,
+				New:      g.GrantStatus,
+			})
+		}
+	}
+	for _, g := range before {
+		if !seen[g.ID] {
+			diff.Removed = append(diff.Removed, g)
+		}
+	}
+	return diff
+}
+
+func newGrantDiffCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <old-snapshot> <new-snapshot>",
+		Short: "Compare two grant snapshots",
+		Long: `Compare two grant inventories from 'nylas admin grants export' (CSV or JSON)
+and show new, removed and status-changed grants.`,
+		Example: `  nylas admin grants diff grants-2026-10-01.json grants-2026-10-18.json`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			before, err := readGrantSnapshot(args[0])
+			if err != nil {
+				return common.WrapError(err)
+			}
+			after, err := readGrantSnapshot(args[1])
+			if err != nil {
+				return common.WrapError(err)
+			}
+
+			diff := diffGrants(before, after)
+
+			if jsonOutput {
+				return json.NewEncoder(cmd.OutOrStdout()).Encode(diff)
+			}
+
+			if len(diff.Added)+len(diff.Removed)+len(diff.StatusChanged) == 0 {
+				common.PrintSuccess("No changes (%d grant(s))", len(after))
+				return nil
+			}
+
+			printGrantList(common.Green.Sprint("New"), "+", diff.Added)
+			printGrantList(common.Red.Sprint("Removed"), "-", diff.Removed)
+
+			if len(diff.StatusChanged) > 0 {
+				_, _ = common.Bold.Printf("Status changed (%d)\n", len(diff.StatusChanged))
+				table := common.NewTable("EMAIL", "ID", "PROVIDER", "OLD", "NEW")
+				for _, c := range diff.StatusChanged {
+					table.AddRow(common.Cyan.Sprint(c.Email), c.ID, c.Provider, colorGrantStatus(c.Old), colorGrantStatus(c.New))
+				}
+				table.Render()
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// printGrantList prints one section of a diff.
+func printGrantList(title, marker string, grants []domain.Grant) {
+	if len(grants) == 0 {
+		return
+	}
+	_, _ = common.Bold.Printf("%s (%d)\n", title, len(grants))
+	slices.SortStableFunc(grants, func(a, b domain.Grant) int { return strings.Compare(a.Email, b.Email) })
+	for _, g := range grants {
+		fmt.Printf("  %s %-32s %-38s %s\n", marker, g.Email, g.ID, g.Provider)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,170 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestGrantFilter_Match(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	fresh := domain.Grant{Provider: "google", GrantStatus: "valid", UpdatedAt: domain.UnixTime{Time: now.Add(-time.Hour)}}
+	stale := domain.Grant{Provider: "microsoft", GrantStatus: "invalid", CreatedAt: domain.UnixTime{Time: now.Add(-100 * time.Hour)}}
+
+	tests := []struct {
+		name   string
+		filter grantFilter
+		grant  domain.Grant
+		want   bool
+	}{
+		{"no filters", grantFilter{}, fresh, true},
+		{"provider matches", grantFilter{provider: "Google"}, fresh, true},
+		{"provider differs", grantFilter{provider: "google"}, stale, false},
+		{"status differs", grantFilter{status: "invalid"}, fresh, false},
+		{"fresh is not stale", grantFilter{staleFor: 72 * time.Hour}, fresh, false},
+		{"falls back to created_at", grantFilter{staleFor: 72 * time.Hour}, stale, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.match(tt.grant, now))
+		})
+	}
+}
+
+func TestSelectGrants(t *testing.T) {
+	client := nylas.NewMockClient()
+	ctx := context.Background()
+
+	_, err := selectGrants(ctx, client, grantFilter{}, nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput, "a bulk operation needs IDs or filters")
+
+	grants, err := selectGrants(ctx, client, grantFilter{provider: "microsoft"}, nil)
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, "grant-2", grants[0].ID)
+
+	grants, err = selectGrants(ctx, client, grantFilter{}, []string{"grant-2", "grant-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "grant-2", grants[0].ID)
+	assert.Equal(t, "grant-1", grants[1].ID)
+
+	_, err = selectGrants(ctx, client, grantFilter{provider: "google"}, []string{"grant-2"})
+	assert.ErrorIs(t, err, domain.ErrGrantNotFound)
+}
+
+func TestReauthURL(t *testing.T) {
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-123", "", "api-key")
+
+	link, err := reauthURL(client, domain.Grant{Provider: "google", Email: "user@example.com"}, "https://example.com/cb")
+	require.NoError(t, err)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "client-123", u.Query().Get("client_id"))
+	assert.Equal(t, "user@example.com", u.Query().Get("login_hint"))
+	assert.Equal(t, "https://example.com/cb", u.Query().Get("redirect_uri"))
+
+	noClientID := nylas.NewHTTPClient()
+	noClientID.SetCredentials("", "", "api-key")
+	_, err = reauthURL(noClientID, domain.Grant{Provider: "google"}, "https://example.com/cb")
+	assert.Error(t, err)
+}
+
+func TestGrantSnapshots(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	grants := []domain.Grant{
+		{ID: "g1", Email: "a@example.com", Provider: "google", GrantStatus: "valid", Scope: []string{"email", "calendar"}, CreatedAt: domain.UnixTime{Time: created}},
+		{ID: "g2", Email: "b@example.com", Provider: "microsoft", GrantStatus: "invalid"},
+	}
+	dir := t.TempDir()
+
+	var csvBuf bytes.Buffer
+	require.NoError(t, writeGrantInventory(newExportTestCmd(t, "--format", "csv"), &csvBuf, grants))
+	csvPath := filepath.Join(dir, "grants.csv")
+	require.NoError(t, os.WriteFile(csvPath, csvBuf.Bytes(), 0600))
+
+	// JSON is the default
+	var jsonBuf bytes.Buffer
+	require.NoError(t, writeGrantInventory(newExportTestCmd(t), &jsonBuf, grants))
+	jsonPath := filepath.Join(dir, "grants.json")
+	require.NoError(t, os.WriteFile(jsonPath, jsonBuf.Bytes(), 0600))
+
+	listPath := filepath.Join(dir, "list.json")
+	require.NoError(t, os.WriteFile(listPath, []byte(`[{"id":"g1","grant_status":"valid","created_at":1767323045}]`), 0600))
+
+	for _, path := range []string{csvPath, jsonPath} {
+		got, err := readGrantSnapshot(path)
+		require.NoError(t, err, path)
+		require.Len(t, got, 2, path)
+		assert.Equal(t, "a@example.com", got[0].Email, path)
+		assert.Equal(t, []string{"email", "calendar"}, got[0].Scope, path)
+		assert.True(t, got[0].CreatedAt.Equal(created), path)
+		assert.Equal(t, "invalid", got[1].GrantStatus, path)
+	}
+
+	got, err := readGrantSnapshot(listPath)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "g1", got[0].ID)
+
+	notInventory := filepath.Join(dir, "other.csv")
+	require.NoError(t, os.WriteFile(notInventory, []byte("name,value\nx,y\n"), 0600))
+	_, err = readGrantSnapshot(notInventory)
+	assert.Error(t, err)
+}
+
+func TestWriteGrantInventory_Columns(t *testing.T) {
+	grants := []domain.Grant{{ID: "g1", Email: "a@example.com", Provider: "google", GrantStatus: "valid"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeGrantInventory(newExportTestCmd(t, "--format", "csv", "--columns", "email,id"), &buf, grants))
+	assert.Equal(t, "email,id\na@example.com,g1\n", buf.String())
+}
+
+// newExportTestCmd returns a command with the global output flags parsed from args.
+func newExportTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "export"}
+	common.AddOutputFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestDiffGrants(t *testing.T) {
+	before := []domain.Grant{
+		{ID: "kept", GrantStatus: "valid"},
+		{ID: "broke", GrantStatus: "valid"},
+		{ID: "gone", GrantStatus: "valid"},
+	}
+	after := []domain.Grant{
+		{ID: "kept", GrantStatus: "valid"},
+		{ID: "broke", Email: "b@example.com", GrantStatus: "invalid"},
+		{ID: "new", GrantStatus: "valid"},
+	}
+
+	diff := diffGrants(before, after)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "new", diff.Added[0].ID)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "gone", diff.Removed[0].ID)
+	assert.Equal(t, []grantStatusChange{{ID: "broke", Email: "b@example.com", Old: "valid", New: "invalid"}}, diff.StatusChanged)
+
+	empty := diffGrants(before, before)
+	assert.Empty(t, empty.Added)
+	assert.Empty(t, empty.Removed)
+	assert.Empty(t, empty.StatusChanged)
+}
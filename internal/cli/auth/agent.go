@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/adapters/keyring"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func newAgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Manage the unlock agent for passphrase-protected credentials",
+		Long: `Manage the unlock agent.
+
+With the passphrase backend, the agent keeps the vault key in memory after
+'nylas auth unlock', so later commands don't ask for the passphrase. Keys
+expire after the agent's TTL. The agent listens on a socket only the current
+user can open (NYLAS_AGENT_SOCK overrides its location).`,
+	}
+
+	cmd.AddCommand(newAgentStartCmd())
+	cmd.AddCommand(newAgentServeCmd())
+	cmd.AddCommand(newAgentStopCmd())
+	cmd.AddCommand(newAgentStatusCmd())
+
+	return cmd
+}
+
+func newAgentStartCmd() *cobra.Command {
+	var ttl time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start the unlock agent in the background",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := keyring.AgentSocketPath()
+			if keyring.AgentRunning(path) {
+				common.PrintInfo("Agent already running on %s", path)
+				return nil
+			}
+			if err := startAgent(path, ttl); err != nil {
+				return err
+			}
+			common.PrintSuccess("Agent started on %s", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", keyring.DefaultAgentTTL, "How long unlocked keys are kept")
+
+	return cmd
+}
+
+func newAgentServeCmd() *cobra.Command {
+	var ttl time.Duration
+
+	cmd := &cobra.Command{
+		Use:    "serve",
+		Short:  "Run the unlock agent in the foreground",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := keyring.AgentSocketPath()
+			l, err := keyring.ListenAgent(path)
+			if err != nil {
+				return common.WrapError(err)
+			}
+			defer func() { _ = os.Remove(path) }()
+
+			// Keep running when the terminal that started the agent closes.
+			signal.Ignore(syscall.SIGHUP)
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return keyring.NewAgent(ttl).Serve(ctx, l)
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", keyring.DefaultAgentTTL, "How long unlocked keys are kept")
+
+	return cmd
+}
+
+func newAgentStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the unlock agent, forgetting all keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := keyring.AgentSocketPath()
+			if !keyring.AgentRunning(path) {
+				common.PrintInfo("Agent is not running")
+				return nil
+			}
+			if err := keyring.AgentStop(path); err != nil {
+				return common.WrapError(err)
+			}
+			common.PrintSuccess("Agent stopped")
+			return nil
+		},
+	}
+}
+
+func newAgentStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the unlock agent is running",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := keyring.AgentSocketPath()
+			vaults, err := keyring.AgentStatus(path)
+			if err != nil {
+				fmt.Println("Agent: not running")
+				return nil
+			}
+			fmt.Printf("Agent:    running on %s\n", path)
+			fmt.Printf("Unlocked: %d vault(s)\n", vaults)
+			return nil
+		},
+	}
+}
+
+// startAgent runs `nylas auth agent serve` as a background process and
+// waits for it to answer.
+func startAgent(path string, ttl time.Duration) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return common.WrapError(err)
+	}
+
+	agent := exec.Command(exe, "auth", "agent", "serve", "--ttl", ttl.String()) // #nosec G204 -- re-runs this binary
+	agent.Env = append(os.Environ(), "NYLAS_AGENT_SOCK="+path)
+	if err := agent.Start(); err != nil {
+		return common.WrapError(fmt.Errorf("starting agent: %w", err))
+	}
+	_ = agent.Process.Release()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if keyring.AgentRunning(path) {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return common.NewUserError("the agent didn't start", "Run it in the foreground to see why: nylas auth agent serve")
+}
+
+func newUnlockCmd() *cobra.Command {
+	var ttl time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Unlock passphrase-protected credentials for this session",
+		Long: `Ask for the passphrase once and keep the vault key in the unlock agent,
+starting the agent if needed. Later commands use it until the TTL runs out or
+'nylas auth lock' is run.
+
+Only applies when the profile's secrets.backend is "passphrase". The first
+unlock creates the vault.`,
+		Example: `  # Unlock for the default 8 hours
+  nylas auth unlock
+
+  # Unlock for one hour
+  nylas auth unlock --ttl 1h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.NewDefaultFileStore().Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+			if cfg.Secrets == nil || cfg.Secrets.Backend != domain.SecretBackendPassphrase {
+				return common.NewUserError("this profile doesn't use the passphrase backend",
+					"Move credentials into a passphrase vault with: nylas auth migrate --from keyring --to passphrase")
+			}
+
+			store := keyring.NewPassphraseStore(config.DefaultConfigDir(), nil)
+			passphrase, err := readPassphrase(store)
+			if err != nil {
+				return err
+			}
+
+			path := keyring.AgentSocketPath()
+			if !keyring.AgentRunning(path) {
+				if err := startAgent(path, ttl); err != nil {
+					return err
+				}
+			}
+			if err := store.Unlock(passphrase, ttl); err != nil {
+				return common.WrapError(err)
+			}
+
+			common.PrintSuccess("Unlocked for %s", ttl)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", keyring.DefaultAgentTTL, "How long the vault stays unlocked")
+
+	return cmd
+}
+
+// readPassphrase asks for the vault passphrase, twice when the vault is new.
+func readPassphrase(store *keyring.PassphraseStore) (string, error) {
+	if env := os.Getenv("NYLAS_PASSPHRASE"); env != "" {
+		return env, nil
+	}
+
+	prompt := "Passphrase: "
+	if !store.Exists() {
+		prompt = "New passphrase: "
+	}
+	passphrase, err := keyring.TerminalPrompt(prompt)
+	if err != nil {
+		return "", common.WrapError(err)
+	}
+	if passphrase == "" {
+		return "", common.NewUserError("passphrase can't be empty", "")
+	}
+	if !store.Exists() {
+		again, err := keyring.TerminalPrompt("Repeat passphrase: ")
+		if err != nil {
+			return "", common.WrapError(err)
+		}
+		if again != passphrase {
+			return "", common.NewUserError("passphrases don't match", "Run 'nylas auth unlock' again")
+		}
+	}
+	return passphrase, nil
+}
+
+func newLockCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lock",
+		Short: "Forget unlocked passphrase keys",
+		Long:  "Make the unlock agent forget every vault key, so the next command asks for the passphrase again.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := keyring.AgentSocketPath()
+			if !keyring.AgentRunning(path) {
+				common.PrintInfo("Agent is not running; nothing is unlocked")
+				return nil
+			}
+			if err := keyring.AgentLock(path); err != nil {
+				return common.WrapError(err)
+			}
+			common.PrintSuccess("Locked")
+			return nil
+		},
+	}
+}
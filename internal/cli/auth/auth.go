@@ -18,6 +18,7 @@ Commands:
   status    Show current authentication status
   whoami    Show current user info
   list      List all authenticated accounts
+  health    Check stored grants and re-authenticate broken ones
   show      Show detailed grant information
   switch    Switch between authenticated accounts
   add       Manually add an existing grant
@@ -27,7 +28,10 @@ Commands:
   providers List available authentication providers
   detect    Detect provider from email address
   scopes    Show OAuth scopes for a grant
-  migrate   Migrate credentials to system keyring`,
+  migrate   Move credentials between secret backends
+  unlock    Unlock passphrase-protected credentials for this session
+  lock      Forget unlocked passphrase keys
+  agent     Manage the unlock agent`,
 	}
 
 	cmd.AddCommand(newLoginCmd())
@@ -35,6 +39,7 @@ Commands:
 	cmd.AddCommand(newStatusCmd())
 	cmd.AddCommand(newWhoamiCmd())
 	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newHealthCmd())
 	cmd.AddCommand(newShowCmd())
 	cmd.AddCommand(newSwitchCmd())
 	cmd.AddCommand(newAddCmd())
@@ -46,6 +51,9 @@ Commands:
 	cmd.AddCommand(newDetectCmd())
 	cmd.AddCommand(newScopesCmd())
 	cmd.AddCommand(newMigrateCmd())
+	cmd.AddCommand(newUnlockCmd())
+	cmd.AddCommand(newLockCmd())
+	cmd.AddCommand(newAgentCmd())
 
 	return cmd
 }
@@ -0,0 +1,351 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/adapters/notify"
+	authapp "github.com/mqasimca/nylas/internal/app/auth"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// minWatchInterval keeps --watch from hammering the grants API.
+const minWatchInterval = time.Minute
+
+// healthOptions holds the flags of `nylas auth health`.
+type healthOptions struct {
+	yes           bool
+	noReauth      bool
+	watch         bool
+	interval      time.Duration
+	notifyDesktop bool
+	webhookURL    string
+	webhookSecret string
+}
+
+func newHealthCmd() *cobra.Command {
+	var opts healthOptions
+
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Check stored grants and re-authenticate broken ones",
+		Long: `Check every stored grant against the Nylas API.
+
+A grant is flagged when it is:
+  invalid      Nylas can no longer use its credentials
+  expired      its authorization has expired
+  revoked      it no longer exists on Nylas
+  scope_drift  it has lost scopes it had when it was last seen healthy
+
+For each flagged grant you are offered to sign in again, which re-runs the
+OAuth flow in your browser. If Nylas issues a new grant ID, it replaces the
+old one locally, keeping its place as the default.
+
+With --watch, the check repeats until interrupted and a notification is sent
+whenever a grant degrades: a desktop notification with --notify-desktop, a
+signed JSON POST with --notify-webhook, or both.`,
+		Example: `  # Check all grants, offering to fix broken ones
+  nylas auth health
+
+  # Only report, as JSON
+  nylas auth health --no-reauth --json
+
+  # Re-authenticate every broken grant without asking
+  nylas auth health --yes
+
+  # Check every 30 minutes and notify on the desktop and a webhook
+  nylas auth health --watch --interval 30m --notify-desktop \
+    --notify-webhook https://example.com/hooks/nylas --notify-secret s3cret`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.watch {
+				return runHealthWatch(cmd, opts)
+			}
+			if opts.notifyDesktop || opts.webhookURL != "" {
+				return common.NewUserError("notifications are only sent in watch mode", "add --watch")
+			}
+			return runHealth(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Re-authenticate broken grants without asking")
+	cmd.Flags().BoolVar(&opts.noReauth, "no-reauth", false, "Only report; don't offer to re-authenticate")
+	cmd.Flags().BoolVar(&opts.watch, "watch", false, "Keep checking and notify when a grant degrades")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 15*time.Minute, "Time between checks in watch mode")
+	cmd.Flags().BoolVar(&opts.notifyDesktop, "notify-desktop", false, "Send desktop notifications in watch mode")
+	cmd.Flags().StringVar(&opts.webhookURL, "notify-webhook", "", "POST notifications to this URL in watch mode")
+	cmd.Flags().StringVar(&opts.webhookSecret, "notify-secret", "", "Sign webhook notifications with this secret (HMAC-SHA256)")
+	cmd.MarkFlagsMutuallyExclusive("yes", "no-reauth")
+	cmd.MarkFlagsMutuallyExclusive("watch", "yes")
+
+	return cmd
+}
+
+// runHealth checks the grants once and offers to fix the broken ones.
+func runHealth(cmd *cobra.Command, opts healthOptions) error {
+	grantSvc, _, err := createGrantService()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := common.CreateContext()
+	report, err := grantSvc.CheckHealth(ctx)
+	cancel()
+	if err != nil {
+		return common.WrapError(err)
+	}
+
+	if len(report) == 0 {
+		common.PrintEmptyState("accounts")
+		return nil
+	}
+
+	if common.IsStructuredOutput(cmd) {
+		if err := common.GetOutputWriter(cmd).Write(report); err != nil {
+			return err
+		}
+		return healthError(report)
+	}
+
+	printHealth(report)
+
+	if opts.noReauth || !hasReauthCandidates(report) {
+		return healthError(report)
+	}
+
+	authSvc, _, err := createAuthService()
+	if err != nil {
+		return err
+	}
+
+	fixed := 0
+	for i, h := range report {
+		if !h.NeedsReauth() {
+			continue
+		}
+		if !opts.yes && !common.Confirm(fmt.Sprintf("\nSign in again as %s?", h.Email), false) {
+			continue
+		}
+		if err := reauthenticate(authSvc, h); err != nil {
+			common.PrintError("%s: %v", h.Email, err)
+			continue
+		}
+		report[i].Health = domain.GrantHealthy
+		fixed++
+	}
+
+	if fixed > 0 {
+		fmt.Println()
+		common.PrintSuccess("Re-authenticated %d grant(s)", fixed)
+	}
+	return healthError(report)
+}
+
+// reauthenticate re-runs the OAuth flow for one grant.
+func reauthenticate(authSvc *authapp.Service, h domain.GrantHealth) error {
+	fmt.Printf("Opening browser to sign in as %s...\n", h.Email)
+
+	ctx, cancel := common.CreateLongContext()
+	defer cancel()
+
+	grant, err := authSvc.Reauthenticate(ctx, h.ID)
+	if err != nil {
+		return err
+	}
+
+	_, _ = common.Green.Printf("✓ Re-authenticated %s\n", grant.Email)
+	if grant.ID != h.ID {
+		fmt.Printf("  New grant ID: %s\n", grant.ID)
+	}
+	return nil
+}
+
+// printHealth prints the health report as a table.
+func printHealth(report []domain.GrantHealth) {
+	_, _ = common.Bold.Printf("  %-38s  %-24s  %-12s  %s\n", "GRANT ID", "EMAIL", "PROVIDER", "HEALTH")
+
+	for _, h := range report {
+		fmt.Printf("  %-38s  %-24s  %-12s  ", h.ID, h.Email, h.Provider.DisplayName())
+
+		switch h.Health {
+		case domain.GrantHealthy:
+			_, _ = common.Green.Print("✓ healthy")
+		case domain.GrantCheckError:
+			_, _ = common.Yellow.Print("? error")
+		default:
+			_, _ = common.Red.Printf("✗ %s", h.Health)
+		}
+		if h.IsDefault {
+			_, _ = common.Dim.Print(" (default)")
+		}
+		fmt.Println()
+
+		if detail := healthDetail(h); detail != "" {
+			_, _ = common.Dim.Printf("    %s\n", detail)
+		}
+	}
+}
+
+// healthDetail explains a problem with a grant, or returns "" for a healthy
+// one.
+func healthDetail(h domain.GrantHealth) string {
+	switch h.Health {
+	case domain.GrantInvalid:
+		return "Nylas can no longer use this grant's credentials"
+	case domain.GrantExpired:
+		return "The grant's authorization has expired"
+	case domain.GrantRevoked:
+		return "The grant no longer exists on Nylas"
+	case domain.GrantScopeDrift:
+		return "Lost scopes: " + strings.Join(h.MissingScopes, ", ")
+	case domain.GrantCheckError:
+		return "Could not check: " + h.Error
+	}
+	return ""
+}
+
+// hasReauthCandidates reports whether any grant can be fixed by signing in.
+func hasReauthCandidates(report []domain.GrantHealth) bool {
+	for _, h := range report {
+		if h.NeedsReauth() {
+			return true
+		}
+	}
+	return false
+}
+
+// healthError returns an error when any grant is unhealthy, so scripts can
+// check the exit status.
+func healthError(report []domain.GrantHealth) error {
+	unhealthy := 0
+	for _, h := range report {
+		if !h.IsHealthy() {
+			unhealthy++
+		}
+	}
+	if unhealthy > 0 {
+		return fmt.Errorf("%d grant(s) need attention", unhealthy)
+	}
+	return nil
+}
+
+// runHealthWatch checks the grants every interval until interrupted, sending
+// notifications when a grant degrades.
+func runHealthWatch(cmd *cobra.Command, opts healthOptions) error {
+	if opts.interval < minWatchInterval {
+		return common.NewUserError(
+			fmt.Sprintf("interval %s is too short", opts.interval),
+			fmt.Sprintf("use an interval of at least %s", minWatchInterval),
+		)
+	}
+
+	var notifiers []ports.Notifier
+	if opts.notifyDesktop {
+		notifiers = append(notifiers, notify.NewDesktop())
+	}
+	if opts.webhookURL != "" {
+		if !strings.HasPrefix(opts.webhookURL, "https://") && !strings.HasPrefix(opts.webhookURL, "http://") {
+			return common.NewUserError("invalid webhook URL: "+opts.webhookURL, "use an http:// or https:// URL")
+		}
+		notifiers = append(notifiers, notify.NewWebhook(opts.webhookURL, opts.webhookSecret))
+	}
+
+	grantSvc, _, err := createGrantService()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	common.PrintInfo("Checking grants every %s. Press Ctrl+C to stop.", opts.interval)
+
+	last := map[string]string{}
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, domain.TimeoutAPI)
+		report, err := grantSvc.CheckHealth(checkCtx)
+		cancel()
+
+		switch {
+		case errors.Is(err, context.Canceled):
+		case err != nil:
+			common.PrintError("Health check failed: %v", err)
+		default:
+			for _, h := range report {
+				if degraded(last[h.ID], h) {
+					sendHealthNotification(ctx, notifiers, h)
+				}
+				if h.Health != domain.GrantCheckError {
+					last[h.ID] = h.Health
+				}
+			}
+			printWatchSummary(cmd, report)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// degraded reports whether h is a new problem compared to the grant's last
+// known health. Check errors aren't problems with the grant, so they never
+// count.
+func degraded(previous string, h domain.GrantHealth) bool {
+	if h.IsHealthy() || h.Health == domain.GrantCheckError {
+		return false
+	}
+	return h.Health != previous
+}
+
+// sendHealthNotification tells every notifier that a grant degraded.
+func sendHealthNotification(ctx context.Context, notifiers []ports.Notifier, h domain.GrantHealth) {
+	common.PrintWarning("%s is %s", h.Email, h.Health)
+
+	n := ports.Notification{
+		Event:   "grant.degraded",
+		Title:   fmt.Sprintf("Nylas grant %s", strings.ReplaceAll(h.Health, "_", " ")),
+		Message: fmt.Sprintf("%s: %s. Run 'nylas auth health' to sign in again.", h.Email, healthDetail(h)),
+		Time:    time.Now().UTC(),
+		Data:    h,
+	}
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(ctx, n); err != nil {
+			common.PrintError("%v", err)
+		}
+	}
+}
+
+// printWatchSummary prints one line per check in watch mode, or the report
+// itself in structured output mode.
+func printWatchSummary(cmd *cobra.Command, report []domain.GrantHealth) {
+	if common.IsStructuredOutput(cmd) {
+		_ = common.GetOutputWriter(cmd).Write(report)
+		return
+	}
+
+	healthy := 0
+	for _, h := range report {
+		if h.IsHealthy() {
+			healthy++
+		}
+	}
+	_, _ = common.Dim.Printf("%s  ", time.Now().Format("15:04:05"))
+	fmt.Printf("%d/%d grant(s) healthy\n", healthy, len(report))
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestHealthCommand(t *testing.T) {
+	cmd := newHealthCmd()
+
+	if cmd.Use != "health" {
+		t.Errorf("Command Use = %q, want %q", cmd.Use, "health")
+	}
+	for _, flag := range []string{"yes", "no-reauth", "watch", "interval", "notify-desktop", "notify-webhook", "notify-secret"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("Missing flag --%s", flag)
+		}
+	}
+}
+
+func TestDegraded(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		current  string
+		want     bool
+	}{
+		{"first check, healthy", "", domain.GrantHealthy, false},
+		{"first check, broken", "", domain.GrantInvalid, true},
+		{"became invalid", domain.GrantHealthy, domain.GrantInvalid, true},
+		{"still invalid", domain.GrantInvalid, domain.GrantInvalid, false},
+		{"invalid then revoked", domain.GrantInvalid, domain.GrantRevoked, true},
+		{"recovered", domain.GrantScopeDrift, domain.GrantHealthy, false},
+		{"check failed", domain.GrantHealthy, domain.GrantCheckError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := degraded(tt.previous, domain.GrantHealth{Health: tt.current}); got != tt.want {
+				t.Errorf("degraded(%q, %q) = %v, want %v", tt.previous, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthError(t *testing.T) {
+	healthy := []domain.GrantHealth{{Health: domain.GrantHealthy}}
+	if err := healthError(healthy); err != nil {
+		t.Errorf("healthError(healthy) = %v", err)
+	}
+
+	mixed := append(healthy, domain.GrantHealth{Health: domain.GrantExpired}, domain.GrantHealth{Health: domain.GrantCheckError})
+	if err := healthError(mixed); err == nil || err.Error() != "2 grant(s) need attention" {
+		t.Errorf("healthError(mixed) = %v", err)
+	}
+}
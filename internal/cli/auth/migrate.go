@@ -3,99 +3,126 @@ package auth
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mqasimca/nylas/internal/adapters/config"
 	"github.com/mqasimca/nylas/internal/adapters/keyring"
 	"github.com/mqasimca/nylas/internal/cli/common"
-	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/mqasimca/nylas/internal/domain"
 )
 
 func newMigrateCmd() *cobra.Command {
+	var (
+		from         string
+		to           string
+		deleteSource bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "migrate",
-		Short: "Migrate credentials from encrypted file to system keyring",
-		Long: `Migrate credentials from the encrypted file store to the system keyring.
-
-This command is useful when credentials were originally stored in the encrypted
-file (e.g., when running in a sandboxed environment) but you now want to use
-the more secure system keyring.
-
-The system keyring provides better security as it's protected by your OS
-authentication (macOS Keychain, Linux Secret Service, Windows Credential Manager).`,
-		RunE: runMigrate,
+		Short: "Move credentials between secret backends",
+		Long: `Copy credentials and stored accounts from one secret backend to another,
+then make the target the profile's backend (secrets.backend).
+
+Backends:
+  keyring      System keyring (macOS Keychain, Linux Secret Service, Windows Credential Manager)
+  file         Encrypted file keyed to this machine
+  passphrase   Encrypted file unlocked with a passphrase (see 'nylas auth unlock')
+  pass         pass, the standard Unix password manager (GPG)
+  helper       External credential helper (secrets.helper)
+
+The defaults move credentials from the encrypted file into the system keyring,
+for installs that started out in a sandbox without a keyring.`,
+		Example: `  # Encrypted file to system keyring
+  nylas auth migrate
+
+  # System keyring to a passphrase-protected vault, removing the keyring copies
+  nylas auth migrate --from keyring --to passphrase --delete-source
+
+  # Into pass, under a custom prefix
+  nylas config set secrets.pass_prefix work/nylas
+  nylas auth migrate --from keyring --to pass`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrate(from, to, deleteSource)
+		},
 	}
 
+	cmd.Flags().StringVar(&from, "from", domain.SecretBackendFile, "Backend to copy from: "+strings.Join(keyring.Backends, ", "))
+	cmd.Flags().StringVar(&to, "to", domain.SecretBackendKeyring, "Backend to copy to: "+strings.Join(keyring.Backends, ", "))
+	cmd.Flags().BoolVar(&deleteSource, "delete-source", false, "Remove the secrets from the source backend after copying")
+
 	return cmd
 }
 
-func runMigrate(_ *cobra.Command, _ []string) error {
-	configDir := config.DefaultConfigDir()
-
-	// Check if keyring is disabled
-	if os.Getenv("NYLAS_DISABLE_KEYRING") == "true" {
-		return fmt.Errorf("NYLAS_DISABLE_KEYRING is set. Unset it to use system keyring:\n  unset NYLAS_DISABLE_KEYRING")
+func runMigrate(from, to string, deleteSource bool) error {
+	if from == to {
+		return common.NewUserError("--from and --to are the same backend", "Pick two different backends")
 	}
-
-	// Check if system keyring is available
-	kr := keyring.NewSystemKeyring()
-	if !kr.IsAvailable() {
-		return fmt.Errorf("system keyring is not available on this system")
+	if to == domain.SecretBackendKeyring && os.Getenv("NYLAS_DISABLE_KEYRING") == "true" {
+		return common.NewUserError("NYLAS_DISABLE_KEYRING is set", "Unset it to use the system keyring: unset NYLAS_DISABLE_KEYRING")
 	}
 
-	// Check if already using keyring
-	if _, err := kr.Get(ports.KeyAPIKey); err == nil {
-		fmt.Println("✓ Already using system keyring")
-		return nil
+	configStore := config.NewDefaultFileStore()
+	cfg, err := configStore.Load()
+	if err != nil {
+		return common.WrapLoadError("configuration", err)
 	}
 
-	// Load credentials from encrypted file
-	fileStore, err := keyring.NewEncryptedFileStore(configDir)
+	opts := keyring.BackendOptions{
+		Profile:   config.ActiveProfile(),
+		ConfigDir: config.DefaultConfigDir(),
+		Secrets:   cfg.Secrets,
+		Prompt:    keyring.TerminalPrompt,
+	}
+	source, err := keyring.NewBackend(from, opts)
 	if err != nil {
-		return common.WrapGetError("encrypted file store", err)
+		return common.NewUserError(err.Error(), "Valid backends: "+strings.Join(keyring.Backends, ", "))
 	}
-
-	// Get credentials from file store
-	apiKey, err := fileStore.Get(ports.KeyAPIKey)
+	target, err := keyring.NewBackend(to, opts)
 	if err != nil {
-		return fmt.Errorf("no credentials found in encrypted file store")
+		return common.NewUserError(err.Error(), "Valid backends: "+strings.Join(keyring.Backends, ", "))
 	}
-
-	// Migrate API key
-	if err := kr.Set(ports.KeyAPIKey, apiKey); err != nil {
-		return common.WrapSaveError("API key", err)
+	if !source.IsAvailable() {
+		return common.NewUserError(source.Name()+" is not available on this system", "")
 	}
-	fmt.Println("✓ Migrated API key")
-
-	// Migrate optional credentials
-	if clientID, err := fileStore.Get(ports.KeyClientID); err == nil && clientID != "" {
-		if err := kr.Set(ports.KeyClientID, clientID); err == nil {
-			fmt.Println("✓ Migrated client ID")
-		}
+	if !target.IsAvailable() {
+		return common.NewUserError(target.Name()+" is not available on this system", "")
 	}
 
-	if clientSecret, err := fileStore.Get(ports.KeyClientSecret); err == nil && clientSecret != "" {
-		if err := kr.Set(ports.KeyClientSecret, clientSecret); err == nil {
-			fmt.Println("✓ Migrated client secret")
-		}
+	copied, err := keyring.MigrateSecrets(source, target)
+	for _, key := range copied {
+		fmt.Printf("✓ Migrated %s\n", key)
 	}
-
-	// Migrate grants data
-	if grants, err := fileStore.Get("grants"); err == nil && grants != "" {
-		if err := kr.Set("grants", grants); err == nil {
-			fmt.Println("✓ Migrated grants")
-		}
+	if err != nil {
+		return common.WrapError(err)
+	}
+	if len(copied) == 0 {
+		return common.NewUserError(
+			"no credentials found in "+source.Name(),
+			"Check --from; the configuration was not changed",
+		)
 	}
 
-	if defaultGrant, err := fileStore.Get("default_grant"); err == nil && defaultGrant != "" {
-		if err := kr.Set("default_grant", defaultGrant); err == nil {
-			fmt.Println("✓ Migrated default grant")
-		}
+	// Switch backends before touching the source, so a failed save never
+	// leaves the config pointing at a store whose secrets are gone.
+	if cfg.Secrets == nil {
+		cfg.Secrets = &domain.SecretsConfig{}
+	}
+	cfg.Secrets.Backend = to
+	if err := configStore.Save(cfg); err != nil {
+		return common.WrapSaveError("configuration", err)
 	}
 
 	fmt.Println()
-	fmt.Println("Migration complete! Your credentials are now stored in the system keyring.")
+	common.PrintSuccess("Credentials are now stored in %s", target.Name())
+	if deleteSource {
+		if err := keyring.DeleteSecrets(source, copied); err != nil {
+			return common.WrapError(err)
+		}
+		fmt.Printf("Removed %d secrets from %s.\n", len(copied), source.Name())
+	}
 	fmt.Println("Run 'nylas doctor' to verify.")
 
 	return nil
@@ -183,6 +183,30 @@ func describeScopeCategory(scope string) string {
 	return ""
 }
 
+// ScopeAreas returns which of email, calendar and contacts a grant's scopes
+// give access to, in that order.
+func ScopeAreas(scopes []string) []string {
+	covered := map[string]bool{}
+	for _, scope := range scopes {
+		switch {
+		case contains(scope, "gmail") || contains(scope, "Mail."):
+			covered["email"] = true
+		case contains(scope, "calendar") || contains(scope, "Calendars."):
+			covered["calendar"] = true
+		case contains(scope, "contacts") || contains(scope, "Contacts."):
+			covered["contacts"] = true
+		}
+	}
+
+	var areas []string
+	for _, area := range []string{"email", "calendar", "contacts"} {
+		if covered[area] {
+			areas = append(areas, area)
+		}
+	}
+	return areas
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr ||
 		(len(s) > len(substr) && (s[:len(substr)] == substr ||
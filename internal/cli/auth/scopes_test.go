@@ -113,3 +113,23 @@ func TestDescribeScopeCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestScopeAreas(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		want   string
+	}{
+		{"google", []string{"https://www.googleapis.com/auth/gmail.modify", "https://www.googleapis.com/auth/calendar", "https://www.googleapis.com/auth/contacts"}, "email,calendar,contacts"},
+		{"microsoft", []string{"Mail.Read", "User.Read", "Calendars.ReadWrite"}, "email,calendar"},
+		{"none", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(ScopeAreas(tt.scopes), ","); got != tt.want {
+				t.Errorf("ScopeAreas(%v) = %q, want %q", tt.scopes, got, tt.want)
+			}
+		})
+	}
+}
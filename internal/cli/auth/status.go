@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/mqasimca/nylas/internal/adapters/config"
 	"github.com/mqasimca/nylas/internal/cli/common"
 )
 
@@ -22,6 +23,7 @@ func newStatusCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			status.Profile = config.ActiveProfile()
 
 			// Get current grant info
 			ctx, cancel := common.CreateContext()
@@ -45,6 +47,7 @@ func newStatusCmd() *cobra.Command {
 			jsonOutput, _ := cmd.Root().PersistentFlags().GetBool("json")
 			if jsonOutput {
 				output := map[string]any{
+					"profile":       status.Profile,
 					"configured":    status.IsConfigured,
 					"region":        status.Region,
 					"config_path":   status.ConfigPath,
@@ -81,6 +84,7 @@ func newStatusCmd() *cobra.Command {
 			}
 
 			_, _ = common.Bold.Println("Configuration:")
+			fmt.Printf("  Profile: %s\n", status.Profile)
 			fmt.Printf("  Region: %s\n", status.Region)
 			if status.ClientID != "" {
 				fmt.Printf("  Client ID: %s\n", status.ClientID)
@@ -74,19 +74,16 @@ func newAIRescheduleCmd() *cobra.Command {
 
 				// Analyze patterns
 				fmt.Println("\n🔍 Analyzing your calendar patterns...")
-				learner := analytics.NewPatternLearner(client)
-				analysis, err := learner.AnalyzeHistory(ctx, grantID, 90)
+				store := analytics.NewDefaultPatternFileStore()
+				learner := analytics.NewPatternLearner(client).WithStore(store)
+				patterns, err := learner.Patterns(ctx, grantID, 90)
 				if err != nil {
 					fmt.Printf("⚠️  Could not analyze patterns: %v\n", err)
 				}
 
-				var patterns *domain.MeetingPattern
-				if analysis != nil && analysis.Patterns != nil {
-					patterns = analysis.Patterns
-				}
-
 				// Create conflict resolver
 				resolver := analytics.NewConflictResolver(client, patterns)
+				resolver.SetPatternStore(store)
 
 				// Parse preferred times if provided
 				var preferredTimeParsed []time.Time
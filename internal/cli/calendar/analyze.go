@@ -3,6 +3,7 @@ package calendar
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -21,6 +22,9 @@ func newAnalyzeCmd() *cobra.Command {
 		scoreTime    string
 		participants []string
 		duration     int
+		exportPath   string
+		importPath   string
+		refresh      bool
 	)
 
 	cmd := &cobra.Command{
@@ -35,7 +39,12 @@ This command analyzes your calendar history to discover:
 - Productivity insights (peak focus times)
 - Per-participant preferences
 
-It provides actionable AI recommendations for optimizing your calendar.`,
+It provides actionable AI recommendations for optimizing your calendar.
+
+Learned patterns are stored per grant in ~/.config/nylas/patterns and updated
+incrementally, so later runs only fetch events added since the previous one.
+The same profile is used by conflict detection, rescheduling and focus time.
+Use --export and --import to move a profile between machines.`,
 		Example: `  # Analyze last 90 days
   nylas calendar analyze
 
@@ -46,7 +55,14 @@ It provides actionable AI recommendations for optimizing your calendar.`,
   nylas calendar analyze --score-time "2025-01-15T14:00:00Z" --participants user@example.com --duration 30
 
   # Apply top recommendations automatically
-  nylas calendar analyze --apply`,
+  nylas calendar analyze --apply
+
+  # Rebuild the stored profile from scratch
+  nylas calendar analyze --refresh
+
+  # Move a learned profile to another machine
+  nylas calendar analyze --export patterns.json
+  nylas calendar analyze --import patterns.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load config to get working hours - respect --config flag
 			configStore := common.GetConfigStore(cmd)
@@ -58,9 +74,25 @@ It provides actionable AI recommendations for optimizing your calendar.`,
 				workingHours = cfg.WorkingHours.Default
 			}
 
+			if exportPath != "" && importPath != "" {
+				return common.NewUserError("--export and --import cannot be used together", "run them as separate commands")
+			}
+
+			store := analytics.NewDefaultPatternFileStore()
+
 			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				if importPath != "" {
+					return struct{}{}, importPatternProfile(store, grantID, importPath)
+				}
+
+				if refresh {
+					if err := store.DeleteProfile(grantID); err != nil {
+						return struct{}{}, err
+					}
+				}
+
 				// Create pattern learner with working hours
-				learner := analytics.NewPatternLearnerWithWorkingHours(client, workingHours)
+				learner := analytics.NewPatternLearnerWithWorkingHours(client, workingHours).WithStore(store)
 
 				// If scoring a specific time
 				if scoreTime != "" {
@@ -78,6 +110,12 @@ It provides actionable AI recommendations for optimizing your calendar.`,
 				// Display results
 				displayAnalysis(analysis, workingHours)
 
+				if exportPath != "" {
+					if err := exportPatternProfile(store, grantID, exportPath); err != nil {
+						return struct{}{}, err
+					}
+				}
+
 				// Apply recommendations if requested
 				if applyRecs {
 					return struct{}{}, applyRecommendations(ctx, client, grantID, analysis)
@@ -94,6 +132,9 @@ It provides actionable AI recommendations for optimizing your calendar.`,
 	cmd.Flags().StringVar(&scoreTime, "score-time", "", "Score a specific meeting time (RFC3339 format)")
 	cmd.Flags().StringSliceVar(&participants, "participants", nil, "Participants for scoring (email addresses)")
 	cmd.Flags().IntVar(&duration, "duration", 30, "Meeting duration in minutes for scoring")
+	cmd.Flags().StringVar(&exportPath, "export", "", "Write the learned pattern profile to a file")
+	cmd.Flags().StringVar(&importPath, "import", "", "Load a pattern profile exported with --export")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Discard the stored profile and re-learn from scratch")
 
 	return cmd
 }
@@ -244,19 +285,19 @@ func scoreSpecificTime(ctx context.Context, learner *analytics.PatternLearner, c
 		return common.NewUserError("invalid time format", "use RFC3339 format")
 	}
 
-	// Analyze history to get patterns
+	// Load stored patterns, analyzing history only if they are stale
 	fmt.Println("🔍 Analyzing historical patterns...")
-	analysis, err := learner.AnalyzeHistory(ctx, grantID, 90)
+	patterns, err := learner.Patterns(ctx, grantID, 90)
 	if err != nil {
 		return common.WrapGetError("meeting analysis", err)
 	}
 
-	if analysis.Patterns == nil {
+	if patterns == nil {
 		return fmt.Errorf("insufficient historical data for scoring")
 	}
 
 	// Create scorer and score the time
-	scorer := analytics.NewMeetingScorer(analysis.Patterns)
+	scorer := analytics.NewMeetingScorer(patterns)
 	score := scorer.ScoreMeetingTime(proposedTime, participants, duration)
 
 	// Display score
@@ -348,3 +389,63 @@ func applyRecommendations(ctx context.Context, client ports.NylasClient, grantID
 
 	return nil
 }
+
+// exportPatternProfile writes the stored profile for a grant to path.
+func exportPatternProfile(store *analytics.PatternFileStore, grantID, path string) error {
+	profile, err := store.LoadProfile(grantID)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return common.NewUserError("no pattern profile to export", "not enough meeting history was found to learn patterns")
+	}
+
+	// #nosec G304 -- path comes from the --export flag
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("creating export file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := analytics.WritePatternProfile(f, profile); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+	fmt.Printf("\n💾 Exported pattern profile (%d events) to %s\n", len(profile.Samples), path)
+	return nil
+}
+
+// importPatternProfile replaces the stored profile for a grant with one read from path.
+func importPatternProfile(store *analytics.PatternFileStore, grantID, path string) error {
+	// #nosec G304 -- path comes from the --import flag
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening import file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	profile, err := analytics.ReadPatternProfile(f)
+	if err != nil {
+		return err
+	}
+	if profile.Version != domain.PatternProfileVersion {
+		return common.NewUserError(
+			fmt.Sprintf("unsupported pattern profile version %d", profile.Version),
+			fmt.Sprintf("re-export the profile with this version of the CLI (expects version %d)", domain.PatternProfileVersion),
+		)
+	}
+	if profile.Patterns == nil {
+		return common.NewUserError("pattern profile contains no patterns", "export it again after running 'nylas calendar analyze'")
+	}
+
+	if profile.GrantID != grantID {
+		profile.ImportedFrom = profile.GrantID
+	}
+	profile.GrantID = grantID
+	if err := store.SaveProfile(profile); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Imported pattern profile (%d events, learned %s)\n",
+		len(profile.Samples), profile.Patterns.LastUpdated.Format("2006-01-02"))
+	return nil
+}
@@ -94,19 +94,16 @@ func newCheckConflictsCmd() *cobra.Command {
 			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				// Analyze patterns first
 				fmt.Println("🔍 Analyzing your calendar patterns...")
-				learner := analytics.NewPatternLearner(client)
-				analysis, err := learner.AnalyzeHistory(ctx, grantID, 90)
+				store := analytics.NewDefaultPatternFileStore()
+				learner := analytics.NewPatternLearner(client).WithStore(store)
+				patterns, err := learner.Patterns(ctx, grantID, 90)
 				if err != nil {
 					fmt.Printf("⚠️  Could not analyze patterns: %v\n", err)
 				}
 
 				// Create conflict resolver
-				var patterns *domain.MeetingPattern
-				if analysis != nil && analysis.Patterns != nil {
-					patterns = analysis.Patterns
-				}
-
 				resolver := analytics.NewConflictResolver(client, patterns)
+				resolver.SetPatternStore(store)
 
 				// Detect conflicts
 				fmt.Println("\n⚙️  Detecting conflicts...")
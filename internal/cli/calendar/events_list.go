@@ -19,6 +19,7 @@ func newEventsListCmd() *cobra.Command {
 		showAll    bool
 		targetTZ   string
 		showTZ     bool
+		allGrants  bool
 	)
 
 	cmd := &cobra.Command{
@@ -35,7 +36,10 @@ Examples:
   nylas calendar events list --timezone America/Los_Angeles
 
   # List events with timezone abbreviations shown
-  nylas calendar events list --show-tz`,
+  nylas calendar events list --show-tz
+
+  # One agenda across every authenticated account (primary calendars)
+  nylas calendar events list --all-grants`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Auto-detect timezone if not specified
@@ -54,6 +58,29 @@ Examples:
 				}
 			}
 
+			params := &domain.EventQueryParams{
+				Limit:   limit,
+				OrderBy: "start", // Sort by start time ascending
+			}
+
+			// Set time range if days specified
+			if days > 0 {
+				now := time.Now()
+				params.Start = now.Unix()
+				params.End = now.AddDate(0, 0, days).Unix()
+			}
+
+			if showAll {
+				params.ShowCancelled = true
+			}
+
+			if allGrants {
+				if err := common.CheckAllGrantsArgs(allGrants, args); err != nil {
+					return err
+				}
+				return runEventsListAllGrants(cmd, params, targetTZ, showTZ)
+			}
+
 			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				// If no calendar specified, try to get the primary calendar
 				calID, err := GetDefaultCalendarID(ctx, client, grantID, calendarID, false)
@@ -61,29 +88,13 @@ Examples:
 					return struct{}{}, err
 				}
 
-				params := &domain.EventQueryParams{
-					Limit:   limit,
-					OrderBy: "start", // Sort by start time ascending
-				}
-
-				// Set time range if days specified
-				if days > 0 {
-					now := time.Now()
-					params.Start = now.Unix()
-					params.End = now.AddDate(0, 0, days).Unix()
-				}
-
-				if showAll {
-					params.ShowCancelled = true
-				}
-
 				events, err := client.GetEvents(ctx, grantID, calID, params)
 				if err != nil {
 					return struct{}{}, common.WrapListError("events", err)
 				}
 
 				// JSON output (including empty array)
-				if common.IsJSON(cmd) {
+				if common.IsStructuredOutput(cmd) {
 					out := common.GetOutputWriter(cmd)
 					return struct{}{}, out.Write(events)
 				}
@@ -96,80 +107,7 @@ Examples:
 				fmt.Printf("Found %d event(s):\n\n", len(events))
 
 				for _, event := range events {
-					// Title with timezone badge (if showing timezone info)
-					fmt.Printf("%s", common.Cyan.Sprint(event.Title))
-					if showTZ && !event.When.IsAllDay() {
-						// Get event's original timezone
-						start := event.When.StartDateTime()
-						originalTZ := start.Location().String()
-						if originalTZ == "Local" {
-							originalTZ = getLocalTimeZone()
-						}
-
-						// Add colored timezone badge
-						badge := formatTimezoneBadge(originalTZ, true) // Use abbreviation
-						fmt.Printf(" %s", common.Blue.Sprint(badge))
-					}
-					fmt.Println()
-
-					// Time (with timezone conversion if requested)
-					timeDisplay, err := formatEventTimeWithTZ(&event, targetTZ)
-					if err != nil {
-						fmt.Printf("  %s %s (timezone conversion error: %v)\n",
-							common.Dim.Sprint("When:"),
-							formatEventTime(event.When),
-							err)
-					} else {
-						if timeDisplay.ShowConversion {
-							// Show converted time prominently
-							fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.ConvertedTime)
-							if showTZ {
-								fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.ConvertedTimezone))
-							}
-							fmt.Println()
-							// Show original time as reference
-							fmt.Printf("       %s %s",
-								common.Dim.Sprint("(Original:"),
-								common.Dim.Sprint(timeDisplay.OriginalTime))
-							if showTZ {
-								fmt.Printf(" %s", common.Dim.Sprint(timeDisplay.OriginalTimezone))
-							}
-							fmt.Printf("%s\n", common.Dim.Sprint(")"))
-						} else {
-							// No conversion - show original time
-							fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.OriginalTime)
-							if showTZ && timeDisplay.OriginalTimezone != "" {
-								fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.OriginalTimezone))
-							}
-							fmt.Println()
-						}
-					}
-
-					// Location
-					if event.Location != "" {
-						fmt.Printf("  %s %s\n", common.Dim.Sprint("Location:"), event.Location)
-					}
-
-					// Status
-					statusColor := common.Green
-					switch event.Status {
-					case "cancelled":
-						statusColor = common.Red
-					case "tentative":
-						statusColor = common.Yellow
-					}
-					if event.Status != "" {
-						fmt.Printf("  %s %s\n", common.Dim.Sprint("Status:"), statusColor.Sprint(event.Status))
-					}
-
-					// Participants count
-					if len(event.Participants) > 0 {
-						fmt.Printf("  %s %d participant(s)\n", common.Dim.Sprint("Guests:"), len(event.Participants))
-					}
-
-					// ID
-					fmt.Printf("  %s %s\n", common.Dim.Sprint("ID:"), common.Dim.Sprint(event.ID))
-					fmt.Println()
+					printEventSummary(event, "", targetTZ, showTZ)
 				}
 
 				return struct{}{}, nil
@@ -184,6 +122,136 @@ Examples:
 	cmd.Flags().BoolVar(&showAll, "show-cancelled", false, "Include cancelled events")
 	cmd.Flags().StringVar(&targetTZ, "timezone", "", "Display times in this timezone (e.g., America/Los_Angeles). Defaults to local timezone.")
 	cmd.Flags().BoolVar(&showTZ, "show-tz", false, "Show timezone abbreviations (e.g., PST, EST)")
+	common.AddAllGrantsFlag(cmd, &allGrants)
+	cmd.MarkFlagsMutuallyExclusive("calendar", "all-grants")
 
 	return cmd
 }
+
+// accountEvent is an event from an --all-grants listing, labelled with the
+// account it belongs to.
+type accountEvent struct {
+	Account      string `json:"account" yaml:"account"`
+	domain.Event `yaml:",inline"`
+}
+
+// runEventsListAllGrants lists upcoming events from every stored grant's
+// primary calendar as one agenda.
+func runEventsListAllGrants(cmd *cobra.Command, params *domain.EventQueryParams, targetTZ string, showTZ bool) error {
+	events, err := common.WithAllGrants(cmd, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Event, error) {
+		calID, err := GetDefaultCalendarID(ctx, client, grantID, "", false)
+		if err != nil {
+			return nil, err
+		}
+		return client.GetEvents(ctx, grantID, calID, params)
+	}, func(grant domain.GrantInfo, event domain.Event) accountEvent {
+		return accountEvent{Account: common.AccountName(grant), Event: event}
+	}, func(a, b accountEvent) bool {
+		return a.When.StartDateTime().Before(b.When.StartDateTime())
+	})
+	if err != nil {
+		return common.WrapListError("events", err)
+	}
+	if params.Limit > 0 && len(events) > params.Limit {
+		events = events[:params.Limit]
+	}
+
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(events)
+	}
+
+	if len(events) == 0 {
+		common.PrintEmptyState("events")
+		return nil
+	}
+
+	fmt.Printf("Found %d event(s):\n\n", len(events))
+	for _, event := range events {
+		printEventSummary(event.Event, event.Account, targetTZ, showTZ)
+	}
+	return nil
+}
+
+// printEventSummary prints an event in the events list format. account is
+// shown when set, for --all-grants.
+func printEventSummary(event domain.Event, account, targetTZ string, showTZ bool) {
+	// Title with timezone badge (if showing timezone info)
+	fmt.Printf("%s", common.Cyan.Sprint(event.Title))
+	if showTZ && !event.When.IsAllDay() {
+		// Get event's original timezone
+		start := event.When.StartDateTime()
+		originalTZ := start.Location().String()
+		if originalTZ == "Local" {
+			originalTZ = getLocalTimeZone()
+		}
+
+		// Add colored timezone badge
+		badge := formatTimezoneBadge(originalTZ, true) // Use abbreviation
+		fmt.Printf(" %s", common.Blue.Sprint(badge))
+	}
+	fmt.Println()
+
+	// Time (with timezone conversion if requested)
+	timeDisplay, err := formatEventTimeWithTZ(&event, targetTZ)
+	if err != nil {
+		fmt.Printf("  %s %s (timezone conversion error: %v)\n",
+			common.Dim.Sprint("When:"),
+			formatEventTime(event.When),
+			err)
+	} else {
+		if timeDisplay.ShowConversion {
+			// Show converted time prominently
+			fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.ConvertedTime)
+			if showTZ {
+				fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.ConvertedTimezone))
+			}
+			fmt.Println()
+			// Show original time as reference
+			fmt.Printf("       %s %s",
+				common.Dim.Sprint("(Original:"),
+				common.Dim.Sprint(timeDisplay.OriginalTime))
+			if showTZ {
+				fmt.Printf(" %s", common.Dim.Sprint(timeDisplay.OriginalTimezone))
+			}
+			fmt.Printf("%s\n", common.Dim.Sprint(")"))
+		} else {
+			// No conversion - show original time
+			fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.OriginalTime)
+			if showTZ && timeDisplay.OriginalTimezone != "" {
+				fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.OriginalTimezone))
+			}
+			fmt.Println()
+		}
+	}
+
+	// Location
+	if event.Location != "" {
+		fmt.Printf("  %s %s\n", common.Dim.Sprint("Location:"), event.Location)
+	}
+
+	// Status
+	statusColor := common.Green
+	switch event.Status {
+	case "cancelled":
+		statusColor = common.Red
+	case "tentative":
+		statusColor = common.Yellow
+	}
+	if event.Status != "" {
+		fmt.Printf("  %s %s\n", common.Dim.Sprint("Status:"), statusColor.Sprint(event.Status))
+	}
+
+	// Participants count
+	if len(event.Participants) > 0 {
+		fmt.Printf("  %s %d participant(s)\n", common.Dim.Sprint("Guests:"), len(event.Participants))
+	}
+
+	// Account (--all-grants)
+	if account != "" {
+		fmt.Printf("  %s %s\n", common.Dim.Sprint("Account:"), account)
+	}
+
+	// ID
+	fmt.Printf("  %s %s\n", common.Dim.Sprint("ID:"), common.Dim.Sprint(event.ID))
+	fmt.Println()
+}
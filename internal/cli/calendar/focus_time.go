@@ -29,7 +29,10 @@ automatically blocks focus time to protect deep work sessions.`,
   nylas calendar ai focus-time --analyze
 
   # Create focus blocks
-  nylas calendar ai focus-time --create`,
+  nylas calendar ai focus-time --create
+
+  # Show protected hours this week
+  nylas calendar ai focus-time status`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			enable, _ := cmd.Flags().GetBool("enable")
 			analyze, _ := cmd.Flags().GetBool("analyze")
@@ -38,8 +41,12 @@ automatically blocks focus time to protect deep work sessions.`,
 			autoDecline, _ := cmd.Flags().GetBool("auto-decline")
 			allowOverride, _ := cmd.Flags().GetBool("allow-override")
 
+			// Load config to get working hours - respect --config flag
+			cfg, _ := common.GetConfigStore(cmd).Load()
+
 			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				optimizer := analytics.NewFocusOptimizer(client)
+				optimizer.SetPatternStore(analytics.NewDefaultPatternFileStore())
 
 				// Create default settings
 				settings := &domain.FocusTimeSettings{
@@ -61,6 +68,9 @@ automatically blocks focus time to protect deep work sessions.`,
 						WeeklySummary:      true,
 					},
 				}
+				if cfg != nil {
+					settings.WorkingHours = cfg.WorkingHours
+				}
 
 				if analyze || enable {
 					return struct{}{}, runFocusTimeAnalysis(ctx, optimizer, grantID, settings)
@@ -83,9 +93,134 @@ automatically blocks focus time to protect deep work sessions.`,
 	cmd.Flags().Bool("auto-decline", false, "Auto-decline meeting requests during focus time")
 	cmd.Flags().Bool("allow-override", true, "Allow urgent meeting overrides")
 
+	cmd.AddCommand(newFocusTimeStatusCmd())
+
 	return cmd
 }
 
+// newFocusTimeStatusCmd creates the focus-time status command.
+func newFocusTimeStatusCmd() *cobra.Command {
+	var (
+		targetHours float64
+		apply       bool
+		noReconcile bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status [grant-id]",
+		Short: "Show protected focus hours this week",
+		Long: `Report focus time protected this week against your weekly target.
+
+Focus blocks created with --create are tagged so they can be found again.
+Upcoming blocks are also checked against your calendar: blocks that
+meetings land on are trimmed or moved, blocks are extended when room frees
+up, and blocks are removed when no focus time is left that day. Blocks
+only move within your configured working hours (9-5 if unset), and never
+into the past. Time overlapped by meetings does not count as protected.
+
+These changes are only previewed; pass --apply to make them.`,
+		Example: `  # Show status and preview changes to upcoming blocks
+  nylas calendar ai focus-time status
+
+  # Reconcile upcoming blocks with the calendar
+  nylas calendar ai focus-time status --apply
+
+  # Compare against a 10 hour target
+  nylas calendar ai focus-time status --target-hours 10`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if apply && noReconcile {
+				return common.NewUserError("--apply and --no-reconcile cannot be used together", "drop one of them")
+			}
+
+			// Load config to get working hours - respect --config flag
+			cfg, _ := common.GetConfigStore(cmd).Load()
+
+			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				optimizer := analytics.NewFocusOptimizer(client)
+				settings := &domain.FocusTimeSettings{
+					TargetHoursPerWeek: targetHours,
+					MinBlockDuration:   60,
+				}
+				if cfg != nil {
+					settings.WorkingHours = cfg.WorkingHours
+				}
+
+				status, err := optimizer.FocusTimeStatus(ctx, grantID, settings, !noReconcile, apply)
+				if err != nil {
+					return struct{}{}, common.WrapGetError("focus time status", err)
+				}
+
+				if common.IsJSON(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(status)
+				}
+				displayFocusTimeStatus(status, !apply)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().Float64Var(&targetHours, "target-hours", 14.0, "Target focus hours per week")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Write reconciliation changes to the calendar")
+	cmd.Flags().BoolVar(&noReconcile, "no-reconcile", false, "Report status without reconciling blocks")
+
+	return cmd
+}
+
+// displayFocusTimeStatus prints weekly focus protection and reconciliation changes.
+func displayFocusTimeStatus(status *domain.FocusTimeStatus, dryRun bool) {
+	fmt.Printf("\n🧠 Focus Time: week of %s\n", status.WeekStart.Format("Mon, Jan 2"))
+
+	pct := 0.0
+	if status.TargetHours > 0 {
+		pct = status.ProtectedHours / status.TargetHours
+	}
+	bars := min(int(pct*20), 20)
+	fmt.Printf("  %s%s %.1f / %.1f hours (%.0f%%)\n",
+		strings.Repeat("█", bars), strings.Repeat("░", 20-bars),
+		status.ProtectedHours, status.TargetHours, pct*100)
+
+	if len(status.Blocks) == 0 {
+		fmt.Println("\n  No focus blocks this week. Create some with:")
+		fmt.Println("    nylas calendar ai focus-time --create")
+	} else {
+		fmt.Println("\n📅 Focus Blocks:")
+		for _, block := range status.Blocks {
+			fmt.Printf("  • %s %s--%s (%d min)\n",
+				block.StartTime.Format("Mon Jan 2"),
+				block.StartTime.Format("3:04 PM"),
+				block.EndTime.Format("3:04 PM"),
+				block.Duration)
+		}
+	}
+
+	displayFocusBlockChanges(status.Changes, dryRun)
+}
+
+// displayFocusBlockChanges prints reconciliation decisions.
+func displayFocusBlockChanges(changes []domain.FocusBlockChange, dryRun bool) {
+	if len(changes) == 0 {
+		return
+	}
+
+	if dryRun {
+		fmt.Println("\n🔄 Proposed changes (run with --apply to make them):")
+	} else {
+		fmt.Println("\n🔄 Reconciled Focus Blocks:")
+	}
+	for _, change := range changes {
+		old := fmt.Sprintf("%s %s--%s", change.OldStart.Format("Mon Jan 2"),
+			change.OldStart.Format("3:04 PM"), change.OldEnd.Format("3:04 PM"))
+		if change.Action == domain.FocusBlockRemoved {
+			fmt.Printf("  • %s %s: %s\n", change.Action, old, change.Reason)
+			continue
+		}
+		fmt.Printf("  • %s %s → %s--%s: %s\n", change.Action, old,
+			change.NewStart.Format("3:04 PM"), change.NewEnd.Format("3:04 PM"), change.Reason)
+	}
+}
+
 // runFocusTimeAnalysis analyzes productivity patterns and shows recommendations.
 func runFocusTimeAnalysis(ctx context.Context, optimizer *analytics.FocusOptimizer, grantID string, settings *domain.FocusTimeSettings) error {
 	fmt.Println("\n🧠 AI Focus Time Protection")
@@ -137,6 +272,8 @@ func runFocusTimeAnalysis(ctx context.Context, optimizer *analytics.FocusOptimiz
 	}
 
 	fmt.Printf("\nTotal: %.1f hours/week protected for focus time\n", totalHours)
+	fmt.Printf("Currently protected this week: %.1f of %.1f hours\n",
+		analysis.CurrentProtection, settings.TargetHoursPerWeek)
 
 	// Protection Rules
 	fmt.Println("\n🛡️  Protection Rules:")
@@ -232,12 +369,24 @@ func runCreateFocusBlocks(ctx context.Context, optimizer *analytics.FocusOptimiz
 		return nil
 	}
 
+	// Bring blocks from earlier runs in line with the calendar first
+	changes, err := optimizer.ReconcileFocusBlocks(ctx, grantID, settings, true)
+	if err != nil {
+		return fmt.Errorf("reconcile focus blocks: %w", err)
+	}
+	displayFocusBlockChanges(changes, false)
+
 	// Create the blocks
 	protectedBlocks, err := optimizer.CreateProtectedBlocks(ctx, grantID, analysis.RecommendedBlocks, settings)
 	if err != nil {
 		return common.WrapCreateError("protected blocks", err)
 	}
 
+	if len(protectedBlocks) == 0 {
+		fmt.Println("✅ All recommended focus blocks are already on your calendar.")
+		return nil
+	}
+
 	fmt.Printf("✅ Created %d focus time blocks:\n\n", len(protectedBlocks))
 
 	for i, block := range protectedBlocks {
@@ -284,6 +433,7 @@ suggests optimizations to protect focus time and reduce meeting overload.`,
 
 			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				optimizer := analytics.NewFocusOptimizer(client)
+				optimizer.SetPatternStore(analytics.NewDefaultPatternFileStore())
 
 				// Parse trigger
 				trigger := parseTrigger(triggerStr)
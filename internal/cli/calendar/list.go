@@ -27,7 +27,7 @@ func newListCmd() *cobra.Command {
 			}
 
 			if len(calendars) == 0 {
-				if !common.IsQuiet() && !common.IsJSON(cmd) {
+				if !common.IsQuiet() && !common.IsStructuredOutput(cmd) {
 					common.PrintEmptyState("calendars")
 				}
 				return nil
@@ -40,7 +40,7 @@ func newListCmd() *cobra.Command {
 			}
 
 			// Table output with header
-			if !common.IsQuiet() {
+			if !common.IsQuiet() && !common.IsStructuredOutput(cmd) {
 				fmt.Printf("Found %d calendar(s):\n\n", len(calendars))
 			}
 
@@ -93,6 +93,14 @@ func TestGlobalFlags(t *testing.T) {
 			t.Error("Expected --config flag to exist")
 		}
 	})
+
+	t.Run("profile_flag_exists", func(t *testing.T) {
+		rootCmd := GetRootCmd()
+		flag := rootCmd.PersistentFlags().Lookup("profile")
+		if flag == nil {
+			t.Error("Expected --profile flag to exist")
+		}
+	})
 }
 
 // TestCommandDescriptions ensures all commands have proper descriptions.
@@ -1,9 +1,13 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mqasimca/nylas/internal/domain"
@@ -16,6 +20,7 @@ type CLIError struct {
 	Suggestion  string   // Single suggestion (deprecated, use Suggestions)
 	Suggestions []string // Multiple suggestions
 	Code        string
+	RequestID   string // Nylas request ID, for API errors
 }
 
 func (e *CLIError) Error() string {
@@ -36,8 +41,48 @@ const (
 	ErrCodeInvalidInput     = "E006"
 	ErrCodeRateLimited      = "E007"
 	ErrCodeServerError      = "E008"
+	ErrCodeConflict         = "E009"
 )
 
+// Process exit codes. Each error class has its own, so scripts can tell a
+// missing resource from an auth failure or an outage.
+const (
+	ExitOK            = 0
+	ExitError         = 1 // Unclassified failure
+	ExitInvalidInput  = 2
+	ExitNotConfigured = 3
+	ExitAuthFailed    = 4
+	ExitPermission    = 5
+	ExitNotFound      = 6
+	ExitConflict      = 7
+	ExitRateLimited   = 8
+	ExitServerError   = 9
+	ExitNetworkError  = 10
+)
+
+// exitCodes maps error codes to exit codes.
+var exitCodes = map[string]int{
+	ErrCodeNotConfigured:    ExitNotConfigured,
+	ErrCodeAuthFailed:       ExitAuthFailed,
+	ErrCodeNetworkError:     ExitNetworkError,
+	ErrCodeNotFound:         ExitNotFound,
+	ErrCodePermissionDenied: ExitPermission,
+	ErrCodeInvalidInput:     ExitInvalidInput,
+	ErrCodeRateLimited:      ExitRateLimited,
+	ErrCodeServerError:      ExitServerError,
+	ErrCodeConflict:         ExitConflict,
+}
+
+// notFoundErrors are the resource-specific not-found errors.
+var notFoundErrors = []error{
+	domain.ErrContactNotFound, domain.ErrEventNotFound, domain.ErrCalendarNotFound,
+	domain.ErrMessageNotFound, domain.ErrFolderNotFound, domain.ErrDraftNotFound,
+	domain.ErrThreadNotFound, domain.ErrAttachmentNotFound, domain.ErrWebhookNotFound,
+	domain.ErrNotetakerNotFound, domain.ErrTemplateNotFound, domain.ErrApplicationNotFound,
+	domain.ErrConnectorNotFound, domain.ErrCredentialNotFound, domain.ErrBookingNotFound,
+	domain.ErrSessionNotFound, domain.ErrConfigurationNotFound, domain.ErrPageNotFound,
+}
+
 // WrapError wraps an error with CLI-friendly context.
 func WrapError(err error) *CLIError {
 	if err == nil {
@@ -50,6 +95,11 @@ func WrapError(err error) *CLIError {
 		return cliErr
 	}
 
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		return wrapAPIError(err, apiErr)
+	}
+
 	// Map domain errors to CLI errors
 	switch {
 	case errors.Is(err, domain.ErrNotConfigured):
@@ -131,6 +181,23 @@ func WrapError(err error) *CLIError {
 			Suggestion: "Supported providers are 'google' and 'microsoft'",
 			Code:       ErrCodeInvalidInput,
 		}
+
+	case errors.Is(err, domain.ErrInvalidInput):
+		return &CLIError{
+			Err:     err,
+			Message: err.Error(),
+			Code:    ErrCodeInvalidInput,
+		}
+	}
+
+	for _, notFound := range notFoundErrors {
+		if errors.Is(err, notFound) {
+			return &CLIError{
+				Err:     err,
+				Message: err.Error(),
+				Code:    ErrCodeNotFound,
+			}
+		}
 	}
 
 	// Check for common error patterns in the error message
@@ -188,6 +255,110 @@ func WrapError(err error) *CLIError {
 	}
 }
 
+// wrapAPIError builds the CLI error for a failed API request, with
+// suggestions for its class. The message keeps the command's context.
+func wrapAPIError(err error, apiErr *domain.APIError) *CLIError {
+	cliErr := &CLIError{Err: err, Message: err.Error(), RequestID: apiErr.RequestID}
+
+	switch {
+	case errors.Is(apiErr, domain.ErrAPIUnauthorized):
+		cliErr.Code = ErrCodeAuthFailed
+		cliErr.Suggestions = []string{
+			"Check your API key with 'nylas auth status'",
+			"Update it with 'nylas auth config'",
+		}
+	case errors.Is(apiErr, domain.ErrAPIForbidden):
+		cliErr.Code = ErrCodePermissionDenied
+		cliErr.Suggestions = []string{
+			"Check the grant's scopes with 'nylas auth scopes'",
+			"Make sure the grant belongs to the application your API key is for",
+		}
+	case errors.Is(apiErr, domain.ErrAPINotFound):
+		cliErr.Code = ErrCodeNotFound
+		cliErr.Suggestion = "Check the ID, and that it belongs to the current account ('nylas auth whoami')"
+	case errors.Is(apiErr, domain.ErrAPIConflict):
+		cliErr.Code = ErrCodeConflict
+		cliErr.Suggestion = "The resource changed or already exists; fetch it again and retry"
+	case errors.Is(apiErr, domain.ErrAPIRateLimited):
+		cliErr.Code = ErrCodeRateLimited
+		cliErr.Suggestion = "Wait a moment and try again, or reduce the frequency of requests"
+		if apiErr.RetryAfter > 0 {
+			cliErr.Suggestion = fmt.Sprintf("Wait %s before retrying", apiErr.RetryAfter.Round(time.Second))
+		}
+	case errors.Is(apiErr, domain.ErrAPIServerError):
+		cliErr.Code = ErrCodeServerError
+		cliErr.Suggestions = []string{"This is usually temporary. Please try again in a few minutes"}
+		if apiErr.RequestID != "" {
+			cliErr.Suggestions = append(cliErr.Suggestions, "If it keeps happening, contact Nylas support with the request ID")
+		}
+	case apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity:
+		cliErr.Code = ErrCodeInvalidInput
+		cliErr.Suggestion = "Check the values passed to the command"
+	}
+	return cliErr
+}
+
+// ExitCode returns the process exit code for err's class.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if code, ok := exitCodes[WrapError(err).Code]; ok {
+		return code
+	}
+	return ExitError
+}
+
+// ErrorReport is the machine-readable form of an error, written to stderr
+// in JSON output mode.
+type ErrorReport struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes an error in an ErrorReport. The HTTP fields are
+// set for API errors.
+type ErrorDetail struct {
+	Message           string   `json:"message"`
+	Code              string   `json:"code,omitempty"`
+	ExitCode          int      `json:"exit_code"`
+	Status            int      `json:"status,omitempty"`
+	Type              string   `json:"type,omitempty"`
+	RequestID         string   `json:"request_id,omitempty"`
+	RetryAfterSeconds int      `json:"retry_after_seconds,omitempty"`
+	Suggestions       []string `json:"suggestions,omitempty"`
+}
+
+// NewErrorReport describes err for JSON output.
+func NewErrorReport(err error) ErrorReport {
+	cliErr := WrapError(err)
+	detail := ErrorDetail{
+		Message:     cliErr.Message,
+		Code:        cliErr.Code,
+		ExitCode:    ExitCode(err),
+		RequestID:   cliErr.RequestID,
+		Suggestions: cliErr.Suggestions,
+	}
+	if len(detail.Suggestions) == 0 && cliErr.Suggestion != "" {
+		detail.Suggestions = []string{cliErr.Suggestion}
+	}
+
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		detail.Status = apiErr.StatusCode
+		detail.Type = apiErr.Type
+		detail.RequestID = apiErr.RequestID
+		detail.RetryAfterSeconds = int(apiErr.RetryAfter.Round(time.Second) / time.Second)
+	}
+	return ErrorReport{Error: detail}
+}
+
+// PrintJSONError writes err to stderr as an ErrorReport.
+func PrintJSONError(err error) {
+	encoder := json.NewEncoder(os.Stderr)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(NewErrorReport(err))
+}
+
 // FormatError formats an error for CLI display.
 func FormatError(err error) string {
 	cliErr := WrapError(err)
@@ -204,6 +375,9 @@ func FormatError(err error) string {
 	if cliErr.Code != "" {
 		_, _ = Dim.Fprintf(&sb, "  Code: %s\n", cliErr.Code)
 	}
+	if cliErr.RequestID != "" {
+		_, _ = Dim.Fprintf(&sb, "  Request ID: %s\n", cliErr.RequestID)
+	}
 
 	// Multiple suggestions (preferred)
 	if len(cliErr.Suggestions) > 0 {
@@ -3,9 +3,12 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -366,3 +369,83 @@ func TestNewInputError_Extended(t *testing.T) {
 		})
 	}
 }
+
+// TestWrapError_APIError tests classification of typed API errors.
+func TestWrapError_APIError(t *testing.T) {
+	tests := []struct {
+		status   int
+		code     string
+		exitCode int
+	}{
+		{401, ErrCodeAuthFailed, ExitAuthFailed},
+		{403, ErrCodePermissionDenied, ExitPermission},
+		{404, ErrCodeNotFound, ExitNotFound},
+		{409, ErrCodeConflict, ExitConflict},
+		{429, ErrCodeRateLimited, ExitRateLimited},
+		{503, ErrCodeServerError, ExitServerError},
+		{400, ErrCodeInvalidInput, ExitInvalidInput},
+		{418, "", ExitError},
+	}
+
+	for _, tt := range tests {
+		apiErr := &domain.APIError{StatusCode: tt.status, Message: "boom", RequestID: "req-1"}
+		err := WrapFetchError("messages", apiErr)
+
+		cliErr := WrapError(err)
+		assert.Equal(t, tt.code, cliErr.Code, "status %d", tt.status)
+		assert.Equal(t, "req-1", cliErr.RequestID)
+		assert.Equal(t, "failed to fetch messages: nylas API error: boom", cliErr.Message)
+		assert.Equal(t, tt.exitCode, ExitCode(err), "status %d", tt.status)
+	}
+}
+
+// TestWrapError_RetryAfter tests that rate limit suggestions use Retry-After.
+func TestWrapError_RetryAfter(t *testing.T) {
+	cliErr := WrapError(&domain.APIError{StatusCode: 429, RetryAfter: 30 * time.Second})
+	assert.Equal(t, "Wait 30s before retrying", cliErr.Suggestion)
+}
+
+// TestExitCode tests exit codes for non-API errors.
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, ExitCode(nil))
+	assert.Equal(t, ExitError, ExitCode(errors.New("something broke")))
+	assert.Equal(t, ExitNotConfigured, ExitCode(domain.ErrNotConfigured))
+	assert.Equal(t, ExitNetworkError, ExitCode(fmt.Errorf("%w: dial tcp", domain.ErrNetworkError)))
+	assert.Equal(t, ExitNotFound, ExitCode(fmt.Errorf("%w: abc", domain.ErrMessageNotFound)))
+	assert.Equal(t, ExitInvalidInput, ExitCode(fmt.Errorf("%w: grant ID is required", domain.ErrInvalidInput)))
+	assert.Equal(t, ExitInvalidInput, ExitCode(NewUserErrorWithSuggestions("bad flag", "use --x")))
+}
+
+// TestNewErrorReport tests the JSON error object.
+func TestNewErrorReport(t *testing.T) {
+	err := WrapGetError("event", &domain.APIError{
+		StatusCode: 429,
+		Type:       "rate_limit_error",
+		Message:    "Too many requests",
+		RequestID:  "req-9",
+		RetryAfter: 5 * time.Second,
+	})
+
+	data, jsonErr := json.Marshal(NewErrorReport(err))
+	require.NoError(t, jsonErr)
+	assert.JSONEq(t, `{"error": {
+		"message": "failed to get event: nylas API error: Too many requests",
+		"code": "E007",
+		"exit_code": 8,
+		"status": 429,
+		"type": "rate_limit_error",
+		"request_id": "req-9",
+		"retry_after_seconds": 5,
+		"suggestions": ["Wait 5s before retrying"]
+	}}`, string(data))
+
+	plain := NewErrorReport(NewUserError("profile missing", "create it"))
+	assert.Equal(t, ErrorDetail{Message: "profile missing", ExitCode: ExitError, Suggestions: []string{"create it"}}, plain.Error)
+}
+
+// TestFormatError_RequestID tests that formatted errors show the request ID.
+func TestFormatError_RequestID(t *testing.T) {
+	out := FormatError(&domain.APIError{StatusCode: 500, RequestID: "req-42"})
+	assert.Contains(t, out, "Request ID: req-42")
+	assert.Contains(t, out, "contact Nylas support")
+}
@@ -27,6 +27,11 @@ func AddPageTokenFlag(cmd *cobra.Command, target *string) {
 	cmd.Flags().StringVar(target, "page-token", "", "Page token for pagination")
 }
 
+// AddAllGrantsFlag adds an --all-grants flag for querying every authenticated account.
+func AddAllGrantsFlag(cmd *cobra.Command, target *bool) {
+	cmd.Flags().BoolVar(target, "all-grants", false, "Query every authenticated account and merge the results")
+}
+
 // AddForceFlag adds a --force/-f flag for skipping confirmations.
 func AddForceFlag(cmd *cobra.Command, target *bool) {
 	cmd.Flags().BoolVarP(target, "force", "f", false, "Skip confirmation prompts")
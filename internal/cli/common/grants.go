@@ -0,0 +1,131 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/adapters/keyring"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// maxConcurrentGrants bounds how many grants are queried at once with --all-grants.
+const maxConcurrentGrants = 5
+
+// GrantResult is one grant's part of a query run across all grants.
+type GrantResult[T any] struct {
+	Grant domain.GrantInfo
+	Items []T
+	Err   error
+}
+
+// AccountName returns the label used for a grant in cross-grant output.
+func AccountName(grant domain.GrantInfo) string {
+	if grant.Email != "" {
+		return grant.Email
+	}
+	return grant.ID
+}
+
+// GetAllGrants returns every stored grant, for commands run with --all-grants.
+func GetAllGrants() ([]domain.GrantInfo, error) {
+	secretStore, err := keyring.NewSecretStore(config.DefaultConfigDir())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't access secret store: %w", err)
+	}
+	grants, err := keyring.NewGrantStore(secretStore).ListGrants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grants: %w", err)
+	}
+	if len(grants) == 0 {
+		return nil, NewUserError("no authenticated accounts", "Run: nylas auth login")
+	}
+	return grants, nil
+}
+
+// QueryGrants runs query for every grant concurrently and returns the results
+// in grant order. A failing grant doesn't stop the others.
+func QueryGrants[T any](ctx context.Context, grants []domain.GrantInfo, query func(ctx context.Context, grantID string) ([]T, error)) []GrantResult[T] {
+	results := make([]GrantResult[T], len(grants))
+	sem := make(chan struct{}, maxConcurrentGrants)
+	var wg sync.WaitGroup
+
+	for i, grant := range grants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			items, err := query(ctx, grant.ID)
+			results[i] = GrantResult[T]{Grant: grant, Items: items, Err: err}
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// MergeGrantResults tags every item with its grant and sorts the merged list
+// with less. Failed grants are reported to w; the error is only returned when
+// every grant failed.
+func MergeGrantResults[T, R any](w io.Writer, results []GrantResult[T], tag func(grant domain.GrantInfo, item T) R, less func(a, b R) bool) ([]R, error) {
+	var merged []R
+	var lastErr error
+	failed := 0
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			lastErr = result.Err
+			_, _ = fmt.Fprintf(w, "%s %s: %v\n", Yellow.Sprint("Warning:"), AccountName(result.Grant), result.Err)
+			continue
+		}
+		for _, item := range result.Items {
+			merged = append(merged, tag(result.Grant, item))
+		}
+	}
+
+	if failed > 0 && failed == len(results) {
+		return nil, lastErr
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return less(merged[i], merged[j]) })
+	return merged, nil
+}
+
+// WithAllGrants is WithClient for --all-grants: it runs fn for every stored
+// grant concurrently, tags each item with tag and merges the lists sorted by
+// less. Grants that fail are reported on the command's stderr.
+func WithAllGrants[T, R any](cmd *cobra.Command, fn func(ctx context.Context, client ports.NylasClient, grantID string) ([]T, error), tag func(grant domain.GrantInfo, item T) R, less func(a, b R) bool) ([]R, error) {
+	client, err := GetNylasClient()
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := GetAllGrants()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := CreateContext()
+	defer cancel()
+
+	results := QueryGrants(ctx, grants, func(ctx context.Context, grantID string) ([]T, error) {
+		return fn(ctx, client, grantID)
+	})
+	return MergeGrantResults(cmd.ErrOrStderr(), results, tag, less)
+}
+
+// CheckAllGrantsArgs rejects a grant ID argument alongside --all-grants.
+func CheckAllGrantsArgs(allGrants bool, grantArgs []string) error {
+	if allGrants && len(grantArgs) > 0 {
+		return NewUserError("--all-grants can't be combined with a grant ID", "Drop the grant ID to query every account")
+	}
+	return nil
+}
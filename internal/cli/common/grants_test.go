@@ -0,0 +1,93 @@
+//go:build !integration
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type taggedItem struct {
+	Account string
+	N       int
+}
+
+func tagItem(grant domain.GrantInfo, n int) taggedItem {
+	return taggedItem{Account: AccountName(grant), N: n}
+}
+
+func TestQueryGrants(t *testing.T) {
+	grants := []domain.GrantInfo{{ID: "g1", Email: "a@example.com"}, {ID: "g2"}, {ID: "g3", Email: "c@example.com"}}
+
+	var running, peak atomic.Int32
+	results := QueryGrants(context.Background(), grants, func(ctx context.Context, grantID string) ([]int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		switch grantID {
+		case "g1":
+			return []int{1, 5}, nil
+		case "g2":
+			return nil, errors.New("token expired")
+		default:
+			return []int{3}, nil
+		}
+	})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "g1", results[0].Grant.ID, "results keep grant order")
+	assert.Equal(t, []int{1, 5}, results[0].Items)
+	assert.EqualError(t, results[1].Err, "token expired")
+	assert.Greater(t, peak.Load(), int32(1), "grants should be queried concurrently")
+}
+
+func TestMergeGrantResults(t *testing.T) {
+	results := []GrantResult[int]{
+		{Grant: domain.GrantInfo{ID: "g1", Email: "a@example.com"}, Items: []int{1, 5}},
+		{Grant: domain.GrantInfo{ID: "g2"}, Err: errors.New("token expired")},
+		{Grant: domain.GrantInfo{ID: "g3", Email: "c@example.com"}, Items: []int{3}},
+	}
+
+	var stderr bytes.Buffer
+	merged, err := MergeGrantResults(&stderr, results, tagItem, func(a, b taggedItem) bool { return a.N > b.N })
+
+	require.NoError(t, err, "one failing grant shouldn't fail the command")
+	assert.Equal(t, []taggedItem{
+		{Account: "a@example.com", N: 5},
+		{Account: "c@example.com", N: 3},
+		{Account: "a@example.com", N: 1},
+	}, merged)
+	assert.Contains(t, stderr.String(), "g2: token expired")
+}
+
+func TestMergeGrantResults_AllFailed(t *testing.T) {
+	results := []GrantResult[int]{
+		{Grant: domain.GrantInfo{ID: "g1"}, Err: errors.New("first")},
+		{Grant: domain.GrantInfo{ID: "g2"}, Err: errors.New("second")},
+	}
+
+	var stderr bytes.Buffer
+	_, err := MergeGrantResults(&stderr, results, tagItem, func(a, b taggedItem) bool { return a.N < b.N })
+	assert.EqualError(t, err, "second")
+}
+
+func TestCheckAllGrantsArgs(t *testing.T) {
+	assert.NoError(t, CheckAllGrantsArgs(true, nil))
+	assert.NoError(t, CheckAllGrantsArgs(false, []string{"grant"}))
+	assert.Error(t, CheckAllGrantsArgs(true, []string{"grant"}))
+}
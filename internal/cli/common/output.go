@@ -11,7 +11,11 @@ import (
 // AddOutputFlags adds common output flags to a command
 // These flags are inherited by all subcommands when added to a parent
 func AddOutputFlags(cmd *cobra.Command) {
-	cmd.PersistentFlags().String("format", "", "Output format: table, json, yaml")
+	cmd.PersistentFlags().String("format", "", "Output format: table, json, yaml, csv, ndjson, template")
+	cmd.PersistentFlags().String("template", "", "Go template for each row, e.g. '{{.ID}}\\t{{.Subject}}' (implies --format template)")
+	cmd.PersistentFlags().StringSlice("columns", nil, "Columns or fields to output, in order (e.g. id,subject,date)")
+	cmd.PersistentFlags().String("sort", "", "Sort list output by a column or field; prefix with - for descending")
+	cmd.PersistentFlags().String("query", "", "JMESPath expression applied to the output data, e.g. \"[?unread].{id:id,subject:subject}\"")
 	cmd.PersistentFlags().Bool("json", false, "Output in JSON format")
 	cmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet mode - only output essential data (IDs)")
 	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
@@ -33,14 +37,27 @@ func GetOutputWriterTo(cmd *cobra.Command, w io.Writer) ports.OutputWriter {
 func GetOutputOptions(cmd *cobra.Command, w io.Writer) ports.OutputOptions {
 	format := getOutputFormat(cmd)
 	noColor, _ := cmd.Flags().GetBool("no-color")
+	tmpl, _ := cmd.Flags().GetString("template")
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	sortBy, _ := cmd.Flags().GetString("sort")
 
 	return ports.OutputOptions{
-		Format:  format,
-		NoColor: noColor,
-		Writer:  w,
+		Format:   format,
+		NoColor:  noColor,
+		Writer:   w,
+		Template: tmpl,
+		Columns:  columns,
+		Sort:     sortBy,
+		Query:    outputQuery(cmd),
 	}
 }
 
+// outputQuery returns the global --query expression.
+func outputQuery(cmd *cobra.Command) string {
+	query, _ := cmd.Flags().GetString("query")
+	return query
+}
+
 // getOutputFormat determines the output format from flags
 func getOutputFormat(cmd *cobra.Command) ports.OutputFormat {
 	// --quiet/-q takes precedence
@@ -64,9 +81,21 @@ func getOutputFormat(cmd *cobra.Command) ports.OutputFormat {
 		return ports.FormatYAML
 	case "quiet":
 		return ports.FormatQuiet
-	default:
+	case "csv":
+		return ports.FormatCSV
+	case "ndjson", "jsonl":
+		return ports.FormatNDJSON
+	case "template":
+		return ports.FormatTemplate
+	case "table":
 		return ports.FormatTable
 	}
+
+	// --template on its own implies template output
+	if tmpl, _ := cmd.Flags().GetString("template"); tmpl != "" {
+		return ports.FormatTemplate
+	}
+	return ports.FormatTable
 }
 
 // IsJSON returns true if JSON output is enabled
@@ -79,6 +108,21 @@ func IsJSON(cmd *cobra.Command) bool {
 	return format == "json"
 }
 
+// IsStructuredOutput returns true when output goes through the output writer
+// (JSON, YAML, CSV, NDJSON, a template, or any format with --query) instead
+// of a command's own display.
+func IsStructuredOutput(cmd *cobra.Command) bool {
+	if outputQuery(cmd) != "" {
+		return true
+	}
+	switch getOutputFormat(cmd) {
+	case ports.FormatTable, ports.FormatQuiet:
+		return false
+	default:
+		return true
+	}
+}
+
 // IsWide returns true if wide output mode is enabled
 func IsWide(cmd *cobra.Command) bool {
 	wide, _ := cmd.Flags().GetBool("wide")
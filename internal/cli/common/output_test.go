@@ -0,0 +1,63 @@
+//go:build !integration
+
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOutputCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+	AddOutputFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestGetOutputFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		expected   ports.OutputFormat
+		structured bool
+	}{
+		{"default", nil, ports.FormatTable, false},
+		{"json flag", []string{"--json"}, ports.FormatJSON, true},
+		{"quiet wins", []string{"--quiet", "--format", "csv"}, ports.FormatQuiet, false},
+		{"csv", []string{"--format", "csv"}, ports.FormatCSV, true},
+		{"ndjson", []string{"--format", "ndjson"}, ports.FormatNDJSON, true},
+		{"jsonl alias", []string{"--format", "jsonl"}, ports.FormatNDJSON, true},
+		{"template flag implies template", []string{"--template", "{{.ID}}"}, ports.FormatTemplate, true},
+		{"explicit table beats template", []string{"--format", "table", "--template", "{{.ID}}"}, ports.FormatTable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newOutputCmd(t, tt.args...)
+			assert.Equal(t, tt.expected, getOutputFormat(cmd))
+			assert.Equal(t, tt.structured, IsStructuredOutput(cmd))
+		})
+	}
+}
+
+func TestGetOutputOptions_ColumnsAndSort(t *testing.T) {
+	cmd := newOutputCmd(t, "--format", "csv", "--columns", "id,subject", "--sort", "-date", "--template", "{{.ID}}")
+
+	opts := GetOutputOptions(cmd, &bytes.Buffer{})
+	assert.Equal(t, ports.FormatCSV, opts.Format)
+	assert.Equal(t, []string{"id", "subject"}, opts.Columns)
+	assert.Equal(t, "-date", opts.Sort)
+	assert.Equal(t, "{{.ID}}", opts.Template)
+}
+
+func TestOutputQuery(t *testing.T) {
+	cmd := newOutputCmd(t, "--query", "[].id")
+	assert.Equal(t, "[].id", GetOutputOptions(cmd, &bytes.Buffer{}).Query)
+	assert.True(t, IsStructuredOutput(cmd), "--query routes table output through the writer")
+}
@@ -2,9 +2,14 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/spf13/cobra"
 )
 
 // PageResult represents a paginated API response.
@@ -29,6 +34,7 @@ type PaginationConfig struct {
 	MaxPages     int       // Maximum pages to fetch (0 = unlimited)
 	ShowProgress bool      // Show progress indicator
 	Writer       io.Writer // Output writer for progress
+	StartCursor  string    // Cursor to start from, e.g. to resume an interrupted listing
 }
 
 // DefaultPaginationConfig returns default pagination settings.
@@ -42,68 +48,230 @@ func DefaultPaginationConfig() PaginationConfig {
 	}
 }
 
+// PaginationInterruptedError reports a listing that stopped part way through,
+// with the cursor it can be resumed from.
+type PaginationInterruptedError struct {
+	Cursor  string // Cursor of the first page not written
+	Fetched int    // Items written before stopping
+	Err     error  // Why it stopped
+}
+
+func (e *PaginationInterruptedError) Error() string {
+	reason := "interrupted"
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		reason = "timed out"
+	}
+	return fmt.Sprintf("%s after %d items; resume with --page-token %s", reason, e.Fetched, e.Cursor)
+}
+
+func (e *PaginationInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// pageFetch is one fetched page, or the error fetching it.
+type pageFetch[T any] struct {
+	page PageResult[T]
+	err  error
+}
+
+// PageIterator yields pages as they arrive. The next page is fetched in the
+// background while the caller handles the current one.
+type PageIterator[T any] struct {
+	ctx    context.Context
+	stop   context.CancelFunc
+	closed chan struct{}
+	pages  chan pageFetch[T]
+	cursor string // Cursor of the page after the last one returned
+	more   bool
+	count  int
+	err    error
+}
+
+// NewPageIterator starts fetching pages from cursor, stopping after maxPages
+// pages (0 = unlimited). Close must be called when done.
+func NewPageIterator[T any](ctx context.Context, fetcher PageFetcher[T], cursor string, maxPages int) *PageIterator[T] {
+	fetchCtx, stop := context.WithCancel(ctx)
+	it := &PageIterator[T]{
+		ctx:    ctx,
+		stop:   stop,
+		closed: make(chan struct{}),
+		pages:  make(chan pageFetch[T]),
+		cursor: cursor,
+		more:   true,
+	}
+
+	go func() {
+		defer close(it.pages)
+		for n := 0; maxPages == 0 || n < maxPages; n++ {
+			var f pageFetch[T]
+			if err := ctx.Err(); err != nil {
+				f.err = err
+			} else {
+				f.page, f.err = fetcher(fetchCtx, cursor)
+			}
+
+			select {
+			case it.pages <- f:
+			case <-it.closed:
+				return
+			}
+			if f.err != nil || !f.page.HasMore() {
+				return
+			}
+			cursor = f.page.NextCursor
+		}
+	}()
+	return it
+}
+
+// Next returns the next page. It returns false when there are no more pages,
+// on error, or when the context is done; Err tells them apart.
+func (it *PageIterator[T]) Next() (PageResult[T], bool) {
+	if it.err != nil || !it.more {
+		return PageResult[T]{}, false
+	}
+
+	f, ok := <-it.pages
+	switch {
+	case !ok:
+		it.more = false
+	case f.err != nil && it.ctx.Err() != nil:
+		it.err = it.ctx.Err()
+	case f.err != nil:
+		it.err = fmt.Errorf("failed to fetch page %d: %w", it.count+1, f.err)
+	default:
+		it.count++
+		it.cursor = f.page.NextCursor
+		it.more = f.page.HasMore()
+		return f.page, true
+	}
+	return PageResult[T]{}, false
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor of the next page not yet returned by Next, or
+// "" when every page has been returned.
+func (it *PageIterator[T]) Cursor() string {
+	if !it.more {
+		return ""
+	}
+	return it.cursor
+}
+
+// Close stops the background fetch. The page being fetched, if any, is dropped.
+func (it *PageIterator[T]) Close() {
+	select {
+	case <-it.closed:
+	default:
+		it.stop()
+		close(it.closed)
+	}
+}
+
 // FetchAllPages fetches all pages using the provided fetcher function.
 func FetchAllPages[T any](ctx context.Context, config PaginationConfig, fetcher PageFetcher[T]) ([]T, error) {
 	var results []T
-	cursor := ""
-	pageCount := 0
+	_, err := StreamAllPages(ctx, config, fetcher, func(page []T) error {
+		results = append(results, page...)
+		return nil
+	})
+
+	// The caller gets the partial results, not a cursor to resume from
+	var interrupted *PaginationInterruptedError
+	if errors.As(err, &interrupted) {
+		return results, interrupted.Err
+	}
+	return results, err
+}
+
+// StreamAllPages fetches pages like FetchAllPages but hands each page to emit
+// as it arrives instead of collecting them, prefetching the next page while
+// emit runs. It returns the number of items emitted. If ctx is done before the
+// last page, the error is a *PaginationInterruptedError with the resume cursor.
+func StreamAllPages[T any](ctx context.Context, config PaginationConfig, fetcher PageFetcher[T], emit func(page []T) error) (int, error) {
+	total := 0
 
 	var counter *Counter
 	if config.ShowProgress && !IsQuiet() {
 		counter = NewCounter("Fetching items")
+		defer counter.Finish()
 	}
 
+	it := NewPageIterator(ctx, fetcher, config.StartCursor, config.MaxPages)
+	defer it.Close()
+
 	for {
-		// Check context cancellation
-		select {
-		case <-ctx.Done():
-			return results, ctx.Err()
-		default:
+		page, ok := it.Next()
+		if !ok {
+			break
 		}
 
-		// Fetch the next page
-		page, err := fetcher(ctx, cursor)
-		if err != nil {
-			if counter != nil {
-				counter.Finish()
-			}
-			return results, fmt.Errorf("failed to fetch page %d: %w", pageCount+1, err)
+		// Trim the page if it would go past the limit
+		data := page.Data
+		if config.MaxItems > 0 && total+len(data) > config.MaxItems {
+			data = data[:config.MaxItems-total]
 		}
-
-		// Append results
-		results = append(results, page.Data...)
-		pageCount++
+		if err := emit(data); err != nil {
+			return total, err
+		}
+		total += len(data)
 
 		// Update progress
 		if counter != nil {
-			for range page.Data {
+			for range data {
 				counter.Increment()
 			}
 		}
 
 		// Check if we've reached the limit
-		if config.MaxItems > 0 && len(results) >= config.MaxItems {
-			results = results[:config.MaxItems]
-			break
+		if config.MaxItems > 0 && total >= config.MaxItems {
+			return total, nil
 		}
+	}
 
-		if config.MaxPages > 0 && pageCount >= config.MaxPages {
-			break
+	if err := it.Err(); err != nil {
+		if ctx.Err() != nil && it.Cursor() != "" {
+			return total, &PaginationInterruptedError{Cursor: it.Cursor(), Fetched: total, Err: ctx.Err()}
 		}
+		return total, err
+	}
+	return total, nil
+}
 
-		// Check if there are more pages
-		if !page.HasMore() {
-			break
-		}
+// WithInterrupt returns a context that is canceled on Ctrl-C, so a streamed
+// listing can stop cleanly and report where to resume.
+func WithInterrupt(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt)
+}
 
-		cursor = page.NextCursor
+// FetchAllOrStream fetches every page, or writes the list to the command's
+// output as each page arrives when the output writer can stream. streamed
+// reports that the list was already written and items is empty. Ctrl-C stops
+// a streamed list with a *PaginationInterruptedError, after closing the list.
+func FetchAllOrStream[T any](ctx context.Context, cmd *cobra.Command, config PaginationConfig, fetcher PageFetcher[T], columns []ports.Column) (items []T, streamed bool, err error) {
+	streamer, ok := GetOutputWriter(cmd).(ports.ListStreamer)
+	if !ok {
+		items, err = FetchAllPages(ctx, config, fetcher)
+		return items, false, err
 	}
 
-	if counter != nil {
-		counter.Finish()
-	}
+	ctx, stop := WithInterrupt(ctx)
+	defer stop()
 
-	return results, nil
+	// Progress would interleave with the rows
+	config.ShowProgress = false
+	list := streamer.StreamList(columns)
+	_, err = StreamAllPages(ctx, config, fetcher, func(page []T) error {
+		return list.WriteRows(page)
+	})
+	if closeErr := list.Close(); err == nil {
+		err = closeErr
+	}
+	return nil, true, err
 }
 
 // FetchAllWithProgress fetches all pages and shows a progress indicator.
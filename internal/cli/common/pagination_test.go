@@ -387,3 +387,173 @@ func TestFetchAllPages_ContextDeadline(t *testing.T) {
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 	assert.Empty(t, results)
 }
+
+func TestStreamAllPages(t *testing.T) {
+	ResetLogger()
+	InitLogger(false, true)
+
+	config := DefaultPaginationConfig()
+	config.ShowProgress = false
+	config.MaxItems = 3
+
+	fetcher := func(ctx context.Context, cursor string) (PageResult[string], error) {
+		if cursor == "" {
+			return PageResult[string]{Data: []string{"a", "b"}, NextCursor: "next"}, nil
+		}
+		return PageResult[string]{Data: []string{"c", "d"}, NextCursor: "more"}, nil
+	}
+
+	var pages [][]string
+	total, err := StreamAllPages(context.Background(), config, fetcher, func(page []string) error {
+		pages = append(pages, page)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}}, pages)
+}
+
+func TestStreamAllPages_EmitError(t *testing.T) {
+	config := DefaultPaginationConfig()
+	config.ShowProgress = false
+
+	fetcher := func(ctx context.Context, cursor string) (PageResult[string], error) {
+		return PageResult[string]{Data: []string{"a"}, NextCursor: "more"}, nil
+	}
+
+	emitErr := errors.New("write failed")
+	_, err := StreamAllPages(context.Background(), config, fetcher, func([]string) error { return emitErr })
+	assert.ErrorIs(t, err, emitErr)
+}
+
+func TestFetchAllOrStream(t *testing.T) {
+	ResetLogger()
+	InitLogger(false, true)
+
+	config := DefaultPaginationConfig()
+	config.ShowProgress = false
+
+	fetcher := func(ctx context.Context, cursor string) (PageResult[map[string]string], error) {
+		if cursor == "" {
+			return PageResult[map[string]string]{Data: []map[string]string{{"id": "1"}}, NextCursor: "next"}, nil
+		}
+		return PageResult[map[string]string]{Data: []map[string]string{{"id": "2"}}}, nil
+	}
+
+	t.Run("ndjson streams", func(t *testing.T) {
+		cmd := newOutputCmd(t, "--format", "ndjson")
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+
+		items, streamed, err := FetchAllOrStream(context.Background(), cmd, config, fetcher, nil)
+		require.NoError(t, err)
+		assert.True(t, streamed)
+		assert.Empty(t, items)
+		assert.Equal(t, "{\"id\":\"1\"}\n{\"id\":\"2\"}\n", out.String())
+	})
+
+	t.Run("json streams an array", func(t *testing.T) {
+		cmd := newOutputCmd(t, "--json")
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+
+		_, streamed, err := FetchAllOrStream(context.Background(), cmd, config, fetcher, nil)
+		require.NoError(t, err)
+		assert.True(t, streamed)
+		assert.JSONEq(t, `[{"id":"1"},{"id":"2"}]`, out.String())
+	})
+
+	t.Run("sorted output buffers", func(t *testing.T) {
+		cmd := newOutputCmd(t, "--json", "--sort", "-id")
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+
+		items, streamed, err := FetchAllOrStream(context.Background(), cmd, config, fetcher, nil)
+		require.NoError(t, err)
+		assert.False(t, streamed)
+		assert.Len(t, items, 2)
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestStreamAllPages_Interrupted(t *testing.T) {
+	ResetLogger()
+	InitLogger(false, true)
+
+	config := DefaultPaginationConfig()
+	config.ShowProgress = false
+	config.StartCursor = "p1"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetcher := func(ctx context.Context, cursor string) (PageResult[string], error) {
+		if cursor == "p3" {
+			<-ctx.Done() // A request still in flight when Ctrl-C is pressed
+			return PageResult[string]{}, ctx.Err()
+		}
+		next := map[string]string{"p1": "p2", "p2": "p3", "p3": "p4"}[cursor]
+		return PageResult[string]{Data: []string{cursor + "a", cursor + "b"}, NextCursor: next}, nil
+	}
+
+	var got []string
+	total, err := StreamAllPages(ctx, config, fetcher, func(page []string) error {
+		got = append(got, page...)
+		if len(got) == 4 {
+			cancel() // Ctrl-C while the second page is written
+		}
+		return nil
+	})
+
+	var interrupted *PaginationInterruptedError
+	require.ErrorAs(t, err, &interrupted)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "p3", interrupted.Cursor, "resume from the first page not written")
+	assert.Equal(t, 4, interrupted.Fetched)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []string{"p1a", "p1b", "p2a", "p2b"}, got)
+	assert.Contains(t, err.Error(), "resume with --page-token p3")
+}
+
+func TestPageIterator_Prefetches(t *testing.T) {
+	fetched := make(chan string, 3)
+	fetcher := func(ctx context.Context, cursor string) (PageResult[int], error) {
+		fetched <- cursor
+		if cursor == "" {
+			return PageResult[int]{Data: []int{1}, NextCursor: "2"}, nil
+		}
+		return PageResult[int]{Data: []int{2}}, nil
+	}
+
+	it := NewPageIterator(context.Background(), fetcher, "", 0)
+	defer it.Close()
+
+	page, ok := it.Next()
+	require.True(t, ok)
+	assert.Equal(t, []int{1}, page.Data)
+	assert.Equal(t, "2", it.Cursor())
+
+	// The second page is fetched before it's asked for
+	select {
+	case cursor := <-fetched:
+		assert.Equal(t, "", cursor)
+	case <-time.After(time.Second):
+		t.Fatal("first page was not fetched")
+	}
+	select {
+	case cursor := <-fetched:
+		assert.Equal(t, "2", cursor)
+	case <-time.After(time.Second):
+		t.Fatal("second page was not prefetched")
+	}
+
+	page, ok = it.Next()
+	require.True(t, ok)
+	assert.Equal(t, []int{2}, page.Data)
+	assert.Empty(t, it.Cursor())
+
+	_, ok = it.Next()
+	assert.False(t, ok)
+	assert.NoError(t, it.Err())
+}
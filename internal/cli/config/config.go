@@ -9,10 +9,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-var configStore *config.FileStore
-
-func init() {
-	configStore = config.NewDefaultFileStore()
+// configStore returns the config file store for the active profile.
+func configStore() *config.FileStore {
+	return config.NewDefaultFileStore()
 }
 
 // NewConfigCmd creates the config command.
@@ -23,7 +22,9 @@ func NewConfigCmd() *cobra.Command {
 		Long: `Manage Nylas CLI configuration settings.
 
 Configuration is stored in ~/.config/nylas/config.yaml by default.
-If the config file doesn't exist, sensible defaults are used automatically.`,
+If the config file doesn't exist, sensible defaults are used automatically.
+Named profiles (see 'nylas config profile') keep theirs under
+~/.config/nylas/profiles/<name>/.`,
 		Example: `  # Show all configuration
   nylas config list
 
@@ -37,7 +38,10 @@ If the config file doesn't exist, sensible defaults are used automatically.`,
   nylas config set default_grant grant_abc123
 
   # Initialize config with defaults
-  nylas config init`,
+  nylas config init
+
+  # Work with a second Nylas application
+  nylas config profile create staging --region eu`,
 	}
 
 	cmd.AddCommand(newListCmd())
@@ -45,6 +49,7 @@ If the config file doesn't exist, sensible defaults are used automatically.`,
 	cmd.AddCommand(newSetCmd())
 	cmd.AddCommand(newInitCmd())
 	cmd.AddCommand(newPathCmd())
+	cmd.AddCommand(newProfileCmd())
 
 	return cmd
 }
@@ -56,7 +61,7 @@ func newListCmd() *cobra.Command {
 		Short:   "Show all configuration",
 		Long:    "Display all configuration settings in YAML format.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := configStore.Load()
+			cfg, err := configStore().Load()
 			if err != nil {
 				return common.WrapLoadError("configuration", err)
 			}
@@ -78,8 +83,8 @@ func newPathCmd() *cobra.Command {
 		Short: "Show configuration file path",
 		Long:  "Display the path to the configuration file.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Println(configStore.Path())
-			if !configStore.Exists() {
+			fmt.Println(configStore().Path())
+			if !configStore().Exists() {
 				fmt.Println(common.Yellow.Sprint("(file does not exist yet - using defaults)"))
 			}
 			return nil
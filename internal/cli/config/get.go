@@ -32,7 +32,7 @@ Examples of keys:
   nylas config get output.format`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := configStore.Load()
+			cfg, err := configStore().Load()
 			if err != nil {
 				return common.WrapLoadError("configuration", err)
 			}
@@ -62,9 +62,7 @@ func getConfigValue(cfg any, key string) (string, error) {
 			return "", fmt.Errorf("cannot access field %s", part)
 		}
 
-		// Convert snake_case to PascalCase for struct fields
-		fieldName := snakeToPascal(part)
-		field := v.FieldByName(fieldName)
+		field := configField(v, part)
 
 		if !field.IsValid() {
 			return "", fmt.Errorf("unknown config key: %s", key)
@@ -84,6 +82,18 @@ func getConfigValue(cfg any, key string) (string, error) {
 	return fmt.Sprintf("%v", v.Interface()), nil
 }
 
+// configField finds the struct field for a config key: the field whose yaml
+// name matches, or else the field named after the key in PascalCase.
+func configField(v reflect.Value, key string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name == key {
+			return v.Field(i)
+		}
+	}
+	return v.FieldByName(snakeToPascal(key))
+}
+
 func snakeToPascal(s string) string {
 	parts := strings.Split(s, "_")
 	for i, part := range parts {
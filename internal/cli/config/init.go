@@ -23,17 +23,17 @@ This will NOT overwrite an existing configuration file unless --force is used.`,
   # Force overwrite existing config
   nylas config init --force`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if configStore.Exists() && !force {
-				return fmt.Errorf("configuration file already exists at %s\nUse --force to overwrite", configStore.Path())
+			if configStore().Exists() && !force {
+				return fmt.Errorf("configuration file already exists at %s\nUse --force to overwrite", configStore().Path())
 			}
 
 			cfg := domain.DefaultConfig()
-			if err := configStore.Save(cfg); err != nil {
+			if err := configStore().Save(cfg); err != nil {
 				return common.WrapSaveError("configuration", err)
 			}
 
 			fmt.Printf("%s Configuration initialized with defaults\n", common.Green.Sprint("✓"))
-			fmt.Printf("Config file: %s\n", configStore.Path())
+			fmt.Printf("Config file: %s\n", configStore().Path())
 			fmt.Println("\nEdit with: nylas config list")
 			return nil
 		},
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/adapters/keyring"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+func newProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "profile",
+		Aliases: []string{"profiles"},
+		Short:   "Manage configuration profiles",
+		Long: `Manage named configuration profiles.
+
+Each profile has its own region or API URL, credentials and authenticated
+accounts, so one install can work against several Nylas applications (for
+example staging and production, in the US and EU).
+
+Select a profile for one command with --profile or NYLAS_PROFILE, or make it
+the default with 'nylas config profile use'. The "default" profile is the
+original top-level configuration.`,
+		Example: `  # Create a profile for an EU staging application and add its credentials
+  nylas config profile create staging-eu --region eu
+  nylas --profile staging-eu auth config
+
+  # Run one command against it
+  nylas --profile staging-eu email list
+
+  # Switch to it for every command
+  nylas config profile use staging-eu`,
+	}
+
+	cmd.AddCommand(newProfileCreateCmd())
+	cmd.AddCommand(newProfileListCmd())
+	cmd.AddCommand(newProfileUseCmd())
+	cmd.AddCommand(newProfileDeleteCmd())
+
+	return cmd
+}
+
+func newProfileCreateCmd() *cobra.Command {
+	var (
+		region string
+		apiURL string
+		use    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a profile",
+		Long: `Create a profile with its own region or API URL.
+
+Credentials and accounts start empty; add them by running 'nylas auth config'
+and 'nylas auth login' with --profile.`,
+		Example: `  # US production
+  nylas config profile create prod
+
+  # EU staging, used from now on
+  nylas config profile create staging-eu --region eu --use
+
+  # Custom API endpoint
+  nylas config profile create local --api-url http://localhost:8080`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if region != "us" && region != "eu" {
+				return common.NewUserError(fmt.Sprintf("invalid region %q", region), "Use --region us or --region eu")
+			}
+			if err := config.CreateProfile(name); err != nil {
+				return common.NewUserError(err.Error(), "See existing profiles with: nylas config profile list")
+			}
+
+			cfg := domain.DefaultConfig()
+			cfg.Region = region
+			if apiURL != "" {
+				cfg.API = &domain.APIConfig{BaseURL: apiURL}
+			}
+			if err := config.NewProfileFileStore(name).Save(cfg); err != nil {
+				return common.WrapSaveError("configuration", err)
+			}
+
+			if use {
+				if err := config.SetCurrentProfile(name); err != nil {
+					return common.WrapSaveError("current profile", err)
+				}
+			}
+
+			common.PrintSuccess("Profile %s created", name)
+			fmt.Printf("Config file: %s\n", config.NewProfileFileStore(name).Path())
+			fmt.Printf("\nAdd credentials with: nylas --profile %s auth config\n", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&region, "region", "us", "API region: us or eu")
+	cmd.Flags().StringVar(&apiURL, "api-url", "", "Custom API base URL (overrides region)")
+	cmd.Flags().BoolVar(&use, "use", false, "Make it the current profile")
+
+	return cmd
+}
+
+// profileSummary describes a profile for `config profile list`.
+type profileSummary struct {
+	Name      string `json:"name" yaml:"name"`
+	Current   bool   `json:"current" yaml:"current"`
+	Region    string `json:"region" yaml:"region"`
+	APIURL    string `json:"api_url,omitempty" yaml:"api_url,omitempty"`
+	HasAPIKey bool   `json:"has_api_key" yaml:"has_api_key"`
+	Grants    int    `json:"grants" yaml:"grants"`
+	Path      string `json:"config_path" yaml:"config_path"`
+}
+
+// summarizeProfile loads a profile's config and checks its secrets.
+func summarizeProfile(name, active string) profileSummary {
+	store := config.NewProfileFileStore(name)
+	summary := profileSummary{Name: name, Current: name == active, Path: store.Path()}
+
+	cfg, err := store.Load()
+	if err != nil {
+		cfg = domain.DefaultConfig()
+	}
+	summary.Region = cfg.Region
+	if cfg.API != nil {
+		summary.APIURL = cfg.API.BaseURL
+	}
+
+	if secrets, err := keyring.OpenProfileSecretStore(name, config.ProfileDir(name), nil); err == nil {
+		apiKey, _ := secrets.Get(ports.KeyAPIKey)
+		summary.HasAPIKey = apiKey != ""
+		if grants, err := keyring.NewGrantStore(secrets).ListGrants(); err == nil {
+			summary.Grants = len(grants)
+		}
+	}
+	return summary
+}
+
+func newProfileListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List profiles",
+		Long:    "List profiles with their region, credentials and account count. The active profile is marked with *.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := config.ListProfiles()
+			if err != nil {
+				return common.WrapListError("profiles", err)
+			}
+
+			active := config.ActiveProfile()
+			summaries := make([]profileSummary, 0, len(names))
+			for _, name := range names {
+				summaries = append(summaries, summarizeProfile(name, active))
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(summaries)
+			}
+
+			table := common.NewTable("", "NAME", "REGION", "API URL", "API KEY", "ACCOUNTS")
+			for _, p := range summaries {
+				marker, apiKey, apiURL := " ", "-", "-"
+				if p.Current {
+					marker = common.Green.Sprint("*")
+				}
+				if p.HasAPIKey {
+					apiKey = common.Green.Sprint("✓")
+				}
+				if p.APIURL != "" {
+					apiURL = p.APIURL
+				}
+				table.AddRow(marker, p.Name, p.Region, apiURL, apiKey, fmt.Sprintf("%d", p.Grants))
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+func newProfileUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the current profile",
+		Long: `Set the profile used when neither --profile nor NYLAS_PROFILE is given.
+
+Use "default" to go back to the top-level configuration.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !config.ProfileExists(name) {
+				return common.NewUserError(fmt.Sprintf("profile %q does not exist", name), fmt.Sprintf("Create it with: nylas config profile create %s", name))
+			}
+			if err := config.SetCurrentProfile(name); err != nil {
+				return common.WrapSaveError("current profile", err)
+			}
+
+			common.PrintSuccess("Now using profile %s", name)
+			return nil
+		},
+	}
+}
+
+func newProfileDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a profile",
+		Long: `Delete a profile's configuration, credentials and stored accounts.
+
+Grants on the Nylas side are not revoked. The default profile can't be deleted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if name == config.DefaultProfile {
+				return common.NewUserError("the default profile can't be deleted", "Remove its credentials with: nylas auth logout")
+			}
+			if !config.ProfileExists(name) {
+				return common.NewUserError(fmt.Sprintf("profile %q does not exist", name), "See profiles with: nylas config profile list")
+			}
+			if !yes && !common.Confirm(fmt.Sprintf("Delete profile %s and its stored credentials?", name), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+
+			keyring.DeleteProfileKeyring(name)
+			if err := config.DeleteProfile(name); err != nil {
+				return common.WrapDeleteError("profile", err)
+			}
+
+			common.PrintSuccess("Profile %s deleted", name)
+			return nil
+		},
+	}
+
+	common.AddYesFlag(cmd, &yes)
+
+	return cmd
+}
@@ -30,7 +30,7 @@ The configuration file will be created if it doesn't exist.`,
   nylas config set output.color never`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := configStore.Load()
+			cfg, err := configStore().Load()
 			if err != nil {
 				return common.WrapLoadError("configuration", err)
 			}
@@ -42,12 +42,12 @@ The configuration file will be created if it doesn't exist.`,
 				return err
 			}
 
-			if err := configStore.Save(cfg); err != nil {
+			if err := configStore().Save(cfg); err != nil {
 				return common.WrapSaveError("configuration", err)
 			}
 
 			fmt.Printf("%s Configuration updated: %s = %s\n", common.Green.Sprint("✓"), key, value)
-			fmt.Printf("Config file: %s\n", configStore.Path())
+			fmt.Printf("Config file: %s\n", configStore().Path())
 			return nil
 		},
 	}
@@ -66,8 +66,7 @@ func setConfigValue(cfg any, key, value string) error {
 			return fmt.Errorf("cannot access field %s", part)
 		}
 
-		fieldName := snakeToPascal(part)
-		field := v.FieldByName(fieldName)
+		field := configField(v, part)
 
 		if !field.IsValid() {
 			return fmt.Errorf("unknown config key: %s", key)
@@ -3,6 +3,8 @@ package config
 import (
 	"reflect"
 	"testing"
+
+	"github.com/mqasimca/nylas/internal/domain"
 )
 
 func TestSetFieldValue(t *testing.T) {
@@ -300,3 +302,30 @@ func TestSetConfigValue_RoundTrip(t *testing.T) {
 		t.Errorf("round trip failed: set %q, got %q", value, got)
 	}
 }
+
+func TestSetConfigValue_YAMLNames(t *testing.T) {
+	// Keys follow the config file's names, including acronym fields like HTTP
+	cfg := &domain.Config{}
+
+	for key, value := range map[string]string{
+		"http.proxy":     "http://proxy.internal:3128",
+		"http.ca_cert":   "/etc/ssl/corp.pem",
+		"api.base_url":   "http://localhost:8080",
+		"secrets.helper": "vault",
+	} {
+		if err := setConfigValue(cfg, key, value); err != nil {
+			t.Fatalf("setConfigValue(%q) error: %v", key, err)
+		}
+		got, err := getConfigValue(cfg, key)
+		if err != nil {
+			t.Fatalf("getConfigValue(%q) error: %v", key, err)
+		}
+		if got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+
+	if cfg.HTTP.CACert != "/etc/ssl/corp.pem" {
+		t.Errorf("HTTP.CACert = %q", cfg.HTTP.CACert)
+	}
+}
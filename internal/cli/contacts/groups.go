@@ -42,7 +42,7 @@ func newGroupsListCmd() *cobra.Command {
 				}
 
 				// JSON output (including empty array)
-				if common.IsJSON(cmd) {
+				if common.IsStructuredOutput(cmd) {
 					out := common.GetOutputWriter(cmd)
 					return struct{}{}, out.Write(groups)
 				}
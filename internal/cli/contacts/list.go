@@ -26,7 +26,7 @@ func newListCmd() *cobra.Command {
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if we should use structured output (JSON/YAML/quiet)
-			if common.IsJSON(cmd) {
+			if common.IsStructuredOutput(cmd) {
 				_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 					params := &domain.ContactQueryParams{
 						Limit:  limit,
@@ -24,6 +24,7 @@ func newSearchCmd() *cobra.Command {
 		hasEmail    bool
 		limit       int
 		jsonOutput  bool
+		allGrants   bool
 	)
 
 	cmd := &cobra.Command{
@@ -38,25 +39,21 @@ Advanced Search Options:
   --source: Filter by contact source (address_book, inbox, domain)
   --group: Filter by contact group ID
   --has-email: Only show contacts with email addresses
+  --all-grants: Search every authenticated account, with an account column
 
 Note: Company name filtering searches the company_name field. For more advanced
 text searches, use the regular list command with additional filtering.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
-				params := &domain.ContactQueryParams{
-					Limit:       limit,
-					Email:       email,
-					PhoneNumber: phoneNumber,
-					Source:      source,
-					Group:       group,
-				}
-
-				contacts, err := client.GetContacts(ctx, grantID, params)
-				if err != nil {
-					return struct{}{}, common.WrapSearchError("contacts", err)
-				}
-
-				// Apply client-side filters
+			params := &domain.ContactQueryParams{
+				Limit:       limit,
+				Email:       email,
+				PhoneNumber: phoneNumber,
+				Source:      source,
+				Group:       group,
+			}
+
+			// Apply client-side filters
+			filter := func(contacts []domain.Contact) []domain.Contact {
 				var filtered []domain.Contact
 				for _, contact := range contacts {
 					// Filter by company name (case-insensitive)
@@ -71,6 +68,22 @@ text searches, use the regular list command with additional filtering.`,
 
 					filtered = append(filtered, contact)
 				}
+				return filtered
+			}
+
+			if allGrants {
+				if err := common.CheckAllGrantsArgs(allGrants, args); err != nil {
+					return err
+				}
+				return runSearchAllGrants(cmd, params, filter, jsonOutput)
+			}
+
+			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				contacts, err := client.GetContacts(ctx, grantID, params)
+				if err != nil {
+					return struct{}{}, common.WrapSearchError("contacts", err)
+				}
+				filtered := filter(contacts)
 
 				if jsonOutput {
 					encoder := json.NewEncoder(os.Stdout)
@@ -81,17 +94,7 @@ text searches, use the regular list command with additional filtering.`,
 				// Print results as table
 				table := common.NewTable("ID", "Name", "Email", "Company", "Job Title")
 				for _, contact := range filtered {
-					name := contact.DisplayName()
-					email := contact.PrimaryEmail()
-					company := contact.CompanyName
-					if company == "" {
-						company = "-"
-					}
-					jobTitle := contact.JobTitle
-					if jobTitle == "" {
-						jobTitle = "-"
-					}
-					table.AddRow(contact.ID, name, email, company, jobTitle)
+					table.AddRow(contact.ID, contact.DisplayName(), contact.PrimaryEmail(), orDash(contact.CompanyName), orDash(contact.JobTitle))
 				}
 				table.Render()
 
@@ -111,6 +114,57 @@ text searches, use the regular list command with additional filtering.`,
 	cmd.Flags().BoolVar(&hasEmail, "has-email", false, "Only show contacts with email addresses")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Maximum number of contacts to retrieve")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	common.AddAllGrantsFlag(cmd, &allGrants)
 
 	return cmd
 }
+
+// accountContact is a contact from an --all-grants search, labelled with the
+// account it belongs to.
+type accountContact struct {
+	Account        string `json:"account" yaml:"account"`
+	domain.Contact `yaml:",inline"`
+}
+
+// runSearchAllGrants searches every stored grant and prints the merged
+// contacts sorted by name.
+func runSearchAllGrants(cmd *cobra.Command, params *domain.ContactQueryParams, filter func([]domain.Contact) []domain.Contact, jsonOutput bool) error {
+	contacts, err := common.WithAllGrants(cmd, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Contact, error) {
+		contacts, err := client.GetContacts(ctx, grantID, params)
+		return filter(contacts), err
+	}, func(grant domain.GrantInfo, contact domain.Contact) accountContact {
+		return accountContact{Account: common.AccountName(grant), Contact: contact}
+	}, func(a, b accountContact) bool {
+		return strings.ToLower(a.DisplayName()) < strings.ToLower(b.DisplayName())
+	})
+	if err != nil {
+		return common.WrapSearchError("contacts", err)
+	}
+	if params.Limit > 0 && len(contacts) > params.Limit {
+		contacts = contacts[:params.Limit]
+	}
+
+	if jsonOutput {
+		return common.PrintJSON(contacts)
+	}
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(contacts)
+	}
+
+	table := common.NewTable("Account", "ID", "Name", "Email", "Company", "Job Title")
+	for _, contact := range contacts {
+		table.AddRow(contact.Account, contact.ID, contact.DisplayName(), contact.PrimaryEmail(), orDash(contact.CompanyName), orDash(contact.JobTitle))
+	}
+	table.Render()
+
+	fmt.Printf("\nFound %d contacts\n", len(contacts))
+	return nil
+}
+
+// orDash returns s, or "-" when it's empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
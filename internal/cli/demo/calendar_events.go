@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 )
 
@@ -33,7 +32,7 @@ func newDemoEventsListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List events",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			events, err := client.GetEvents(ctx, "demo-grant", "primary", nil)
@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 )
 
@@ -20,7 +19,7 @@ func newDemoCalendarsListCmd() *cobra.Command {
 		Example: `  # List sample calendars
   nylas demo calendar calendars`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			calendars, err := client.GetCalendars(ctx, "demo-grant")
@@ -113,7 +112,7 @@ func newDemoCalendarListCmd() *cobra.Command {
   # List with IDs shown
   nylas demo calendar list --id`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			events, err := client.GetEvents(ctx, "demo-grant", "primary", nil)
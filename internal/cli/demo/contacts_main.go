@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 )
 
@@ -60,7 +59,7 @@ func newDemoContactsListCmd() *cobra.Command {
   # List with IDs shown
   nylas demo contacts list --id`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			contacts, err := client.GetContacts(ctx, "demo-grant", nil)
@@ -108,7 +107,7 @@ func newDemoContactsShowCmd() *cobra.Command {
   # Show specific contact
   nylas demo contacts show contact-001`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			contactID := "contact-001"
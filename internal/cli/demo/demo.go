@@ -4,8 +4,14 @@ package demo
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/ports"
 )
 
+// cassetteDir is a recorded cassette to replay instead of the sample data.
+var cassetteDir string
+
 // NewDemoCmd creates the demo parent command with all demo subcommands.
 func NewDemoCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -48,9 +54,14 @@ To connect your real email account, run: nylas auth login`,
   nylas demo notetaker list
 
   # Launch the web UI with sample data
-  nylas demo ui`,
+  nylas demo ui
+
+  # Replay real traffic recorded with NYLAS_RECORD=./cassette
+  nylas demo email list --cassette ./cassette`,
 	}
 
+	cmd.PersistentFlags().StringVar(&cassetteDir, "cassette", "", "Replay API responses recorded with NYLAS_RECORD from this directory")
+
 	// Add demo subcommands
 	cmd.AddCommand(newDemoTUICmd())
 	cmd.AddCommand(newDemoUICmd())
@@ -62,3 +73,13 @@ To connect your real email account, run: nylas auth login`,
 
 	return cmd
 }
+
+// newDemoClient returns the client demo commands read from: the built-in
+// sample data, or the --cassette recording. Demo commands ask for their own
+// IDs and page sizes, so a cassette is matched on method and path only.
+func newDemoClient() ports.NylasClient {
+	if cassetteDir != "" {
+		return nylas.NewReplayClient(cassetteDir, true)
+	}
+	return nylas.NewDemoClient()
+}
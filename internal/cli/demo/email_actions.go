@@ -5,24 +5,27 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/spf13/cobra"
 )
 
 func newDemoEmailSearchCmd() *cobra.Command {
-	var query string
-
 	cmd := &cobra.Command{
-		Use:   "search",
+		Use:   "search [query]",
 		Short: "Search sample emails",
 		Long:  "Search through sample emails to see how search works.",
 		Example: `  # Search for emails
-  nylas demo email search --query "meeting"
-  nylas demo email search -q "project"`,
+  nylas demo email search "meeting"
+  nylas demo email search "project"`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			var query string
+			if len(args) > 0 {
+				query = args[0]
+			}
+
+			client := newDemoClient()
 			ctx := context.Background()
 
 			messages, _ := client.GetMessages(ctx, "demo-grant", 10)
@@ -58,8 +61,6 @@ func newDemoEmailSearchCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&query, "query", "q", "", "Search query")
-
 	return cmd
 }
 
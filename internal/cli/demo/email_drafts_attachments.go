@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/spf13/cobra"
 )
@@ -30,7 +29,7 @@ func newDemoEmailDraftsListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List sample drafts",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			drafts, _ := client.GetDrafts(ctx, "demo-grant", 10)
@@ -155,7 +154,7 @@ func newDemoEmailAttachmentsListCmd() *cobra.Command {
 		Use:   "list [message-id]",
 		Short: "List attachments for a message",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			messageID := "msg-001"
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/spf13/cobra"
@@ -30,7 +29,7 @@ func newDemoEmailFoldersListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List sample folders",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			folders, _ := client.GetFolders(ctx, "demo-grant")
@@ -125,7 +124,7 @@ func newDemoEmailThreadsListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List sample threads",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			threads, _ := client.GetThreads(ctx, "demo-grant", nil)
@@ -174,7 +173,7 @@ func newDemoEmailThreadsReadCmd() *cobra.Command {
 		Use:   "read [thread-id]",
 		Short: "Read a sample thread",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			threadID := "thread-001"
@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
 )
@@ -60,7 +59,7 @@ func newDemoEmailListCmd() *cobra.Command {
   # Limit to 5 emails
   nylas demo email list --limit 5`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			messages, err := client.GetMessages(ctx, "demo-grant", limit)
@@ -107,7 +106,7 @@ func newDemoEmailReadCmd() *cobra.Command {
   # Read specific message
   nylas demo email read msg-001`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			messageID := "msg-001"
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/spf13/cobra"
@@ -30,7 +29,7 @@ func newDemoEmailScheduledListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List scheduled messages",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			scheduled, _ := client.ListScheduledMessages(ctx, "demo-grant")
@@ -88,7 +87,7 @@ func newDemoEmailSmartComposeCmd() *cobra.Command {
 		Example: `  # Generate an email draft
   nylas demo email smart-compose --prompt "Thank the team for their hard work"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			if prompt == "" {
@@ -9,7 +9,6 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
 )
@@ -46,7 +45,7 @@ func newDemoNotetakerListCmd() *cobra.Command {
 		Example: `  # List sample notetakers
   nylas demo notetaker list`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			notetakers, err := client.ListNotetakers(ctx, "demo-grant", nil)
@@ -91,7 +90,7 @@ func newDemoNotetakerShowCmd() *cobra.Command {
   # Show specific notetaker
   nylas demo notetaker show notetaker-001`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			notetakerID := "notetaker-001"
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/fatih/color"
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/spf13/cobra"
 )
@@ -216,7 +215,7 @@ func newDemoBookingsListCmd() *cobra.Command {
 		Example: `  # List sample bookings
   nylas demo scheduler bookings list`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			bookings, err := client.ListBookings(ctx, "config-demo-1")
@@ -384,7 +383,7 @@ func newDemoPagesListCmd() *cobra.Command {
 		Example: `  # List sample scheduler pages
   nylas demo scheduler pages list`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			pages, err := client.ListSchedulerPages(ctx)
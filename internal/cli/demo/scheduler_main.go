@@ -8,7 +8,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 )
 
@@ -57,7 +56,7 @@ func newDemoConfigListCmd() *cobra.Command {
 		Example: `  # List sample scheduler configs
   nylas demo scheduler configurations list`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client := nylas.NewDemoClient()
+			client := newDemoClient()
 			ctx := context.Background()
 
 			configs, err := client.ListSchedulerConfigurations(ctx)
@@ -5,7 +5,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/tui"
 )
 
@@ -64,7 +63,7 @@ Themes:
 }
 
 func runDemoTUI(refreshInterval time.Duration, initialView string, theme tui.ThemeName) error {
-	client := nylas.NewDemoClient()
+	client := newDemoClient()
 
 	app := tui.NewApp(tui.Config{
 		Client:          client,
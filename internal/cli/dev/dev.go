@@ -0,0 +1,23 @@
+// Package dev provides CLI commands for developing applications against
+// the Nylas API.
+package dev
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewDevCmd creates the dev command group.
+func NewDevCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Tools for developing against the Nylas API",
+		Long: `Tools for developing applications against the Nylas API.
+
+Run a local mock of the v3 REST API to build and test without an account,
+credentials or network access.`,
+	}
+
+	cmd.AddCommand(newMockServerCmd())
+
+	return cmd
+}
@@ -0,0 +1,131 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/adapters/mockapi"
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/cli/common"
+)
+
+func newMockServerCmd() *cobra.Command {
+	var (
+		host          string
+		port          int
+		latency       time.Duration
+		errorRate     float64
+		errorStatus   int
+		webhookURL    string
+		webhookSecret string
+		quiet         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "mock-server",
+		Short: "Run a local mock of the Nylas v3 API",
+		Long: `Run a local HTTP server that answers the Nylas v3 REST endpoints with
+the same sample data as demo mode.
+
+Served endpoints, under /v3/grants/{grant_id}/:
+  messages (list, get, update, delete, send)
+  drafts (list, get, create, update, send, delete)
+  folders, events, contacts (list, get, create, update, delete)
+  calendars (list, get)
+and /v3/webhooks (list, get, create, update, delete, send-test-event).
+
+Every grant ID starts with its own copy of the sample data. Changes last
+until the server stops. Lists page with limit and page_token, returning
+next_cursor like the real API. Any bearer token is accepted.
+
+Creating, updating or deleting messages, events, contacts and folders sends
+signed notifications to webhooks created through the server and to
+--webhook-url. The signature is in X-Nylas-Signature, as with the real API.
+
+Point the CLI at the server with:
+  nylas config set api.base_url http://localhost:8080`,
+		Example: `  # Start on port 8080
+  nylas dev mock-server --port 8080
+
+  # Slow responses, with 10% of requests rate limited
+  nylas dev mock-server --latency 300ms --error-rate 0.1 --error-status 429
+
+  # Deliver notifications to a local receiver
+  nylas webhook server --port 3000 --secret dev-secret &
+  nylas dev mock-server --webhook-url http://localhost:3000/webhook --webhook-secret dev-secret`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var log io.Writer = cmd.OutOrStdout()
+			if quiet {
+				log = nil
+			}
+			server, err := mockapi.NewServer(mockapi.Config{
+				Latency:       latency,
+				ErrorRate:     errorRate,
+				ErrorStatus:   errorStatus,
+				WebhookURL:    webhookURL,
+				WebhookSecret: webhookSecret,
+				Log:           log,
+			}, nylas.NewDemoClient())
+			if err != nil {
+				return common.NewUserError(err.Error(), "Check the --error-rate and --error-status flags")
+			}
+
+			listener, err := net.Listen("tcp", net.JoinHostPort(host, fmt.Sprint(port)))
+			if err != nil {
+				return common.NewUserError(
+					fmt.Sprintf("failed to listen on port %d: %v", port, err),
+					"Choose another port with --port",
+				)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if !quiet {
+				printMockServerInfo(cmd.OutOrStdout(), listener.Addr(), webhookURL)
+			}
+			if err := server.Serve(ctx, listener); err != nil {
+				return common.WrapError(err)
+			}
+			if !quiet {
+				fmt.Fprintln(cmd.OutOrStdout(), "\nMock server stopped")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "host", "localhost", "Interface to listen on")
+	cmd.Flags().IntVarP(&port, "port", "p", 8080, "Port to listen on")
+	cmd.Flags().DurationVar(&latency, "latency", 0, "Delay added to every API response (e.g. 250ms)")
+	cmd.Flags().Float64Var(&errorRate, "error-rate", 0, "Fraction of API requests that fail, from 0 to 1")
+	cmd.Flags().IntVar(&errorStatus, "error-status", 500, "HTTP status of injected failures (429 adds Retry-After)")
+	cmd.Flags().StringVar(&webhookURL, "webhook-url", "", "Register a webhook for every trigger at this URL")
+	cmd.Flags().StringVar(&webhookSecret, "webhook-secret", "", "Secret that signs --webhook-url deliveries (default: random)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Don't log requests")
+
+	return cmd
+}
+
+// printMockServerInfo prints where the server listens and how to use it.
+func printMockServerInfo(w io.Writer, addr net.Addr, webhookURL string) {
+	baseURL := "http://" + addr.String()
+	fmt.Fprintln(w)
+	_, _ = common.BoldCyan.Fprintln(w, "Nylas API mock server")
+	fmt.Fprintf(w, "  Base URL:  %s\n", baseURL)
+	if webhookURL != "" {
+		fmt.Fprintf(w, "  Webhooks:  %s\n", webhookURL)
+	}
+	fmt.Fprintln(w)
+	_, _ = common.Dim.Fprintf(w, "  Use it from the CLI:  nylas config set api.base_url %s\n", baseURL)
+	_, _ = common.Dim.Fprintf(w, "  Try it:  curl -H 'Authorization: Bearer test' %s/v3/grants/demo-grant/messages\n", baseURL)
+	_, _ = common.Dim.Fprintln(w, "  Press Ctrl+C to stop")
+	fmt.Fprintln(w)
+}
@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"runtime"
@@ -15,6 +17,7 @@ import (
 	"github.com/mqasimca/nylas/internal/adapters/nylas"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
 	"github.com/mqasimca/nylas/internal/ports"
 )
 
@@ -36,8 +39,35 @@ const (
 	CheckStatusSkipped
 )
 
+// String returns the status name used in diagnostics bundles.
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckStatusOK:
+		return "ok"
+	case CheckStatusWarning:
+		return "warning"
+	case CheckStatusError:
+		return "error"
+	default:
+		return "skipped"
+	}
+}
+
+// doctorCheck is one step of the health check. Most steps produce a single
+// result; per-grant checks produce one per grant.
+type doctorCheck struct {
+	progress string
+	run      func() []CheckResult
+}
+
+// single adapts a check returning one result to a doctorCheck.
+func single(check func() CheckResult) func() []CheckResult {
+	return func() []CheckResult { return []CheckResult{check()} }
+}
+
 func newDoctorCmd() *cobra.Command {
 	var verbose bool
+	var bundlePath string
 
 	cmd := &cobra.Command{
 		Use:   "doctor",
@@ -46,25 +76,50 @@ func newDoctorCmd() *cobra.Command {
 
 This command verifies:
   - API credentials are configured and valid
-  - Grant(s) are accessible and not expired
+  - Grant(s) are accessible and not expired, with the scopes of each grant
   - Secret store is working properly
   - Network connectivity to Nylas API
   - Configuration file is valid
+  - Your clock agrees with the Nylas API
+  - Rate limit headroom reported by the API
+  - Configured AI providers are available
+  - The Nylas Air cache passes SQLite and search index integrity checks
+  - Webhook endpoints are reachable from this machine
+
+--bundle writes a zip file to attach to support tickets. It holds the check
+results, versions, the config file with secrets redacted, the HTTP requests
+made by the checks, and the end of the CLI's log files.
 
 Examples:
   nylas doctor
-  nylas doctor --verbose`,
+  nylas doctor --verbose
+  nylas doctor --bundle nylas-diagnostics.zip`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDoctor(verbose)
+			return runDoctor(cmd, verbose, bundlePath)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show detailed information")
+	cmd.Flags().StringVar(&bundlePath, "bundle", "", "Write a diagnostics bundle (.zip) to this path")
 
 	return cmd
 }
 
-func runDoctor(verbose bool) error {
+func runDoctor(cmd *cobra.Command, verbose bool, bundlePath string) error {
+	// Record the checks' HTTP requests for the bundle
+	var trace bytes.Buffer
+	if bundlePath != "" {
+		settings := httpSettings(cmd)
+		if settings.Trace != nil {
+			settings.Trace = io.MultiWriter(settings.Trace, &trace)
+		} else {
+			settings.Trace = &trace
+		}
+		if err := httputil.Configure(settings); err != nil {
+			return common.WrapError(err)
+		}
+	}
+
 	_, _ = common.Bold.Println("Nylas CLI Health Check")
 	fmt.Println()
 
@@ -78,40 +133,30 @@ func runDoctor(verbose bool) error {
 		fmt.Println()
 	}
 
-	// 1. Check configuration file
-	configResult, _ := common.RunWithSpinnerResult("Checking configuration...", func() (CheckResult, error) {
-		return checkConfig(), nil
-	})
-	results = append(results, configResult)
-	printCheckResult(configResult, verbose)
-
-	// 2. Check secret store
-	secretResult, _ := common.RunWithSpinnerResult("Checking secret store...", func() (CheckResult, error) {
-		return checkSecretStore(), nil
-	})
-	results = append(results, secretResult)
-	printCheckResult(secretResult, verbose)
-
-	// 3. Check API credentials
-	apiKeyResult, _ := common.RunWithSpinnerResult("Checking API credentials...", func() (CheckResult, error) {
-		return checkAPICredentials(), nil
-	})
-	results = append(results, apiKeyResult)
-	printCheckResult(apiKeyResult, verbose)
-
-	// 4. Check network connectivity
-	networkResult, _ := common.RunWithSpinnerResult("Checking network connectivity...", func() (CheckResult, error) {
-		return checkNetworkConnectivity(), nil
-	})
-	results = append(results, networkResult)
-	printCheckResult(networkResult, verbose)
-
-	// 5. Check grants
-	grantsResult, _ := common.RunWithSpinnerResult("Checking grants...", func() (CheckResult, error) {
-		return checkGrants(), nil
-	})
-	results = append(results, grantsResult)
-	printCheckResult(grantsResult, verbose)
+	probe := &apiProbe{}
+	checks := []doctorCheck{
+		{"Checking configuration...", single(checkConfig)},
+		{"Checking secret store...", single(checkSecretStore)},
+		{"Checking API credentials...", single(checkAPICredentials)},
+		{"Checking network connectivity...", single(checkNetworkConnectivity)},
+		{"Checking grants...", single(checkGrants)},
+		{"Checking grant tokens and scopes...", checkGrantTokens},
+		{"Checking clock...", single(func() CheckResult { return checkClockSkew(probe) })},
+		{"Checking rate limits...", single(func() CheckResult { return checkRateLimit(probe) })},
+		{"Checking AI providers...", single(checkAIProviders)},
+		{"Checking Air cache...", single(checkAirCache)},
+		{"Checking webhook endpoints...", single(checkWebhookEndpoints)},
+	}
+
+	for _, check := range checks {
+		checkResults, _ := common.RunWithSpinnerResult(check.progress, func() ([]CheckResult, error) {
+			return check.run(), nil
+		})
+		for _, r := range checkResults {
+			results = append(results, r)
+			printCheckResult(r, verbose)
+		}
+	}
 
 	// Summary
 	fmt.Println()
@@ -157,6 +202,15 @@ func runDoctor(verbose bool) error {
 
 	fmt.Println()
 
+	if bundlePath != "" {
+		if err := writeBundle(bundlePath, newDoctorReport(results), common.GetConfigStore(cmd).Path(), trace.Bytes()); err != nil {
+			return common.WrapWriteError("diagnostics bundle", err)
+		}
+		_, _ = common.Green.Printf("Diagnostics bundle written to %s\n", bundlePath)
+		_, _ = common.Dim.Println("Review it before attaching it to a support ticket.")
+		fmt.Println()
+	}
+
 	if errCount > 0 {
 		return fmt.Errorf("%d health check(s) failed", errCount)
 	}
@@ -336,7 +390,7 @@ func checkNetworkConnectivity() CheckResult {
 	ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.us.nylas.com/v3/", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiBaseURL(loadDoctorConfig())+"/v3/", nil)
 	if err != nil {
 		return CheckResult{
 			Name:    "Network",
@@ -346,7 +400,7 @@ func checkNetworkConnectivity() CheckResult {
 		}
 	}
 
-	client := &http.Client{Timeout: domain.TimeoutHealthCheck}
+	client := httputil.NewClient(domain.TimeoutHealthCheck)
 	start := time.Now()
 	resp, err := client.Do(req)
 	latency := time.Since(start)
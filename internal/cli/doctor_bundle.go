@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/httputil"
+)
+
+// maxLogLines is how much of the end of each log file goes into a bundle.
+const maxLogLines = 500
+
+const redactedValue = "[REDACTED]"
+
+// doctorReport is report.json in a diagnostics bundle.
+type doctorReport struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Version     string            `json:"version"`
+	Commit      string            `json:"commit"`
+	BuildDate   string            `json:"build_date"`
+	GoVersion   string            `json:"go_version"`
+	Platform    string            `json:"platform"`
+	Profile     string            `json:"profile"`
+	ConfigDir   string            `json:"config_dir"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Checks      []reportCheck     `json:"checks"`
+}
+
+// reportCheck is a CheckResult in report.json.
+type reportCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// newDoctorReport describes this CLI build, its NYLAS_* environment and the
+// check results.
+func newDoctorReport(results []CheckResult) doctorReport {
+	report := doctorReport{
+		GeneratedAt: time.Now().UTC(),
+		Version:     Version,
+		Commit:      Commit,
+		BuildDate:   BuildDate,
+		GoVersion:   runtime.Version(),
+		Platform:    runtime.GOOS + "/" + runtime.GOARCH,
+		Profile:     config.ActiveProfile(),
+		ConfigDir:   config.DefaultConfigDir(),
+		Environment: map[string]string{},
+	}
+
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(name, "NYLAS_") {
+			report.Environment[name] = redactValue(name, value)
+		}
+	}
+
+	for _, r := range results {
+		report.Checks = append(report.Checks, reportCheck{
+			Name:    r.Name,
+			Status:  r.Status.String(),
+			Message: r.Message,
+			Detail:  r.Detail,
+		})
+	}
+	return report
+}
+
+// isSensitiveName reports whether a setting or variable name holds a secret.
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range []string{"key", "secret", "token", "password", "passphrase"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue hides secret values and credentials in URLs. ${VAR}
+// references are kept, since they only name where the secret comes from.
+func redactValue(name, value string) string {
+	if value == "" {
+		return value
+	}
+	if isSensitiveName(name) {
+		if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+			return value
+		}
+		return redactedValue
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		u.User = url.User(redactedValue)
+		return u.String()
+	}
+	return value
+}
+
+// redactConfig returns the config file with secret values replaced.
+func redactConfig(data []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	redactNode(&root)
+	return yaml.Marshal(&root)
+}
+
+// redactNode redacts the scalar values of a YAML node tree in place.
+func redactNode(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if value.Kind == yaml.ScalarNode {
+				value.Value = redactValue(key.Value, value.Value)
+				continue
+			}
+			redactNode(value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		redactNode(child)
+	}
+}
+
+// tailLines returns up to n lines from the end of a file.
+func tailLines(path string, n int) ([]string, error) {
+	//nolint:gosec // G304: path is a log file in the CLI's config directory
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// logFiles returns the CLI's log files, in the active profile's config
+// directory and the base one.
+func logFiles() []string {
+	var files []string
+	for _, dir := range []string{config.DefaultConfigDir(), config.BaseConfigDir()} {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.log"))
+		for _, match := range matches {
+			if !slices.Contains(files, match) {
+				files = append(files, match)
+			}
+		}
+	}
+	return files
+}
+
+// writeBundle writes a diagnostics zip: report.json, the redacted config
+// file, the HTTP trace of the checks and the end of each log file.
+func writeBundle(path string, report doctorReport, configPath string, trace []byte) error {
+	//nolint:gosec // G304: the user chooses where the bundle goes
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(f)
+
+	add := func(name string, data []byte) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	err = func() error {
+		reportJSON, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := add("report.json", reportJSON); err != nil {
+			return err
+		}
+
+		//nolint:gosec // G304: configPath is the CLI's own config file
+		if data, err := os.ReadFile(configPath); err == nil {
+			redacted, err := redactConfig(data)
+			if err != nil {
+				redacted = []byte(fmt.Sprintf("# config file could not be parsed, so it was left out: %v\n", err))
+			}
+			if err := add("config.yaml", redacted); err != nil {
+				return err
+			}
+		}
+
+		if len(trace) > 0 {
+			if err := add("http-trace.log", trace); err != nil {
+				return err
+			}
+		}
+
+		for _, logFile := range logFiles() {
+			lines, err := tailLines(logFile, maxLogLines)
+			if err != nil {
+				continue
+			}
+			for i, line := range lines {
+				lines[i] = httputil.RedactBody("application/json", line)
+			}
+			if err := add("logs/"+filepath.Base(logFile), []byte(strings.Join(lines, "\n")+"\n")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
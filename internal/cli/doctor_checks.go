@@ -0,0 +1,464 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/ai"
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/adapters/keyring"
+	"github.com/mqasimca/nylas/internal/air/cache"
+	"github.com/mqasimca/nylas/internal/cli/auth"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
+)
+
+const (
+	// Clock skew beyond these limits breaks signed tokens and webhook checks.
+	clockSkewWarning = 30 * time.Second
+	clockSkewError   = 5 * time.Minute
+
+	// rateLimitWarning is the share of the rate limit below which headroom is low.
+	rateLimitWarning = 0.1
+
+	// maxWebhookProbes caps how many webhook endpoints are contacted.
+	maxWebhookProbes = 10
+)
+
+// loadDoctorConfig loads the config file, falling back to the defaults.
+func loadDoctorConfig() *domain.Config {
+	cfg, err := config.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil {
+		return &domain.Config{Region: "us"}
+	}
+	return cfg
+}
+
+// apiBaseURL returns the API server the CLI talks to.
+func apiBaseURL(cfg *domain.Config) string {
+	if cfg.API != nil && cfg.API.BaseURL != "" {
+		return strings.TrimRight(cfg.API.BaseURL, "/")
+	}
+	if cfg.Region == "eu" {
+		return "https://api.eu.nylas.com"
+	}
+	return "https://api.us.nylas.com"
+}
+
+// apiProbe is one authenticated request to the Nylas API, shared by the
+// clock and rate limit checks.
+type apiProbe struct {
+	once     sync.Once
+	status   int
+	header   http.Header
+	sent     time.Time
+	received time.Time
+	hasKey   bool
+	err      error
+}
+
+// result sends the request the first time it's called.
+func (p *apiProbe) result() *apiProbe {
+	p.once.Do(func() {
+		ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiBaseURL(loadDoctorConfig())+"/v3/grants?limit=1", nil)
+		if err != nil {
+			p.err = err
+			return
+		}
+		if apiKey, err := common.GetAPIKey(); err == nil {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+			p.hasKey = true
+		}
+
+		p.sent = time.Now()
+		resp, err := httputil.NewClient(domain.TimeoutHealthCheck).Do(req)
+		p.received = time.Now()
+		if err != nil {
+			p.err = err
+			return
+		}
+		_ = resp.Body.Close()
+		p.status = resp.StatusCode
+		p.header = resp.Header
+	})
+	return p
+}
+
+// clockSkew returns how far the local clock is ahead of the server's Date
+// header, measured at the middle of the request.
+func clockSkew(serverDate string, sent, received time.Time) (time.Duration, error) {
+	server, err := http.ParseTime(serverDate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Date header %q", serverDate)
+	}
+	local := sent.Add(received.Sub(sent) / 2)
+	return local.Sub(server).Truncate(time.Second), nil
+}
+
+func checkClockSkew(probe *apiProbe) CheckResult {
+	p := probe.result()
+	if p.err != nil {
+		return CheckResult{Name: "Clock", Status: CheckStatusSkipped, Message: "Cannot reach Nylas API"}
+	}
+	date := p.header.Get("Date")
+	if date == "" {
+		return CheckResult{Name: "Clock", Status: CheckStatusSkipped, Message: "API sent no Date header"}
+	}
+
+	skew, err := clockSkew(date, p.sent, p.received)
+	if err != nil {
+		return CheckResult{Name: "Clock", Status: CheckStatusSkipped, Message: err.Error()}
+	}
+
+	abs := skew.Abs()
+	if abs <= time.Second {
+		return CheckResult{Name: "Clock", Status: CheckStatusOK, Message: "In sync with Nylas API"}
+	}
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	msg := fmt.Sprintf("%s %s Nylas API", abs, direction)
+
+	switch {
+	case abs >= clockSkewError:
+		return CheckResult{
+			Name:    "Clock",
+			Status:  CheckStatusError,
+			Message: msg,
+			Detail:  "Sync your system clock (NTP). Token expiry and webhook timestamps will be wrong.",
+		}
+	case abs >= clockSkewWarning:
+		return CheckResult{
+			Name:    "Clock",
+			Status:  CheckStatusWarning,
+			Message: msg,
+			Detail:  "Sync your system clock (NTP) to avoid token and scheduling errors.",
+		}
+	default:
+		return CheckResult{Name: "Clock", Status: CheckStatusOK, Message: msg}
+	}
+}
+
+// rateLimitHeadroom reads the remaining and total requests from rate limit
+// response headers.
+func rateLimitHeadroom(header http.Header) (remaining, limit int, ok bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		r, errR := strconv.Atoi(header.Get(prefix + "Remaining"))
+		l, errL := strconv.Atoi(header.Get(prefix + "Limit"))
+		if errR == nil && errL == nil && l > 0 {
+			return r, l, true
+		}
+	}
+	return 0, 0, false
+}
+
+func checkRateLimit(probe *apiProbe) CheckResult {
+	p := probe.result()
+	switch {
+	case p.err != nil:
+		return CheckResult{Name: "Rate Limits", Status: CheckStatusSkipped, Message: "Cannot reach Nylas API"}
+	case !p.hasKey:
+		return CheckResult{Name: "Rate Limits", Status: CheckStatusSkipped, Message: "API key not configured"}
+	case p.status == http.StatusTooManyRequests:
+		detail := "Wait before retrying; the CLI retries rate limited requests automatically."
+		if retry := p.header.Get("Retry-After"); retry != "" {
+			detail = fmt.Sprintf("Retry after %ss; the CLI retries rate limited requests automatically.", retry)
+		}
+		return CheckResult{Name: "Rate Limits", Status: CheckStatusError, Message: "Rate limited right now", Detail: detail}
+	case p.status == http.StatusUnauthorized:
+		return CheckResult{
+			Name:    "Rate Limits",
+			Status:  CheckStatusSkipped,
+			Message: "API key rejected",
+			Detail:  "Run 'nylas auth config' to set a valid API key",
+		}
+	}
+
+	remaining, limit, ok := rateLimitHeadroom(p.header)
+	if !ok {
+		return CheckResult{Name: "Rate Limits", Status: CheckStatusSkipped, Message: "API reported no rate limit headers"}
+	}
+	msg := fmt.Sprintf("%d of %d requests left", remaining, limit)
+	if float64(remaining) < float64(limit)*rateLimitWarning {
+		return CheckResult{
+			Name:    "Rate Limits",
+			Status:  CheckStatusWarning,
+			Message: msg,
+			Detail:  "Close to the API rate limit. Slow down scripts or spread requests out.",
+		}
+	}
+	return CheckResult{Name: "Rate Limits", Status: CheckStatusOK, Message: msg}
+}
+
+// checkGrantTokens checks every stored grant with the API: whether its token
+// is still valid and which of email, calendar and contacts its scopes cover.
+func checkGrantTokens() []CheckResult {
+	secretStore, err := keyring.NewSecretStore(config.DefaultConfigDir())
+	if err != nil {
+		return []CheckResult{{Name: "Grant Tokens", Status: CheckStatusSkipped, Detail: "Secret store not available"}}
+	}
+	grants, err := keyring.NewGrantStore(secretStore).ListGrants()
+	if err != nil || len(grants) == 0 {
+		// The grants check already reports this
+		return nil
+	}
+
+	client, err := common.GetNylasClient()
+	if err != nil {
+		return []CheckResult{{Name: "Grant Tokens", Status: CheckStatusSkipped, Message: "API key not configured"}}
+	}
+
+	results := make([]CheckResult, 0, len(grants))
+	for _, info := range grants {
+		name := "Grant " + info.Email
+		if info.Email == "" {
+			name = "Grant " + info.ID
+		}
+
+		ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
+		grant, err := client.GetGrant(ctx, info.ID)
+		cancel()
+
+		switch {
+		case err != nil:
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckStatusError,
+				Message: "Cannot check token",
+				Detail:  fmt.Sprintf("%v. Re-authenticate with 'nylas auth login' or remove it with 'nylas auth remove %s'", err, info.ID),
+			})
+		case !grant.IsValid():
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckStatusError,
+				Message: "Token " + grant.GrantStatus,
+				Detail:  "Re-authenticate with 'nylas auth login'",
+			})
+		case len(grant.Scope) > 0 && len(auth.ScopeAreas(grant.Scope)) == 0:
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckStatusWarning,
+				Message: "Valid, but no email, calendar or contacts scopes",
+				Detail:  fmt.Sprintf("Check its scopes with 'nylas auth scopes %s'", info.ID),
+			})
+		case len(grant.Scope) > 0:
+			results = append(results, CheckResult{
+				Name:    name,
+				Status:  CheckStatusOK,
+				Message: "Valid, scopes: " + strings.Join(auth.ScopeAreas(grant.Scope), ", "),
+			})
+		default:
+			results = append(results, CheckResult{Name: name, Status: CheckStatusOK, Message: "Valid"})
+		}
+	}
+	return results
+}
+
+func checkAIProviders() CheckResult {
+	cfg := loadDoctorConfig()
+	if cfg.AI == nil {
+		return CheckResult{Name: "AI Providers", Status: CheckStatusSkipped, Message: "None configured"}
+	}
+
+	router := ai.NewRouter(cfg.AI)
+	names := router.ListProviders()
+	if len(names) == 0 {
+		return CheckResult{Name: "AI Providers", Status: CheckStatusSkipped, Message: "None configured"}
+	}
+	slices.Sort(names)
+
+	var available, unavailable []string
+	for _, name := range names {
+		provider, err := router.GetProvider(name)
+		if err != nil {
+			unavailable = append(unavailable, name)
+			continue
+		}
+		ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
+		ok := provider.IsAvailable(ctx)
+		cancel()
+		if ok {
+			available = append(available, name)
+		} else {
+			unavailable = append(unavailable, name)
+		}
+	}
+
+	if len(unavailable) > 0 {
+		return CheckResult{
+			Name:    "AI Providers",
+			Status:  CheckStatusWarning,
+			Message: "Unavailable: " + strings.Join(unavailable, ", "),
+			Detail:  "Check the API keys and hosts with 'nylas ai config show'",
+		}
+	}
+	return CheckResult{
+		Name:    "AI Providers",
+		Status:  CheckStatusOK,
+		Message: "Available: " + strings.Join(available, ", "),
+	}
+}
+
+// cacheDatabases opens Air cache databases, encrypted or not.
+type cacheDatabases interface {
+	GetDB(email string) (*sql.DB, error)
+	ListCachedAccounts() ([]string, error)
+	Close() error
+}
+
+func checkAirCache() CheckResult {
+	basePath := cache.DefaultConfig().BasePath
+	if _, err := os.Stat(basePath); err != nil {
+		return CheckResult{Name: "Air Cache", Status: CheckStatusSkipped, Message: "Not used"}
+	}
+
+	settings, err := cache.LoadSettings(basePath)
+	if err != nil {
+		return CheckResult{Name: "Air Cache", Status: CheckStatusError, Message: "Failed to load settings", Detail: err.Error()}
+	}
+
+	var manager cacheDatabases
+	if settings.IsEncryptionEnabled() {
+		manager, err = cache.NewEncryptedManager(settings.ToConfig(basePath), cache.EncryptionConfig{Enabled: true})
+	} else {
+		manager, err = cache.NewManager(settings.ToConfig(basePath))
+	}
+	if err != nil {
+		return CheckResult{Name: "Air Cache", Status: CheckStatusError, Message: "Failed to open cache", Detail: err.Error()}
+	}
+	defer func() { _ = manager.Close() }()
+
+	accounts, err := manager.ListCachedAccounts()
+	if err != nil {
+		return CheckResult{Name: "Air Cache", Status: CheckStatusError, Message: "Failed to list cached accounts", Detail: err.Error()}
+	}
+	// Shared databases such as photos.db aren't account caches
+	accounts = slices.DeleteFunc(accounts, func(a string) bool { return !strings.Contains(a, "@") })
+	if len(accounts) == 0 {
+		return CheckResult{Name: "Air Cache", Status: CheckStatusSkipped, Message: "No cached accounts"}
+	}
+
+	var problems []string
+	for _, account := range accounts {
+		db, err := manager.GetDB(account)
+		if err == nil {
+			err = cache.CheckIntegrity(db)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", account, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return CheckResult{
+			Name:    "Air Cache",
+			Status:  CheckStatusError,
+			Message: fmt.Sprintf("%d of %d account cache(s) damaged", len(problems), len(accounts)),
+			Detail:  strings.Join(problems, "; ") + ". Rebuild with 'nylas air --clear-cache'",
+		}
+	}
+	return CheckResult{
+		Name:    "Air Cache",
+		Status:  CheckStatusOK,
+		Message: fmt.Sprintf("%d account cache(s) intact", len(accounts)),
+	}
+}
+
+// probeEndpoint checks that a webhook URL answers HTTP requests. Any
+// response below 500 counts, since endpoints usually only accept POST.
+func probeEndpoint(ctx context.Context, endpoint string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httputil.NewClient(domain.TimeoutHealthCheck).Do(req)
+	if err != nil {
+		// Drop the URL from the error; it may hold a token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// endpointHost returns the host of a webhook URL, keeping tokens in its
+// path or query out of the output.
+func endpointHost(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return endpoint
+}
+
+func checkWebhookEndpoints() CheckResult {
+	client, err := common.GetNylasClient()
+	if err != nil {
+		return CheckResult{Name: "Webhooks", Status: CheckStatusSkipped, Message: "API key not configured"}
+	}
+
+	ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
+	defer cancel()
+
+	webhooks, err := client.ListWebhooks(ctx)
+	if err != nil {
+		return CheckResult{Name: "Webhooks", Status: CheckStatusWarning, Message: "Failed to list webhooks", Detail: err.Error()}
+	}
+
+	var endpoints []string
+	for _, w := range webhooks {
+		if w.Status == "active" && !slices.Contains(endpoints, w.WebhookURL) {
+			endpoints = append(endpoints, w.WebhookURL)
+		}
+	}
+	if len(endpoints) == 0 {
+		return CheckResult{Name: "Webhooks", Status: CheckStatusSkipped, Message: "No active webhooks"}
+	}
+	if len(endpoints) > maxWebhookProbes {
+		endpoints = endpoints[:maxWebhookProbes]
+	}
+
+	var failures []string
+	for _, endpoint := range endpoints {
+		probeCtx, probeCancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
+		err := probeEndpoint(probeCtx, endpoint)
+		probeCancel()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%v)", endpointHost(endpoint), err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return CheckResult{
+			Name:    "Webhooks",
+			Status:  CheckStatusWarning,
+			Message: fmt.Sprintf("%d of %d endpoint(s) unreachable", len(failures), len(endpoints)),
+			Detail:  "Unreachable from this machine: " + strings.Join(failures, ", "),
+		}
+	}
+	return CheckResult{
+		Name:    "Webhooks",
+		Status:  CheckStatusOK,
+		Message: fmt.Sprintf("%d endpoint(s) reachable", len(endpoints)),
+	}
+}
@@ -0,0 +1,53 @@
+package email
+
+import (
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// accountMessage is a message from an --all-grants listing, labelled with
+// the account it belongs to.
+type accountMessage struct {
+	Account        string `json:"account" yaml:"account"`
+	domain.Message `yaml:",inline"`
+}
+
+func newAccountMessage(grant domain.GrantInfo, msg domain.Message) accountMessage {
+	return accountMessage{Account: common.AccountName(grant), Message: msg}
+}
+
+// newerMessage orders merged messages newest first.
+func newerMessage(a, b accountMessage) bool {
+	return a.Date.After(b.Date)
+}
+
+// printAccountMessages prints merged messages with an account column.
+func printAccountMessages(cmd *cobra.Command, messages []accountMessage, showID bool) error {
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(messages)
+	}
+
+	if len(messages) == 0 {
+		common.PrintEmptyState("messages")
+		return nil
+	}
+
+	headers := []string{"", "Account", "From", "Subject", "Date"}
+	if showID {
+		headers = append(headers, "ID")
+	}
+	table := common.NewTable(headers...)
+	table.SetMaxWidth(1, 30).SetMaxWidth(2, 25).SetMaxWidth(3, 50)
+	for _, msg := range messages {
+		status := " "
+		if msg.Unread {
+			status = common.Cyan.Sprint("●")
+		}
+		table.AddRow(status, msg.Account, common.FormatParticipants(msg.From), msg.Subject, common.FormatTimeAgo(msg.Date), msg.ID)
+	}
+
+	common.PrintListHeader(len(messages), "message")
+	table.Render()
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build !integration
+
+package email
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAccountMessage_Encoding(t *testing.T) {
+	msg := newAccountMessage(domain.GrantInfo{ID: "g1", Email: "work@example.com"}, domain.Message{ID: "m1", Subject: "Hello"})
+
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "work@example.com", fields["account"])
+	assert.Equal(t, "Hello", fields["subject"], "message fields are flattened")
+
+	out, err := yaml.Marshal(msg)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "account: work@example.com\n")
+	assert.Contains(t, string(out), "subject: Hello\n")
+}
+
+func TestNewerMessage(t *testing.T) {
+	now := time.Now()
+	messages := []accountMessage{
+		{Account: "a", Message: domain.Message{ID: "old", Date: now.Add(-time.Hour)}},
+		{Account: "b", Message: domain.Message{ID: "new", Date: now}},
+	}
+	sort.SliceStable(messages, func(i, j int) bool { return newerMessage(messages[i], messages[j]) })
+	assert.Equal(t, "new", messages[0].ID)
+}
+
+func TestListCmd_AllGrantsFlags(t *testing.T) {
+	cmd := newListCmd()
+	require.NotNil(t, cmd.Flags().Lookup("all-grants"))
+
+	cmd.SetArgs([]string{"--all-grants", "--all"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	assert.ErrorContains(t, cmd.Execute(), "none of the others can be")
+}
@@ -46,7 +46,7 @@ func newAttachmentsListCmd() *cobra.Command {
 				}
 
 				// JSON output (including empty array)
-				if common.IsJSON(cmd) {
+				if common.IsStructuredOutput(cmd) {
 					out := common.GetOutputWriter(cmd)
 					return struct{}{}, out.Write(attachments)
 				}
@@ -0,0 +1,282 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// batchSampleSize is how many matching messages are shown before confirming.
+const batchSampleSize = 5
+
+// batchOptions holds the email batch flags.
+type batchOptions struct {
+	search      string
+	action      string
+	concurrency int
+	maxItems    int
+	yes         bool
+	dryRun      bool
+	report      string
+	resume      string
+}
+
+func newBatchCmd() *cobra.Command {
+	var opts batchOptions
+
+	cmd := &cobra.Command{
+		Use:   "batch [grant-id]",
+		Short: "Apply an action to every message matching a search",
+		Long: `Apply an action to every message matching a search.
+
+All pages of results are fetched first, then the match count and a sample are
+shown for confirmation. Messages are updated a few at a time, within the API
+rate limit.
+
+Search operators:
+  from:  to:  cc:  bcc:  subject:  in:<folder>
+  is:unread  is:read  is:starred  is:unstarred  has:attachment
+  older_than:30d  newer_than:12h  (units: h, d, w, m, y)
+  before:2024-01-31  after:2024-01-01
+Other words are matched as full text. Quote phrases: subject:"weekly report".
+
+Actions:
+  archive, delete, mark-read, mark-unread, star, unstar,
+  move:<folder>, label:<folder>
+
+Every run writes a JSON report of the messages that succeeded and failed.
+Pass it to --resume to retry the failures and anything left unprocessed
+after Ctrl-C.`,
+		Example: `  # Archive old notifications
+  nylas email batch --search 'from:noreply@example.com older_than:30d' --action archive
+
+  # Mark unread newsletters as read without prompting
+  nylas email batch --search 'is:unread from:news@example.com' --action mark-read --yes
+
+  # Move receipts into a folder
+  nylas email batch --search 'subject:receipt' --action move:Receipts
+
+  # See what would be deleted
+  nylas email batch --search 'in:spam' --action delete --dry-run
+
+  # Retry the failures from an earlier run
+  nylas email batch --resume nylas-batch-20240131-120000.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.resume != "" {
+				return runBatchResume(opts)
+			}
+			if opts.search == "" || opts.action == "" {
+				return common.NewUserError("--search and --action are required", "Or pass --resume <report> to continue an earlier run")
+			}
+			return runBatchSearch(args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.search, "search", "", "Messages to act on (e.g. 'from:noreply@example.com older_than:30d')")
+	cmd.Flags().StringVar(&opts.action, "action", "", "archive, delete, mark-read, mark-unread, star, unstar, move:<folder> or label:<folder>")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 4, "Messages to update at once")
+	cmd.Flags().IntVar(&opts.maxItems, "max", 0, "Maximum messages to act on (0=unlimited)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be changed without changing anything")
+	cmd.Flags().StringVar(&opts.report, "report", "", "Report file (default nylas-batch-<time>.json, or the --resume file)")
+	cmd.Flags().StringVar(&opts.resume, "resume", "", "Retry failed and pending messages from a report")
+	common.AddYesFlag(cmd, &opts.yes)
+	cmd.MarkFlagsMutuallyExclusive("resume", "search")
+	cmd.MarkFlagsMutuallyExclusive("resume", "action")
+
+	return cmd
+}
+
+// runBatchSearch finds the messages matching --search and applies --action
+// to them.
+func runBatchSearch(args []string, opts batchOptions) error {
+	action, err := parseBatchAction(opts.action)
+	if err != nil {
+		return err
+	}
+	params, err := parseBatchSearch(opts.search, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, grantID, err := batchClient(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutBulkOperation)
+	defer cancel()
+	ctx, stop := common.WithInterrupt(ctx)
+	defer stop()
+
+	for i, name := range params.In {
+		if id, err := resolveFolderName(ctx, client, grantID, name); err == nil && id != "" {
+			params.In[i] = id
+		}
+	}
+
+	targets, sample, err := findBatchTargets(ctx, client, grantID, params, opts.maxItems)
+	if err != nil {
+		return common.WrapSearchError("messages", err)
+	}
+	if len(targets) == 0 {
+		common.PrintEmptyStateWithHint("messages", "No messages match the search")
+		return nil
+	}
+
+	fmt.Printf("Found %d messages matching %q\n\n", len(targets), opts.search)
+	printBatchSample(sample, len(targets))
+
+	if opts.dryRun {
+		fmt.Printf("\nDry run: would %s %d messages.\n", lowerFirst(action.prompt()), len(targets))
+		return nil
+	}
+	if !opts.yes && !common.Confirm(fmt.Sprintf("\n%s %d messages?", action.prompt(), len(targets)), false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	report := &batchReport{
+		GrantID:   grantID,
+		Search:    opts.search,
+		Action:    action.String(),
+		StartedAt: time.Now(),
+		Total:     len(targets),
+	}
+	path := opts.report
+	if path == "" {
+		path = fmt.Sprintf("nylas-batch-%s.json", report.StartedAt.Format("20060102-150405"))
+	}
+	return applyBatch(ctx, client, grantID, action, targets, opts.concurrency, report, path)
+}
+
+// runBatchResume retries the failed and pending messages from a report.
+func runBatchResume(opts batchOptions) error {
+	report, err := loadBatchReport(opts.resume)
+	if err != nil {
+		return err
+	}
+	action, err := parseBatchAction(report.Action)
+	if err != nil {
+		return err
+	}
+
+	targets := report.remaining()
+	if len(targets) == 0 {
+		common.PrintSuccess("Nothing left to do: all %d messages succeeded", len(report.Succeeded))
+		return nil
+	}
+
+	client, grantID, err := batchClient([]string{report.GrantID})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutBulkOperation)
+	defer cancel()
+	ctx, stop := common.WithInterrupt(ctx)
+	defer stop()
+
+	fmt.Printf("Resuming %s on %d messages from %s\n", action, len(targets), opts.resume)
+	if !opts.yes && !common.Confirm(fmt.Sprintf("%s %d messages?", action.prompt(), len(targets)), false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	path := opts.report
+	if path == "" {
+		path = opts.resume
+	}
+	return applyBatch(ctx, client, grantID, action, targets, opts.concurrency, report, path)
+}
+
+// batchClient returns the client and grant for a batch run.
+func batchClient(args []string) (ports.NylasClient, string, error) {
+	client, err := common.GetNylasClient()
+	if err != nil {
+		return nil, "", err
+	}
+	grantID, err := common.GetGrantID(args)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, grantID, nil
+}
+
+// findBatchTargets pages through every message matching params, keeping the
+// IDs and folders of each plus the first few messages as a sample.
+func findBatchTargets(ctx context.Context, client ports.NylasClient, grantID string, params *domain.MessageQueryParams, maxItems int) ([]batchTarget, []domain.Message, error) {
+	fetcher, config := messagePages(client, grantID, params, 0, maxItems)
+
+	var targets []batchTarget
+	var sample []domain.Message
+	_, err := common.StreamAllPages(ctx, config, fetcher, func(page []domain.Message) error {
+		for _, msg := range page {
+			targets = append(targets, batchTarget{ID: msg.ID, Folders: msg.Folders})
+			if len(sample) < batchSampleSize {
+				sample = append(sample, msg)
+			}
+		}
+		return nil
+	})
+	return targets, sample, err
+}
+
+// printBatchSample shows the first matching messages.
+func printBatchSample(sample []domain.Message, total int) {
+	table := common.NewTable("From", "Subject", "Date")
+	table.SetMaxWidth(0, 30).SetMaxWidth(1, 50)
+	for _, msg := range sample {
+		table.AddRow(common.FormatParticipants(msg.From), msg.Subject, common.FormatTimeAgo(msg.Date))
+	}
+	table.Render()
+	if more := total - len(sample); more > 0 {
+		_, _ = common.Dim.Printf("... and %d more\n", more)
+	}
+}
+
+// applyBatch runs the action on targets, then writes and summarises the report.
+func applyBatch(ctx context.Context, client ports.NylasClient, grantID string, action batchAction, targets []batchTarget, concurrency int, report *batchReport, path string) error {
+	applier, err := newBatchApplier(ctx, client, grantID, action)
+	if err != nil {
+		return err
+	}
+
+	bar := common.NewProgressBar(len(targets), action.prompt())
+	runBatch(ctx, targets, concurrency, report, applier.apply, bar.Increment)
+	bar.Finish()
+
+	if err := report.save(path); err != nil {
+		return err
+	}
+
+	succeeded := len(targets) - report.unfinished()
+	common.PrintSuccess("%s: %d of %d messages done", action.prompt(), succeeded, len(targets))
+	for _, f := range report.Failed {
+		common.PrintWarning("%s: %s", f.ID, f.Error)
+	}
+	fmt.Printf("Report written to %s\n", path)
+
+	if n := report.unfinished(); n > 0 {
+		return common.NewUserError(
+			fmt.Sprintf("%d of %d messages were not processed (%d failed, %d pending)", n, len(targets), len(report.Failed), len(report.Pending)),
+			fmt.Sprintf("Run: nylas email batch --resume %s", path),
+		)
+	}
+	return nil
+}
+
+// lowerFirst lowercases the first letter of an action prompt for use
+// mid-sentence.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,296 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// batchAction is a parsed --action for email batch.
+type batchAction struct {
+	Name   string // archive, delete, mark-read, mark-unread, star, unstar, move or label
+	Folder string // Folder name or ID for move and label
+}
+
+// parseBatchAction parses an --action value such as "archive" or "move:Receipts".
+func parseBatchAction(s string) (batchAction, error) {
+	name, folder, hasFolder := strings.Cut(strings.TrimSpace(s), ":")
+	action := batchAction{Name: strings.ToLower(name), Folder: folder}
+
+	switch action.Name {
+	case "archive", "delete", "mark-read", "mark-unread", "star", "unstar":
+		if hasFolder {
+			return batchAction{}, common.NewUserError(fmt.Sprintf("action %q doesn't take a folder", action.Name), "Use move:<folder> or label:<folder> to pick a folder")
+		}
+	case "move", "label":
+		if folder == "" {
+			return batchAction{}, common.NewUserError(fmt.Sprintf("action %q needs a folder", action.Name), fmt.Sprintf("Use --action %s:<folder>", action.Name))
+		}
+	default:
+		return batchAction{}, common.NewUserError(fmt.Sprintf("unknown action %q", s), "Valid actions: archive, delete, mark-read, mark-unread, star, unstar, move:<folder>, label:<folder>")
+	}
+	return action, nil
+}
+
+// String returns the action in --action form.
+func (a batchAction) String() string {
+	if a.Folder != "" {
+		return a.Name + ":" + a.Folder
+	}
+	return a.Name
+}
+
+// prompt describes the action for the confirmation prompt.
+func (a batchAction) prompt() string {
+	switch a.Name {
+	case "archive":
+		return "Archive"
+	case "delete":
+		return "Delete"
+	case "mark-read":
+		return "Mark as read"
+	case "mark-unread":
+		return "Mark as unread"
+	case "star":
+		return "Star"
+	case "unstar":
+		return "Unstar"
+	case "move":
+		return "Move to " + a.Folder
+	default:
+		return "Label with " + a.Folder
+	}
+}
+
+// needsFolders reports whether the action depends on a message's current
+// folders.
+func (a batchAction) needsFolders() bool {
+	return a.Name == "archive" || a.Name == "label"
+}
+
+// batchTarget is a message picked by the search.
+type batchTarget struct {
+	ID      string
+	Folders []string // Current folders; nil when not known (on --resume)
+}
+
+// batchApplier applies an action to one message at a time.
+type batchApplier struct {
+	client   ports.NylasClient
+	grantID  string
+	action   batchAction
+	folderID string // Target folder for move and label, archive folder for archive
+}
+
+// newBatchApplier resolves the folder the action needs once, up front.
+func newBatchApplier(ctx context.Context, client ports.NylasClient, grantID string, action batchAction) (*batchApplier, error) {
+	applier := &batchApplier{client: client, grantID: grantID, action: action}
+
+	switch action.Name {
+	case "archive":
+		folders, err := client.GetFolders(ctx, grantID)
+		if err != nil {
+			return nil, common.WrapGetError("folders", err)
+		}
+		for _, f := range folders {
+			if strings.EqualFold(f.SystemFolder, "archive") || strings.EqualFold(f.Name, "archive") {
+				applier.folderID = f.ID
+				break
+			}
+		}
+	case "move", "label":
+		folderID, err := resolveFolderName(ctx, client, grantID, action.Folder)
+		if err != nil {
+			return nil, common.WrapGetError("folders", err)
+		}
+		if folderID == "" {
+			folderID = action.Folder // Not found by name, use as an ID
+		}
+		applier.folderID = folderID
+	}
+	return applier, nil
+}
+
+// apply runs the action against one message.
+func (b *batchApplier) apply(ctx context.Context, target batchTarget) error {
+	if b.action.Name == "delete" {
+		return b.client.DeleteMessage(ctx, b.grantID, target.ID)
+	}
+
+	folders := target.Folders
+	if folders == nil && b.action.needsFolders() {
+		msg, err := b.client.GetMessage(ctx, b.grantID, target.ID)
+		if err != nil {
+			return err
+		}
+		folders = msg.Folders
+	}
+
+	req := &domain.UpdateMessageRequest{}
+	yes, no := true, false
+	switch b.action.Name {
+	case "archive":
+		req.Folders = archiveFolders(folders, b.folderID)
+	case "mark-read":
+		req.Unread = &no
+	case "mark-unread":
+		req.Unread = &yes
+	case "star":
+		req.Starred = &yes
+	case "unstar":
+		req.Starred = &no
+	case "move":
+		req.Folders = []string{b.folderID}
+	case "label":
+		req.Folders = folders
+		if !slices.Contains(folders, b.folderID) {
+			req.Folders = append(slices.Clone(folders), b.folderID)
+		}
+	}
+
+	_, err := b.client.UpdateMessage(ctx, b.grantID, target.ID, req)
+	return err
+}
+
+// archiveFolders returns a message's folders after archiving: the archive
+// folder when the account has one, otherwise its folders without the inbox.
+func archiveFolders(current []string, archiveID string) []string {
+	if archiveID != "" {
+		return []string{archiveID}
+	}
+	return slices.DeleteFunc(slices.Clone(current), func(id string) bool {
+		return id == "INBOX" || id == "inbox"
+	})
+}
+
+// batchFailure is a message the action failed on.
+type batchFailure struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// batchReport is the JSON record of an email batch run. --resume reads it
+// back and retries the failed and pending messages.
+type batchReport struct {
+	GrantID   string         `json:"grant_id"`
+	Search    string         `json:"search"`
+	Action    string         `json:"action"`
+	StartedAt time.Time      `json:"started_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Total     int            `json:"total"`
+	Succeeded []string       `json:"succeeded"`
+	Failed    []batchFailure `json:"failed"`
+	Pending   []string       `json:"pending,omitempty"`
+}
+
+// loadBatchReport reads a report written by an earlier run.
+func loadBatchReport(path string) (*batchReport, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's --resume argument
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch report: %w", err)
+	}
+	var report batchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse batch report %s: %w", path, err)
+	}
+	if report.GrantID == "" || report.Action == "" {
+		return nil, common.NewUserError(fmt.Sprintf("%s is not an email batch report", path), "Pass the file written by --report")
+	}
+	return &report, nil
+}
+
+// save writes the report to path.
+func (r *batchReport) save(path string) error {
+	r.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write batch report: %w", err)
+	}
+	return nil
+}
+
+// remaining returns the messages still to process: the failures, then the
+// ones never attempted. They're cleared so a rerun can record them afresh.
+func (r *batchReport) remaining() []batchTarget {
+	targets := make([]batchTarget, 0, len(r.Failed)+len(r.Pending))
+	for _, f := range r.Failed {
+		targets = append(targets, batchTarget{ID: f.ID})
+	}
+	for _, id := range r.Pending {
+		targets = append(targets, batchTarget{ID: id})
+	}
+	r.Failed = nil
+	r.Pending = nil
+	return targets
+}
+
+// unfinished is the number of messages that failed or were never attempted.
+func (r *batchReport) unfinished() int {
+	return len(r.Failed) + len(r.Pending)
+}
+
+// runBatch applies fn to every target with at most concurrency calls in
+// flight, recording each outcome in report. Once ctx is done no more targets
+// are started; they, and any calls cut short, are recorded as pending.
+// done is called after each target.
+func runBatch(ctx context.Context, targets []batchTarget, concurrency int, report *batchReport, fn func(ctx context.Context, target batchTarget) error, done func()) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	record := func(target batchTarget, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err == nil:
+			report.Succeeded = append(report.Succeeded, target.ID)
+		case ctx.Err() != nil:
+			report.Pending = append(report.Pending, target.ID)
+		default:
+			report.Failed = append(report.Failed, batchFailure{ID: target.ID, Error: err.Error()})
+		}
+	}
+
+	for i, target := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			mu.Lock()
+			for _, t := range targets[i:] {
+				report.Pending = append(report.Pending, t.ID)
+			}
+			mu.Unlock()
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			record(target, fn(ctx, target))
+			if done != nil {
+				done()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
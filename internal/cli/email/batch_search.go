@@ -0,0 +1,143 @@
+package email
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// parseBatchSearch turns a Gmail-style search string into message query
+// params. Supported operators are from:, to:, cc:, bcc:, subject:, in:,
+// is:unread|read|starred|unstarred, has:attachment, older_than: and
+// newer_than: (h, d, w, m or y) and before:/after: (YYYY-MM-DD). Any other
+// words become the full-text query. in: is left as a folder name for the
+// caller to resolve.
+func parseBatchSearch(search string, now time.Time) (*domain.MessageQueryParams, error) {
+	params := &domain.MessageQueryParams{}
+	var text []string
+
+	for _, term := range splitSearchTerms(search) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			text = append(text, term)
+			continue
+		}
+
+		key = strings.ToLower(key)
+		switch key {
+		case "from":
+			params.From = value
+		case "to":
+			params.To = value
+		case "cc":
+			params.Cc = value
+		case "bcc":
+			params.Bcc = value
+		case "subject":
+			params.Subject = value
+		case "in":
+			params.In = append(params.In, value)
+		case "is":
+			yes, no := true, false
+			switch strings.ToLower(value) {
+			case "unread":
+				params.Unread = &yes
+			case "read":
+				params.Unread = &no
+			case "starred":
+				params.Starred = &yes
+			case "unstarred":
+				params.Starred = &no
+			default:
+				return nil, searchTermError(term, "is: takes unread, read, starred or unstarred")
+			}
+		case "has":
+			if strings.ToLower(value) != "attachment" {
+				return nil, searchTermError(term, "has: only supports attachment")
+			}
+			yes := true
+			params.HasAttachment = &yes
+		case "older_than", "newer_than":
+			age, err := parseSearchAge(value)
+			if err != nil {
+				return nil, searchTermError(term, "Use a number and unit, e.g. 12h, 30d, 2w, 6m or 1y")
+			}
+			if key == "older_than" {
+				params.ReceivedBefore = now.Add(-age).Unix()
+			} else {
+				params.ReceivedAfter = now.Add(-age).Unix()
+			}
+		case "before", "after":
+			day, err := time.ParseInLocation("2006-01-02", value, now.Location())
+			if err != nil {
+				return nil, searchTermError(term, "Dates use the YYYY-MM-DD format")
+			}
+			if key == "before" {
+				params.ReceivedBefore = day.Unix()
+			} else {
+				params.ReceivedAfter = day.Unix()
+			}
+		default:
+			text = append(text, term)
+		}
+	}
+
+	params.SearchQuery = strings.Join(text, " ")
+	return params, nil
+}
+
+// searchTermError reports a search term that couldn't be parsed.
+func searchTermError(term, suggestion string) error {
+	return common.NewUserError(fmt.Sprintf("invalid search term %q", term), suggestion)
+}
+
+// parseSearchAge parses an older_than:/newer_than: value such as "30d".
+// Months and years are counted as 30 and 365 days.
+func parseSearchAge(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q", value)
+	}
+
+	day := 24 * time.Hour
+	units := map[byte]time.Duration{'h': time.Hour, 'd': day, 'w': 7 * day, 'm': 30 * day, 'y': 365 * day}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid age unit in %q", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// splitSearchTerms splits a search on whitespace, keeping double-quoted
+// phrases together and dropping the quotes: subject:"weekly report" is one
+// term.
+func splitSearchTerms(search string) []string {
+	var terms []string
+	var current strings.Builder
+	quoted := false
+
+	for _, r := range search {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms
+}
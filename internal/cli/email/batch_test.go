@@ -0,0 +1,250 @@
+//go:build !integration
+
+package email
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBatchSearch(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	params, err := parseBatchSearch(`from:noreply@example.com older_than:30d is:unread has:attachment subject:"weekly report" in:Receipts invoice`, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "noreply@example.com", params.From)
+	assert.Equal(t, now.AddDate(0, 0, -30).Unix(), params.ReceivedBefore)
+	require.NotNil(t, params.Unread)
+	assert.True(t, *params.Unread)
+	require.NotNil(t, params.HasAttachment)
+	assert.True(t, *params.HasAttachment)
+	assert.Equal(t, "weekly report", params.Subject)
+	assert.Equal(t, []string{"Receipts"}, params.In)
+	assert.Equal(t, "invoice", params.SearchQuery)
+}
+
+func TestParseBatchSearch_Dates(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	params, err := parseBatchSearch("after:2024-01-01 before:2024-02-01 newer_than:2w", now)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Unix(), params.ReceivedBefore)
+	// newer_than comes after after:, so it wins
+	assert.Equal(t, now.AddDate(0, 0, -14).Unix(), params.ReceivedAfter)
+}
+
+func TestParseBatchSearch_Invalid(t *testing.T) {
+	for _, search := range []string{"is:important", "has:link", "older_than:30x", "older_than:d", "before:31/01/2024"} {
+		t.Run(search, func(t *testing.T) {
+			_, err := parseBatchSearch(search, time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseBatchAction(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    batchAction
+		wantErr bool
+	}{
+		{input: "archive", want: batchAction{Name: "archive"}},
+		{input: "Mark-Read", want: batchAction{Name: "mark-read"}},
+		{input: "move:Receipts", want: batchAction{Name: "move", Folder: "Receipts"}},
+		{input: "label:Work/Projects", want: batchAction{Name: "label", Folder: "Work/Projects"}},
+		{input: "move", wantErr: true},
+		{input: "delete:Trash", wantErr: true},
+		{input: "explode", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseBatchAction(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBatchApplier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("archive drops the inbox without an archive folder", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		var got *domain.UpdateMessageRequest
+		client.UpdateMessageFunc = func(_ context.Context, _, _ string, req *domain.UpdateMessageRequest) (*domain.Message, error) {
+			got = req
+			return &domain.Message{}, nil
+		}
+
+		applier, err := newBatchApplier(ctx, client, "grant", batchAction{Name: "archive"})
+		require.NoError(t, err)
+		require.NoError(t, applier.apply(ctx, batchTarget{ID: "m1", Folders: []string{"INBOX", "work"}}))
+		assert.Equal(t, []string{"work"}, got.Folders)
+	})
+
+	t.Run("archive moves to the archive folder", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		client.GetFoldersFunc = func(context.Context, string) ([]domain.Folder, error) {
+			return []domain.Folder{{ID: "inbox-id", Name: "Inbox"}, {ID: "archive-id", Name: "Archive", SystemFolder: "archive"}}, nil
+		}
+		var got *domain.UpdateMessageRequest
+		client.UpdateMessageFunc = func(_ context.Context, _, _ string, req *domain.UpdateMessageRequest) (*domain.Message, error) {
+			got = req
+			return &domain.Message{}, nil
+		}
+
+		applier, err := newBatchApplier(ctx, client, "grant", batchAction{Name: "archive"})
+		require.NoError(t, err)
+		require.NoError(t, applier.apply(ctx, batchTarget{ID: "m1", Folders: []string{"inbox-id"}}))
+		assert.Equal(t, []string{"archive-id"}, got.Folders)
+	})
+
+	t.Run("label looks up folders when resuming", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		client.GetMessageFunc = func(context.Context, string, string) (*domain.Message, error) {
+			return &domain.Message{Folders: []string{"inbox"}}, nil
+		}
+		var got *domain.UpdateMessageRequest
+		client.UpdateMessageFunc = func(_ context.Context, _, _ string, req *domain.UpdateMessageRequest) (*domain.Message, error) {
+			got = req
+			return &domain.Message{}, nil
+		}
+
+		applier, err := newBatchApplier(ctx, client, "grant", batchAction{Name: "label", Folder: "sent"})
+		require.NoError(t, err)
+		require.NoError(t, applier.apply(ctx, batchTarget{ID: "m1"}))
+		assert.True(t, client.GetMessageCalled)
+		assert.Equal(t, []string{"inbox", "sent"}, got.Folders)
+	})
+
+	t.Run("mark-read", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		applier, err := newBatchApplier(ctx, client, "grant", batchAction{Name: "mark-read"})
+		require.NoError(t, err)
+		require.NoError(t, applier.apply(ctx, batchTarget{ID: "m1"}))
+		assert.True(t, client.UpdateMessageCalled)
+		assert.False(t, client.GetMessageCalled)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		applier, err := newBatchApplier(ctx, client, "grant", batchAction{Name: "delete"})
+		require.NoError(t, err)
+		require.NoError(t, applier.apply(ctx, batchTarget{ID: "m1"}))
+		assert.True(t, client.DeleteMessageCalled)
+		assert.Equal(t, "m1", client.LastMessageID)
+	})
+}
+
+func batchTargets(ids ...string) []batchTarget {
+	targets := make([]batchTarget, len(ids))
+	for i, id := range ids {
+		targets[i] = batchTarget{ID: id}
+	}
+	return targets
+}
+
+func TestRunBatch(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	report := &batchReport{}
+	var done atomic.Int32
+
+	runBatch(context.Background(), batchTargets("a", "b", "c", "d", "e", "f"), 2, report, func(_ context.Context, target batchTarget) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if target.ID == "c" {
+			return errors.New("rate limited")
+		}
+		return nil
+	}, func() { done.Add(1) })
+
+	sort.Strings(report.Succeeded)
+	assert.Equal(t, []string{"a", "b", "d", "e", "f"}, report.Succeeded)
+	assert.Equal(t, []batchFailure{{ID: "c", Error: "rate limited"}}, report.Failed)
+	assert.Empty(t, report.Pending)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Equal(t, int32(6), done.Load())
+}
+
+func TestRunBatch_Interrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	report := &batchReport{}
+	var mu sync.Mutex
+	runBatch(ctx, batchTargets("a", "b", "c", "d"), 1, report, func(ctx context.Context, target batchTarget) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if target.ID == "b" {
+			cancel()
+			return ctx.Err()
+		}
+		return nil
+	}, nil)
+
+	assert.Equal(t, []string{"a"}, report.Succeeded)
+	assert.Empty(t, report.Failed)
+	sort.Strings(report.Pending)
+	assert.Equal(t, []string{"b", "c", "d"}, report.Pending)
+}
+
+func TestBatchReport_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	report := &batchReport{
+		GrantID:   "grant",
+		Search:    "from:a@example.com",
+		Action:    "move:Receipts",
+		StartedAt: time.Now(),
+		Total:     4,
+		Succeeded: []string{"a"},
+		Failed:    []batchFailure{{ID: "b", Error: "boom"}},
+		Pending:   []string{"c", "d"},
+	}
+	require.NoError(t, report.save(path))
+
+	loaded, err := loadBatchReport(path)
+	require.NoError(t, err)
+	assert.Equal(t, "move:Receipts", loaded.Action)
+
+	targets := loaded.remaining()
+	assert.Equal(t, batchTargets("b", "c", "d"), targets)
+	assert.Zero(t, loaded.unfinished())
+
+	runBatch(context.Background(), targets, 2, loaded, func(context.Context, batchTarget) error { return nil }, nil)
+	sort.Strings(loaded.Succeeded)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, loaded.Succeeded)
+	assert.Zero(t, loaded.unfinished())
+}
+
+func TestLoadBatchReport_NotAReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "other.json")
+	require.NoError(t, (&batchReport{}).save(path))
+
+	_, err := loadBatchReport(path)
+	assert.Error(t, err)
+}
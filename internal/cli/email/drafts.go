@@ -47,7 +47,7 @@ func newDraftsListCmd() *cobra.Command {
 				}
 
 				// JSON output (including empty array)
-				if common.IsJSON(cmd) {
+				if common.IsStructuredOutput(cmd) {
 					out := common.GetOutputWriter(cmd)
 					return struct{}{}, out.Write(drafts)
 				}
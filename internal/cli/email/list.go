@@ -11,17 +11,24 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// listOptions holds the email list flags.
+type listOptions struct {
+	limit        int
+	unread       bool
+	starred      bool
+	from         string
+	folder       string
+	showID       bool
+	all          bool
+	allFolders   bool
+	allGrants    bool
+	maxItems     int
+	metadataPair string
+	pageToken    string
+}
+
 func newListCmd() *cobra.Command {
-	var limit int
-	var unread bool
-	var starred bool
-	var from string
-	var folder string
-	var showID bool
-	var all bool
-	var allFolders bool
-	var maxItems int
-	var metadataPair string
+	var opts listOptions
 
 	cmd := &cobra.Command{
 		Use:   "list [grant-id]",
@@ -31,8 +38,13 @@ func newListCmd() *cobra.Command {
 By default, only shows messages from INBOX. Use --folder to specify a different
 folder, or --all-folders to show messages from all folders.
 
-Use --all to fetch all messages (paginated automatically).
-Use --max to limit total messages when using --all.`,
+Use --all to fetch all messages (paginated automatically). Messages are
+printed as each page arrives; press Ctrl-C to stop, and resume later with the
+--page-token it prints.
+Use --max to limit total messages when using --all.
+
+Use --all-grants to list the newest messages across every authenticated
+account, with an account column. --limit applies to the merged list.`,
 		Example: `  # List recent emails from inbox
   nylas email list
 
@@ -46,99 +58,39 @@ Use --max to limit total messages when using --all.`,
   nylas email list --folder SENT
 
   # Fetch all emails with pagination
-  nylas email list --all --max 500`,
+  nylas email list --all --max 500
+
+  # Resume an interrupted --all listing
+  nylas email list --all --page-token CURSOR
+
+  # Unread mail across all accounts
+  nylas email list --all-grants --unread`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.allGrants {
+				if err := common.CheckAllGrantsArgs(opts.allGrants, args); err != nil {
+					return err
+				}
+				return runListAllGrants(cmd, opts)
+			}
+
 			// Check if we should use structured output (JSON/YAML/quiet)
-			if common.IsJSON(cmd) {
-				return runListStructured(cmd, args, limit, unread, starred, from, folder, allFolders, all, maxItems, metadataPair)
+			if common.IsStructuredOutput(cmd) {
+				return runListStructured(cmd, args, opts)
 			}
 
 			// Traditional formatted output
 			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
-				params := &domain.MessageQueryParams{
-					Limit: limit,
-				}
+				params := opts.params(ctx, cmd, client, grantID)
 
-				if cmd.Flags().Changed("unread") {
-					params.Unread = &unread
-				}
-				if cmd.Flags().Changed("starred") {
-					params.Starred = &starred
-				}
-				if from != "" {
-					params.From = from
-				}
-				if metadataPair != "" {
-					params.MetadataPair = metadataPair
-				}
-
-				// Default to INBOX unless --all-folders is set or specific folder is provided
-				if folder != "" {
-					// Resolve folder name to ID if needed (for Microsoft accounts)
-					resolvedFolder, err := resolveFolderName(ctx, client, grantID, folder)
-					if err != nil {
-						// API error - warn user but continue with literal name
-						_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not resolve folder '%s': %v\n", folder, err)
-						params.In = []string{folder}
-					} else if resolvedFolder != "" {
-						params.In = []string{resolvedFolder}
-					} else {
-						// Folder not found by name, use literal
-						params.In = []string{folder}
-					}
-				} else if !allFolders {
-					// Try to find inbox folder ID (works for both Google and Microsoft)
-					inboxID, err := resolveFolderName(ctx, client, grantID, "INBOX")
-					if err != nil {
-						// API error - warn but fallback to literal INBOX
-						_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not resolve INBOX folder: %v\n", err)
-						params.In = []string{"INBOX"}
-					} else if inboxID != "" {
-						params.In = []string{inboxID}
-					} else {
-						// Fallback to INBOX (works for Google)
-						params.In = []string{"INBOX"}
-					}
+				if opts.all {
+					return struct{}{}, streamMessageSummaries(ctx, client, grantID, params, opts.limit, opts.maxItems, opts.showID)
 				}
 
-				var messages []domain.Message
-				var err error
-
-				if all {
-					// Use pagination to fetch all messages
-					pageSize := 50 // Optimal page size for API
-					if limit > 0 && limit < pageSize {
-						pageSize = limit
-					}
-					params.Limit = pageSize
-
-					fetcher := func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
-						params.PageToken = cursor
-						resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
-						if err != nil {
-							return common.PageResult[domain.Message]{}, err
-						}
-						return common.PageResult[domain.Message]{
-							Data:       resp.Data,
-							NextCursor: resp.Pagination.NextCursor,
-						}, nil
-					}
-
-					config := common.DefaultPaginationConfig()
-					config.PageSize = pageSize
-					config.MaxItems = maxItems
-
-					messages, err = common.FetchAllPages(ctx, config, fetcher)
-					if err != nil {
-						return struct{}{}, common.WrapFetchError("messages", err)
-					}
-				} else {
-					// Standard single-page fetch
-					messages, err = client.GetMessagesWithParams(ctx, grantID, params)
-					if err != nil {
-						return struct{}{}, common.WrapGetError("messages", err)
-					}
+				// Standard single-page fetch
+				messages, err := client.GetMessagesWithParams(ctx, grantID, params)
+				if err != nil {
+					return struct{}{}, common.WrapGetError("messages", err)
 				}
 
 				if len(messages) == 0 {
@@ -148,7 +100,7 @@ Use --max to limit total messages when using --all.`,
 
 				fmt.Printf("Found %d messages:\n\n", len(messages))
 				for i, msg := range messages {
-					printMessageSummaryWithID(msg, i+1, showID)
+					printMessageSummaryWithID(msg, i+1, opts.showID)
 				}
 
 				return struct{}{}, nil
@@ -157,20 +109,77 @@ Use --max to limit total messages when using --all.`,
 		},
 	}
 
-	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of messages to fetch (per page with --all)")
-	cmd.Flags().BoolVarP(&unread, "unread", "u", false, "Only show unread messages")
-	cmd.Flags().BoolVarP(&starred, "starred", "s", false, "Only show starred messages")
-	cmd.Flags().StringVarP(&from, "from", "f", "", "Filter by sender email")
-	cmd.Flags().StringVar(&folder, "folder", "", "Filter by folder (e.g., INBOX, SENT, TRASH, or folder ID)")
-	cmd.Flags().BoolVar(&allFolders, "all-folders", false, "Show messages from all folders (default: INBOX only)")
-	cmd.Flags().BoolVar(&showID, "id", false, "Show message IDs")
-	cmd.Flags().BoolVarP(&all, "all", "a", false, "Fetch all messages (paginated)")
-	cmd.Flags().IntVar(&maxItems, "max", 0, "Maximum messages to fetch with --all (0=unlimited)")
-	cmd.Flags().StringVar(&metadataPair, "metadata", "", "Filter by metadata (format: key:value, only key1-key5 supported)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 10, "Number of messages to fetch (per page with --all)")
+	cmd.Flags().BoolVarP(&opts.unread, "unread", "u", false, "Only show unread messages")
+	cmd.Flags().BoolVarP(&opts.starred, "starred", "s", false, "Only show starred messages")
+	cmd.Flags().StringVarP(&opts.from, "from", "f", "", "Filter by sender email")
+	cmd.Flags().StringVar(&opts.folder, "folder", "", "Filter by folder (e.g., INBOX, SENT, TRASH, or folder ID)")
+	cmd.Flags().BoolVar(&opts.allFolders, "all-folders", false, "Show messages from all folders (default: INBOX only)")
+	cmd.Flags().BoolVar(&opts.showID, "id", false, "Show message IDs")
+	cmd.Flags().BoolVarP(&opts.all, "all", "a", false, "Fetch all messages (paginated)")
+	cmd.Flags().IntVar(&opts.maxItems, "max", 0, "Maximum messages to fetch with --all (0=unlimited)")
+	cmd.Flags().StringVar(&opts.metadataPair, "metadata", "", "Filter by metadata (format: key:value, only key1-key5 supported)")
+	common.AddPageTokenFlag(cmd, &opts.pageToken)
+	common.AddAllGrantsFlag(cmd, &opts.allGrants)
+	cmd.MarkFlagsMutuallyExclusive("all", "all-grants")
+	cmd.MarkFlagsMutuallyExclusive("page-token", "all-grants")
 
 	return cmd
 }
 
+// params builds the message query for a grant. Folder names are resolved to
+// the grant's folder IDs, defaulting to the inbox unless --all-folders is set.
+func (o listOptions) params(ctx context.Context, cmd *cobra.Command, client ports.NylasClient, grantID string) *domain.MessageQueryParams {
+	params := &domain.MessageQueryParams{
+		Limit:     o.limit,
+		PageToken: o.pageToken,
+	}
+
+	if cmd.Flags().Changed("unread") {
+		params.Unread = &o.unread
+	}
+	if cmd.Flags().Changed("starred") {
+		params.Starred = &o.starred
+	}
+	if o.from != "" {
+		params.From = o.from
+	}
+	if o.metadataPair != "" {
+		params.MetadataPair = o.metadataPair
+	}
+
+	// Default to INBOX unless --all-folders is set or specific folder is provided
+	if o.folder != "" {
+		// Resolve folder name to ID if needed (for Microsoft accounts)
+		resolvedFolder, err := resolveFolderName(ctx, client, grantID, o.folder)
+		if err != nil {
+			// API error - warn user but continue with literal name
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not resolve folder '%s': %v\n", o.folder, err)
+			params.In = []string{o.folder}
+		} else if resolvedFolder != "" {
+			params.In = []string{resolvedFolder}
+		} else {
+			// Folder not found by name, use literal
+			params.In = []string{o.folder}
+		}
+	} else if !o.allFolders {
+		// Try to find inbox folder ID (works for both Google and Microsoft)
+		inboxID, err := resolveFolderName(ctx, client, grantID, "INBOX")
+		if err != nil {
+			// API error - warn but fallback to literal INBOX
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not resolve INBOX folder: %v\n", err)
+			params.In = []string{"INBOX"}
+		} else if inboxID != "" {
+			params.In = []string{inboxID}
+		} else {
+			// Fallback to INBOX (works for Google)
+			params.In = []string{"INBOX"}
+		}
+	}
+
+	return params
+}
+
 // resolveFolderName looks up a folder by name and returns its ID.
 // This is needed for Microsoft accounts which use folder IDs, not names like "INBOX".
 // For Google accounts, this will just return the original name if no match is found.
@@ -232,80 +241,25 @@ func contains(slice []string, item string) bool {
 }
 
 // runListStructured handles structured output (JSON/YAML/quiet) for the list command.
-func runListStructured(cmd *cobra.Command, args []string, limit int, unread, starred bool,
-	from, folder string, allFolders, all bool, maxItems int, metadataPair string) error {
-
+func runListStructured(cmd *cobra.Command, args []string, opts listOptions) error {
 	_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
-		params := &domain.MessageQueryParams{
-			Limit: limit,
-		}
-
-		if cmd.Flags().Changed("unread") {
-			params.Unread = &unread
-		}
-		if cmd.Flags().Changed("starred") {
-			params.Starred = &starred
-		}
-		if from != "" {
-			params.From = from
-		}
-		if metadataPair != "" {
-			params.MetadataPair = metadataPair
-		}
-
-		// Default to INBOX unless --all-folders is set or specific folder is provided
-		if folder != "" {
-			resolvedFolder, err := resolveFolderName(ctx, client, grantID, folder)
-			if err != nil {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not resolve folder '%s': %v\n", folder, err)
-				params.In = []string{folder}
-			} else if resolvedFolder != "" {
-				params.In = []string{resolvedFolder}
-			} else {
-				params.In = []string{folder}
-			}
-		} else if !allFolders {
-			inboxID, err := resolveFolderName(ctx, client, grantID, "INBOX")
-			if err != nil {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not resolve INBOX folder: %v\n", err)
-				params.In = []string{"INBOX"}
-			} else if inboxID != "" {
-				params.In = []string{inboxID}
-			} else {
-				params.In = []string{"INBOX"}
-			}
-		}
+		params := opts.params(ctx, cmd, client, grantID)
 
 		var messages []domain.Message
 		var err error
 
-		if all {
-			pageSize := 50
-			if limit > 0 && limit < pageSize {
-				pageSize = limit
-			}
-			params.Limit = pageSize
-
-			fetcher := func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
-				params.PageToken = cursor
-				resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
-				if err != nil {
-					return common.PageResult[domain.Message]{}, err
-				}
-				return common.PageResult[domain.Message]{
-					Data:       resp.Data,
-					NextCursor: resp.Pagination.NextCursor,
-				}, nil
-			}
+		if opts.all {
+			fetcher, config := messagePages(client, grantID, params, opts.limit, opts.maxItems)
 
-			config := common.DefaultPaginationConfig()
-			config.PageSize = pageSize
-			config.MaxItems = maxItems
-
-			messages, err = common.FetchAllPages(ctx, config, fetcher)
+			// Written page by page unless the output has to be sorted or queried
+			var streamed bool
+			messages, streamed, err = common.FetchAllOrStream(ctx, cmd, config, fetcher, nil)
 			if err != nil {
 				return struct{}{}, common.WrapFetchError("messages", err)
 			}
+			if streamed {
+				return struct{}{}, nil
+			}
 		} else {
 			messages, err = client.GetMessagesWithParams(ctx, grantID, params)
 			if err != nil {
@@ -319,3 +273,75 @@ func runListStructured(cmd *cobra.Command, args []string, limit int, unread, sta
 	})
 	return err
 }
+
+// runListAllGrants lists the newest messages across every stored grant.
+func runListAllGrants(cmd *cobra.Command, opts listOptions) error {
+	messages, err := common.WithAllGrants(cmd, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Message, error) {
+		return client.GetMessagesWithParams(ctx, grantID, opts.params(ctx, cmd, client, grantID))
+	}, newAccountMessage, newerMessage)
+	if err != nil {
+		return common.WrapGetError("messages", err)
+	}
+	if opts.limit > 0 && len(messages) > opts.limit {
+		messages = messages[:opts.limit]
+	}
+	return printAccountMessages(cmd, messages, opts.showID)
+}
+
+// messagePages returns a page fetcher and pagination config for --all.
+// params.PageToken, if set, is where the listing starts.
+func messagePages(client ports.NylasClient, grantID string, params *domain.MessageQueryParams, limit, maxItems int) (common.PageFetcher[domain.Message], common.PaginationConfig) {
+	pageSize := 50 // Optimal page size for API
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	params.Limit = pageSize
+
+	fetcher := func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		query := *params
+		query.PageToken = cursor
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, &query)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{
+			Data:       resp.Data,
+			NextCursor: resp.Pagination.NextCursor,
+		}, nil
+	}
+
+	config := common.DefaultPaginationConfig()
+	config.PageSize = pageSize
+	config.MaxItems = maxItems
+	config.StartCursor = params.PageToken
+	return fetcher, config
+}
+
+// streamMessageSummaries prints every message, a page at a time as pages
+// arrive. Ctrl-C stops it with the cursor to resume from.
+func streamMessageSummaries(ctx context.Context, client ports.NylasClient, grantID string, params *domain.MessageQueryParams, limit, maxItems int, showID bool) error {
+	fetcher, config := messagePages(client, grantID, params, limit, maxItems)
+	config.ShowProgress = false
+
+	ctx, stop := common.WithInterrupt(ctx)
+	defer stop()
+
+	printed := 0
+	count, err := common.StreamAllPages(ctx, config, fetcher, func(page []domain.Message) error {
+		for _, msg := range page {
+			printed++
+			printMessageSummaryWithID(msg, printed, showID)
+		}
+		return nil
+	})
+	if err != nil {
+		return common.WrapFetchError("messages", err)
+	}
+
+	if count == 0 {
+		common.PrintEmptyState("messages")
+		return nil
+	}
+	_, _ = common.Dim.Printf("\nFetched %d messages\n", count)
+	return nil
+}
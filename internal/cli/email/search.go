@@ -24,6 +24,7 @@ func newSearchCmd() *cobra.Command {
 		starred       bool
 		inFolder      string
 		jsonOutput    bool
+		allGrants     bool
 	)
 
 	cmd := &cobra.Command{
@@ -48,13 +49,16 @@ Examples:
   nylas email search "invoice" --after 2024-01-01 --before 2024-12-31
 
   # Search for messages with attachments
-  nylas email search "*" --has-attachment --from "hr@company.com"`,
+  nylas email search "*" --has-attachment --from "hr@company.com"
+
+  # Search every authenticated account
+  nylas email search "invoice" --all-grants`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := args[0]
 			remainingArgs := args[1:]
 
-			_, err := common.WithClient(remainingArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+			buildParams := func() (*domain.MessageQueryParams, error) {
 				params := &domain.MessageQueryParams{
 					Limit: limit,
 				}
@@ -90,18 +94,45 @@ Examples:
 				if after != "" {
 					t, err := parseDate(after)
 					if err != nil {
-						return struct{}{}, common.WrapDateParseError("after", err)
+						return nil, common.WrapDateParseError("after", err)
 					}
 					params.ReceivedAfter = t.Unix()
 				}
 				if before != "" {
 					t, err := parseDate(before)
 					if err != nil {
-						return struct{}{}, common.WrapDateParseError("before", err)
+						return nil, common.WrapDateParseError("before", err)
 					}
 					params.ReceivedBefore = t.Unix()
 				}
+				return params, nil
+			}
+
+			params, err := buildParams()
+			if err != nil {
+				return err
+			}
+
+			if allGrants {
+				if err := common.CheckAllGrantsArgs(allGrants, remainingArgs); err != nil {
+					return err
+				}
+				messages, err := common.WithAllGrants(cmd, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Message, error) {
+					return client.GetMessagesWithParams(ctx, grantID, params)
+				}, newAccountMessage, newerMessage)
+				if err != nil {
+					return common.WrapSearchError("messages", err)
+				}
+				if limit > 0 && len(messages) > limit {
+					messages = messages[:limit]
+				}
+				if jsonOutput {
+					return common.PrintJSON(messages)
+				}
+				return printAccountMessages(cmd, messages, false)
+			}
 
+			_, err = common.WithClient(remainingArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				messages, err := client.GetMessagesWithParams(ctx, grantID, params)
 				if err != nil {
 					return struct{}{}, common.WrapSearchError("messages", err)
@@ -138,6 +169,7 @@ Examples:
 	cmd.Flags().BoolVar(&starred, "starred", false, "Only starred messages")
 	cmd.Flags().StringVar(&inFolder, "in", "", "Filter by folder (e.g., INBOX, SENT)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	common.AddAllGrantsFlag(cmd, &allGrants)
 
 	return cmd
 }
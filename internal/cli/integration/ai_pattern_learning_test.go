@@ -1,343 +0,0 @@
-//go:build integration
-
-package integration
-
-import (
-	"context"
-	"testing"
-	"time"
-
-	"github.com/mqasimca/nylas/internal/adapters/ai"
-	"github.com/mqasimca/nylas/internal/domain"
-)
-
-// TestAI_PatternLearning tests the pattern learning functionality.
-func TestAI_PatternLearning(t *testing.T) {
-	skipIfMissingCreds(t)
-
-	t.Run("learn_patterns_from_calendar_history", func(t *testing.T) {
-		// Create Nylas client
-		client := getTestClient()
-
-		// Create LLM router with Ollama default
-		cfg := &domain.AIConfig{
-			DefaultProvider: "ollama",
-			Ollama: &domain.OllamaConfig{
-				Host:  "http://localhost:11434",
-				Model: "llama3.1:8b",
-			},
-		}
-		llmRouter := ai.NewRouter(cfg)
-
-		// Create pattern learner
-		learner := ai.NewPatternLearner(client, llmRouter)
-
-		// Create learning request (analyze last 30 days)
-		req := &ai.LearnPatternsRequest{
-			GrantID:          testGrantID,
-			LookbackDays:     30,
-			MinConfidence:    0.5,
-			IncludeRecurring: false,
-		}
-
-		// Learn patterns
-		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
-		defer cancel()
-
-		t.Log("Analyzing calendar history for patterns...")
-		patterns, err := learner.LearnPatterns(ctx, req)
-		if err != nil {
-			// No events is expected if the test account doesn't have recent calendar data
-			if err.Error() == "no events found in the specified period" {
-				t.Logf("No events found (expected for empty test account): %v", err)
-				return
-			}
-			t.Fatalf("Failed to learn patterns: %v", err)
-		}
-
-		// Verify patterns structure
-		if patterns == nil {
-			t.Fatal("Expected patterns, got nil")
-		}
-
-		t.Logf("Analysis Period: %d days (%s to %s)",
-			patterns.AnalysisPeriod.Days,
-			patterns.AnalysisPeriod.StartDate.Format("Jan 2"),
-			patterns.AnalysisPeriod.EndDate.Format("Jan 2"))
-		t.Logf("Total Events Analyzed: %d", patterns.TotalEventsAnalyzed)
-
-		// Test acceptance patterns
-		if len(patterns.AcceptancePatterns) > 0 {
-			t.Logf("Found %d acceptance patterns:", len(patterns.AcceptancePatterns))
-			for i, pattern := range patterns.AcceptancePatterns {
-				if i >= 3 {
-					break // Show top 3
-				}
-				t.Logf("  - %s: %.0f%% acceptance rate (%d events, %.0f%% confidence)",
-					pattern.TimeSlot,
-					pattern.AcceptRate*100,
-					pattern.EventCount,
-					pattern.Confidence*100)
-			}
-		} else {
-			t.Log("No acceptance patterns found (might need more calendar history)")
-		}
-
-		// Test duration patterns
-		if len(patterns.DurationPatterns) > 0 {
-			t.Logf("Found %d duration patterns:", len(patterns.DurationPatterns))
-			for i, pattern := range patterns.DurationPatterns {
-				if i >= 3 {
-					break // Show top 3
-				}
-				t.Logf("  - %s: avg %d min (%d events)",
-					pattern.MeetingType,
-					pattern.ScheduledDuration,
-					pattern.EventCount)
-			}
-		}
-
-		// Test timezone patterns
-		if len(patterns.TimezonePatterns) > 0 {
-			t.Logf("Found %d timezone patterns:", len(patterns.TimezonePatterns))
-			for i, pattern := range patterns.TimezonePatterns {
-				if i >= 3 {
-					break // Show top 3
-				}
-				t.Logf("  - %s: %.0f%% of meetings (%d events)",
-					pattern.Timezone,
-					pattern.Percentage*100,
-					pattern.EventCount)
-			}
-		}
-
-		// Test productivity insights
-		if len(patterns.ProductivityInsights) > 0 {
-			t.Logf("Found %d productivity insights:", len(patterns.ProductivityInsights))
-			for _, insight := range patterns.ProductivityInsights {
-				t.Logf("  - %s: %s (score: %d/100)",
-					insight.InsightType,
-					insight.Description,
-					insight.Score)
-			}
-		}
-
-		// Test recommendations
-		if len(patterns.Recommendations) > 0 {
-			t.Logf("AI Recommendations (%d):", len(patterns.Recommendations))
-			for i, rec := range patterns.Recommendations {
-				if i >= 5 {
-					break // Show top 5
-				}
-				t.Logf("  %d. %s", i+1, rec)
-			}
-		}
-
-		// Verify basic structure
-		if patterns.UserID == "" {
-			t.Error("Expected UserID to be set")
-		}
-		if patterns.GeneratedAt.IsZero() {
-			t.Error("Expected GeneratedAt to be set")
-		}
-	})
-
-	t.Run("pattern_learning_with_no_events", func(t *testing.T) {
-		// Create Nylas client
-		client := getTestClient()
-
-		// Create LLM router
-		cfg := &domain.AIConfig{
-			DefaultProvider: "ollama",
-			Ollama: &domain.OllamaConfig{
-				Host:  "http://localhost:11434",
-				Model: "llama3.1:8b",
-			},
-		}
-		llmRouter := ai.NewRouter(cfg)
-
-		// Create pattern learner
-		learner := ai.NewPatternLearner(client, llmRouter)
-
-		// Create learning request with very short lookback (likely no events)
-		req := &ai.LearnPatternsRequest{
-			GrantID:          testGrantID,
-			LookbackDays:     1,   // Only 1 day
-			MinConfidence:    0.9, // High confidence threshold
-			IncludeRecurring: false,
-		}
-
-		// Learn patterns
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		patterns, err := learner.LearnPatterns(ctx, req)
-
-		// Should handle empty/small dataset gracefully
-		if err != nil && patterns == nil {
-			t.Logf("No patterns found with minimal data (expected): %v", err)
-			return
-		}
-
-		if patterns != nil {
-			t.Logf("Found patterns even with minimal data: %d events analyzed",
-				patterns.TotalEventsAnalyzed)
-		}
-	})
-
-	t.Run("pattern_export_to_json", func(t *testing.T) {
-		// Create Nylas client
-		client := getTestClient()
-
-		// Create LLM router
-		cfg := &domain.AIConfig{
-			DefaultProvider: "ollama",
-			Ollama: &domain.OllamaConfig{
-				Host:  "http://localhost:11434",
-				Model: "llama3.1:8b",
-			},
-		}
-		llmRouter := ai.NewRouter(cfg)
-
-		// Create pattern learner
-		learner := ai.NewPatternLearner(client, llmRouter)
-
-		// Create learning request
-		req := &ai.LearnPatternsRequest{
-			GrantID:          testGrantID,
-			LookbackDays:     30,
-			MinConfidence:    0.5,
-			IncludeRecurring: false,
-		}
-
-		// Learn patterns
-		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
-		defer cancel()
-
-		patterns, err := learner.LearnPatterns(ctx, req)
-		if err != nil {
-			// No events is expected if the test account doesn't have recent calendar data
-			if err.Error() == "no events found in the specified period" {
-				t.Logf("No events found (expected for empty test account): %v", err)
-				return
-			}
-			t.Fatalf("Failed to learn patterns: %v", err)
-		}
-
-		// Export to JSON
-		jsonData, err := learner.ExportPatterns(patterns)
-		if err != nil {
-			t.Fatalf("Failed to export patterns: %v", err)
-		}
-
-		if len(jsonData) == 0 {
-			t.Error("Expected JSON data, got empty")
-		}
-
-		t.Logf("Exported %d bytes of JSON pattern data", len(jsonData))
-
-		// Verify JSON structure (basic check)
-		jsonStr := string(jsonData)
-		if !containsStr(jsonStr, "user_id") {
-			t.Error("JSON should contain 'user_id' field")
-		}
-		if !containsStr(jsonStr, "analysis_period") {
-			t.Error("JSON should contain 'analysis_period' field")
-		}
-	})
-}
-
-// TestCLI_AI_PatternAnalyze tests the CLI analyze command.
-func TestCLI_AI_PatternAnalyze(t *testing.T) {
-	skipIfMissingCreds(t)
-
-	// Check if Ollama is available
-	if !checkOllamaAvailable() {
-		t.Skip("Ollama not available - skipping pattern analysis test")
-	}
-
-	t.Run("cli_analyze_patterns", func(t *testing.T) {
-		t.Log("Running: nylas calendar ai analyze --days 30")
-
-		stdout, stderr, err := runCLI("calendar", "ai", "analyze",
-			"--days", "30")
-
-		output := stdout + stderr
-
-		// Command may timeout with context canceled - that's okay if we got partial output
-		if err != nil && !containsStr(output, "Analysis Period") {
-			// Only fail if we got no output at all
-			if len(output) < 100 {
-				t.Fatalf("Command failed with no output: %v\nOutput: %s", err, output)
-			}
-			t.Logf("Command completed with error but produced output: %v", err)
-		}
-
-		// Verify output contains expected sections
-		if !containsStr(output, "Analysis Period") && !containsStr(output, "Analyzing") {
-			t.Error("Output should contain 'Analysis Period' or 'Analyzing' text")
-		}
-
-		t.Logf("Pattern Analysis Output:\n%s", truncateOutput(output, 500))
-	})
-
-	t.Run("cli_analyze_patterns_json", func(t *testing.T) {
-		t.Log("Running: nylas calendar ai analyze --json")
-
-		stdout, stderr, err := runCLI("calendar", "ai", "analyze",
-			"--days", "30",
-			"--json")
-
-		output := stdout + stderr
-
-		// Command may show insufficient data warning - that's expected
-		if containsStr(output, "Insufficient data") || containsStr(output, "no events found") {
-			t.Logf("No events found (expected): %s", output)
-			return
-		}
-
-		// Command may timeout but still produce output - that's okay
-		if err != nil && !containsStr(output, "Analysis Period") {
-			// Only fail if we got no output at all
-			if len(output) < 100 {
-				t.Fatalf("Command failed with no output: %v\nOutput: %s", err, output)
-			}
-			t.Logf("Command completed with error but produced output: %v", err)
-		}
-
-		// Note: --json flag may not be fully implemented yet for analyze command
-		// Just verify we got some output with analysis data
-		if !containsStr(output, "Analysis Period") && !containsStr(output, "Total Meetings") {
-			t.Error("Output should contain analysis data")
-		}
-
-		t.Logf("Output (first 500 chars):\n%s", truncateOutput(output, 500))
-	})
-}
-
-// Helper functions
-
-func containsStr(s, substr string) bool {
-	// Simple substring check using strings package would be better,
-	// but keeping it simple for now
-	for i := 0; i <= len(s)-len(substr); i++ {
-		match := true
-		for j := 0; j < len(substr); j++ {
-			if s[i+j] != substr[j] {
-				match = false
-				break
-			}
-		}
-		if match {
-			return true
-		}
-	}
-	return false
-}
-
-func truncateOutput(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen] + "..."
-}
@@ -75,12 +75,12 @@ func TestSlack_Search(t *testing.T) {
 	}{
 		{
 			name:     "search messages",
-			args:     []string{"slack", "search", "--query", "test"},
+			args:     []string{"slack", "search", "test"},
 			contains: []string{}, // Just verify it runs (may return no results)
 		},
 		{
 			name:     "search with limit",
-			args:     []string{"slack", "search", "--query", "hello", "--limit", "5"},
+			args:     []string{"slack", "search", "hello", "--limit", "5"},
 			contains: []string{},
 		},
 		{
@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/mqasimca/nylas/internal/adapters/mcp"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// auditRow is the table view of an audit entry.
+type auditRow struct {
+	Time     string `json:"time"`
+	Tool     string `json:"tool"`
+	Grant    string `json:"grant"`
+	Outcome  string `json:"outcome"`
+	Latency  string `json:"latency"`
+	ArgsHash string `json:"args_hash"`
+	Error    string `json:"error"`
+}
+
+func newAuditCmd() *cobra.Command {
+	var (
+		limit   int
+		tool    string
+		outcome string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the MCP tool call audit log",
+		Long: `Show the local audit log of tool calls made through 'nylas mcp serve'.
+
+Each entry records the tool name, a hash of its arguments, the grant used,
+the outcome (ok, error, denied, confirmation_required, local) and latency.`,
+		Example: `  # Show the last 50 tool calls
+  nylas mcp audit
+
+  # Show denied calls only
+  nylas mcp audit --outcome denied
+
+  # Export as JSON
+  nylas mcp audit --limit 0 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := mcp.ReadAuditLog(defaultAuditPath(), 0)
+			if err != nil {
+				return common.WrapLoadError("audit log", err)
+			}
+
+			entries = filterAuditEntries(entries, tool, outcome, limit)
+			if len(entries) == 0 {
+				if !common.IsStructuredOutput(cmd) {
+					common.PrintEmptyState("audit entries")
+				}
+				return nil
+			}
+
+			if common.IsJSON(cmd) {
+				return common.GetOutputWriter(cmd).Write(entries)
+			}
+
+			rows := make([]auditRow, len(entries))
+			for i, e := range entries {
+				rows[i] = auditRow{
+					Time:     e.Time.Local().Format("2006-01-02 15:04:05"),
+					Tool:     e.Tool,
+					Grant:    e.Grant,
+					Outcome:  e.Outcome,
+					Latency:  (time.Duration(e.LatencyMS) * time.Millisecond).String(),
+					ArgsHash: e.ArgsHash,
+					Error:    e.Error,
+				}
+			}
+
+			columns := []ports.Column{
+				{Header: "Time", Field: "Time"},
+				{Header: "Tool", Field: "Tool"},
+				{Header: "Grant", Field: "Grant", Width: 20},
+				{Header: "Outcome", Field: "Outcome"},
+				{Header: "Latency", Field: "Latency"},
+				{Header: "Args", Field: "ArgsHash"},
+				{Header: "Error", Field: "Error", Width: 40},
+			}
+			wideColumns := []ports.Column{
+				{Header: "Time", Field: "Time"},
+				{Header: "Tool", Field: "Tool"},
+				{Header: "Grant", Field: "Grant", Width: -1},
+				{Header: "Outcome", Field: "Outcome"},
+				{Header: "Latency", Field: "Latency"},
+				{Header: "Args", Field: "ArgsHash"},
+				{Header: "Error", Field: "Error", Width: -1},
+			}
+			return common.WriteListWithWideColumns(cmd, rows, columns, wideColumns)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Number of most recent entries to show (0 for all)")
+	cmd.Flags().StringVar(&tool, "tool", "", "Only show calls to this tool")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "Only show calls with this outcome")
+
+	return cmd
+}
+
+// filterAuditEntries filters entries by tool and outcome and keeps the last limit entries.
+func filterAuditEntries(entries []mcp.AuditEntry, tool, outcome string, limit int) []mcp.AuditEntry {
+	filtered := make([]mcp.AuditEntry, 0, len(entries))
+	for _, e := range entries {
+		if tool != "" && e.Tool != tool {
+			continue
+		}
+		if outcome != "" && e.Outcome != outcome {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[len(filtered)-limit:]
+	}
+	return filtered
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"os"
+	"slices"
+
+	"github.com/mqasimca/nylas/internal/adapters/mcp"
+	"github.com/mqasimca/nylas/internal/air/cache"
+)
+
+// airMessageCache exposes the Nylas Air SQLite cache to the MCP proxy.
+type airMessageCache struct {
+	manager *cache.Manager
+}
+
+// newAirMessageCache opens the Air cache if it is enabled.
+// Returns nil when the cache is disabled or unavailable.
+func newAirMessageCache() mcp.MessageCache {
+	cfg := cache.DefaultConfig()
+	settings, err := cache.LoadSettings(cfg.BasePath)
+	if err != nil || !settings.IsCacheEnabled() {
+		return nil
+	}
+	manager, err := cache.NewManager(settings.ToConfig(cfg.BasePath))
+	if err != nil {
+		return nil
+	}
+	return &airMessageCache{manager: manager}
+}
+
+// emailStore returns the email store for an account, without creating a new cache file.
+func (c *airMessageCache) emailStore(email string) (*cache.EmailStore, error) {
+	if _, err := os.Stat(c.manager.DBPath(email)); err != nil {
+		return nil, err
+	}
+	db, err := c.manager.GetDB(email)
+	if err != nil {
+		return nil, err
+	}
+	return cache.NewEmailStore(db), nil
+}
+
+// RecentThreads groups the most recent cached emails by thread.
+func (c *airMessageCache) RecentThreads(email string, limit int) ([]mcp.CachedThread, error) {
+	store, err := c.emailStore(email)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []mcp.CachedThread{}, nil
+		}
+		return nil, err
+	}
+
+	// Emails are returned newest first; over-fetch so threads have full counts
+	emails, err := store.List(cache.ListOptions{Limit: limit * 10})
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]mcp.CachedThread, 0, limit)
+	index := make(map[string]int)
+	for _, e := range emails {
+		threadID := e.ThreadID
+		if threadID == "" {
+			threadID = e.ID
+		}
+
+		i, seen := index[threadID]
+		if !seen {
+			if len(threads) >= limit {
+				continue
+			}
+			i = len(threads)
+			index[threadID] = i
+			threads = append(threads, mcp.CachedThread{
+				ID:          threadID,
+				Subject:     e.Subject,
+				LastMessage: e.Date,
+			})
+		}
+
+		t := &threads[i]
+		t.MessageCount++
+		t.Unread = t.Unread || e.Unread
+		if e.FromEmail != "" && !slices.Contains(t.Participants, e.FromEmail) {
+			t.Participants = append(t.Participants, e.FromEmail)
+		}
+	}
+	return threads, nil
+}
+
+// ThreadMessages returns the cached messages of a thread, oldest first.
+func (c *airMessageCache) ThreadMessages(email, threadID string) ([]mcp.CachedMessage, error) {
+	store, err := c.emailStore(email)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	emails, err := store.List(cache.ListOptions{ThreadID: threadID})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]mcp.CachedMessage, 0, len(emails))
+	for i := len(emails) - 1; i >= 0; i-- {
+		e := emails[i]
+		body := e.BodyText
+		if body == "" {
+			body = e.Snippet
+		}
+		from := e.FromEmail
+		if e.FromName != "" {
+			from = e.FromName + " <" + e.FromEmail + ">"
+		}
+		messages = append(messages, mcp.CachedMessage{
+			ID:       e.ID,
+			ThreadID: e.ThreadID,
+			From:     from,
+			To:       e.To,
+			Subject:  e.Subject,
+			Date:     e.Date,
+			Body:     body,
+		})
+	}
+	return messages, nil
+}
@@ -45,6 +45,7 @@ For more information about Nylas MCP: https://developer.nylas.com/docs/dev-guide
 	cmd.AddCommand(newInstallCmd())
 	cmd.AddCommand(newUninstallCmd())
 	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newAuditCmd())
 
 	return cmd
 }
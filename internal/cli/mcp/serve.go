@@ -5,13 +5,15 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/mqasimca/nylas/internal/adapters/config"
 	"github.com/mqasimca/nylas/internal/adapters/keyring"
 	"github.com/mqasimca/nylas/internal/adapters/mcp"
+	"github.com/mqasimca/nylas/internal/adapters/templates"
 	"github.com/mqasimca/nylas/internal/cli/common"
-	"github.com/mqasimca/nylas/internal/ports"
 	"github.com/spf13/cobra"
 )
 
@@ -24,8 +26,14 @@ func newServeCmd() *cobra.Command {
 This command acts as a proxy to the official Nylas MCP server, providing
 access to all Nylas email and calendar tools through the Model Context Protocol.
 
-The server communicates via STDIO (standard input/output) and requires
-Nylas credentials to be configured via 'nylas auth login'.
+By default the server communicates via STDIO (standard input/output).
+With --http it serves the MCP Streamable HTTP transport instead, at
+http://<addr>/mcp, with per-client sessions and SSE streaming. HTTP clients
+must send "Authorization: Bearer <token>"; the token comes from --token,
+NYLAS_MCP_TOKEN, or is generated and printed on startup. An address without
+a host (":8080") binds to localhost only.
+
+Nylas credentials must be configured via 'nylas auth login'.
 
 Available tools (from Nylas MCP):
 
@@ -50,13 +58,56 @@ Available tools (from Nylas MCP):
     current_time      - Get current time
     epoch_to_datetime - Convert epoch to datetime
 
+Tool policy:
+  A YAML policy file (default: ~/.config/nylas/mcp-policy.yaml) can hide
+  tools, block all write tools, restrict tools to specific grants, or
+  require a confirmation token before a tool runs:
+
+    default: allow          # or deny
+    read_only: false        # true hides and blocks create_/update_/send_ tools
+    tools:
+      send_message:
+        confirm: true
+      list_messages:
+        grants: [<grant-id>]
+      update_event:
+        action: hide        # allow, deny or hide
+
+Local resources and prompts (served without a network round trip):
+  nylas://grants           Authenticated accounts and the default grant
+  nylas://templates[/{id}] Locally stored email templates
+  nylas://threads[/{id}]   Cached threads from the Nylas Air cache
+  template-<id> prompts    One prompt per email template
+
+Every tools/call is recorded in a local audit log; view it with 'nylas mcp audit'.
+
 For more information: https://developer.nylas.com/docs/dev-guide/mcp/`,
+		Example: `  # STDIO (used by AI assistants)
+  nylas mcp serve
+
+  # Streamable HTTP on localhost:8765
+  nylas mcp serve --http :8765`,
 		RunE: runServe,
 	}
 
+	cmd.Flags().String("http", "", "Serve Streamable HTTP on this address (e.g. :8765) instead of STDIO")
+	cmd.Flags().String("token", "", "Bearer token for HTTP clients (default: $NYLAS_MCP_TOKEN or generated)")
+	cmd.Flags().String("policy", "", "Path to tool policy file (default: ~/.config/nylas/mcp-policy.yaml)")
+	cmd.Flags().Bool("no-audit", false, "Disable the tools/call audit log")
+
 	return cmd
 }
 
+// defaultPolicyPath returns the default MCP tool policy location.
+func defaultPolicyPath() string {
+	return filepath.Join(config.DefaultConfigDir(), "mcp-policy.yaml")
+}
+
+// defaultAuditPath returns the default MCP audit log location.
+func defaultAuditPath() string {
+	return filepath.Join(config.DefaultConfigDir(), "mcp-audit.log")
+}
+
 func runServe(cmd *cobra.Command, args []string) error {
 	// Get API key from credentials
 	apiKey, err := common.GetAPIKey()
@@ -80,18 +131,36 @@ func runServe(cmd *cobra.Command, args []string) error {
 		proxy.SetDefaultGrant(grantID)
 	}
 
+	// Load tool policy (a missing default file means allow everything)
+	policyPath, _ := cmd.Flags().GetString("policy")
+	explicitPolicy := policyPath != ""
+	if !explicitPolicy {
+		policyPath = defaultPolicyPath()
+	}
+	policy, err := mcp.LoadPolicy(policyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load MCP policy: %w", err)
+	}
+	if policy == nil && explicitPolicy {
+		return fmt.Errorf("MCP policy file not found: %s", policyPath)
+	}
+	proxy.SetPolicy(policy)
+
+	if noAudit, _ := cmd.Flags().GetBool("no-audit"); !noAudit {
+		proxy.SetAuditLog(mcp.NewAuditLog(defaultAuditPath()))
+	}
+
 	// Set up grant store for local grant lookups (allows get_grant without email)
-	// Try multiple secret store backends to ensure we can access grants
-	var secretStore ports.SecretStore
-	secretStore, err = keyring.NewSecretStore(config.DefaultConfigDir())
+	grantStore, err := openGrantStore()
 	if err != nil {
-		// Fallback to encrypted file store if keyring fails
-		// This can happen when the MCP server runs in a sandboxed context
-		secretStore, err = keyring.NewEncryptedFileStore(config.DefaultConfigDir())
+		return err
 	}
-	if err == nil && secretStore != nil {
-		grantStore := keyring.NewGrantStore(secretStore)
-		proxy.SetGrantStore(grantStore)
+	proxy.SetGrantStore(grantStore)
+
+	// Local resources and prompts (templates and the Air message cache)
+	proxy.SetTemplateStore(templates.NewDefaultFileStore())
+	if messageCache := newAirMessageCache(); messageCache != nil {
+		proxy.SetMessageCache(messageCache)
 	}
 
 	// Setup context with signal handling
@@ -107,6 +176,53 @@ func runServe(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	if httpAddr, _ := cmd.Flags().GetString("http"); httpAddr != "" {
+		return serveHTTP(ctx, cmd, proxy, httpAddr)
+	}
+
 	// Run the proxy (blocks until context is cancelled or error)
 	return proxy.Run(ctx)
 }
+
+// openGrantStore opens the configured secret store for local grant lookups.
+// A store that can't be opened or is locked is an error rather than a
+// reason to read another store, which would hide the grants or serve stale
+// ones.
+func openGrantStore() (*keyring.GrantStore, error) {
+	secretStore, err := keyring.NewSecretStore(config.DefaultConfigDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the secret store: %w", err)
+	}
+	grantStore := keyring.NewGrantStore(secretStore)
+	if _, err := grantStore.ListGrants(); err != nil {
+		return nil, fmt.Errorf("failed to read grants from %s: %w", secretStore.Name(), err)
+	}
+	return grantStore, nil
+}
+
+// serveHTTP runs the proxy over the Streamable HTTP transport.
+func serveHTTP(ctx context.Context, cmd *cobra.Command, proxy *mcp.Proxy, addr string) error {
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv("NYLAS_MCP_TOKEN")
+	}
+	if token == "" {
+		generated, err := mcp.GenerateToken()
+		if err != nil {
+			return err
+		}
+		token = generated
+		fmt.Fprintf(os.Stderr, "Generated bearer token: %s\n", token)
+	}
+
+	fmt.Fprintf(os.Stderr, "Nylas MCP server listening on http://%s%s\n", displayAddr(addr), mcp.HTTPEndpointPath)
+	return mcp.NewHTTPServer(proxy, token).ListenAndServe(ctx, addr)
+}
+
+// displayAddr returns the address a local client should connect to.
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
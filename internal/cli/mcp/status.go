@@ -65,6 +65,14 @@ func runStatus() error {
 		}
 	}
 
+	fmt.Println()
+	if _, err := os.Stat(defaultPolicyPath()); err == nil {
+		fmt.Printf("Tool policy: %s\n", defaultPolicyPath())
+	} else {
+		_, _ = common.HiBlack.Printf("Tool policy: none (all tools allowed, see 'nylas mcp serve --help')\n")
+	}
+	fmt.Printf("Audit log:   %s\n", defaultAuditPath())
+
 	fmt.Println()
 	fmt.Println("Legend:")
 	_, _ = common.Green.Print("  ✓")
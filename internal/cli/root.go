@@ -2,7 +2,16 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/adapters/config"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
 )
 
 var rootCmd = &cobra.Command{
@@ -63,13 +72,18 @@ OTP MANAGEMENT:
 
 INTERACTIVE TUI:
   nylas tui            Launch k9s-style terminal UI for emails`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+	PersistentPreRunE: setup,
 }
 
 func init() {
 	// Global output flags (format, json, quiet, wide, no-color)
-	rootCmd.PersistentFlags().String("format", "", "Output format: table, json, yaml")
+	rootCmd.PersistentFlags().String("format", "", "Output format: table, json, yaml, csv, ndjson, template")
+	rootCmd.PersistentFlags().String("template", "", "Go template for each row, e.g. '{{.ID}}\\t{{.Subject}}' (implies --format template)")
+	rootCmd.PersistentFlags().StringSlice("columns", nil, "Columns or fields to output, in order (e.g. id,subject,date)")
+	rootCmd.PersistentFlags().String("sort", "", "Sort list output by a column or field; prefix with - for descending")
+	rootCmd.PersistentFlags().String("query", "", "JMESPath expression applied to the output data, e.g. \"[?unread].{id:id,subject:subject}\"")
 	rootCmd.PersistentFlags().Bool("json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet mode - only output essential data (IDs)")
 	rootCmd.PersistentFlags().BoolP("wide", "w", false, "Wide output - show full IDs without truncation")
@@ -78,10 +92,94 @@ func init() {
 	// Other global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().String("config", "", "Custom config file path")
+	rootCmd.PersistentFlags().String("profile", "", "Configuration profile to use (overrides NYLAS_PROFILE)")
+	rootCmd.PersistentFlags().Bool("debug-http", false, "Log every HTTP request and response to stderr, with secrets redacted")
 
 	rootCmd.AddCommand(newVersionCmd())
 }
 
+// setup runs before every command: it selects the profile, then configures
+// the HTTP clients from it.
+func setup(cmd *cobra.Command, args []string) error {
+	if err := selectProfile(cmd, args); err != nil {
+		return err
+	}
+	return configureHTTP(cmd)
+}
+
+// selectProfile applies --profile and checks that the active profile exists.
+// The profile commands themselves are exempt so a profile can be created
+// while NYLAS_PROFILE already names it.
+func selectProfile(cmd *cobra.Command, _ []string) error {
+	if name, _ := cmd.Flags().GetString("profile"); name != "" {
+		config.SetProfile(name)
+	}
+
+	if cmd.Parent() != nil && cmd.Parent().Name() == "profile" {
+		return nil
+	}
+	name := config.ActiveProfile()
+	if err := config.ValidateProfileName(name); err != nil {
+		return common.NewUserError(err.Error(), "See available profiles with: nylas config profile list")
+	}
+	if !config.ProfileExists(name) {
+		return common.NewUserError(
+			fmt.Sprintf("profile %q does not exist", name),
+			fmt.Sprintf("Create it with: nylas config profile create %s", name),
+		)
+	}
+	return nil
+}
+
+// configureHTTP applies the proxy, certificate and tracing settings from
+// the config file and environment to every outbound HTTP client. The
+// environment wins over the config file.
+func configureHTTP(cmd *cobra.Command) error {
+	if err := httputil.Configure(httpSettings(cmd)); err != nil {
+		return common.NewUserError(err.Error(), "Check the http settings with: nylas config get http, and the NYLAS_PROXY, NYLAS_CA_CERT, NYLAS_CLIENT_CERT and NYLAS_CLIENT_KEY variables")
+	}
+	return nil
+}
+
+// httpSettings builds the HTTP client settings for cmd from the config
+// file, environment and --debug-http.
+func httpSettings(cmd *cobra.Command) httputil.ClientSettings {
+	httpCfg := &domain.HTTPConfig{}
+	if cfg, err := common.GetConfigStore(cmd).Load(); err == nil && cfg.HTTP != nil {
+		httpCfg = cfg.HTTP
+	}
+
+	settings := httputil.ClientSettings{
+		Proxy:      envOr("NYLAS_PROXY", httpCfg.Proxy),
+		NoProxy:    envOr("NYLAS_NO_PROXY", httpCfg.NoProxy),
+		ClientCert: envOr("NYLAS_CLIENT_CERT", httpCfg.ClientCert),
+		ClientKey:  envOr("NYLAS_CLIENT_KEY", httpCfg.ClientKey),
+		UserAgent:  "nylas-cli/" + Version,
+	}
+	if caCert := envOr("NYLAS_CA_CERT", httpCfg.CACert); caCert != "" {
+		settings.CACerts = []string{caCert}
+	}
+
+	debug, _ := cmd.Flags().GetBool("debug-http")
+	if env, err := strconv.ParseBool(os.Getenv("NYLAS_DEBUG_HTTP")); err == nil && env {
+		debug = true
+	}
+	verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
+	if debug || verbose {
+		settings.Trace = os.Stderr
+		settings.TraceDetail = debug
+	}
+	return settings
+}
+
+// envOr returns the environment variable if it's set, otherwise fallback.
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
 // GetRootCmd returns the root command for adding subcommands.
 func GetRootCmd() *cobra.Command {
 	return rootCmd
@@ -91,3 +189,18 @@ func GetRootCmd() *cobra.Command {
 func Execute() error {
 	return rootCmd.Execute()
 }
+
+// ReportError writes err to stderr and returns the exit code for its class.
+// With --json or --format json/ndjson the error is a JSON object, so scripts
+// can read it like any other output.
+func ReportError(err error) int {
+	flags := rootCmd.PersistentFlags()
+	jsonOutput, _ := flags.GetBool("json")
+	format, _ := flags.GetString("format")
+	if jsonOutput || format == "json" || format == "ndjson" {
+		common.PrintJSONError(err)
+	} else {
+		common.PrintFormattedError(err)
+	}
+	return common.ExitCode(err)
+}
@@ -128,8 +128,19 @@ Examples:
 				if fetchErr != nil {
 					return common.PageResult[domain.SlackChannel]{}, fetchErr
 				}
+
+				// Filter by creation date per page, so a streamed list is filtered too
+				channels := resp.Channels
+				if !createdAfterTime.IsZero() {
+					channels = make([]domain.SlackChannel, 0, len(resp.Channels))
+					for _, ch := range resp.Channels {
+						if ch.Created.After(createdAfterTime) {
+							channels = append(channels, ch)
+						}
+					}
+				}
 				return common.PageResult[domain.SlackChannel]{
-					Data:       resp.Channels,
+					Data:       channels,
 					NextCursor: resp.NextCursor,
 				}, nil
 			}
@@ -141,20 +152,22 @@ Examples:
 				config.ShowProgress = false
 			}
 
-			allChannels, err := common.FetchAllPages(ctx, config, fetcher)
-			if err != nil {
-				return common.WrapListError("channels", err)
-			}
-
-			// Filter by creation date if specified
-			if !createdAfterTime.IsZero() {
-				filtered := make([]domain.SlackChannel, 0)
-				for _, ch := range allChannels {
-					if ch.Created.After(createdAfterTime) {
-						filtered = append(filtered, ch)
-					}
+			// Structured output of every page is written as each page arrives
+			var allChannels []domain.SlackChannel
+			if fetchAll && common.IsStructuredOutput(cmd) {
+				var streamed bool
+				allChannels, streamed, err = common.FetchAllOrStream(ctx, cmd, config, fetcher, nil)
+				if err != nil {
+					return common.WrapListError("channels", err)
+				}
+				if streamed {
+					return nil
+				}
+			} else {
+				allChannels, err = common.FetchAllPages(ctx, config, fetcher)
+				if err != nil {
+					return common.WrapListError("channels", err)
 				}
-				allChannels = filtered
 			}
 
 			if len(allChannels) == 0 {
@@ -163,9 +176,8 @@ Examples:
 			}
 
 			// Handle structured output (JSON/YAML/quiet)
-			format, _ := cmd.Flags().GetString("format")
 			quiet, _ := cmd.Flags().GetBool("quiet")
-			if common.IsJSON(cmd) || format == "yaml" || quiet {
+			if common.IsStructuredOutput(cmd) || quiet {
 				out := common.GetOutputWriter(cmd)
 				return out.Write(allChannels)
 			}
@@ -124,9 +124,8 @@ Examples:
 			}
 
 			// Handle structured output (JSON/YAML/quiet)
-			format, _ := cmd.Flags().GetString("format")
 			quiet, _ := cmd.Flags().GetBool("quiet")
-			if common.IsJSON(cmd) || format == "yaml" || quiet {
+			if common.IsStructuredOutput(cmd) || quiet {
 				out := common.GetOutputWriter(cmd)
 				return out.Write(resp.Files)
 			}
@@ -210,9 +209,8 @@ func newFilesShowCmd() *cobra.Command {
 			}
 
 			// Handle structured output (JSON/YAML/quiet)
-			format, _ := cmd.Flags().GetString("format")
 			quiet, _ := cmd.Flags().GetBool("quiet")
-			if common.IsJSON(cmd) || format == "yaml" || quiet {
+			if common.IsStructuredOutput(cmd) || quiet {
 				out := common.GetOutputWriter(cmd)
 				return out.Write(file)
 			}
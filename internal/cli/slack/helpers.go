@@ -15,15 +15,13 @@ import (
 	"github.com/mqasimca/nylas/internal/ports"
 )
 
-const slackTokenKey = "slack_user_token"
-
 // storeSlackToken stores the Slack token in the keyring.
 func storeSlackToken(token string) error {
 	store, err := keyring.NewSecretStore(config.DefaultConfigDir())
 	if err != nil {
 		return err
 	}
-	return store.Set(slackTokenKey, token)
+	return store.Set(ports.KeySlackUserToken, token)
 }
 
 // getSlackToken retrieves the Slack token from environment or keyring.
@@ -37,7 +35,7 @@ func getSlackToken() (string, error) {
 		return "", err
 	}
 
-	token, err := store.Get(slackTokenKey)
+	token, err := store.Get(ports.KeySlackUserToken)
 	if err != nil {
 		return "", domain.ErrSlackNotConfigured
 	}
@@ -51,7 +49,7 @@ func removeSlackToken() error {
 	if err != nil {
 		return err
 	}
-	return store.Delete(slackTokenKey)
+	return store.Delete(ports.KeySlackUserToken)
 }
 
 // getSlackClientFromKeyring creates a client using stored credentials.
@@ -63,6 +61,12 @@ func getSlackClientFromKeyring() (ports.SlackClient, error) {
 	return getSlackClient(token)
 }
 
+// NewClientFromKeyring creates a Slack client from SLACK_USER_TOKEN or the
+// token stored by 'nylas slack auth set'.
+func NewClientFromKeyring() (ports.SlackClient, error) {
+	return getSlackClientFromKeyring()
+}
+
 // getSlackClient creates a new Slack client with the given token.
 func getSlackClient(token string) (ports.SlackClient, error) {
 	config := slackadapter.DefaultConfig()
@@ -39,6 +39,7 @@ func newMessageListCmd() *cobra.Command {
 		threadTS      string
 		fetchAll      bool
 		expandThreads bool
+		pageToken     string
 	)
 
 	cmd := &cobra.Command{
@@ -63,6 +64,9 @@ Examples:
   # Fetch ALL messages (paginate through entire history)
   nylas slack messages list --channel general --all
 
+  # Stream all messages as NDJSON; Ctrl-C prints a --page-token to resume from
+  nylas slack messages list --channel general --all --format ndjson
+
   # Expand all threads inline (show thread replies under parent messages)
   nylas slack messages list --channel general --expand-threads`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -123,14 +127,30 @@ Examples:
 
 			config := common.DefaultPaginationConfig()
 			config.PageSize = pageSize
+			config.StartCursor = pageToken
 			if !fetchAll {
 				config.MaxPages = 1
 				config.ShowProgress = false
 			}
 
-			allMessages, err := common.FetchAllPages(ctx, config, fetcher)
-			if err != nil {
-				return common.WrapGetError("messages", err)
+			// Structured output skips enrichment, so it can stream page by page
+			var allMessages []domain.SlackMessage
+			if fetchAll && common.IsStructuredOutput(cmd) {
+				var streamed bool
+				allMessages, streamed, err = common.FetchAllOrStream(ctx, cmd, config, fetcher, nil)
+				if err != nil {
+					return common.WrapGetError("messages", err)
+				}
+				if streamed {
+					return nil
+				}
+			} else {
+				// Text output resolves usernames and thread replies across the
+				// whole list before printing, so it has to buffer every page
+				allMessages, err = common.FetchAllPages(ctx, config, fetcher)
+				if err != nil {
+					return common.WrapGetError("messages", err)
+				}
 			}
 
 			// Show hint when more messages are available
@@ -144,9 +164,8 @@ Examples:
 			}
 
 			// Handle structured output (JSON/YAML/quiet) - before enrichment for performance
-			format, _ := cmd.Flags().GetString("format")
 			quiet, _ := cmd.Flags().GetBool("quiet")
-			if common.IsJSON(cmd) || format == "yaml" || quiet {
+			if common.IsStructuredOutput(cmd) || quiet {
 				out := common.GetOutputWriter(cmd)
 				return out.Write(allMessages)
 			}
@@ -219,6 +238,7 @@ Examples:
 	cmd.Flags().StringVar(&threadTS, "thread", "", "Thread timestamp to show replies")
 	cmd.Flags().BoolVar(&fetchAll, "all", false, "Fetch all messages (paginate through entire history)")
 	cmd.Flags().BoolVar(&expandThreads, "expand-threads", false, "Expand thread replies inline")
+	common.AddPageTokenFlag(cmd, &pageToken)
 
 	return cmd
 }
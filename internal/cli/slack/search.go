@@ -13,13 +13,12 @@ import (
 // newSearchCmd creates the search command for searching messages.
 func newSearchCmd() *cobra.Command {
 	var (
-		query  string
 		limit  int
 		showID bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "search",
+		Use:   "search <query>",
 		Short: "Search messages",
 		Long: `Search for messages in your Slack workspace.
 
@@ -31,16 +30,18 @@ Uses Slack's search syntax. Examples:
 
 Examples:
   # Search for messages
-  nylas slack search --query "project update"
+  nylas slack search "project update"
 
   # Search with Slack modifiers
-  nylas slack search --query "from:@alice in:#general"
+  nylas slack search "from:@alice in:#general"
 
   # Limit results
-  nylas slack search --query "important" --limit 5`,
+  nylas slack search "important" --limit 5`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			query := args[0]
 			if query == "" {
-				return common.NewUserError("search query is required", "Use --query")
+				return common.NewUserError("search query is required", "Run: nylas slack search \"<query>\"")
 			}
 
 			client, err := getSlackClientFromKeyring()
@@ -65,9 +66,8 @@ Examples:
 			}
 
 			// Handle structured output (JSON/YAML/quiet)
-			format, _ := cmd.Flags().GetString("format")
 			quiet, _ := cmd.Flags().GetBool("quiet")
-			if common.IsJSON(cmd) || format == "yaml" || quiet {
+			if common.IsStructuredOutput(cmd) || quiet {
 				out := common.GetOutputWriter(cmd)
 				return out.Write(messages)
 			}
@@ -82,11 +82,8 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVar(&query, "query", "", "Search query")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Maximum number of results")
 	cmd.Flags().BoolVar(&showID, "id", false, "Show message IDs")
 
-	_ = cmd.MarkFlagRequired("query")
-
 	return cmd
 }
@@ -39,7 +39,7 @@ Examples:
   nylas slack reply --channel general --thread 1234567890.123456 --text "Got it!"
 
   # Search messages
-  nylas slack search --query "important"
+  nylas slack search "important"
 
   # List files in a channel
   nylas slack files list --channel general
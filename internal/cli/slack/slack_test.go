@@ -241,11 +241,12 @@ func TestSearchCommand(t *testing.T) {
 	cmd := newSearchCmd()
 
 	t.Run("command_name", func(t *testing.T) {
-		assert.Equal(t, "search", cmd.Use)
+		assert.Equal(t, "search <query>", cmd.Use)
 	})
 
-	t.Run("has_query_flag", func(t *testing.T) {
-		flag := cmd.Flags().Lookup("query")
-		assert.NotNil(t, flag, "Expected --query flag")
+	t.Run("takes_query_argument", func(t *testing.T) {
+		assert.Error(t, cmd.Args(cmd, nil), "Expected the query to be required")
+		assert.NoError(t, cmd.Args(cmd, []string{"project update"}))
+		assert.Nil(t, cmd.LocalFlags().Lookup("query"), "--query is the global JMESPath flag")
 	})
 }
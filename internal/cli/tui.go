@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/mqasimca/nylas/internal/adapters/config"
 	"github.com/mqasimca/nylas/internal/adapters/keyring"
+	"github.com/mqasimca/nylas/internal/adapters/webhookserver"
 	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/cli/slack"
 	"github.com/mqasimca/nylas/internal/domain"
 	"github.com/mqasimca/nylas/internal/ports"
 	"github.com/mqasimca/nylas/internal/tui"
@@ -20,6 +23,7 @@ import (
 func NewTUICmd() *cobra.Command {
 	var refreshInterval int
 	var theme string
+	var webhooks tuiWebhookFlags
 
 	cmd := &cobra.Command{
 		Use:   "tui [resource]",
@@ -27,10 +31,12 @@ func NewTUICmd() *cobra.Command {
 		Long: `Launch a k9s-style terminal interface for managing your Nylas email.
 
 The TUI provides:
-  - Real-time email list with auto-refresh
+  - Live views that refresh in the background and highlight new rows
+  - Notifications for new mail and upcoming event reminders
   - Keyboard-driven navigation (vim-style: j/k)
   - Read, star, and manage messages
-  - Resource views for messages, events, contacts, webhooks, grants
+  - Resource views for messages, events, contacts, webhooks, grants,
+    Slack, notetakers and scheduler bookings
 
 Navigation:
   ↑/k, ↓/j    Move up/down
@@ -42,6 +48,9 @@ Navigation:
   ?           Help
   Ctrl+C      Quit
 
+Keys, aliases and plugins can be customized in ~/.config/nylas/tui/keys.yaml
+(see 'nylas tui keys init').
+
 Themes:
   k9s         Default k9s style (blue/orange)
   amber       Amber phosphor CRT
@@ -58,7 +67,10 @@ Resources:
   events      Calendar events
   contacts    Contacts
   webhooks    Webhooks
-  grants      Connected accounts`,
+  grants      Connected accounts
+  slack       Slack channels and messages
+  notetakers  Meeting notetakers and media
+  bookings    Scheduler configurations and bookings`,
 		Example: `  # Launch TUI at dashboard
   nylas tui
 
@@ -72,19 +84,23 @@ Resources:
   nylas tui messages --theme green
 
   # Launch directly to events with custom refresh
-  nylas tui events --refresh 5`,
+  nylas tui events --refresh 5
+
+  # Push updates from a local webhook receiver instead of polling
+  nylas tui --webhook-port 3000 --webhook-secret $NYLAS_WEBHOOK_SECRET`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			initialView := ""
 			if len(args) > 0 {
 				initialView = args[0]
 			}
 			themeExplicitlySet := cmd.Flags().Changed("theme")
-			return runTUI(time.Duration(refreshInterval)*time.Second, initialView, tui.ThemeName(theme), themeExplicitlySet)
+			return runTUI(time.Duration(refreshInterval)*time.Second, initialView, tui.ThemeName(theme), themeExplicitlySet, webhooks)
 		},
 	}
 
-	cmd.Flags().IntVar(&refreshInterval, "refresh", 3, "Refresh interval in seconds")
+	cmd.Flags().IntVar(&refreshInterval, "refresh", 3, "Refresh interval in seconds (0 disables background refresh)")
 	cmd.Flags().StringVar(&theme, "theme", "k9s", "Color theme (k9s, amber, green, apple2, vintage, ibm, futuristic, matrix, norton, or custom)")
+	webhooks.register(cmd)
 
 	// Add subcommands for direct navigation
 	cmd.AddCommand(newTUIResourceCmd("messages", "m", "Launch TUI directly to messages view"))
@@ -92,9 +108,13 @@ Resources:
 	cmd.AddCommand(newTUIResourceCmd("contacts", "c", "Launch TUI directly to contacts view"))
 	cmd.AddCommand(newTUIResourceCmd("webhooks", "w", "Launch TUI directly to webhooks view"))
 	cmd.AddCommand(newTUIResourceCmd("grants", "g", "Launch TUI directly to grants view"))
+	cmd.AddCommand(newTUIResourceCmd("slack", "sl", "Launch TUI directly to Slack view"))
+	cmd.AddCommand(newTUIResourceCmd("notetakers", "nt", "Launch TUI directly to notetakers view"))
+	cmd.AddCommand(newTUIResourceCmd("bookings", "bk", "Launch TUI directly to scheduler bookings view"))
 
-	// Add theme management subcommand
+	// Add theme and key binding management subcommands
 	cmd.AddCommand(newThemeCmd())
+	cmd.AddCommand(newKeysCmd())
 
 	return cmd
 }
@@ -345,6 +365,7 @@ func newTUIResourceCmd(resource, alias, desc string) *cobra.Command {
 func newTUIResourceCmdWithAliases(resource string, aliases []string, desc string) *cobra.Command {
 	var refreshInterval int
 	var theme string
+	var webhooks tuiWebhookFlags
 
 	cmd := &cobra.Command{
 		Use:     resource,
@@ -353,16 +374,47 @@ func newTUIResourceCmdWithAliases(resource string, aliases []string, desc string
 		Example: fmt.Sprintf("  nylas tui %s\n  nylas tui %s --refresh 5\n  nylas tui %s --theme amber", resource, aliases[0], resource),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			themeExplicitlySet := cmd.Flags().Changed("theme")
-			return runTUI(time.Duration(refreshInterval)*time.Second, resource, tui.ThemeName(theme), themeExplicitlySet)
+			return runTUI(time.Duration(refreshInterval)*time.Second, resource, tui.ThemeName(theme), themeExplicitlySet, webhooks)
 		},
 	}
 
-	cmd.Flags().IntVar(&refreshInterval, "refresh", 3, "Refresh interval in seconds")
+	cmd.Flags().IntVar(&refreshInterval, "refresh", 3, "Refresh interval in seconds (0 disables background refresh)")
 	cmd.Flags().StringVar(&theme, "theme", "k9s", "Color theme (k9s, amber, green, apple2, vintage, ibm, futuristic, matrix, norton, or custom)")
+	webhooks.register(cmd)
 	return cmd
 }
 
-func runTUI(refreshInterval time.Duration, initialView string, theme tui.ThemeName, themeExplicitlySet bool) error {
+// tuiWebhookFlags configures a local webhook receiver that drives live updates.
+type tuiWebhookFlags struct {
+	port   int
+	secret string
+}
+
+func (f *tuiWebhookFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.port, "webhook-port", 0, "Receive webhooks on this port and refresh from them instead of polling (0 = poll)")
+	cmd.Flags().StringVar(&f.secret, "webhook-secret", "", "Webhook secret for signature verification")
+}
+
+// start launches the webhook receiver, or returns nil when polling.
+func (f tuiWebhookFlags) start() (ports.WebhookServer, error) {
+	if f.port == 0 {
+		return nil, nil
+	}
+	server := webhookserver.NewServer(ports.WebhookServerConfig{
+		Port:          f.port,
+		Path:          "/webhook",
+		WebhookSecret: f.secret,
+	})
+	if err := server.Start(context.Background()); err != nil {
+		return nil, common.NewUserError(
+			fmt.Sprintf("failed to start webhook receiver: %v", err),
+			"Choose a free port with --webhook-port, or omit it to poll",
+		)
+	}
+	return server, nil
+}
+
+func runTUI(refreshInterval time.Duration, initialView string, theme tui.ThemeName, themeExplicitlySet bool, webhooks tuiWebhookFlags) error {
 	// Load config
 	configStore := config.NewDefaultFileStore()
 	cfg, err := configStore.Load()
@@ -398,10 +450,18 @@ func runTUI(refreshInterval time.Duration, initialView string, theme tui.ThemeNa
 		return common.WrapGetError("grant info", err)
 	}
 
-	return runTViewTUI(client, grantStore, grantID, grantInfo, cfg, theme, themeExplicitlySet, refreshInterval, initialView)
+	webhookServer, err := webhooks.start()
+	if err != nil {
+		return err
+	}
+	if webhookServer != nil {
+		defer func() { _ = webhookServer.Stop() }()
+	}
+
+	return runTViewTUI(client, grantStore, grantID, grantInfo, cfg, theme, themeExplicitlySet, refreshInterval, initialView, webhookServer)
 }
 
-func runTViewTUI(client ports.NylasClient, grantStore ports.GrantStore, grantID string, grantInfo *domain.GrantInfo, cfg *domain.Config, theme tui.ThemeName, themeExplicitlySet bool, refreshInterval time.Duration, initialView string) error {
+func runTViewTUI(client ports.NylasClient, grantStore ports.GrantStore, grantID string, grantInfo *domain.GrantInfo, cfg *domain.Config, theme tui.ThemeName, themeExplicitlySet bool, refreshInterval time.Duration, initialView string, webhookServer ports.WebhookServer) error {
 	// Use config theme if no explicit --theme flag was provided
 	if !themeExplicitlySet && cfg.TUITheme != "" {
 		theme = tui.ThemeName(cfg.TUITheme)
@@ -416,16 +476,25 @@ func runTViewTUI(client ports.NylasClient, grantStore ports.GrantStore, grantID
 		fmt.Fprintf(os.Stderr, "To fix this, run: nylas tui theme validate %s\n\n", theme)
 	}
 
+	// Slack is optional; the Slack view explains how to connect when it's missing
+	var slackClient ports.SlackClient
+	if sc, err := slack.NewClientFromKeyring(); err == nil {
+		slackClient = sc
+	}
+
 	// Create TUI app (k9s-style using tview)
 	app := tui.NewApp(tui.Config{
 		Client:          client,
 		GrantStore:      grantStore, // Enable grant switching in TUI
+		Slack:           slackClient,
 		GrantID:         grantID,
+		Profile:         config.ActiveProfile(),
 		Email:           grantInfo.Email,
 		Provider:        string(grantInfo.Provider),
 		RefreshInterval: refreshInterval,
 		InitialView:     initialView,
 		Theme:           theme,
+		WebhookServer:   webhookServer,
 	})
 
 	// Run the application
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/tui"
+)
+
+// newKeysCmd creates the key bindings management command.
+func newKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage TUI key bindings, aliases and plugins",
+		Long: `Manage custom TUI key bindings (k9s-style YAML configuration).
+
+Key bindings are loaded from ~/.config/nylas/tui/keys.yaml. The file can:
+  - Remap actions per view (or globally)
+  - Define command aliases for the : prompt
+  - Add plugins that pipe the selected item's JSON to an external program
+
+Use 'nylas tui keys init' to create a starter file.`,
+	}
+
+	cmd.AddCommand(newKeysInitCmd())
+	cmd.AddCommand(newKeysValidateCmd())
+
+	return cmd
+}
+
+// newKeysInitCmd creates a starter keys.yaml.
+func newKeysInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "init",
+		Short: "Create a starter key bindings file",
+		Long: `Create a starter key bindings file at ~/.config/nylas/tui/keys.yaml
+
+The generated file includes example bindings, aliases and plugins.`,
+		Example: `  # Create the key bindings file
+  nylas tui keys init
+
+  # Then check it
+  nylas tui keys validate`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keysPath := tui.GetKeysPath()
+			if keysPath == "" {
+				return common.NewUserError("cannot determine home directory", "Set the HOME environment variable")
+			}
+
+			if _, err := os.Stat(keysPath); err == nil {
+				return fmt.Errorf("key bindings file already exists: %s", keysPath)
+			}
+
+			if err := tui.CreateDefaultKeysFile(keysPath); err != nil {
+				return common.WrapCreateError("key bindings file", err)
+			}
+
+			fmt.Printf("Created key bindings file: %s\n\n", keysPath)
+			fmt.Printf("Edit it to remap keys, add aliases and plugins, then run:\n")
+			fmt.Printf("  nylas tui keys validate\n")
+
+			return nil
+		},
+	}
+}
+
+// newKeysValidateCmd validates keys.yaml.
+func newKeysValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the key bindings file",
+		Long: `Validate ~/.config/nylas/tui/keys.yaml and check for common errors.
+
+This command checks:
+  - YAML syntax is valid
+  - Views and actions exist and keys can be parsed
+  - No two actions, plugins or global keys share a key
+  - Aliases and plugin names don't shadow built-in commands`,
+		Example: `  # Validate key bindings
+  nylas tui keys validate`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := tui.ValidateKeys(tui.GetKeysPath())
+			if err != nil {
+				return common.WrapError(err)
+			}
+
+			fmt.Printf("File: %s\n", result.FilePath)
+			if !result.Exists {
+				fmt.Println("\nNo key bindings file found; default keys are used.")
+				fmt.Println("Create one with: nylas tui keys init")
+				return nil
+			}
+			fmt.Println()
+
+			if len(result.Errors) > 0 {
+				fmt.Println(common.Red.Sprint("Errors:"))
+				for _, e := range result.Errors {
+					fmt.Printf("  ✗ %s\n", e)
+				}
+			}
+
+			if len(result.Warnings) > 0 {
+				fmt.Println(common.Yellow.Sprint("Warnings:"))
+				for _, warn := range result.Warnings {
+					fmt.Printf("  ! %s\n", warn)
+				}
+			}
+
+			for _, section := range []struct {
+				title string
+				items []string
+			}{
+				{"Bindings:", result.Bindings},
+				{"Aliases:", result.Aliases},
+				{"Plugins:", result.Plugins},
+			} {
+				if len(section.items) == 0 {
+					continue
+				}
+				fmt.Println(common.Green.Sprint(section.title))
+				for _, item := range section.items {
+					fmt.Printf("  ✓ %s\n", item)
+				}
+			}
+
+			fmt.Println()
+
+			if !result.Valid {
+				fmt.Println(common.Red.Sprint("✗ Key bindings have errors"))
+				return fmt.Errorf("key bindings validation failed")
+			}
+			fmt.Println(common.Green.Sprint("✓ Key bindings are valid!"))
+			return nil
+		},
+	}
+}
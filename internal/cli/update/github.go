@@ -7,7 +7,10 @@ import (
 	"net/http"
 	"time"
 
+	"golang.org/x/mod/semver"
+
 	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/httputil"
 )
 
 const (
@@ -16,7 +19,12 @@ const (
 	repoName  = "nylas"
 
 	// GitHub API endpoints
-	releasesAPIURL = "https://api.github.com/repos/%s/%s/releases/latest"
+	releasesAPIURL    = "https://api.github.com/repos/%s/%s/releases/latest"
+	allReleasesAPIURL = "https://api.github.com/repos/%s/%s/releases?per_page=30"
+
+	// Release channels
+	channelStable = "stable"
+	channelBeta   = "beta"
 
 	// HTTP client timeout
 	httpTimeout = 30 * time.Second
@@ -43,37 +51,77 @@ type Asset struct {
 
 // getLatestRelease fetches the latest release from GitHub.
 func getLatestRelease(ctx context.Context) (*Release, error) {
-	url := fmt.Sprintf(releasesAPIURL, repoOwner, repoName)
+	var release Release
+	if err := getGitHubJSON(ctx, fmt.Sprintf(releasesAPIURL, repoOwner, repoName), &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// getChannelRelease fetches the newest release on a channel. The stable
+// channel follows GitHub's latest release; beta also includes pre-releases.
+func getChannelRelease(ctx context.Context, channel string) (*Release, error) {
+	if channel != channelBeta {
+		return getLatestRelease(ctx)
+	}
+
+	var releases []Release
+	if err := getGitHubJSON(ctx, fmt.Sprintf(allReleasesAPIURL, repoOwner, repoName), &releases); err != nil {
+		return nil, err
+	}
+	release := newestRelease(releases)
+	if release == nil {
+		return nil, fmt.Errorf("no releases found")
+	}
+	return release, nil
+}
+
+// newestRelease returns the published release with the highest version.
+func newestRelease(releases []Release) *Release {
+	var newest *Release
+	for i := range releases {
+		r := &releases[i]
+		v := normalizeVersion(r.TagName)
+		if r.Draft || !semver.IsValid(v) {
+			continue
+		}
+		if newest == nil || semver.Compare(v, normalizeVersion(newest.TagName)) > 0 {
+			newest = r
+		}
+	}
+	return newest
+}
 
+// getGitHubJSON fetches a GitHub API URL and decodes the response into v.
+func getGitHubJSON(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, common.WrapCreateError("request", err)
+		return common.WrapCreateError("request", err)
 	}
 
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", "nylas-cli")
 
-	client := &http.Client{Timeout: httpTimeout}
+	client := httputil.NewClient(httpTimeout)
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, common.WrapFetchError("release", err)
+		return common.WrapFetchError("release", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("no releases found")
+		return fmt.Errorf("no releases found")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
 
-	var release Release
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
 	}
 
-	return &release, nil
+	return nil
 }
 
 // findAsset finds the asset matching the given name.
@@ -88,5 +136,10 @@ func findAsset(release *Release, assetName string) *Asset {
 
 // findChecksumAsset finds the checksums.txt asset.
 func findChecksumAsset(release *Release) *Asset {
-	return findAsset(release, "checksums.txt")
+	return findAsset(release, checksumsFileName)
+}
+
+// findSignatureAsset finds the minisign signature of checksums.txt.
+func findSignatureAsset(release *Release) *Asset {
+	return findAsset(release, checksumsFileName+signatureExt)
 }
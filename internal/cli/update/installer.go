@@ -4,10 +4,12 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/httputil"
 )
 
 const binaryName = "nylas"
@@ -38,6 +41,32 @@ func getAssetName(version string) string {
 	return fmt.Sprintf("%s_%s_%s_%s%s", binaryName, version, goos, goarch, ext)
 }
 
+// archiveVersion returns the version in a release archive name, checking
+// that the archive is built for this platform.
+func archiveVersion(name string) (string, error) {
+	rest, ok := strings.CutPrefix(name, binaryName+"_")
+	if !ok {
+		return "", fmt.Errorf("%s is not a Nylas CLI release archive", name)
+	}
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		if before, found := strings.CutSuffix(rest, ext); found {
+			rest = before
+			break
+		}
+	}
+
+	// The platform is always the last two fields.
+	parts := strings.Split(rest, "_")
+	if len(parts) < 3 {
+		return "", fmt.Errorf("%s is not a Nylas CLI release archive", name)
+	}
+	version := strings.Join(parts[:len(parts)-2], "_")
+	if expected := getAssetName(version); name != expected {
+		return "", fmt.Errorf("%s is not built for %s/%s (expected %s)", name, runtime.GOOS, runtime.GOARCH, expected)
+	}
+	return version, nil
+}
+
 // downloadFile downloads a file from URL to a temporary file.
 func downloadFile(ctx context.Context, url string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -47,7 +76,7 @@ func downloadFile(ctx context.Context, url string) (string, error) {
 
 	req.Header.Set("User-Agent", "nylas-cli")
 
-	client := &http.Client{Timeout: httpTimeout}
+	client := httputil.NewClient(httpTimeout)
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("download: %w", err)
@@ -77,8 +106,11 @@ func downloadFile(ctx context.Context, url string) (string, error) {
 	return tmpFile.Name(), nil
 }
 
-// downloadChecksums downloads and parses the checksums.txt file.
-func downloadChecksums(ctx context.Context, url string) (map[string]string, error) {
+// maxMetadataSize caps checksums.txt and signature downloads (1MB).
+const maxMetadataSize = 1024 * 1024
+
+// downloadBytes downloads a small release file, such as checksums.txt, into memory.
+func downloadBytes(ctx context.Context, url string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, common.WrapCreateError("request", err)
@@ -86,19 +118,28 @@ func downloadChecksums(ctx context.Context, url string) (map[string]string, erro
 
 	req.Header.Set("User-Agent", "nylas-cli")
 
-	client := &http.Client{Timeout: httpTimeout}
+	client := httputil.NewClient(httpTimeout)
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("download checksums: %w", err)
+		return nil, fmt.Errorf("download: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("checksums download failed with status %d", resp.StatusCode)
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
 	}
+	return data, nil
+}
 
+// parseChecksums parses a checksums.txt file into a map of file name to SHA256.
+func parseChecksums(data []byte) (map[string]string, error) {
 	checksums := make(map[string]string)
-	scanner := bufio.NewScanner(resp.Body)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
@@ -284,7 +325,16 @@ func isHomebrewInstall() bool {
 		strings.Contains(exePath, "/homebrew/")
 }
 
-// installBinary replaces the current binary with the new one.
+// previousBinaryPath is where the binary replaced by the last update is kept.
+func previousBinaryPath(targetPath string) string {
+	return targetPath + ".previous"
+}
+
+// errNoPreviousBinary means there is no earlier version to roll back to.
+var errNoPreviousBinary = errors.New("no previous version to roll back to")
+
+// installBinary replaces the current binary with the new one, keeping the
+// current one for rollbackBinary.
 func installBinary(newBinaryPath, targetPath string) error {
 	// Check if we can write to the target directory
 	targetDir := filepath.Dir(targetPath)
@@ -292,8 +342,9 @@ func installBinary(newBinaryPath, targetPath string) error {
 		return fmt.Errorf("insufficient permissions for %s: %w\nTry running with sudo", targetDir, err)
 	}
 
-	// Create backup
-	backupPath := targetPath + ".bak"
+	// Keep the current binary as the previous version
+	backupPath := previousBinaryPath(targetPath)
+	_ = os.Remove(backupPath)
 	if err := os.Rename(targetPath, backupPath); err != nil {
 		return common.WrapCreateError("backup", err)
 	}
@@ -318,8 +369,39 @@ func installBinary(newBinaryPath, targetPath string) error {
 		return fmt.Errorf("chmod failed, restored backup: %w", err)
 	}
 
-	// Remove backup on success
-	_ = os.Remove(backupPath)
+	return nil
+}
+
+// rollbackBinary swaps the current binary with the previous version, so a
+// second rollback undoes the first.
+func rollbackBinary(targetPath string) error {
+	previousPath := previousBinaryPath(targetPath)
+	if _, err := os.Stat(previousPath); err != nil {
+		if os.IsNotExist(err) {
+			return errNoPreviousBinary
+		}
+		return fmt.Errorf("check previous version: %w", err)
+	}
+
+	targetDir := filepath.Dir(targetPath)
+	if err := checkWritePermission(targetDir); err != nil {
+		return fmt.Errorf("insufficient permissions for %s: %w\nTry running with sudo", targetDir, err)
+	}
+
+	swapPath := targetPath + ".swap"
+	_ = os.Remove(swapPath)
+	if err := os.Rename(targetPath, swapPath); err != nil {
+		return fmt.Errorf("move current version aside: %w", err)
+	}
+	if err := os.Rename(previousPath, targetPath); err != nil {
+		if restoreErr := os.Rename(swapPath, targetPath); restoreErr != nil {
+			return fmt.Errorf("rollback failed (%w) and restore failed (%v)", err, restoreErr)
+		}
+		return fmt.Errorf("rollback failed, kept current version: %w", err)
+	}
+	if err := os.Rename(swapPath, previousPath); err != nil {
+		return fmt.Errorf("rolled back, but could not keep the replaced version: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,55 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mqasimca/nylas/internal/cli/common"
+)
+
+func newRollbackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback",
+		Short: "Go back to the version before the last update",
+		Long: `Restore the binary that the last 'nylas update' replaced.
+
+The replaced version is kept next to the binary as nylas.previous. Rolling
+back swaps the two, so running rollback again returns to the newer version.`,
+		Example: `  # Undo the last update
+  nylas update rollback`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback()
+		},
+	}
+}
+
+func runRollback() error {
+	if isHomebrewInstall() {
+		fmt.Println("Nylas CLI was installed via Homebrew.")
+		fmt.Println("To go back to an earlier version, use Homebrew instead.")
+		return nil
+	}
+
+	currentBinaryPath, err := getCurrentBinaryPath()
+	if err != nil {
+		return common.WrapGetError("current binary", err)
+	}
+
+	if err := rollbackBinary(currentBinaryPath); err != nil {
+		if errors.Is(err, errNoPreviousBinary) {
+			return common.NewUserError(
+				"no previous version to roll back to",
+				"A previous version is kept only after 'nylas update' installs a new one",
+			)
+		}
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+
+	fmt.Println("Restored the previous version.")
+	fmt.Println("Run 'nylas version' to verify, or 'nylas update rollback' again to undo.")
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package update
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// testSigner creates minisign keys and signatures like the minisign tool.
+type testSigner struct {
+	keyID      [8]byte
+	publicKey  ed25519.PublicKey
+	privateKey ed25519.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testSigner{publicKey: public, privateKey: private}
+	if _, err := rand.Read(s.keyID[:]); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// publicKeyFile returns the contents of a minisign .pub file.
+func (s *testSigner) publicKeyFile() string {
+	raw := append([]byte(algPure), s.keyID[:]...)
+	raw = append(raw, s.publicKey...)
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(raw) + "\n"
+}
+
+// sign returns a .minisig file for message using algorithm alg.
+func (s *testSigner) sign(message []byte, alg, comment string) []byte {
+	signed := message
+	if alg == algPrehashed {
+		sum := blake2b.Sum512(message)
+		signed = sum[:]
+	}
+	sig := ed25519.Sign(s.privateKey, signed)
+	raw := append([]byte(alg), s.keyID[:]...)
+	raw = append(raw, sig...)
+	global := ed25519.Sign(s.privateKey, append(append([]byte{}, sig...), comment...))
+
+	return []byte("untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(raw) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n")
+}
+
+func TestVerifySignature(t *testing.T) {
+	signer := newTestSigner(t)
+	other := newTestSigner(t)
+	message := []byte("abc123  nylas_1.2.0_linux_amd64.tar.gz\n")
+
+	tests := []struct {
+		name      string
+		publicKey string
+		message   []byte
+		signature []byte
+		wantErr   string
+	}{
+		{"prehashed", signer.publicKeyFile(), message, signer.sign(message, algPrehashed, "nylas v1.2.0"), ""},
+		{"legacy", signer.publicKeyFile(), message, signer.sign(message, algPure, "nylas v1.2.0"), ""},
+		{"key line only", strings.Split(signer.publicKeyFile(), "\n")[1], message, signer.sign(message, algPrehashed, "nylas v1.2.0"), ""},
+		{"tampered message", signer.publicKeyFile(), []byte("evil"), signer.sign(message, algPrehashed, "nylas v1.2.0"), "does not match"},
+		{"other key", other.publicKeyFile(), message, signer.sign(message, algPrehashed, "nylas v1.2.0"), "different key"},
+		{"garbage", signer.publicKeyFile(), message, []byte("not a signature"), "invalid signature"},
+		{"bad key", "bogus", message, signer.sign(message, algPrehashed, "nylas v1.2.0"), "invalid minisign public key"},
+		{"other release", signer.publicKeyFile(), message, signer.sign(message, algPrehashed, "nylas v1.1.0"), "not \"nylas v1.2.0\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySignature(tt.publicKey, tt.message, tt.signature, "nylas v1.2.0")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("verifySignature() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifySignature() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("tampered trusted comment", func(t *testing.T) {
+		sig := strings.Replace(string(signer.sign(message, algPrehashed, "nylas v1.2.0")), "v1.2.0", "v9.9.9", 1)
+		err := verifySignature(signer.publicKeyFile(), message, []byte(sig), "nylas v9.9.9")
+		if err == nil || !strings.Contains(err.Error(), "trusted comment") {
+			t.Errorf("verifySignature() error = %v, want a trusted comment error", err)
+		}
+	})
+}
+
+func TestVerifyChecksumsSignature(t *testing.T) {
+	signer := newTestSigner(t)
+	checksums := []byte("abc123  nylas_1.2.0_linux_amd64.tar.gz\n")
+
+	signature := signer.sign(checksums, algPrehashed, "nylas v1.2.0")
+	t.Cleanup(func() { releasePublicKey = "" })
+
+	releasePublicKey = ""
+	if err := verifyChecksumsSignature(checksums, signature, "v1.2.0"); !errors.Is(err, errNoReleaseKey) {
+		t.Errorf("without a key: error = %v, want errNoReleaseKey", err)
+	}
+
+	releasePublicKey = signer.publicKeyFile()
+	if err := verifyChecksumsSignature(checksums, nil, "v1.2.0"); !errors.Is(err, errUnsigned) {
+		t.Errorf("missing signature: error = %v, want errUnsigned", err)
+	}
+	if err := verifyChecksumsSignature(checksums, signature, "v1.2.0"); err != nil {
+		t.Errorf("signed: error = %v", err)
+	}
+	if err := verifyChecksumsSignature(checksums, signature, "v1.3.0"); err == nil {
+		t.Error("a signature for another release should fail")
+	}
+}
@@ -3,8 +3,10 @@ package update
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -15,11 +17,21 @@ import (
 	"github.com/mqasimca/nylas/internal/domain"
 )
 
+// updateOptions holds the flags of the update command.
+type updateOptions struct {
+	checkOnly     bool
+	force         bool
+	yes           bool
+	channel       string
+	fromFile      string
+	checksumsFile string
+	signatureFile string
+	skipSignature bool
+}
+
 // NewUpdateCmd creates the update command.
 func NewUpdateCmd() *cobra.Command {
-	var checkOnly bool
-	var force bool
-	var yes bool
+	var opts updateOptions
 
 	cmd := &cobra.Command{
 		Use:   "update",
@@ -27,34 +39,75 @@ func NewUpdateCmd() *cobra.Command {
 		Long: `Check for and install the latest version of Nylas CLI from GitHub releases.
 
 This command will:
-1. Check GitHub for the latest release
+1. Check GitHub for the latest release on your channel
 2. Compare with your current version
-3. Download and verify the new binary
-4. Replace the current binary with the new one`,
+3. Download the archive, check the signature of checksums.txt, then the
+   archive's checksum
+4. Replace the current binary, keeping the old one for 'nylas update rollback'
+
+The stable channel installs full releases. The beta channel also installs
+pre-releases.
+
+Release builds verify checksums.txt with the minisign key built into the
+binary and refuse unsigned or tampered releases, or a signature made for
+another release. --insecure-skip-signature installs without checking the
+signature; only the checksum is checked then. Builds without the key, such
+as 'go install' builds, check the checksum only.
+
+Machines without internet access can install an archive downloaded
+elsewhere with --from-file. Put checksums.txt and checksums.txt.minisig
+from the same release next to it, or pass --checksums and --signature.`,
 		Example: `  # Check for updates and install if available
   nylas update
 
   # Only check for updates without installing
   nylas update --check
 
+  # Install the newest pre-release
+  nylas update --channel beta
+
   # Force update even if already on latest version
   nylas update --force
 
   # Skip confirmation prompt
-  nylas update --yes`,
+  nylas update --yes
+
+  # Install a downloaded release offline
+  nylas update --from-file ./nylas_1.2.0_linux_amd64.tar.gz
+
+  # Go back to the version before the last update
+  nylas update rollback`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(cmd.Context(), checkOnly, force, yes)
+			return runUpdate(cmd.Context(), opts)
 		},
 	}
 
-	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for updates without installing")
-	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force update even if already on latest version")
-	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.checkOnly, "check", false, "Only check for updates without installing")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Force update even if already on latest version")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&opts.channel, "channel", channelStable, "Release channel: stable or beta")
+	cmd.Flags().StringVar(&opts.fromFile, "from-file", "", "Install a downloaded release archive instead of fetching one")
+	cmd.Flags().StringVar(&opts.checksumsFile, "checksums", "", "checksums.txt for --from-file (default: next to the archive)")
+	cmd.Flags().StringVar(&opts.signatureFile, "signature", "", "checksums.txt.minisig for --from-file (default: next to checksums.txt)")
+	cmd.Flags().BoolVar(&opts.skipSignature, "insecure-skip-signature", false, "Install without checking the release signature (checksum only)")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "check")
+	cmd.MarkFlagsMutuallyExclusive("insecure-skip-signature", "signature")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "channel")
+
+	cmd.AddCommand(newRollbackCmd())
 
 	return cmd
 }
 
-func runUpdate(ctx context.Context, checkOnly, force, yes bool) error {
+func runUpdate(ctx context.Context, opts updateOptions) error {
+	if opts.channel != channelStable && opts.channel != channelBeta {
+		return common.NewUserError(
+			fmt.Sprintf("invalid channel %q", opts.channel),
+			"Use --channel stable or --channel beta",
+		)
+	}
+
 	currentVersion := cli.Version
 
 	fmt.Printf("Current version: %s\n", currentVersion)
@@ -66,24 +119,32 @@ func runUpdate(ctx context.Context, checkOnly, force, yes bool) error {
 		return nil
 	}
 
+	if opts.fromFile != "" {
+		return runOfflineUpdate(currentVersion, opts)
+	}
+
 	// Fetch latest release
 	fmt.Println("Checking for updates...")
 
-	release, err := getLatestRelease(ctx)
+	release, err := getChannelRelease(ctx, opts.channel)
 	if err != nil {
 		return common.WrapGetError("updates", err)
 	}
 
 	latestVersion := parseVersion(release.TagName)
-	fmt.Printf("Latest version:  %s\n", latestVersion)
+	if release.Prerelease {
+		fmt.Printf("Latest version:  %s (pre-release)\n", latestVersion)
+	} else {
+		fmt.Printf("Latest version:  %s\n", latestVersion)
+	}
 
 	// Compare versions
-	if !force && !isUpdateAvailable(currentVersion, latestVersion) {
+	if !opts.force && !isUpdateAvailable(currentVersion, latestVersion) {
 		fmt.Println("\nYou are already running the latest version.")
 		return nil
 	}
 
-	if checkOnly {
+	if opts.checkOnly {
 		if isUpdateAvailable(currentVersion, latestVersion) {
 			fmt.Println("\nUpdate available! Run 'nylas update' to install.")
 			fmt.Printf("Release notes: %s\n", release.HTMLURL)
@@ -95,18 +156,10 @@ func runUpdate(ctx context.Context, checkOnly, force, yes bool) error {
 	fmt.Println("\nA new version is available!")
 	fmt.Printf("Release notes: %s\n", release.HTMLURL)
 
-	// Confirm update
-	if !yes {
-		fmt.Print("\nDo you want to update? [y/N]: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("read input: %w", err)
-		}
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
-			fmt.Println("Update cancelled.")
-			return nil
+	if !opts.yes {
+		confirmed, err := confirmUpdate()
+		if err != nil || !confirmed {
+			return err
 		}
 	}
 
@@ -125,30 +178,160 @@ func runUpdate(ctx context.Context, checkOnly, force, yes bool) error {
 	}
 	defer func() { _ = os.Remove(archivePath) }()
 
-	// Download and verify checksum
-	checksumAsset := findChecksumAsset(release)
-	if checksumAsset != nil {
-		fmt.Println("Verifying checksum...")
-		checksums, err := downloadChecksums(ctx, checksumAsset.BrowserDownloadURL)
-		if err != nil {
+	// Download checksums and their signature
+	var checksums, signature []byte
+	if checksumAsset := findChecksumAsset(release); checksumAsset != nil {
+		if checksums, err = downloadBytes(ctx, checksumAsset.BrowserDownloadURL); err != nil {
 			return common.WrapDownloadError("checksums", err)
 		}
+	}
+	if signatureAsset := findSignatureAsset(release); signatureAsset != nil {
+		if signature, err = downloadBytes(ctx, signatureAsset.BrowserDownloadURL); err != nil {
+			return common.WrapDownloadError("checksums signature", err)
+		}
+	}
+
+	if err := verifyArchive(archivePath, assetName, release.TagName, checksums, signature, opts.skipSignature); err != nil {
+		return err
+	}
+
+	return installArchive(archivePath, latestVersion)
+}
+
+// runOfflineUpdate installs a release archive from disk, verified against
+// checksums.txt and its signature from the same release.
+func runOfflineUpdate(currentVersion string, opts updateOptions) error {
+	assetName := filepath.Base(opts.fromFile)
+	version, err := archiveVersion(assetName)
+	if err != nil {
+		return common.NewUserError(err.Error(), "Download the archive for this platform from https://github.com/mqasimca/nylas/releases")
+	}
+	fmt.Printf("Archive version: %s\n", version)
 
-		expectedChecksum, ok := checksums[assetName]
-		if !ok {
-			return fmt.Errorf("checksum not found for %s", assetName)
+	checksumsPath := opts.checksumsFile
+	if checksumsPath == "" {
+		checksumsPath = filepath.Join(filepath.Dir(opts.fromFile), checksumsFileName)
+	}
+	//nolint:gosec // G304: the user names the file to install
+	checksums, err := os.ReadFile(checksumsPath)
+	if err != nil {
+		return common.NewUserError(
+			fmt.Sprintf("failed to read checksums: %v", err),
+			"Copy checksums.txt from the same release next to the archive, or pass --checksums",
+		)
+	}
+
+	var signature []byte
+	if !opts.skipSignature {
+		signaturePath := opts.signatureFile
+		if signaturePath == "" {
+			signaturePath = checksumsPath + signatureExt
 		}
+		//nolint:gosec // G304: the user names the file to install
+		signature, err = os.ReadFile(signaturePath)
+		// Without a release key a missing signature is fine; verifyArchive
+		// rejects it otherwise.
+		if err != nil && (opts.signatureFile != "" || !os.IsNotExist(err)) {
+			return common.NewUserError(
+				fmt.Sprintf("failed to read signature: %v", err),
+				"Copy checksums.txt.minisig from the same release, or pass --signature",
+			)
+		}
+	}
 
-		valid, err := verifyChecksum(archivePath, expectedChecksum)
-		if err != nil {
-			return fmt.Errorf("checksum verification error: %w", err)
+	if !opts.force && !isUpdateAvailable(currentVersion, version) {
+		fmt.Println("\nYou are already running this version or a newer one. Use --force to install it anyway.")
+		return nil
+	}
+
+	if !opts.yes {
+		confirmed, err := confirmUpdate()
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+
+	fmt.Println()
+	if err := verifyArchive(opts.fromFile, assetName, "v"+version, checksums, signature, opts.skipSignature); err != nil {
+		return err
+	}
+
+	return installArchive(opts.fromFile, version)
+}
+
+// confirmUpdate asks whether to go ahead with the update.
+func confirmUpdate() (bool, error) {
+	fmt.Print("\nDo you want to update? [y/N]: ")
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("read input: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("Update cancelled.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// verifyArchive checks the signature of checksums.txt from the release
+// tagged tag, then the archive's checksum in it. skipSignature, and builds
+// without a release key, only check the checksum.
+func verifyArchive(archivePath, assetName, tag string, checksums, signature []byte, skipSignature bool) error {
+	if checksums == nil {
+		if releasePublicKey != "" && !skipSignature {
+			return fmt.Errorf("release has no %s to verify the download against", checksumsFileName)
 		}
-		if !valid {
-			return fmt.Errorf("checksum verification failed - download may be corrupted")
+		fmt.Println("Release has no checksums, skipping verification.")
+		return nil
+	}
+
+	if skipSignature {
+		common.PrintWarning("Signature not checked (--insecure-skip-signature)")
+	} else {
+		fmt.Println("Verifying signature...")
+		switch err := verifyChecksumsSignature(checksums, signature, tag); {
+		case errors.Is(err, errNoReleaseKey):
+			fmt.Println("Signature not checked: this build has no release signing key.")
+		case errors.Is(err, errUnsigned):
+			return common.NewUserError(
+				fmt.Sprintf("release is not signed: %v", err),
+				"Install a signed release, or pass --insecure-skip-signature to check the checksum only",
+			)
+		case err != nil:
+			return fmt.Errorf("signature verification failed for %s: %w - the release may have been tampered with", checksumsFileName, err)
+		default:
+			fmt.Println("Signature verified.")
 		}
-		fmt.Println("Checksum verified.")
 	}
 
+	fmt.Println("Verifying checksum...")
+	parsed, err := parseChecksums(checksums)
+	if err != nil {
+		return err
+	}
+
+	expectedChecksum, ok := parsed[assetName]
+	if !ok {
+		return fmt.Errorf("checksum not found for %s", assetName)
+	}
+
+	valid, err := verifyChecksum(archivePath, expectedChecksum)
+	if err != nil {
+		return fmt.Errorf("checksum verification error: %w", err)
+	}
+	if !valid {
+		return fmt.Errorf("checksum verification failed - download may be corrupted")
+	}
+	fmt.Println("Checksum verified.")
+
+	return nil
+}
+
+// installArchive extracts the binary from a verified archive and installs it
+// over the running one.
+func installArchive(archivePath, version string) error {
 	// Extract binary
 	fmt.Println("Extracting...")
 	binaryPath, err := extractBinary(archivePath, runtime.GOOS)
@@ -169,20 +352,20 @@ func runUpdate(ctx context.Context, checkOnly, force, yes bool) error {
 		return fmt.Errorf("installation failed: %w", err)
 	}
 
-	fmt.Printf("\nSuccessfully updated to %s!\n", latestVersion)
-	fmt.Println("Run 'nylas version' to verify.")
+	fmt.Printf("\nSuccessfully updated to %s!\n", version)
+	fmt.Println("Run 'nylas version' to verify, or 'nylas update rollback' to go back.")
 
 	return nil
 }
 
 // CheckForUpdateAsync checks for updates in the background and prints a message if available.
 // This can be called during CLI startup for non-blocking update notifications.
-func CheckForUpdateAsync(currentVersion string) {
+func CheckForUpdateAsync(currentVersion, channel string) {
 	go func() {
 		ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutQuickCheck)
 		defer cancel()
 
-		release, err := getLatestRelease(ctx)
+		release, err := getChannelRelease(ctx, channel)
 		if err != nil {
 			return // Silently ignore errors in async check
 		}
@@ -190,7 +373,11 @@ func CheckForUpdateAsync(currentVersion string) {
 		latestVersion := parseVersion(release.TagName)
 		if isUpdateAvailable(currentVersion, latestVersion) {
 			fmt.Printf("\nA new version of Nylas CLI is available: %s (current: %s)\n", latestVersion, currentVersion)
-			fmt.Println("Run 'nylas update' to upgrade.")
+			if channel == channelBeta {
+				fmt.Println("Run 'nylas update --channel beta' to upgrade.")
+			} else {
+				fmt.Println("Run 'nylas update' to upgrade.")
+			}
 		}
 	}()
 }
@@ -1,7 +1,12 @@
 package update
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -201,3 +206,149 @@ func TestFindChecksumAsset(t *testing.T) {
 		t.Errorf("findChecksumAsset() = %v, want nil", got)
 	}
 }
+
+func TestNewestRelease(t *testing.T) {
+	releases := []Release{
+		{TagName: "v1.1.0"},
+		{TagName: "v1.3.0-beta.1", Prerelease: true},
+		{TagName: "v1.4.0", Draft: true},
+		{TagName: "v1.2.0"},
+		{TagName: "nightly"},
+	}
+
+	got := newestRelease(releases)
+	if got == nil || got.TagName != "v1.3.0-beta.1" {
+		t.Errorf("newestRelease() = %v, want v1.3.0-beta.1", got)
+	}
+
+	if got := newestRelease(nil); got != nil {
+		t.Errorf("newestRelease(nil) = %v, want nil", got)
+	}
+}
+
+func TestArchiveVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive string
+		want    string
+		wantErr bool
+	}{
+		{"release", getAssetName("1.2.0"), "1.2.0", false},
+		{"pre-release", getAssetName("1.3.0-beta.1"), "1.3.0-beta.1", false},
+		{"other platform", "nylas_1.2.0_plan9_mips" + testExt(), "", true},
+		{"not an archive", "something.tar.gz", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := archiveVersion(tt.archive)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("archiveVersion(%q) error = %v, wantErr %v", tt.archive, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("archiveVersion(%q) = %q, want %q", tt.archive, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	checksums, err := parseChecksums([]byte("abc123  nylas_1.2.0_linux_amd64.tar.gz\n\ndef456  nylas_1.2.0_windows_amd64.zip\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checksums) != 2 || checksums["nylas_1.2.0_windows_amd64.zip"] != "def456" {
+		t.Errorf("parseChecksums() = %v", checksums)
+	}
+}
+
+func TestVerifyArchive(t *testing.T) {
+	signer := newTestSigner(t)
+	t.Cleanup(func() { releasePublicKey = "" })
+
+	archive := filepath.Join(t.TempDir(), "nylas_1.2.0_linux_amd64.tar.gz")
+	if err := os.WriteFile(archive, []byte("archive"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("archive"))
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  nylas_1.2.0_linux_amd64.tar.gz\n")
+	signature := signer.sign(checksums, algPrehashed, "nylas v1.2.0")
+	name := filepath.Base(archive)
+	tampered := []byte(strings.Repeat("0", 64) + "  nylas_1.2.0_linux_amd64.tar.gz\n")
+
+	releasePublicKey = signer.publicKeyFile()
+	if err := verifyArchive(archive, name, "v1.2.0", checksums, signature, false); err != nil {
+		t.Errorf("signed archive: error = %v", err)
+	}
+	if err := verifyArchive(archive, name, "v1.1.0", checksums, signature, false); err == nil {
+		t.Error("a signature for another release should fail")
+	}
+	if err := verifyArchive(archive, name, "v1.2.0", checksums, nil, false); err == nil {
+		t.Error("unsigned checksums should fail with a release key")
+	}
+	if err := verifyArchive(archive, name, "v1.2.0", nil, nil, false); err == nil {
+		t.Error("missing checksums should fail with a release key")
+	}
+	if err := verifyArchive(archive, name, "v1.2.0", tampered, signature, false); err == nil {
+		t.Error("tampered checksums should fail")
+	}
+	if err := verifyArchive(archive, name, "v1.2.0", checksums, nil, true); err != nil {
+		t.Errorf("skipping the signature: error = %v", err)
+	}
+	if err := verifyArchive(archive, name, "v1.2.0", tampered, nil, true); err == nil {
+		t.Error("a wrong checksum should fail when skipping the signature")
+	}
+
+	releasePublicKey = ""
+	if err := verifyArchive(archive, name, "v1.2.0", checksums, nil, false); err != nil {
+		t.Errorf("without a release key: error = %v", err)
+	}
+	if err := verifyArchive(archive, name, "v1.2.0", tampered, nil, false); err == nil {
+		t.Error("a wrong checksum should fail without a release key")
+	}
+}
+
+func TestInstallAndRollback(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "nylas")
+	newBinary := filepath.Join(dir, "new")
+	if err := os.WriteFile(target, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newBinary, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rollbackBinary(target); err != errNoPreviousBinary {
+		t.Fatalf("rollbackBinary() before any update: error = %v, want errNoPreviousBinary", err)
+	}
+
+	if err := installBinary(newBinary, target); err != nil {
+		t.Fatalf("installBinary() error = %v", err)
+	}
+	assertFile(t, target, "new")
+	assertFile(t, previousBinaryPath(target), "old")
+
+	if err := rollbackBinary(target); err != nil {
+		t.Fatalf("rollbackBinary() error = %v", err)
+	}
+	assertFile(t, target, "old")
+	assertFile(t, previousBinaryPath(target), "new")
+
+	// A second rollback returns to the newer version.
+	if err := rollbackBinary(target); err != nil {
+		t.Fatalf("second rollbackBinary() error = %v", err)
+	}
+	assertFile(t, target, "new")
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(got) != want {
+		t.Errorf("%s = %q, want %q", filepath.Base(path), got, want)
+	}
+}
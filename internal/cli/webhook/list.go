@@ -39,7 +39,7 @@ Shows webhook ID, description, URL, status, and trigger types.`,
   nylas webhook list --format yaml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if we should use structured output from global flags
-			if common.IsJSON(cmd) {
+			if common.IsStructuredOutput(cmd) {
 				_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
 					webhooks, err := common.RunWithSpinnerResult("Fetching webhooks...", func() ([]domain.Webhook, error) {
 						return client.ListWebhooks(ctx)
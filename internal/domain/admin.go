@@ -119,6 +119,7 @@ type GrantsQueryParams struct {
 	Limit       int    `json:"limit,omitempty"`
 	Offset      int    `json:"offset,omitempty"`
 	ConnectorID string `json:"connector_id,omitempty"`
+	Provider    string `json:"provider,omitempty"`
 	Status      string `json:"status,omitempty"` // "valid", "invalid"
 }
 
@@ -119,11 +119,26 @@ type FocusTimeBlock struct {
 	Conflicts int     `json:"conflicts"` // Number of meetings that would conflict
 }
 
-// PatternStore defines the interface for storing learned patterns.
+// PatternProfileVersion is the current on-disk format of a PatternProfile.
+// Profiles with a different version are discarded and rebuilt.
+const PatternProfileVersion = 1
+
+// PatternProfile is a persisted, incrementally updated set of learned patterns for a grant.
+type PatternProfile struct {
+	Version      int             `json:"version"`
+	GrantID      string          `json:"grant_id"`
+	CoveredFrom  time.Time       `json:"covered_from"` // Earliest time the samples cover
+	SyncedAt     time.Time       `json:"synced_at"`    // Last time new events were fetched
+	Patterns     *MeetingPattern `json:"patterns"`     // Patterns learned from Samples
+	Samples      []Event         `json:"samples"`      // Compacted events the patterns are learned from
+	ImportedFrom string          `json:"imported_from,omitempty"`
+}
+
+// PatternStore defines the interface for storing learned pattern profiles.
 type PatternStore interface {
-	SavePattern(pattern *MeetingPattern) error
-	LoadPattern(userEmail string) (*MeetingPattern, error)
-	DeletePattern(userEmail string) error
+	SaveProfile(profile *PatternProfile) error
+	LoadProfile(grantID string) (*PatternProfile, error) // Returns nil, nil when no profile exists
+	DeleteProfile(grantID string) error
 }
 
 // ============================================================================
@@ -250,6 +265,7 @@ type FocusTimeSettings struct {
 	ProtectedDays        []string                   `json:"protected_days"`        // Days to protect (e.g., "Wednesday")
 	ExcludedTimeRanges   []TimeRange                `json:"excluded_time_ranges"`  // Times to exclude from protection
 	NotificationSettings FocusTimeNotificationPrefs `json:"notification_settings"`
+	WorkingHours         *WorkingHoursConfig        `json:"working_hours,omitempty"` // Where blocks may move; 9-5 if unset
 	// Bool fields grouped to minimize padding
 	Enabled             bool `json:"enabled"`
 	AutoBlock           bool `json:"auto_block"`            // Auto-create focus blocks
@@ -324,6 +340,53 @@ type FocusProtectionRule struct {
 	RequireApproval      bool `json:"require_approval"`       // Require manual approval
 }
 
+// Event metadata keys used to tag focus blocks created by the CLI.
+const (
+	FocusBlockMetadataKey   = "nylas_focus_block" // "true" on every focus block
+	FocusBlockMetadataDay   = "nylas_focus_day"   // Target day, e.g. "Tuesday"
+	FocusBlockMetadataStart = "nylas_focus_start" // Target start, "HH:MM"
+	FocusBlockMetadataEnd   = "nylas_focus_end"   // Target end, "HH:MM"
+)
+
+// IsFocusBlock reports whether an event is a focus block created by the CLI.
+func (e *Event) IsFocusBlock() bool {
+	return e.Metadata[FocusBlockMetadataKey] == "true"
+}
+
+// FocusBlockAction describes what reconciliation did to a focus block.
+type FocusBlockAction string
+
+const (
+	FocusBlockKept     FocusBlockAction = "kept"     // No conflicts, unchanged
+	FocusBlockExtended FocusBlockAction = "extended" // Grown back toward its target slot
+	FocusBlockShrunk   FocusBlockAction = "shrunk"   // Trimmed around a conflicting meeting
+	FocusBlockMoved    FocusBlockAction = "moved"    // Moved to a free slot the same day
+	FocusBlockRemoved  FocusBlockAction = "removed"  // No room left, deleted
+)
+
+// FocusBlockChange records a reconciliation decision for one focus block.
+type FocusBlockChange struct {
+	EventID    string           `json:"event_id"`
+	CalendarID string           `json:"calendar_id"`
+	Action     FocusBlockAction `json:"action"`
+	OldStart   time.Time        `json:"old_start"`
+	OldEnd     time.Time        `json:"old_end"`
+	NewStart   time.Time        `json:"new_start,omitempty"`
+	NewEnd     time.Time        `json:"new_end,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+	Applied    bool             `json:"applied"`
+}
+
+// FocusTimeStatus reports protected focus time for a week.
+type FocusTimeStatus struct {
+	WeekStart      time.Time          `json:"week_start"`
+	WeekEnd        time.Time          `json:"week_end"`
+	ProtectedHours float64            `json:"protected_hours"`
+	TargetHours    float64            `json:"target_hours"`
+	Blocks         []ProtectedBlock   `json:"blocks"`
+	Changes        []FocusBlockChange `json:"changes,omitempty"`
+}
+
 // OverrideRequest represents a request to override a protected focus block.
 type OverrideRequest struct {
 	ID                    string          `json:"id"`
@@ -51,6 +51,12 @@ type Config struct {
 	// API settings
 	API *APIConfig `yaml:"api,omitempty"`
 
+	// Secret storage settings
+	Secrets *SecretsConfig `yaml:"secrets,omitempty"`
+
+	// Outbound HTTP settings (proxy and certificates)
+	HTTP *HTTPConfig `yaml:"http,omitempty"`
+
 	// TUI settings
 	TUITheme string `yaml:"tui_theme,omitempty"`
 
@@ -66,6 +72,33 @@ type APIConfig struct {
 	BaseURL string `yaml:"base_url,omitempty"` // API base URL
 }
 
+// HTTPConfig configures outbound connections to Nylas and other services.
+// Each setting can also come from the environment (NYLAS_PROXY,
+// NYLAS_NO_PROXY, NYLAS_CA_CERT, NYLAS_CLIENT_CERT, NYLAS_CLIENT_KEY).
+type HTTPConfig struct {
+	Proxy      string `yaml:"proxy,omitempty"`       // Proxy URL; unset uses HTTPS_PROXY/HTTP_PROXY
+	NoProxy    string `yaml:"no_proxy,omitempty"`    // Comma-separated hosts that bypass the proxy
+	CACert     string `yaml:"ca_cert,omitempty"`     // PEM bundle trusted in addition to system roots
+	ClientCert string `yaml:"client_cert,omitempty"` // PEM client certificate for mutual TLS
+	ClientKey  string `yaml:"client_key,omitempty"`  // PEM key for the client certificate
+}
+
+// Secret store backends.
+const (
+	SecretBackendKeyring    = "keyring"    // System keychain
+	SecretBackendFile       = "file"       // File encrypted with a machine-derived key
+	SecretBackendPassphrase = "passphrase" // File encrypted with an Argon2id key from a passphrase
+	SecretBackendPass       = "pass"       // pass, the GPG-based password store
+	SecretBackendHelper     = "helper"     // External credential helper executable
+)
+
+// SecretsConfig selects where credentials and grants are stored.
+type SecretsConfig struct {
+	Backend    string `yaml:"backend,omitempty"`     // One of the SecretBackend* values; empty uses the keyring, falling back to file
+	PassPrefix string `yaml:"pass_prefix,omitempty"` // pass entry prefix (default nylas/<profile>)
+	Helper     string `yaml:"helper,omitempty"`      // Helper name (runs nylas-credential-<name>) or path, with optional arguments
+}
+
 // DefaultConfig returns a config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
@@ -76,6 +109,7 @@ func DefaultConfig() *Config {
 
 // ConfigStatus represents the current configuration status.
 type ConfigStatus struct {
+	Profile         string `json:"profile"`
 	IsConfigured    bool   `json:"configured"`
 	Region          string `json:"region"`
 	ClientID        string `json:"client_id,omitempty"`
@@ -1,7 +1,12 @@
 // Package domain contains the core business logic and domain models.
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 // Sentinel errors for the application.
 var (
@@ -13,14 +18,25 @@ var (
 	ErrGrantNotFound   = errors.New("grant not found")
 	ErrNoDefaultGrant  = errors.New("no default grant set")
 	ErrInvalidGrant    = errors.New("invalid or expired grant")
+	ErrReauthMismatch  = errors.New("signed in to a different account")
 	ErrTokenExpired    = errors.New("token expired")
 	ErrAPIError        = errors.New("nylas API error")
 	ErrNetworkError    = errors.New("network error")
 	ErrInvalidInput    = errors.New("invalid input")
 
+	// API error classes. An *APIError matches ErrAPIError and the class for
+	// its status code with errors.Is.
+	ErrAPIUnauthorized = errors.New("unauthorized") // 401
+	ErrAPIForbidden    = errors.New("forbidden")    // 403
+	ErrAPINotFound     = errors.New("not found")    // 404
+	ErrAPIConflict     = errors.New("conflict")     // 409
+	ErrAPIRateLimited  = errors.New("rate limited") // 429
+	ErrAPIServerError  = errors.New("server error") // 5xx
+
 	// Secret store errors
 	ErrSecretNotFound    = errors.New("secret not found")
 	ErrSecretStoreFailed = errors.New("secret store operation failed")
+	ErrSecretStoreLocked = errors.New("secret store is locked")
 
 	// Config errors
 	ErrConfigNotFound = errors.New("config not found")
@@ -62,3 +78,48 @@ var (
 	ErrConfigurationNotFound = errors.New("configuration not found")
 	ErrPageNotFound          = errors.New("page not found")
 )
+
+// APIError is a failed Nylas API request.
+type APIError struct {
+	StatusCode int           // HTTP status
+	Type       string        // Nylas error type, e.g. "invalid_request_error"
+	Message    string        // Nylas error message
+	RequestID  string        // Request ID for Nylas support
+	RetryAfter time.Duration // Wait requested by the Retry-After header, if any
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s: %s", ErrAPIError, e.Message)
+	}
+	return fmt.Sprintf("%s: status %d", ErrAPIError, e.StatusCode)
+}
+
+// Class returns the ErrAPI* sentinel for the status code, or nil for
+// statuses without one (such as 400).
+func (e *APIError) Class() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrAPIUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrAPIForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrAPINotFound
+	case e.StatusCode == http.StatusConflict:
+		return ErrAPIConflict
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrAPIRateLimited
+	case e.StatusCode >= 500:
+		return ErrAPIServerError
+	default:
+		return nil
+	}
+}
+
+// Unwrap lets errors.Is match ErrAPIError and the error's class.
+func (e *APIError) Unwrap() []error {
+	if class := e.Class(); class != nil {
+		return []error{ErrAPIError, class}
+	}
+	return []error{ErrAPIError}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestAPIError tests the API error message and class matching.
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		status int
+		class  error
+	}{
+		{401, ErrAPIUnauthorized},
+		{403, ErrAPIForbidden},
+		{404, ErrAPINotFound},
+		{409, ErrAPIConflict},
+		{429, ErrAPIRateLimited},
+		{500, ErrAPIServerError},
+		{503, ErrAPIServerError},
+		{400, nil},
+	}
+
+	classes := []error{ErrAPIUnauthorized, ErrAPIForbidden, ErrAPINotFound, ErrAPIConflict, ErrAPIRateLimited, ErrAPIServerError}
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.status})
+		if !errors.Is(err, ErrAPIError) {
+			t.Errorf("status %d: should match ErrAPIError", tt.status)
+		}
+		for _, class := range classes {
+			if got := errors.Is(err, class); got != (class == tt.class) {
+				t.Errorf("status %d: errors.Is(%v) = %v", tt.status, class, got)
+			}
+		}
+	}
+
+	withMessage := &APIError{StatusCode: 404, Message: "grant not found"}
+	if got := withMessage.Error(); got != "nylas API error: grant not found" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := (&APIError{StatusCode: 502}).Error(); got != "nylas API error: status 502" {
+		t.Errorf("Error() without message = %q", got)
+	}
+}
@@ -56,6 +56,9 @@ type GrantInfo struct {
 	ID       string   `yaml:"id" json:"id"`
 	Email    string   `yaml:"email" json:"email"`
 	Provider Provider `yaml:"provider" json:"provider"`
+	// Scopes are the scopes the grant had when it was last seen healthy,
+	// the baseline for spotting scope drift.
+	Scopes []string `yaml:"scopes,omitempty" json:"scopes,omitempty"`
 }
 
 // GrantStatus represents the status information for a grant.
@@ -67,3 +70,40 @@ type GrantStatus struct {
 	IsDefault bool     `json:"is_default"`
 	Error     string   `json:"error,omitempty"`
 }
+
+// Grant health states reported by a grant health check.
+const (
+	GrantHealthy    = "healthy"
+	GrantInvalid    = "invalid"
+	GrantExpired    = "expired"
+	GrantRevoked    = "revoked"
+	GrantScopeDrift = "scope_drift"
+	GrantCheckError = "error"
+)
+
+// GrantHealth is the result of checking a stored grant against the API.
+type GrantHealth struct {
+	ID            string   `json:"id"`
+	Email         string   `json:"email"`
+	Provider      Provider `json:"provider"`
+	Health        string   `json:"health"`
+	GrantStatus   string   `json:"grant_status,omitempty"`
+	MissingScopes []string `json:"missing_scopes,omitempty"`
+	IsDefault     bool     `json:"is_default"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// IsHealthy returns true if the grant is usable with all of its scopes.
+func (h GrantHealth) IsHealthy() bool {
+	return h.Health == GrantHealthy
+}
+
+// NeedsReauth returns true if signing in again would fix the grant.
+// Check errors are left out, since they say nothing about the grant.
+func (h GrantHealth) NeedsReauth() bool {
+	switch h.Health {
+	case GrantInvalid, GrantExpired, GrantRevoked, GrantScopeDrift:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// A cassette is a directory of recorded HTTP interactions, one JSON file
+// per request, named in recording order. Requests are stored without
+// headers and with secrets redacted, so cassettes can be committed and
+// shared.
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the matched part of a request.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`            // Grant IDs are replaced with {grant_id}
+	Query  string `json:"query,omitempty"` // Sorted, secrets redacted
+	Body   string `json:"body,omitempty"`  // Textual bodies only, secrets redacted
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	Status   int               `json:"status"`
+	Header   map[string]string `json:"header,omitempty"`
+	Body     string            `json:"body,omitempty"`
+	Encoding string            `json:"encoding,omitempty"` // "base64" for binary bodies
+}
+
+// recordedHeaders are the response headers kept in a cassette.
+var recordedHeaders = []string{"Content-Type", "Content-Disposition", "Retry-After", "X-Request-Id"}
+
+// grantPath matches the grant ID segment of an API path.
+var grantPath = regexp.MustCompile(`(/grants/)[^/]+`)
+
+// newRecordedRequest describes req for matching, reading its body through
+// GetBody so the request can still be sent.
+func newRecordedRequest(req *http.Request) RecordedRequest {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   grantPath.ReplaceAllString(req.URL.Path, "${1}{grant_id}"),
+		Query:  normalizeQuery(req.URL.Query()),
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if isTextual(contentType) && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+			recorded.Body = normalizeBody(RedactBody(contentType, string(data)))
+		}
+	}
+	return recorded
+}
+
+// key returns the string requests are matched on.
+func (r RecordedRequest) key() string {
+	return r.Method + " " + r.Path + "?" + r.Query + "\n" + r.Body
+}
+
+// normalizeQuery encodes a query with sorted keys and secrets redacted.
+func normalizeQuery(query url.Values) string {
+	for name := range query {
+		if sensitiveParam.MatchString(name) {
+			query.Set(name, redacted)
+		}
+	}
+	return query.Encode() // Encode sorts by key
+}
+
+// normalizeBody re-encodes JSON compactly with sorted keys, so field order
+// and whitespace don't affect matching.
+func normalizeBody(body string) string {
+	var value any
+	if json.Unmarshal([]byte(body), &value) != nil {
+		return body
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// Recorder is an http.RoundTripper that sends requests through a base
+// transport and saves each request and response to a cassette directory.
+type Recorder struct {
+	base http.RoundTripper
+	dir  string
+	mu   sync.Mutex
+	seq  int
+}
+
+// NewRecorder creates a Recorder writing to dir, continuing the numbering
+// of any interactions already there.
+func NewRecorder(dir string, base http.RoundTripper) *Recorder {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	return &Recorder{base: base, dir: dir, seq: len(files)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := newRecordedRequest(req)
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	interaction := Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{Status: resp.StatusCode, Header: map[string]string{}},
+	}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			interaction.Response.Header[name] = value
+		}
+	}
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case len(data) == 0:
+	case isTextual(contentType) && utf8.Valid(data):
+		interaction.Response.Body = RedactBody(contentType, string(data))
+	default:
+		interaction.Response.Body = base64.StdEncoding.EncodeToString(data)
+		interaction.Response.Encoding = "base64"
+	}
+
+	if err := r.save(interaction); err != nil {
+		return nil, fmt.Errorf("recording %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// save writes an interaction as the next file in the cassette.
+func (r *Recorder) save(interaction Interaction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.dir, 0700); err != nil {
+		return err
+	}
+	r.seq++
+	name := fmt.Sprintf("%04d-%s%s.json", r.seq, interaction.Request.Method, fileSafe(interaction.Request.Path))
+	return os.WriteFile(filepath.Join(r.dir, name), data, 0600)
+}
+
+// fileSafe turns a path into something usable in a file name.
+func fileSafe(path string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, strings.NewReplacer("{grant_id}", "grant").Replace(path))
+	if len(safe) > 80 {
+		safe = safe[:80]
+	}
+	return safe
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// without touching the network. Matching requests are served in recorded
+// order; once they run out, the last one repeats.
+type Replayer struct {
+	dir     string
+	loose   bool
+	mu      sync.Mutex
+	loadErr error
+	byKey   map[string][]Interaction
+	served  map[string]int
+}
+
+// NewReplayer loads the cassette in dir. Requests must match a recording
+// on method, path, query and body; with loose, method and path are enough.
+// A cassette that can't be loaded answers every request with an error.
+func NewReplayer(dir string, loose bool) *Replayer {
+	r := &Replayer{dir: dir, loose: loose, byKey: make(map[string][]Interaction), served: make(map[string]int)}
+	r.loadErr = r.load()
+	return r
+}
+
+// load reads every interaction in the cassette, in file name order.
+func (r *Replayer) load() error {
+	files, err := filepath.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no recorded interactions in %s", r.dir)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304 -- cassette directory chosen by the user
+		if err != nil {
+			return err
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		key := r.matchKey(interaction.Request)
+		r.byKey[key] = append(r.byKey[key], interaction)
+	}
+	return nil
+}
+
+// matchKey returns the key a request is matched on.
+func (r *Replayer) matchKey(req RecordedRequest) string {
+	if r.loose {
+		return req.Method + " " + req.Path
+	}
+	return req.key()
+}
+
+// RoundTrip implements http.RoundTripper. A request with no recording gets
+// a 501 response naming it, which isn't retried.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	if r.loadErr != nil {
+		return missResponse(req, fmt.Sprintf("can't replay cassette: %v", r.loadErr)), nil
+	}
+
+	recorded := newRecordedRequest(req)
+	key := r.matchKey(recorded)
+
+	r.mu.Lock()
+	interactions := r.byKey[key]
+	n := r.served[key]
+	r.served[key]++
+	r.mu.Unlock()
+
+	if len(interactions) == 0 {
+		return missResponse(req, fmt.Sprintf("no recorded response for %s %s?%s in %s", recorded.Method, recorded.Path, recorded.Query, r.dir)), nil
+	}
+	return interactions[min(n, len(interactions)-1)].Response.toHTTP(req)
+}
+
+// toHTTP builds the recorded response for req.
+func (rr RecordedResponse) toHTTP(req *http.Request) (*http.Response, error) {
+	body := []byte(rr.Body)
+	if rr.Encoding == "base64" {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(rr.Body); err != nil {
+			return nil, fmt.Errorf("cassette body for %s %s: %w", req.Method, req.URL.Path, err)
+		}
+	}
+
+	header := make(http.Header)
+	for name, value := range rr.Header {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.Status, http.StatusText(rr.Status)),
+		StatusCode:    rr.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// missResponse is the Nylas-style error returned when replay can't answer.
+func missResponse(req *http.Request, message string) *http.Response {
+	data, _ := json.Marshal(map[string]any{
+		"error": map[string]string{"type": "cassette_miss", "message": message},
+	})
+	resp, _ := RecordedResponse{
+		Status: http.StatusNotImplemented,
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   string(data),
+	}.toHTTP(req)
+	return resp
+}
@@ -0,0 +1,136 @@
+package httputil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// do sends a request through client and returns the status and body.
+func do(t *testing.T, client *http.Client, method, url, body string) (int, string) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer nyk-secret")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestCassette(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cassette")
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Request-Id", "req-1")
+		switch r.URL.Path {
+		case "/v3/grants/grant-abc/messages":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"data":[{"id":"m%d"}]}`, calls)
+		case "/v3/grants/grant-abc/attachments/a1/download":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte{0xff, 0x00, 0xfe})
+		case "/v3/connect/token":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"access_token":"tok-secret"}`)
+		}
+	}))
+
+	recorder := &http.Client{Transport: NewRecorder(dir, http.DefaultTransport)}
+	do(t, recorder, http.MethodGet, server.URL+"/v3/grants/grant-abc/messages?limit=5&api_key=key-secret", "")
+	do(t, recorder, http.MethodGet, server.URL+"/v3/grants/grant-abc/messages?limit=5&api_key=key-secret", "")
+	do(t, recorder, http.MethodGet, server.URL+"/v3/grants/grant-abc/attachments/a1/download", "")
+	status, body := do(t, recorder, http.MethodPost, server.URL+"/v3/connect/token", `{"code":"oauth-code", "grant_type":"authorization_code"}`)
+	if status != http.StatusOK || body != `{"access_token":"tok-secret"}` {
+		t.Fatalf("recording changed the response: %d %q", status, body)
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 4 || filepath.Base(files[0]) != "0001-GET-v3-grants-grant-messages.json" {
+		t.Fatalf("cassette files = %v", files)
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		for _, secret := range []string{"nyk-secret", "key-secret", "oauth-code", "tok-secret", "grant-abc"} {
+			if bytes.Contains(data, []byte(secret)) {
+				t.Errorf("%s leaks %q:\n%s", file, secret, data)
+			}
+		}
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		replayer := &http.Client{Transport: NewReplayer(dir, false)}
+
+		// Another grant and host replay the same recording, in order.
+		url := "http://replay.invalid/v3/grants/grant-xyz/messages?api_key=other&limit=5"
+		for _, want := range []string{`{"data":[{"id":"m1"}]}`, `{"data":[{"id":"m2"}]}`, `{"data":[{"id":"m2"}]}`} {
+			if status, body := do(t, replayer, http.MethodGet, url, ""); status != http.StatusOK || body != want {
+				t.Errorf("replay = %d %q, want %q", status, body, want)
+			}
+		}
+
+		if _, body := do(t, replayer, http.MethodGet, "http://replay.invalid/v3/grants/g/attachments/a1/download", ""); body != "\xff\x00\xfe" {
+			t.Errorf("binary body = %q", body)
+		}
+
+		// Field order and whitespace don't matter; the body does.
+		if status, _ := do(t, replayer, http.MethodPost, "http://replay.invalid/v3/connect/token", `{"grant_type":"authorization_code","code":"other"}`); status != http.StatusOK {
+			t.Errorf("token replay status = %d", status)
+		}
+		if status, _ := do(t, replayer, http.MethodPost, "http://replay.invalid/v3/connect/token", `{"grant_type":"refresh_token"}`); status != http.StatusNotImplemented {
+			t.Errorf("different body status = %d, want 501", status)
+		}
+
+		status, body := do(t, replayer, http.MethodGet, "http://replay.invalid/v3/grants/g/messages?limit=10", "")
+		if status != http.StatusNotImplemented || !strings.Contains(body, "cassette_miss") {
+			t.Errorf("query miss = %d %q", status, body)
+		}
+	})
+
+	t.Run("loose", func(t *testing.T) {
+		replayer := &http.Client{Transport: NewReplayer(dir, true)}
+		if status, _ := do(t, replayer, http.MethodGet, "http://replay.invalid/v3/grants/g/messages?limit=10", ""); status != http.StatusOK {
+			t.Errorf("loose replay status = %d", status)
+		}
+		if status, _ := do(t, replayer, http.MethodDelete, "http://replay.invalid/v3/grants/g/messages", ""); status != http.StatusNotImplemented {
+			t.Errorf("method miss status = %d, want 501", status)
+		}
+	})
+
+	t.Run("record continues numbering", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		do(t, &http.Client{Transport: NewRecorder(dir, http.DefaultTransport)}, http.MethodGet, server.URL+"/v3/grants", "")
+		if _, err := os.Stat(filepath.Join(dir, "0005-GET-v3-grants.json")); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		replayer := &http.Client{Transport: NewReplayer(filepath.Join(dir, "missing"), false)}
+		status, body := do(t, replayer, http.MethodGet, "http://replay.invalid/v3/grants", "")
+		if status != http.StatusNotImplemented || !strings.Contains(body, "no recorded interactions") {
+			t.Errorf("missing cassette = %d %q", status, body)
+		}
+	})
+}
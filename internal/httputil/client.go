@@ -0,0 +1,161 @@
+package httputil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ClientSettings configures every outbound HTTP client the CLI creates:
+// the Nylas, Slack, AI and MCP clients.
+type ClientSettings struct {
+	Proxy      string   // Proxy URL for all requests; empty uses HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	NoProxy    string   // Comma-separated hosts or domain suffixes that bypass Proxy
+	CACerts    []string // PEM files trusted in addition to the system roots
+	ClientCert string   // PEM client certificate for mutual TLS
+	ClientKey  string   // PEM private key for ClientCert
+	UserAgent  string   // Sent when a request doesn't set its own
+
+	Trace       io.Writer // Logs each request and response when set
+	TraceDetail bool      // Adds redacted headers and bodies to the trace
+}
+
+var (
+	clientMu        sync.RWMutex
+	clientTransport http.RoundTripper = newTracingTransport(http.DefaultTransport, ClientSettings{})
+)
+
+// Configure builds the transport used by NewClient from settings. It fails
+// if a proxy URL or certificate file is invalid, leaving the previous
+// transport in place.
+func Configure(settings ClientSettings) error {
+	base, err := newBaseTransport(settings)
+	if err != nil {
+		return err
+	}
+
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	clientTransport = newTracingTransport(base, settings)
+	return nil
+}
+
+// Transport returns the configured transport, for clients that build their
+// own http.Client.
+func Transport() http.RoundTripper {
+	clientMu.RLock()
+	defer clientMu.RUnlock()
+	return clientTransport
+}
+
+// NewClient returns an http.Client using the configured proxy, certificates
+// and tracing. A zero timeout leaves timing to the request context.
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: Transport(), Timeout: timeout}
+}
+
+// RequestID returns the request ID a server assigned to resp, if any.
+func RequestID(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	// Nylas uses X-Request-Id header
+	if id := resp.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return resp.Header.Get("Request-Id")
+}
+
+// newBaseTransport clones the default transport with the proxy and TLS
+// settings applied.
+func newBaseTransport(settings ClientSettings) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if settings.Proxy != "" {
+		proxyURL, err := url.Parse(settings.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", settings.Proxy)
+		}
+		noProxy := splitNoProxy(settings.NoProxy)
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassProxy(req.URL.Hostname(), noProxy) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+	}
+
+	if len(settings.CACerts) == 0 && settings.ClientCert == "" && settings.ClientKey == "" {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(settings.CACerts) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, file := range settings.CACerts {
+			pem, err := os.ReadFile(file) // #nosec G304 -- user-configured CA bundle
+			if err != nil {
+				return nil, fmt.Errorf("reading CA certificates: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no PEM certificates found in %s", file)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.ClientCert != "" || settings.ClientKey != "" {
+		if settings.ClientCert == "" || settings.ClientKey == "" {
+			return nil, fmt.Errorf("a client certificate needs both a certificate and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(settings.ClientCert, settings.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// splitNoProxy parses a comma-separated NO_PROXY style list.
+func splitNoProxy(list string) []string {
+	var hosts []string
+	for _, host := range strings.Split(list, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// bypassProxy reports whether host matches a no-proxy entry: the host
+// itself, a parent domain (example.com or .example.com), or "*".
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range noProxy {
+		if entry == "*" {
+			return true
+		}
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
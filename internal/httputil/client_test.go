@@ -0,0 +1,225 @@
+package httputil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracingTransport(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		_, _ = io.WriteString(w, `{"access_token":"tok-secret","grant_id":"g1"}`)
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	client := &http.Client{Transport: newTracingTransport(http.DefaultTransport, ClientSettings{
+		UserAgent:   "nylas-cli/test",
+		Trace:       &trace,
+		TraceDetail: true,
+	})}
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v3/connect/token?api_key=key-secret&limit=5",
+		strings.NewReader(`{"client_secret":"cs-secret","code":"oauth-code"}`))
+	req.Header.Set("Authorization", "Bearer nyk-secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if string(body) != `{"access_token":"tok-secret","grant_id":"g1"}` {
+		t.Errorf("caller saw body %q, want the full response", body)
+	}
+	if gotUserAgent != "nylas-cli/test" {
+		t.Errorf("User-Agent = %q, want nylas-cli/test", gotUserAgent)
+	}
+
+	out := trace.String()
+	for _, want := range []string{"HTTP POST", "200 OK", "request_id=req-123", "> Authorization: [REDACTED]", `"grant_id":"g1"`, "limit=5"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trace missing %q:\n%s", want, out)
+		}
+	}
+	for _, secret := range []string{"tok-secret", "nyk-secret", "cs-secret", "oauth-code", "key-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("trace leaks %q:\n%s", secret, out)
+		}
+	}
+}
+
+func TestTracingTransport_Summary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var trace bytes.Buffer
+	client := &http.Client{Transport: newTracingTransport(http.DefaultTransport, ClientSettings{Trace: &trace})}
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v3/grants", nil)
+	req.Header.Set("Authorization", "Bearer nyk-secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	lines := strings.Split(strings.TrimSpace(trace.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], "GET "+server.URL+"/v3/grants → 404 Not Found") {
+		t.Errorf("summary trace = %q", trace.String())
+	}
+}
+
+func TestTracingTransport_EventStream(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: first\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var trace bytes.Buffer
+	client := &http.Client{
+		Transport: newTracingTransport(http.DefaultTransport, ClientSettings{Trace: &trace, TraceDetail: true}),
+		Timeout:   5 * time.Second,
+	}
+	resp, err := client.Get(server.URL + "/stream")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// The stream is still open, so the trace must not have waited for its body
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || line != "data: first\n" {
+		t.Errorf("caller read %q, %v; want the first event", line, err)
+	}
+	if strings.Contains(trace.String(), "data: first") {
+		t.Errorf("trace logged the stream body:\n%s", trace.String())
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	form := RedactBody("application/x-www-form-urlencoded", "token=xoxp-1&channel=C1&refresh_token=r")
+	if form != "token=[REDACTED]&channel=C1&refresh_token=[REDACTED]" {
+		t.Errorf("form = %q", form)
+	}
+
+	json := RedactBody("application/json", `{"api_key": "k", "nested": {"password":"p\"q"}, "subject":"hi"}`)
+	if json != `{"api_key": "[REDACTED]", "nested": {"password":"[REDACTED]"}, "subject":"hi"}` {
+		t.Errorf("json = %q", json)
+	}
+}
+
+func TestBypassProxy(t *testing.T) {
+	noProxy := splitNoProxy("localhost, .internal.example.com,10.0.0.1:8080")
+	tests := map[string]bool{
+		"localhost":                true,
+		"internal.example.com":     true,
+		"api.internal.example.com": true,
+		"10.0.0.1":                 true,
+		"api.us.nylas.com":         false,
+		"example.com":              false,
+	}
+	for host, want := range tests {
+		if got := bypassProxy(host, noProxy); got != want {
+			t.Errorf("bypassProxy(%q) = %v, want %v", host, got, want)
+		}
+	}
+	if !bypassProxy("anything", []string{"*"}) {
+		t.Error("* should bypass every host")
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { _ = Configure(ClientSettings{}) })
+
+	t.Run("proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+		}))
+		defer proxy.Close()
+
+		if err := Configure(ClientSettings{Proxy: proxy.URL, NoProxy: "bypass.invalid"}); err != nil {
+			t.Fatalf("Configure() error: %v", err)
+		}
+		resp, err := NewClient(0).Get("http://api.example.invalid/v3/grants")
+		if err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+		_ = resp.Body.Close()
+		if proxied != "http://api.example.invalid/v3/grants" {
+			t.Errorf("proxy saw %q", proxied)
+		}
+
+		u, _ := url.Parse("http://bypass.invalid/")
+		if proxyURL, _ := Transport().(*tracingTransport).base.(*http.Transport).Proxy(&http.Request{URL: u}); proxyURL != nil {
+			t.Errorf("no_proxy host went through %v", proxyURL)
+		}
+	})
+
+	t.Run("custom CA", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		if err := Configure(ClientSettings{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewClient(0).Get(server.URL); err == nil {
+			t.Fatal("expected the test server's certificate to be untrusted by default")
+		}
+
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := Configure(ClientSettings{CACerts: []string{caFile}}); err != nil {
+			t.Fatalf("Configure() error: %v", err)
+		}
+		resp, err := NewClient(0).Get(server.URL)
+		if err != nil {
+			t.Fatalf("Get() with CA error: %v", err)
+		}
+		_ = resp.Body.Close()
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.pem")
+		_ = os.WriteFile(empty, []byte("not a certificate"), 0600)
+
+		for name, settings := range map[string]ClientSettings{
+			"proxy URL":        {Proxy: "::not a url"},
+			"missing CA":       {CACerts: []string{"/nonexistent/ca.pem"}},
+			"CA without PEM":   {CACerts: []string{empty}},
+			"cert without key": {ClientCert: "/etc/client.pem"},
+		} {
+			if err := Configure(settings); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
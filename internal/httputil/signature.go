@@ -0,0 +1,21 @@
+package httputil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignHMAC returns the hex HMAC-SHA256 of body, the form of the
+// X-Nylas-Signature header on webhook deliveries.
+func SignHMAC(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHMAC reports whether signature is the SignHMAC of body, comparing
+// in constant time.
+func VerifyHMAC(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(SignHMAC(secret, body)))
+}
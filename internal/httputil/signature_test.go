@@ -0,0 +1,18 @@
+package httputil
+
+import "testing"
+
+func TestSignHMAC(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	want := "06988fa1cf02b8383043f7f2735f723f7bb350950d9214409cde13490d6a6373"
+
+	if got := SignHMAC("s3cret", body); got != want {
+		t.Errorf("SignHMAC() = %s, want %s", got, want)
+	}
+	if !VerifyHMAC("s3cret", body, want) {
+		t.Error("VerifyHMAC() rejected a valid signature")
+	}
+	if VerifyHMAC("other", body, want) || VerifyHMAC("s3cret", []byte("{}"), want) || VerifyHMAC("s3cret", body, "") {
+		t.Error("VerifyHMAC() accepted a wrong signature")
+	}
+}
@@ -0,0 +1,225 @@
+package httputil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTraceBody caps how much of each body a detailed trace shows.
+const maxTraceBody = 8 * 1024
+
+// redacted replaces secrets in traces.
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are never written to a trace.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"Api-Key":             true,
+	"X-Goog-Api-Key":      true,
+}
+
+// sensitiveName matches field names whose values are secrets: tokens,
+// secrets, passwords, API keys and OAuth codes.
+const sensitiveName = `(?i:[a-z_]*(?:token|secret|password|api_?key)|code)`
+
+var (
+	sensitiveJSON  = regexp.MustCompile(`("` + sensitiveName + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	sensitiveForm  = regexp.MustCompile(`(^|&)(` + sensitiveName + `)=[^&]*`)
+	sensitiveParam = regexp.MustCompile(`^` + sensitiveName + `$`)
+)
+
+// traceMu keeps concurrent requests' trace entries from interleaving.
+var traceMu sync.Mutex
+
+// tracingTransport sets the User-Agent and logs requests when tracing is on.
+type tracingTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	w         io.Writer
+	detail    bool
+}
+
+func newTracingTransport(base http.RoundTripper, settings ClientSettings) *tracingTransport {
+	return &tracingTransport{
+		base:      base,
+		userAgent: settings.UserAgent,
+		w:         settings.Trace,
+		detail:    settings.TraceDetail,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	if t.w == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	var entry strings.Builder
+	if t.detail {
+		writeHeaders(&entry, "> ", req.Header)
+		writeBody(&entry, "> ", req.Header.Get("Content-Type"), requestBody(req), req.ContentLength)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	summary := fmt.Sprintf("HTTP %s %s", req.Method, redactURL(req.URL))
+	if err != nil {
+		summary += fmt.Sprintf(" → error: %v (%s)", err, elapsed)
+	} else {
+		summary += fmt.Sprintf(" → %s (%s)", resp.Status, elapsed)
+		if id := RequestID(resp); id != "" {
+			summary += " request_id=" + id
+		}
+		if t.detail {
+			writeHeaders(&entry, "< ", resp.Header)
+			writeBody(&entry, "< ", resp.Header.Get("Content-Type"), peekBody(resp), resp.ContentLength)
+		}
+	}
+
+	traceMu.Lock()
+	_, _ = fmt.Fprintln(t.w, summary)
+	_, _ = io.WriteString(t.w, entry.String())
+	traceMu.Unlock()
+
+	return resp, err
+}
+
+// requestBody returns up to maxTraceBody bytes of a request body without
+// consuming it, or nil when the body can't be replayed.
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(body, maxTraceBody+1))
+	return data
+}
+
+// peekBody reads up to maxTraceBody bytes of a response body and puts them
+// back so the caller still sees the whole body. Event streams are skipped:
+// reading ahead would hold the response until the stream sends enough or ends.
+func peekBody(resp *http.Response) []byte {
+	contentType := resp.Header.Get("Content-Type")
+	if resp.Body == nil || resp.Body == http.NoBody || !isTextual(contentType) || isEventStream(contentType) {
+		return nil
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxTraceBody+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+	return data
+}
+
+// writeHeaders writes headers in name order with secrets redacted.
+func writeHeaders(w *strings.Builder, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(w, "%s%s: %s\n", prefix, name, value)
+	}
+}
+
+// writeBody writes a textual body with secrets redacted, or a note about a
+// binary one.
+func writeBody(w *strings.Builder, prefix, contentType string, body []byte, length int64) {
+	if !isTextual(contentType) {
+		if length > 0 {
+			fmt.Fprintf(w, "%s(%d bytes of %s)\n", prefix, length, contentType)
+		}
+		return
+	}
+	if len(body) == 0 {
+		return
+	}
+
+	truncated := len(body) > maxTraceBody
+	if truncated {
+		body = body[:maxTraceBody]
+	}
+	text := RedactBody(contentType, string(body))
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		fmt.Fprintf(w, "%s%s\n", prefix, line)
+	}
+	if truncated {
+		fmt.Fprintf(w, "%s… (truncated)\n", prefix)
+	}
+}
+
+// RedactBody replaces secret values in a JSON or form-encoded body.
+func RedactBody(contentType, body string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		return sensitiveForm.ReplaceAllString(body, "${1}${2}="+redacted)
+	}
+	return sensitiveJSON.ReplaceAllString(body, `${1}"`+redacted+`"`)
+}
+
+// redactURL returns u with secret query parameters redacted.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	query := u.Query()
+	changed := false
+	for name := range query {
+		if sensitiveParam.MatchString(name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	copied := *u
+	copied.RawQuery = query.Encode()
+	return copied.String()
+}
+
+// isTextual reports whether a content type is worth printing.
+func isTextual(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "json") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
+
+// isEventStream reports whether a content type is a server-sent event stream.
+func isEventStream(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "text/event-stream"
+}
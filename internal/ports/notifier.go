@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Notification is an alert for the user, delivered outside the terminal.
+type Notification struct {
+	Event   string    `json:"event"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	Data    any       `json:"data,omitempty"`
+}
+
+// Notifier delivers notifications, for example as desktop notifications or
+// webhook calls.
+type Notifier interface {
+	// Notify delivers a notification.
+	Notify(ctx context.Context, n Notification) error
+}
@@ -7,10 +7,13 @@ import "io"
 type OutputFormat string
 
 const (
-	FormatTable OutputFormat = "table"
-	FormatJSON  OutputFormat = "json"
-	FormatYAML  OutputFormat = "yaml"
-	FormatQuiet OutputFormat = "quiet"
+	FormatTable    OutputFormat = "table"
+	FormatJSON     OutputFormat = "json"
+	FormatYAML     OutputFormat = "yaml"
+	FormatQuiet    OutputFormat = "quiet"
+	FormatCSV      OutputFormat = "csv"
+	FormatNDJSON   OutputFormat = "ndjson"
+	FormatTemplate OutputFormat = "template"
 )
 
 // OutputWriter handles formatted output for CLI commands.
@@ -26,6 +29,25 @@ type OutputWriter interface {
 	WriteError(err error) error
 }
 
+// ListStreamer is implemented by writers that can output a list while its
+// rows are still arriving, so paginated commands don't buffer every page.
+type ListStreamer interface {
+	OutputWriter
+
+	// StreamList starts a list with the given columns. The returned stream
+	// must be closed to finish the list.
+	StreamList(columns []Column) ListStream
+}
+
+// ListStream writes the rows of a list started by ListStreamer.StreamList.
+type ListStream interface {
+	// WriteRows outputs a slice of rows, typically one page.
+	WriteRows(rows any) error
+
+	// Close finishes the list, e.g. closing a JSON array.
+	Close() error
+}
+
 // Column defines a column for table output.
 type Column struct {
 	// Header is the column header text
@@ -48,6 +70,20 @@ type OutputOptions struct {
 
 	// Writer is the destination for output
 	Writer io.Writer
+
+	// Template is the Go text/template applied to each row (FormatTemplate)
+	Template string
+
+	// Columns selects and orders list columns by header or field name.
+	// Names that aren't default columns are looked up as fields.
+	Columns []string
+
+	// Sort orders list rows by a column or field name; a leading "-" sorts descending
+	Sort string
+
+	// Query is a JMESPath expression applied to the JSON form of the data
+	// before it is rendered
+	Query string
 }
 
 // QuietFielder can be implemented by types to specify their quiet-mode output.
@@ -25,6 +25,9 @@ const (
 	KeyClientSecret = "client_secret"
 	KeyAPIKey       = "api_key"
 	KeyOrgID        = "org_id"
+
+	// KeySlackUserToken is the Slack user token of the slack commands.
+	KeySlackUserToken = "slack_user_token"
 )
 
 // GrantTokenKey returns the keystore key for a grant's access token.
@@ -3,6 +3,7 @@ package tui
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mqasimca/nylas/internal/ports"
@@ -12,13 +13,17 @@ import (
 // Config holds the TUI configuration.
 type Config struct {
 	Client          ports.NylasClient
-	GrantStore      ports.GrantStore // Optional: enables grant switching in TUI
+	GrantStore      ports.GrantStore  // Optional: enables grant switching in TUI
+	Slack           ports.SlackClient // Optional: enables the Slack view
 	GrantID         string
+	Profile         string // Configuration profile, shown in the header unless it's the default
 	Email           string
 	Provider        string
 	RefreshInterval time.Duration
-	InitialView     string    // Initial view to navigate to (messages, events, contacts, webhooks, grants)
-	Theme           ThemeName // Theme name (k9s, amber, green, apple2, vintage, ibm, futuristic, matrix)
+	InitialView     string              // Initial view to navigate to (messages, events, contacts, webhooks, grants)
+	Theme           ThemeName           // Theme name (k9s, amber, green, apple2, vintage, ibm, futuristic, matrix)
+	KeysPath        string              // Optional: key bindings file, defaults to ~/.config/nylas/tui/keys.yaml
+	WebhookServer   ports.WebhookServer // Optional: push updates from a running webhook server instead of polling
 }
 
 // App is the main TUI application using tview (like k9s).
@@ -31,6 +36,7 @@ type App struct {
 	logo    *Logo
 	status  *StatusIndicator
 	crumbs  *Crumbs
+	notify  *Notifier // New mail and event reminders
 	menu    *Menu
 	prompt  *Prompt         // For filter mode (/)
 	palette *CommandPalette // For command mode (:) with autocomplete
@@ -41,6 +47,10 @@ type App struct {
 	// Command registry for help and autocomplete
 	cmdRegistry *CommandRegistry
 
+	// User key bindings, aliases and plugins from keys.yaml
+	keys    *Keymap
+	keysErr error
+
 	// State
 	config      Config
 	styles      *Styles
@@ -48,8 +58,9 @@ type App struct {
 	mx          sync.RWMutex
 	cmdActive   bool
 	filterMode  bool
-	lastKey     rune      // For vim-style 'gg' command
-	lastKeyTime time.Time // Timeout for key sequences
+	lastKey     rune        // For vim-style 'gg' command
+	lastKeyTime time.Time   // Timeout for key sequences
+	refreshing  atomic.Bool // A background refresh is in flight
 
 	// View registry
 	views map[string]ResourceView
@@ -65,10 +76,18 @@ func NewApp(cfg Config) *App {
 		styles = DefaultStyles()
 	}
 
+	keysPath := cfg.KeysPath
+	if keysPath == "" {
+		keysPath = GetKeysPath()
+	}
+	keys, keysErr := LoadKeymap(keysPath)
+
 	app := &App{
 		Application: tview.NewApplication(),
 		config:      cfg,
 		styles:      styles,
+		keys:        keys,
+		keysErr:     keysErr,
 		views:       make(map[string]ResourceView),
 	}
 
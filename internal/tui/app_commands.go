@@ -79,6 +79,11 @@ func (a *App) goToRow(row int) {
 	}
 }
 
+// bulkDeleter is implemented by views that can delete the marked or selected items.
+type bulkDeleter interface {
+	DeleteSelected()
+}
+
 // executeCommand executes a command on the current view/item.
 func (a *App) executeCommand(cmd string) {
 	view := a.getCurrentView()
@@ -91,7 +96,10 @@ func (a *App) executeCommand(cmd string) {
 
 	switch cmd {
 	case "delete":
-		// For now, just flash a message - delete requires view-specific handling
+		if deleter, ok := view.(bulkDeleter); ok {
+			deleter.DeleteSelected()
+			return
+		}
 		a.Flash(FlashWarn, "Delete not implemented for this view")
 		return
 	case "star":
@@ -111,8 +119,7 @@ func (a *App) executeCommand(cmd string) {
 	case "replyall":
 		event = tcell.NewEventKey(tcell.KeyRune, 'A', tcell.ModNone)
 	case "forward":
-		a.Flash(FlashInfo, "Forward: coming soon")
-		return
+		event = tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModNone)
 	default:
 		return
 	}
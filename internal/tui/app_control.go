@@ -31,9 +31,7 @@ func (a *App) statusTicker() {
 		}
 
 		<-ticker.C
-		a.QueueUpdateDraw(func() {
-			a.status.Update()
-		})
+		a.QueueUpdateDraw(a.onTick)
 	}
 }
 
@@ -79,6 +77,7 @@ func (a *App) SwitchGrant(grantID, email, provider string) error {
 
 	// Update status indicator
 	a.status.UpdateGrant(email, provider, grantID)
+	a.notify.Reset()
 
 	// Refresh the current view to load data for the new grant
 	if view := a.getCurrentView(); view != nil {
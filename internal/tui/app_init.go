@@ -11,6 +11,7 @@ import (
 func (a *App) init() {
 	// Create command registry
 	a.cmdRegistry = NewCommandRegistry()
+	a.registerUserCommands()
 
 	// Create components (k9s style)
 	a.logo = NewLogo(a.styles)
@@ -20,6 +21,7 @@ func (a *App) init() {
 	a.prompt = NewPrompt(a.styles, a.onCommand, a.onFilter)
 	a.palette = NewCommandPalette(a, a.cmdRegistry, a.onPaletteExecute, a.onPaletteCancel)
 	a.content = NewPageStack()
+	a.notify = NewNotifier(a)
 
 	// Header: Logo on left, Status on right (like k9s)
 	a.header = tview.NewFlex().SetDirection(tview.FlexColumn).
@@ -27,11 +29,12 @@ func (a *App) init() {
 		AddItem(a.status, 0, 1, false)
 
 	// Main layout (vertical flex - like k9s)
-	// Layout: Header -> Crumbs -> Content -> Menu
+	// Layout: Header -> Crumbs -> Notifications -> Content -> Menu
 	a.main = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(a.header, 1, 0, false).
 		AddItem(a.crumbs, 1, 0, false).
-		AddItem(a.content, 0, 1, true). // Content takes remaining space
+		AddItem(a.notify.Primitive(), 0, 0, false). // Shown only while there is something new
+		AddItem(a.content, 0, 1, true).             // Content takes remaining space
 		AddItem(a.menu, 1, 0, false)
 
 	// Set up key bindings
@@ -44,6 +47,14 @@ func (a *App) init() {
 	}
 	a.navigateTo(initialView)
 
+	if a.config.WebhookServer != nil {
+		a.watchWebhooks(a.config.WebhookServer)
+	}
+
+	if a.keysErr != nil {
+		a.Flash(FlashWarn, "Key bindings: %v", a.keysErr)
+	}
+
 	// Set root and enable mouse
 	a.SetRoot(a.main, true)
 	a.EnableMouse(true)
@@ -87,6 +98,15 @@ func (a *App) setupKeys() {
 			return event
 		}
 
+		// Apply user plugins and key bindings from keys.yaml
+		if currentView != nil {
+			if plugin := a.keys.PluginForKey(currentView.Name(), event); plugin != nil {
+				a.runPlugin(plugin)
+				return nil
+			}
+			event = a.keys.Translate(currentView.Name(), event)
+		}
+
 		switch event.Key() {
 		case tcell.KeyCtrlC:
 			// Quit with Ctrl+C
@@ -148,10 +168,7 @@ func (a *App) setupKeys() {
 			case 'r':
 				// Refresh (lowercase only - uppercase R is for reply)
 				if currentView != nil {
-					go func() {
-						currentView.Refresh()
-						a.QueueUpdateDraw(func() {})
-					}()
+					a.reloadInBackground(currentView)
 				}
 				return nil
 
@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+// liveViews are the views refreshed in the background. Views without a
+// meaningful remote list (dashboard, availability, webhook server) are left alone.
+var liveViews = map[string]bool{
+	"messages":   true,
+	"threads":    true,
+	"drafts":     true,
+	"events":     true,
+	"contacts":   true,
+	"webhooks":   true,
+	"grants":     true,
+	"inbound":    true,
+	"slack":      true,
+	"notetakers": true,
+	"bookings":   true,
+}
+
+// webhookViews maps webhook trigger prefixes to the views they affect.
+var webhookViews = []struct {
+	prefix string
+	views  []string
+}{
+	{"message.", []string{"messages", "threads", "inbound"}},
+	{"thread.", []string{"messages", "threads"}},
+	{"folder.", []string{"messages", "threads"}},
+	{"draft.", []string{"drafts"}},
+	{"event.", []string{"events"}},
+	{"calendar.", []string{"events"}},
+	{"contact.", []string{"contacts"}},
+	{"notetaker.", []string{"notetakers"}},
+	{"booking.", []string{"bookings"}},
+	{"grant.", []string{"grants"}},
+}
+
+// viewsForWebhook returns the views affected by a webhook trigger type.
+func viewsForWebhook(eventType string) []string {
+	for _, m := range webhookViews {
+		if strings.HasPrefix(eventType, m.prefix) {
+			return m.views
+		}
+	}
+	return nil
+}
+
+// liveTarget returns the view to refresh in the background, or nil if the
+// user is busy (typing a command, filtering, reading a detail page) or the
+// view isn't live.
+func (a *App) liveTarget() ResourceView {
+	if !a.status.IsLive() || a.cmdActive || a.filterMode {
+		return nil
+	}
+	view := a.getCurrentView()
+	if view == nil || !liveViews[view.Name()] {
+		return nil
+	}
+	return view
+}
+
+// loader fetches a view's data. It is created on the UI goroutine, where it
+// captures the view's query state. Calling it makes the API calls, which may
+// happen on any goroutine, and returns a function that shows the results and
+// must run on the UI goroutine.
+type loader func() (apply func())
+
+// backgroundLoader is a view that can be reloaded off the UI goroutine.
+type backgroundLoader interface {
+	loader() loader
+}
+
+// loadNow runs a loader to completion on the calling goroutine.
+func loadNow(l loader) {
+	l()()
+}
+
+// reloadInBackground reloads a view, fetching on another goroutine and
+// applying the results on the UI goroutine. It must be called on the UI
+// goroutine. Views that can't split their loading are refreshed as before.
+func (a *App) reloadInBackground(view ResourceView) {
+	bl, ok := view.(backgroundLoader)
+	if !ok {
+		go func() {
+			view.Refresh()
+			a.QueueUpdateDraw(func() {})
+		}()
+		return
+	}
+
+	load := bl.loader()
+	go func() {
+		a.QueueUpdateDraw(load())
+	}()
+}
+
+// liveRefresh reloads the current view in the background. Tables keep the
+// cursor and marks across reloads and highlight rows that are new. Only views
+// that can fetch off the UI goroutine are refreshed, since table state is
+// only safe to touch on it.
+func (a *App) liveRefresh(view ResourceView) {
+	bl, ok := view.(backgroundLoader)
+	if !ok || !a.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+
+	load := bl.loader()
+	go func() {
+		apply := load()
+		a.QueueUpdateDraw(func() {
+			defer a.refreshing.Store(false)
+			apply()
+		})
+	}()
+}
+
+// onTick runs once a second on the UI goroutine.
+func (a *App) onTick() {
+	due := a.status.Update()
+	if due && a.config.WebhookServer == nil {
+		a.liveRefresh(a.liveTarget())
+	}
+	if a.status.IsLive() {
+		a.notify.Tick(time.Now())
+	}
+}
+
+// toggleLive pauses or resumes background updates.
+func (a *App) toggleLive() {
+	a.status.ToggleLive()
+	if a.status.IsLive() {
+		a.Flash(FlashInfo, "Live updates resumed")
+	} else {
+		a.Flash(FlashInfo, "Live updates paused")
+	}
+}
+
+// watchWebhooks refreshes views and raises notifications from events the
+// webhook server receives, replacing polling.
+func (a *App) watchWebhooks(server ports.WebhookServer) {
+	a.status.SetWebhooks(true)
+	a.notify.webhooks = true
+	server.OnEvent(func(event *ports.WebhookEvent) {
+		a.QueueUpdateDraw(func() {
+			a.handleWebhookEvent(event)
+		})
+	})
+}
+
+// handleWebhookEvent applies one webhook event on the UI goroutine.
+func (a *App) handleWebhookEvent(event *ports.WebhookEvent) {
+	if event == nil || !a.status.IsLive() {
+		return
+	}
+	if event.GrantID != "" && event.GrantID != a.config.GrantID {
+		return
+	}
+
+	if strings.HasPrefix(event.Type, "event.") {
+		a.notify.InvalidateEvents()
+	}
+	if event.Type == "message.created" {
+		if msg, ok := webhookMessage(event); ok {
+			a.notify.NotifyMessage(msg)
+		}
+	}
+
+	view := a.liveTarget()
+	if view == nil {
+		return
+	}
+	for _, name := range viewsForWebhook(event.Type) {
+		if name == view.Name() {
+			a.liveRefresh(view)
+			return
+		}
+	}
+}
+
+// webhookMessage extracts the message from a message.created payload.
+func webhookMessage(event *ports.WebhookEvent) (domain.Message, bool) {
+	data, _ := event.Body["data"].(map[string]any)
+	object, _ := data["object"].(map[string]any)
+	if object == nil {
+		return domain.Message{}, false
+	}
+
+	msg := domain.Message{}
+	msg.ID, _ = object["id"].(string)
+	msg.Subject, _ = object["subject"].(string)
+	if from, ok := object["from"].([]any); ok && len(from) > 0 {
+		if p, ok := from[0].(map[string]any); ok {
+			name, _ := p["name"].(string)
+			email, _ := p["email"].(string)
+			msg.From = []domain.EmailParticipant{{Name: name, Email: email}}
+		}
+	}
+	return msg, msg.ID != ""
+}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+)
+
+func TestViewsForWebhook(t *testing.T) {
+	tests := []struct {
+		eventType string
+		want      []string
+	}{
+		{"message.created", []string{"messages", "threads", "inbound"}},
+		{"thread.replied", []string{"messages", "threads"}},
+		{"event.updated", []string{"events"}},
+		{"contact.deleted", []string{"contacts"}},
+		{"booking.created", []string{"bookings"}},
+		{"unknown.thing", nil},
+	}
+	for _, tt := range tests {
+		if got := viewsForWebhook(tt.eventType); !slices.Equal(got, tt.want) {
+			t.Errorf("viewsForWebhook(%q) = %v, want %v", tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookMessage(t *testing.T) {
+	event := &ports.WebhookEvent{
+		Type: "message.created",
+		Body: map[string]any{
+			"data": map[string]any{
+				"object": map[string]any{
+					"id":      "msg-9",
+					"subject": "Quarterly numbers",
+					"from":    []any{map[string]any{"name": "Ann", "email": "ann@example.com"}},
+				},
+			},
+		},
+	}
+
+	msg, ok := webhookMessage(event)
+	if !ok || msg.ID != "msg-9" || msg.Subject != "Quarterly numbers" || msg.From[0].Name != "Ann" {
+		t.Errorf("webhookMessage() = %+v, %v", msg, ok)
+	}
+
+	if _, ok := webhookMessage(&ports.WebhookEvent{Body: map[string]any{}}); ok {
+		t.Error("webhookMessage() should reject payloads without an object")
+	}
+}
+
+func TestHandleWebhookEvent_NotifiesNewMail(t *testing.T) {
+	app := createTestApp(t)
+
+	event := &ports.WebhookEvent{
+		Type:    "message.created",
+		GrantID: "other-grant",
+		Body:    map[string]any{"data": map[string]any{"object": map[string]any{"id": "m1", "subject": "Hi"}}},
+	}
+	app.handleWebhookEvent(event)
+	if len(app.notify.Items()) != 0 {
+		t.Error("events for another grant should be ignored")
+	}
+
+	event.GrantID = app.config.GrantID
+	app.handleWebhookEvent(event)
+	if len(app.notify.Items()) != 1 {
+		t.Errorf("Items() = %d, want 1", len(app.notify.Items()))
+	}
+}
+
+func TestStatusIndicator_UpdateDue(t *testing.T) {
+	s := NewStatusIndicator(DefaultStyles(), Config{RefreshInterval: 2 * time.Second})
+	if s.Update() {
+		t.Error("Update() should not be due after one second")
+	}
+	if !s.Update() {
+		t.Error("Update() should be due when the countdown wraps")
+	}
+
+	off := NewStatusIndicator(DefaultStyles(), Config{})
+	if off.Update() {
+		t.Error("Update() should never be due without a refresh interval")
+	}
+}
+
+func TestStatusIndicator_Profile(t *testing.T) {
+	s := NewStatusIndicator(DefaultStyles(), Config{Profile: "staging-eu", Email: "me@example.com"})
+	if text := s.GetText(true); !strings.Contains(text, "staging-eu") {
+		t.Errorf("status %q should show the profile", text)
+	}
+
+	def := NewStatusIndicator(DefaultStyles(), Config{Profile: "default", Email: "me@example.com"})
+	if text := def.GetText(true); strings.Contains(text, "default") {
+		t.Errorf("status %q should not show the default profile", text)
+	}
+}
+
+// TestLiveRefresh_ConcurrentWithKeys reloads the messages view in the
+// background while keys mark and move through its rows. Run with -race: the
+// table must only be touched on the UI goroutine.
+func TestLiveRefresh_ConcurrentWithKeys(t *testing.T) {
+	app := createTestApp(t)
+
+	var calls atomic.Int32
+	app.config.Client.(*nylas.MockClient).GetThreadsFunc = func(ctx context.Context, grantID string, params *domain.ThreadQueryParams) ([]domain.Thread, error) {
+		// Shift the IDs on every call so rows come and go.
+		n := int(calls.Add(1))
+		threads := make([]domain.Thread, 20)
+		for i := range threads {
+			threads[i] = domain.Thread{ID: fmt.Sprintf("thread-%d", n+i), Subject: "Subject"}
+		}
+		return threads, nil
+	}
+
+	// Pause polling so only the refreshes below hit the (unsynchronized) mock.
+	if app.status.IsLive() {
+		app.status.ToggleLive()
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init() error = %v", err)
+	}
+	screen.SetSize(120, 40)
+	app.SetScreen(screen)
+
+	done := make(chan error, 1)
+	go func() { done <- app.Run() }()
+
+	views := make(chan ResourceView, 1)
+	app.QueueUpdate(func() {
+		app.navigateTo("messages")
+		views <- app.getCurrentView()
+	})
+	view := <-views
+	waitFor(t, func() bool { return calls.Load() >= 1 })
+
+	for range 30 {
+		app.QueueUpdate(func() { app.liveRefresh(view) })
+		screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+		screen.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+	}
+	waitFor(t, func() bool { return calls.Load() >= 3 })
+
+	app.Stop()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -71,6 +71,15 @@ func (a *App) onCommand(cmd string) {
 		return
 	}
 
+	// User aliases and plugins from keys.yaml
+	if target, ok := a.keys.Alias(cmd); ok {
+		cmd = target
+	}
+	if plugin := a.keys.Plugin(cmd); plugin != nil {
+		a.runPlugin(plugin)
+		return
+	}
+
 	// Handle numeric commands (go to row number)
 	if isNumeric(cmd) {
 		a.goToRow(parseInt(cmd))
@@ -81,6 +90,8 @@ func (a *App) onCommand(cmd string) {
 	// Navigation - vim style
 	case "m", "messages", "msg":
 		a.navigateTo("messages")
+	case "t", "threads", "th":
+		a.navigateTo("threads")
 	case "dr", "drafts":
 		a.navigateTo("drafts")
 	case "e", "events", "ev", "cal", "calendar":
@@ -97,6 +108,12 @@ func (a *App) onCommand(cmd string) {
 		a.navigateTo("grants")
 	case "i", "in", "inbound", "inbox":
 		a.navigateTo("inbound")
+	case "sl", "slack":
+		a.navigateTo("slack")
+	case "nt", "notetakers", "notetaker":
+		a.navigateTo("notetakers")
+	case "bk", "bookings", "booking", "scheduler":
+		a.navigateTo("bookings")
 	case "d", "dashboard", "dash", "home":
 		a.navigateTo("dashboard")
 
@@ -137,11 +154,12 @@ func (a *App) onCommand(cmd string) {
 	// View commands
 	case "refresh", "reload":
 		if view := a.getCurrentView(); view != nil {
-			go func() {
-				view.Refresh()
-				a.QueueUpdateDraw(func() {})
-			}()
+			a.reloadInBackground(view)
 		}
+	case "live", "pause":
+		a.toggleLive()
+	case "notifications", "notif", "alerts":
+		a.showNotifications()
 	case "top", "first", "gg":
 		a.goToTop()
 	case "bottom", "last", "G":
@@ -155,6 +173,8 @@ func (a *App) onCommand(cmd string) {
 			switch viewName {
 			case "messages", "m":
 				a.navigateTo("messages")
+			case "threads", "t":
+				a.navigateTo("threads")
 			case "drafts", "dr":
 				a.navigateTo("drafts")
 			case "events", "ev", "cal":
@@ -167,6 +187,12 @@ func (a *App) onCommand(cmd string) {
 				a.navigateTo("webhooks")
 			case "grants", "g":
 				a.navigateTo("grants")
+			case "slack", "sl":
+				a.navigateTo("slack")
+			case "notetakers", "nt":
+				a.navigateTo("notetakers")
+			case "bookings", "bk":
+				a.navigateTo("bookings")
 			}
 		}
 	}
@@ -176,10 +202,7 @@ func (a *App) onFilter(filter string) {
 	a.hidePrompt()
 	if view := a.getCurrentView(); view != nil {
 		view.Filter(filter)
-		go func() {
-			view.Refresh()
-			a.QueueUpdateDraw(func() {})
-		}()
+		a.reloadInBackground(view)
 	}
 }
 
@@ -195,14 +218,11 @@ func (a *App) navigateTo(name string) {
 
 	// Update UI
 	a.crumbs.SetPath(view.Title())
-	a.menu.SetHints(view.Hints())
+	a.menu.SetHints(a.keys.Hints(view.Name(), view.Hints()))
 	a.SetFocus(view.Primitive())
 
 	// Load data asynchronously
-	go func() {
-		view.Load()
-		a.QueueUpdateDraw(func() {})
-	}()
+	a.reloadInBackground(view)
 }
 
 func (a *App) goBack() *tcell.EventKey {
@@ -218,7 +238,7 @@ func (a *App) goBack() *tcell.EventKey {
 	name := a.content.Top()
 	if view, ok := a.views[name]; ok {
 		a.crumbs.SetPath(view.Title())
-		a.menu.SetHints(view.Hints())
+		a.menu.SetHints(a.keys.Hints(view.Name(), view.Hints()))
 		a.SetFocus(view.Primitive())
 	}
 
@@ -229,6 +249,8 @@ func (a *App) createView(name string) ResourceView {
 	switch name {
 	case "messages":
 		return NewMessagesView(a)
+	case "threads":
+		return NewThreadsView(a)
 	case "drafts":
 		return NewDraftsView(a)
 	case "events":
@@ -245,6 +267,12 @@ func (a *App) createView(name string) ResourceView {
 		return NewGrantsView(a)
 	case "inbound":
 		return NewInboundView(a)
+	case "slack":
+		return NewSlackView(a)
+	case "notetakers":
+		return NewNotetakersView(a)
+	case "bookings":
+		return NewBookingsView(a)
 	default:
 		return NewDashboardView(a)
 	}
@@ -281,6 +309,9 @@ func (a *App) PopDetail() {
 		a.content.Pop()
 		if view := a.getCurrentView(); view != nil {
 			a.SetFocus(view.Primitive())
+		} else if _, page := a.content.GetFrontPage(); page != nil {
+			// Back to another detail view, e.g. compose opened from a thread
+			a.SetFocus(page)
 		}
 	}
 }
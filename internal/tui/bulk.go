@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// bulkConcurrency is how many bulk operations run at once. The Nylas client
+// rate limits every request, so this only bounds in-flight calls.
+const bulkConcurrency = 4
+
+// bulkTimeout bounds a whole bulk action.
+const bulkTimeout = 5 * time.Minute
+
+// BulkFailure records one failed item of a bulk action.
+type BulkFailure struct {
+	ID    string
+	Label string
+	Err   error
+}
+
+// BulkResult summarizes a bulk action.
+type BulkResult struct {
+	Total     int
+	Succeeded int
+	Failures  []BulkFailure
+}
+
+// runBulkOps applies op to every item with bounded concurrency, calling
+// progress after each item completes. Items are identified by rows' IDs.
+func runBulkOps(ctx context.Context, items []RowMeta, concurrency int, op func(context.Context, RowMeta) error, progress func(done, total int)) BulkResult {
+	result := BulkResult{Total: len(items)}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		done atomic.Int32
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item RowMeta) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var err error
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			} else {
+				err = op(ctx, item)
+			}
+
+			mu.Lock()
+			if err != nil {
+				result.Failures = append(result.Failures, BulkFailure{ID: item.ID, Label: rowLabel(item), Err: err})
+			} else {
+				result.Succeeded++
+			}
+			mu.Unlock()
+
+			if progress != nil {
+				progress(int(done.Add(1)), len(items))
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	return result
+}
+
+// runBulk runs op over items in the background, showing progress in the
+// status bar and a summary of any failures when done. onDone runs on the UI
+// goroutine after the summary is shown.
+func (a *App) runBulk(verb string, items []RowMeta, op func(context.Context, RowMeta) error, onDone func(BulkResult)) {
+	if len(items) == 0 {
+		a.Flash(FlashWarn, "Nothing selected")
+		return
+	}
+
+	a.Flash(FlashInfo, "%s 0/%d...", verb, len(items))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), bulkTimeout)
+		defer cancel()
+
+		result := runBulkOps(ctx, items, bulkConcurrency, op, func(done, total int) {
+			a.QueueUpdateDraw(func() {
+				a.Flash(FlashInfo, "%s %d/%d...", verb, done, total)
+			})
+		})
+
+		a.QueueUpdateDraw(func() {
+			if len(result.Failures) == 0 {
+				a.Flash(FlashInfo, "%s %d item(s)", verb, result.Succeeded)
+			} else {
+				a.Flash(FlashError, "%s: %d succeeded, %d failed", verb, result.Succeeded, len(result.Failures))
+				a.ShowErrorDialog(verb+" partially failed", formatBulkFailures(result))
+			}
+			if onDone != nil {
+				onDone(result)
+			}
+		})
+	}()
+}
+
+// formatBulkFailures renders a short summary of failed items.
+func formatBulkFailures(result BulkResult) string {
+	const maxShown = 8
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d failed:\n\n", len(result.Failures), result.Total)
+	for i, f := range result.Failures {
+		if i == maxShown {
+			fmt.Fprintf(&b, "...and %d more\n", len(result.Failures)-maxShown)
+			break
+		}
+		fmt.Fprintf(&b, "• %s: %v\n", f.Label, f.Err)
+	}
+	return b.String()
+}
+
+// rowLabel returns a short description of a row for failure summaries.
+func rowLabel(meta RowMeta) string {
+	label := ""
+	switch data := meta.Data.(type) {
+	case *domain.Thread:
+		label = data.Subject
+	case *domain.Contact:
+		label = data.DisplayName()
+	case *domain.Event:
+		label = data.Title
+	case *domain.Notetaker:
+		label = notetakerTitle(data)
+	case *domain.Booking:
+		label = data.Title
+	}
+	if label == "" {
+		return meta.ID
+	}
+	return label
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func newMarkTable(ids ...string) *Table {
+	table := NewTable(DefaultStyles())
+	table.SetColumns([]Column{{Title: "ID", Expand: true}})
+
+	data := make([][]string, len(ids))
+	meta := make([]RowMeta, len(ids))
+	for i, id := range ids {
+		data[i] = []string{id}
+		meta[i] = RowMeta{ID: id}
+	}
+	table.SetData(data, meta)
+	table.Select(1, 0)
+	return table
+}
+
+func metaIDs(meta []RowMeta) []string {
+	ids := make([]string, len(meta))
+	for i, m := range meta {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+func TestTable_ToggleMark(t *testing.T) {
+	table := newMarkTable("a", "b", "c")
+
+	table.ToggleMark()
+	if !table.IsMarked("a") {
+		t.Fatal("ToggleMark() should mark the selected row")
+	}
+	if got := table.GetSelectedRow(); got != 1 {
+		t.Errorf("ToggleMark() should move to the next row, selected = %d", got)
+	}
+
+	table.Select(1, 0)
+	table.ToggleMark()
+	if table.IsMarked("a") || table.MarkedCount() != 0 {
+		t.Error("ToggleMark() twice should unmark the row")
+	}
+}
+
+func TestTable_MarkRangeAndAll(t *testing.T) {
+	table := newMarkTable("a", "b", "c", "d")
+
+	table.Select(2, 0) // "b"
+	table.ToggleMark()
+	table.Select(4, 0) // "d"
+	table.MarkRange()
+	if got := metaIDs(table.MarkedMeta()); !slices.Equal(got, []string{"b", "c", "d"}) {
+		t.Errorf("MarkRange() marked %v, want [b c d]", got)
+	}
+
+	table.ClearMarks()
+	table.MarkAll()
+	if table.MarkedCount() != 4 {
+		t.Errorf("MarkAll() marked %d rows, want 4", table.MarkedCount())
+	}
+}
+
+func TestTable_TargetMeta(t *testing.T) {
+	table := newMarkTable("a", "b", "c")
+
+	table.Select(2, 0)
+	if got := metaIDs(table.TargetMeta()); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("TargetMeta() without marks = %v, want selected row [b]", got)
+	}
+
+	table.Select(3, 0)
+	table.ToggleMark()
+	table.Select(1, 0)
+	if got := metaIDs(table.TargetMeta()); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("TargetMeta() with marks = %v, want marked rows [c]", got)
+	}
+}
+
+func TestTable_SetDataPrunesMarks(t *testing.T) {
+	table := newMarkTable("a", "b")
+	table.MarkAll()
+
+	table.SetData([][]string{{"b"}, {"c"}}, []RowMeta{{ID: "b"}, {ID: "c"}})
+	if table.IsMarked("a") || !table.IsMarked("b") || table.MarkedCount() != 1 {
+		t.Errorf("SetData() should keep only marks for loaded rows, got %v", metaIDs(table.MarkedMeta()))
+	}
+}
+
+func TestRunBulkOps(t *testing.T) {
+	items := []RowMeta{
+		{ID: "t1", Data: &domain.Thread{Subject: "First"}},
+		{ID: "t2", Data: &domain.Thread{Subject: "Second"}},
+		{ID: "t3"},
+		{ID: "t4"},
+		{ID: "t5"},
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	var progressCalls atomic.Int32
+	result := runBulkOps(context.Background(), items, 2, func(ctx context.Context, item RowMeta) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		if item.ID == "t2" {
+			return errors.New("boom")
+		}
+		return nil
+	}, func(done, total int) {
+		progressCalls.Add(1)
+	})
+
+	if result.Total != 5 || result.Succeeded != 4 || len(result.Failures) != 1 {
+		t.Fatalf("runBulkOps() = %+v, want 4 succeeded and 1 failure", result)
+	}
+	if f := result.Failures[0]; f.ID != "t2" || f.Label != "Second" {
+		t.Errorf("failure = %+v, want t2 labelled Second", f)
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("runBulkOps() ran %d ops at once, want at most 2", maxInFlight.Load())
+	}
+	if progressCalls.Load() != 5 {
+		t.Errorf("progress called %d times, want 5", progressCalls.Load())
+	}
+}
+
+func TestRunBulkOps_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	result := runBulkOps(ctx, []RowMeta{{ID: "t1"}}, 1, func(ctx context.Context, item RowMeta) error {
+		called = true
+		return nil
+	}, nil)
+
+	if called {
+		t.Error("op should not run after the context is cancelled")
+	}
+	if len(result.Failures) != 1 {
+		t.Errorf("runBulkOps() failures = %d, want 1", len(result.Failures))
+	}
+}
+
+func TestArchiveFolders(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   []string
+		archiveID string
+		want      []string
+	}{
+		{"archive folder", []string{"INBOX", "work"}, "arch", []string{"arch"}},
+		{"strip inbox", []string{"INBOX", "work"}, "", []string{"work"}},
+		{"inbox only", []string{"INBOX"}, "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := archiveFolders(tt.current, tt.archiveID); !slices.Equal(got, tt.want) {
+				t.Errorf("archiveFolders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ const (
 	CategoryFolders    CommandCategory = "Folders"
 	CategoryVim        CommandCategory = "Vim Commands"
 	CategorySystem     CommandCategory = "System"
+	CategoryPlugins    CommandCategory = "Plugins"
 )
 
 // categoryOrder defines the display order for categories.
@@ -30,6 +31,7 @@ var categoryOrder = []CommandCategory{
 	CategoryFolders,
 	CategoryVim,
 	CategorySystem,
+	CategoryPlugins,
 }
 
 // Command represents a TUI command with metadata.
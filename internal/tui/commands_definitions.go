@@ -11,6 +11,12 @@ func (r *CommandRegistry) registerAllCommands() {
 		Description: "Go to messages view",
 		Category:    CategoryNavigation,
 	})
+	r.Register(Command{
+		Name:        "threads",
+		Aliases:     []string{"t", "th"},
+		Description: "Go to threads (conversations) view",
+		Category:    CategoryNavigation,
+	})
 	r.Register(Command{
 		Name:        "events",
 		Aliases:     []string{"e", "ev", "cal", "calendar"},
@@ -47,6 +53,24 @@ func (r *CommandRegistry) registerAllCommands() {
 		Description: "Go to inbound inboxes view",
 		Category:    CategoryNavigation,
 	})
+	r.Register(Command{
+		Name:        "slack",
+		Aliases:     []string{"sl"},
+		Description: "Go to Slack channels view",
+		Category:    CategoryNavigation,
+	})
+	r.Register(Command{
+		Name:        "notetakers",
+		Aliases:     []string{"nt", "notetaker"},
+		Description: "Go to meeting notetakers view",
+		Category:    CategoryNavigation,
+	})
+	r.Register(Command{
+		Name:        "bookings",
+		Aliases:     []string{"bk", "booking", "scheduler"},
+		Description: "Go to scheduler bookings view",
+		Category:    CategoryNavigation,
+	})
 	r.Register(Command{
 		Name:        "dashboard",
 		Aliases:     []string{"d", "dash", "home"},
@@ -298,4 +322,16 @@ func (r *CommandRegistry) registerAllCommands() {
 		Category:    CategorySystem,
 		Shortcut:    "r",
 	})
+	r.Register(Command{
+		Name:        "live",
+		Aliases:     []string{"pause"},
+		Description: "Pause or resume background updates",
+		Category:    CategorySystem,
+	})
+	r.Register(Command{
+		Name:        "notifications",
+		Aliases:     []string{"notif", "alerts"},
+		Description: "Show new mail and reminder history",
+		Category:    CategorySystem,
+	})
 }
@@ -304,6 +304,7 @@ func TestCategoryOrder(t *testing.T) {
 		CategoryFolders,
 		CategoryVim,
 		CategorySystem,
+		CategoryPlugins,
 	}
 
 	if len(categoryOrder) != len(expectedCategories) {
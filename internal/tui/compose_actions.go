@@ -151,8 +151,8 @@ func (c *ComposeView) send() {
 				Body:    htmlBody,
 			}
 
-			// If replying, include the reply-to message ID for threading
-			if c.replyToMsg != nil && (c.mode == ComposeModeReply || c.mode == ComposeModeReplyAll) {
+			// Replies and forwards stay in the original thread
+			if c.replyToMsg != nil && (c.mode == ComposeModeReply || c.mode == ComposeModeReplyAll || c.mode == ComposeModeForward) {
 				req.ReplyToMsgID = c.replyToMsg.ID
 			}
 
@@ -204,6 +204,9 @@ func (a *App) ShowConfirmDialog(title, message string, onConfirm func()) {
 		a.content.Pop()
 		if view := a.getCurrentView(); view != nil {
 			a.SetFocus(view.Primitive())
+		} else if _, page := a.content.GetFrontPage(); page != nil {
+			// Dialog was opened from a detail view
+			a.SetFocus(page)
 		}
 	}
 
@@ -48,17 +48,24 @@ func NewDraftsView(app *App) *DraftsView {
 	return v
 }
 
-func (v *DraftsView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	drafts, err := v.app.config.Client.GetDrafts(ctx, v.app.config.GrantID, 50)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load drafts: %v", err)
-		return
+func (v *DraftsView) Load() { loadNow(v.loader()) }
+
+func (v *DraftsView) loader() loader {
+	client, grantID := v.app.config.Client, v.app.config.GrantID
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		drafts, err := client.GetDrafts(ctx, grantID, 50)
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load drafts: %v", err)
+				return
+			}
+			v.drafts = drafts
+			v.render()
+		}
 	}
-	v.drafts = drafts
-	v.render()
 }
 
 func (v *DraftsView) Refresh() {
@@ -101,18 +101,24 @@ func (p *FolderPanel) handleInput(event *tcell.EventKey) *tcell.EventKey {
 }
 
 // Load fetches folders from the API.
-func (p *FolderPanel) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	folders, err := p.app.config.Client.GetFolders(ctx, p.app.config.GrantID)
-	if err != nil {
-		p.app.Flash(FlashError, "Failed to load folders: %v", err)
-		return
-	}
+func (p *FolderPanel) Load() { loadNow(p.loader()) }
+
+func (p *FolderPanel) loader() loader {
+	client, grantID := p.app.config.Client, p.app.config.GrantID
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
-	p.folders = folders
-	p.render()
+		folders, err := client.GetFolders(ctx, grantID)
+		return func() {
+			if err != nil {
+				p.app.Flash(FlashError, "Failed to load folders: %v", err)
+				return
+			}
+			p.folders = folders
+			p.render()
+		}
+	}
 }
 
 func (p *FolderPanel) render() {
@@ -215,12 +215,7 @@ func (f *Form) Focus(delegate func(p tview.Primitive)) {
 
 // ShowForm displays a form and handles submit/cancel.
 func (a *App) ShowForm(title string, fields []FormField, onSubmit func(map[string]string)) {
-	onClose := func() {
-		a.content.Pop()
-		if view := a.getCurrentView(); view != nil {
-			a.SetFocus(view.Primitive())
-		}
-	}
+	onClose := a.PopDetail
 
 	form := NewForm(a, title, fields, func(values map[string]string) {
 		onClose()
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// globalKeyScope is the bindings section for keys handled in every view.
+const globalKeyScope = "global"
+
+// defaultKeyActions maps each key scope to its remappable actions and their
+// default keys. Keys use the keys.yaml notation (see parseKeyName).
+var defaultKeyActions = map[string]map[string]string{
+	globalKeyScope: {
+		"command":        ":",
+		"filter":         "/",
+		"help":           "?",
+		"refresh":        "r",
+		"up":             "k",
+		"down":           "j",
+		"bottom":         "G",
+		"delete":         "x",
+		"half-page-down": "ctrl-d",
+		"half-page-up":   "ctrl-u",
+		"page-down":      "ctrl-f",
+		"page-up":        "ctrl-b",
+	},
+	"messages": {
+		"compose":     "n",
+		"reply":       "R",
+		"reply-all":   "A",
+		"forward":     "f",
+		"star":        "s",
+		"toggle-read": "u",
+		"archive":     "a",
+		"move":        "M",
+		"folders":     "F",
+		"mark":        "space",
+		"mark-range":  "V",
+		"mark-all":    "*",
+	},
+	"events": {
+		"new":             "n",
+		"switch-calendar": "c",
+		"calendars":       "C",
+		"month":           "m",
+		"week":            "w",
+		"agenda":          "a",
+		"today":           "t",
+		"prev-month":      "H",
+		"next-month":      "L",
+		"mark":            "space",
+		"mark-range":      "V",
+		"mark-all":        "*",
+	},
+	"contacts": {
+		"new":        "n",
+		"edit":       "e",
+		"mark":       "space",
+		"mark-range": "V",
+		"mark-all":   "*",
+	},
+	"webhooks": {
+		"new":  "n",
+		"edit": "e",
+	},
+	"drafts": {
+		"new":  "n",
+		"send": "s",
+	},
+	"slack": {
+		"send": "n",
+	},
+	"notetakers": {
+		"download":   "D",
+		"mark":       "space",
+		"mark-range": "V",
+		"mark-all":   "*",
+	},
+	"bookings": {
+		"confirm":    "c",
+		"reschedule": "R",
+		"mark":       "space",
+		"mark-range": "V",
+		"mark-all":   "*",
+	},
+}
+
+// keyScopeAliases lets views share another view's actions.
+var keyScopeAliases = map[string]string{
+	"threads": "messages",
+}
+
+// keyScopeActions returns the actions for a view, following scope aliases.
+func keyScopeActions(scope string) (map[string]string, bool) {
+	if target, ok := keyScopeAliases[scope]; ok {
+		scope = target
+	}
+	actions, ok := defaultKeyActions[scope]
+	return actions, ok
+}
+
+// KeyScopes returns the names of all key binding sections.
+func KeyScopes() []string {
+	scopes := make([]string, 0, len(defaultKeyActions)+len(keyScopeAliases))
+	for scope := range defaultKeyActions {
+		scopes = append(scopes, scope)
+	}
+	for scope := range keyScopeAliases {
+		scopes = append(scopes, scope)
+	}
+	slices.Sort(scopes)
+	return scopes
+}
+
+// namedKeys maps key names to tcell keys for non-rune keys.
+var namedKeys = map[string]tcell.Key{
+	"enter":     tcell.KeyEnter,
+	"tab":       tcell.KeyTab,
+	"backtab":   tcell.KeyBacktab,
+	"backspace": tcell.KeyBackspace2,
+	"delete":    tcell.KeyDelete,
+	"insert":    tcell.KeyInsert,
+	"home":      tcell.KeyHome,
+	"end":       tcell.KeyEnd,
+	"pgup":      tcell.KeyPgUp,
+	"pgdn":      tcell.KeyPgDn,
+	"up":        tcell.KeyUp,
+	"down":      tcell.KeyDown,
+	"left":      tcell.KeyLeft,
+	"right":     tcell.KeyRight,
+	"f1":        tcell.KeyF1,
+	"f2":        tcell.KeyF2,
+	"f3":        tcell.KeyF3,
+	"f4":        tcell.KeyF4,
+	"f5":        tcell.KeyF5,
+	"f6":        tcell.KeyF6,
+	"f7":        tcell.KeyF7,
+	"f8":        tcell.KeyF8,
+	"f9":        tcell.KeyF9,
+	"f10":       tcell.KeyF10,
+	"f11":       tcell.KeyF11,
+	"f12":       tcell.KeyF12,
+}
+
+// parseKeyName normalizes a key name from keys.yaml. Single characters are
+// used as-is; "space", named keys such as "enter" or "f5", and "ctrl-<letter>"
+// are also accepted. It returns false for anything else.
+func parseKeyName(name string) (string, bool) {
+	if len([]rune(name)) == 1 {
+		if name == " " {
+			return "space", true
+		}
+		return name, true
+	}
+
+	lower := strings.ToLower(strings.TrimSpace(name))
+	if lower == "space" {
+		return lower, true
+	}
+	if _, ok := namedKeys[lower]; ok {
+		return lower, true
+	}
+	if letter, ok := strings.CutPrefix(lower, "ctrl-"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return lower, true
+	}
+	return "", false
+}
+
+// eventKeyName returns the keys.yaml name of a key event, or "" if it has none.
+func eventKeyName(event *tcell.EventKey) string {
+	switch key := event.Key(); {
+	case key == tcell.KeyRune:
+		if event.Rune() == ' ' {
+			return "space"
+		}
+		return string(event.Rune())
+	case key >= tcell.KeyCtrlA && key <= tcell.KeyCtrlZ:
+		// Enter, Tab and Backspace share codes with Ctrl+M, Ctrl+I and Ctrl+H
+		for name, named := range namedKeys {
+			if named == key {
+				return name
+			}
+		}
+		return "ctrl-" + string(rune('a'+key-tcell.KeyCtrlA))
+	default:
+		for name, named := range namedKeys {
+			if named == key {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// keyEvent builds the event a key name produces.
+func keyEvent(name string) *tcell.EventKey {
+	switch {
+	case name == "space":
+		return tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)
+	case len([]rune(name)) == 1:
+		return tcell.NewEventKey(tcell.KeyRune, []rune(name)[0], tcell.ModNone)
+	}
+	if key, ok := namedKeys[name]; ok {
+		return tcell.NewEventKey(key, 0, tcell.ModNone)
+	}
+	if letter, ok := strings.CutPrefix(name, "ctrl-"); ok && len(letter) == 1 {
+		return tcell.NewEventKey(tcell.KeyCtrlA+tcell.Key(letter[0]-'a'), 0, tcell.ModCtrl)
+	}
+	return nil
+}
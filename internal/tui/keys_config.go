@@ -0,0 +1,515 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// KeyConfig is the user's key configuration from ~/.config/nylas/tui/keys.yaml.
+type KeyConfig struct {
+	// Bindings maps a view (or "global") to action -> key overrides.
+	Bindings map[string]map[string]string `yaml:"bindings"`
+	// Aliases maps extra command names to existing commands, e.g. inbox: messages.
+	Aliases map[string]string `yaml:"aliases"`
+	// Plugins are external programs that receive the selected resource as JSON.
+	Plugins []PluginConfig `yaml:"plugins"`
+}
+
+// PluginConfig describes an external command run against the selected resource.
+type PluginConfig struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Key         string   `yaml:"key,omitempty"`   // Optional shortcut
+	Views       []string `yaml:"views,omitempty"` // Empty means all views
+	Command     string   `yaml:"command"`
+	Args        []string `yaml:"args,omitempty"`
+	// Background captures the output and shows it in a dialog instead of
+	// suspending the TUI and handing the program the terminal.
+	Background bool `yaml:"background,omitempty"`
+}
+
+// appliesTo reports whether the plugin is available in a view.
+func (p *PluginConfig) appliesTo(view string) bool {
+	return len(p.Views) == 0 || slices.Contains(p.Views, view)
+}
+
+// Keymap is a compiled KeyConfig used at runtime.
+type Keymap struct {
+	remap   map[string]map[string]string // scope -> pressed key -> default key
+	display map[string]map[string]string // scope -> default key -> pressed key
+	aliases map[string]string
+	plugins []PluginConfig
+}
+
+// GetKeysPath returns the path of the key configuration file.
+func GetKeysPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", "nylas", "tui", "keys.yaml")
+}
+
+// LoadKeyConfig reads a key configuration file. A missing file is not an
+// error and yields an empty configuration.
+func LoadKeyConfig(path string) (*KeyConfig, error) {
+	// #nosec G304 -- path is the user's own key configuration file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &KeyConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var cfg KeyConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// LoadKeymap loads and compiles the key configuration at path. Invalid
+// entries are skipped; the returned error describes the first problem so it
+// can be shown to the user.
+func LoadKeymap(path string) (*Keymap, error) {
+	cfg, err := LoadKeyConfig(path)
+	if err != nil {
+		return NewKeymap(nil), err
+	}
+
+	keymap := NewKeymap(cfg)
+	if result := ValidateKeyConfig(cfg); len(result.Errors) > 0 {
+		return keymap, fmt.Errorf("%s: %s (run: nylas tui keys validate)", path, result.Errors[0])
+	}
+	return keymap, nil
+}
+
+// NewKeymap compiles a key configuration. Entries that fail validation are ignored.
+func NewKeymap(cfg *KeyConfig) *Keymap {
+	k := &Keymap{
+		remap:   make(map[string]map[string]string),
+		display: make(map[string]map[string]string),
+		aliases: make(map[string]string),
+	}
+	if cfg == nil {
+		return k
+	}
+
+	for scope, bindings := range cfg.Bindings {
+		actions, ok := keyScopeActions(scope)
+		if !ok {
+			continue
+		}
+		for action, key := range bindings {
+			defaultKey, ok := actions[action]
+			name, valid := parseKeyName(key)
+			if !ok || !valid || name == defaultKey {
+				continue
+			}
+			if k.remap[scope] == nil {
+				k.remap[scope] = make(map[string]string)
+				k.display[scope] = make(map[string]string)
+			}
+			k.remap[scope][name] = defaultKey
+			k.display[scope][defaultKey] = name
+		}
+	}
+
+	for alias, target := range cfg.Aliases {
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		if alias != "" && strings.TrimSpace(target) != "" {
+			k.aliases[alias] = strings.TrimSpace(target)
+		}
+	}
+
+	for _, plugin := range cfg.Plugins {
+		if plugin.Name == "" || plugin.Command == "" {
+			continue
+		}
+		if plugin.Key != "" {
+			name, ok := parseKeyName(plugin.Key)
+			if !ok {
+				continue
+			}
+			plugin.Key = name
+		}
+		k.plugins = append(k.plugins, plugin)
+	}
+
+	return k
+}
+
+// Translate rewrites a key event according to the user's bindings for a view.
+// View bindings take precedence over global ones. Unbound keys are returned unchanged.
+func (k *Keymap) Translate(view string, event *tcell.EventKey) *tcell.EventKey {
+	if k == nil {
+		return event
+	}
+	name := eventKeyName(event)
+	if name == "" {
+		return event
+	}
+	for _, scope := range keyScopesFor(view) {
+		if target, ok := k.remap[scope][name]; ok {
+			if translated := keyEvent(target); translated != nil {
+				return translated
+			}
+		}
+	}
+	return event
+}
+
+// Hints returns hints with default keys replaced by the user's bindings.
+func (k *Keymap) Hints(view string, hints []Hint) []Hint {
+	if k == nil || len(k.display) == 0 {
+		return hints
+	}
+	out := make([]Hint, len(hints))
+	for i, hint := range hints {
+		out[i] = hint
+		for _, scope := range keyScopesFor(view) {
+			if key, ok := k.display[scope][hint.Key]; ok {
+				out[i].Key = key
+				break
+			}
+		}
+	}
+	return out
+}
+
+// keyScopesFor returns the binding sections that apply to a view, most specific first.
+func keyScopesFor(view string) []string {
+	scopes := []string{view}
+	if target, ok := keyScopeAliases[view]; ok {
+		scopes = append(scopes, target)
+	}
+	return append(scopes, globalKeyScope)
+}
+
+// Alias resolves a user-defined command alias.
+func (k *Keymap) Alias(cmd string) (string, bool) {
+	if k == nil {
+		return "", false
+	}
+	target, ok := k.aliases[strings.ToLower(cmd)]
+	return target, ok
+}
+
+// Aliases returns the user-defined aliases sorted by name.
+func (k *Keymap) Aliases() []string {
+	if k == nil {
+		return nil
+	}
+	names := make([]string, 0, len(k.aliases))
+	for name := range k.aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Plugins returns the configured plugins.
+func (k *Keymap) Plugins() []PluginConfig {
+	if k == nil {
+		return nil
+	}
+	return k.plugins
+}
+
+// Plugin returns the plugin with the given name.
+func (k *Keymap) Plugin(name string) *PluginConfig {
+	if k == nil {
+		return nil
+	}
+	for i := range k.plugins {
+		if k.plugins[i].Name == name {
+			return &k.plugins[i]
+		}
+	}
+	return nil
+}
+
+// PluginForKey returns the plugin bound to a key event in a view.
+func (k *Keymap) PluginForKey(view string, event *tcell.EventKey) *PluginConfig {
+	if k == nil || len(k.plugins) == 0 {
+		return nil
+	}
+	name := eventKeyName(event)
+	if name == "" {
+		return nil
+	}
+	for i := range k.plugins {
+		if k.plugins[i].Key == name && k.plugins[i].appliesTo(view) {
+			return &k.plugins[i]
+		}
+	}
+	return nil
+}
+
+// KeyValidationResult holds the result of key configuration validation.
+type KeyValidationResult struct {
+	FilePath string
+	Exists   bool
+	Valid    bool
+	Bindings []string
+	Aliases  []string
+	Plugins  []string
+	Warnings []string
+	Errors   []string
+}
+
+// ValidateKeys validates the key configuration file at path.
+func ValidateKeys(path string) (*KeyValidationResult, error) {
+	cfg, err := LoadKeyConfig(path)
+	if err != nil {
+		return &KeyValidationResult{FilePath: path, Exists: true, Errors: []string{err.Error()}}, nil
+	}
+
+	result := ValidateKeyConfig(cfg)
+	result.FilePath = path
+	if _, statErr := os.Stat(path); statErr == nil {
+		result.Exists = true
+	}
+	return result, nil
+}
+
+// ValidateKeyConfig checks a key configuration for unknown views and actions,
+// unparseable keys and conflicting bindings, aliases and plugins.
+func ValidateKeyConfig(cfg *KeyConfig) *KeyValidationResult {
+	result := &KeyValidationResult{}
+	registry := NewCommandRegistry()
+
+	// actionsOnKey returns the actions a key triggers in a scope once the
+	// user's bindings are applied
+	actionsOnKey := func(scope, key string) []string {
+		actions, _ := keyScopeActions(scope)
+		var matched []string
+		for _, action := range sortedKeys(actions) {
+			effective := actions[action]
+			if custom, ok := cfg.Bindings[scope][action]; ok {
+				if name, valid := parseKeyName(custom); valid {
+					effective = name
+				}
+			}
+			if effective == key {
+				matched = append(matched, action)
+			}
+		}
+		return matched
+	}
+
+	for _, scope := range sortedKeys(cfg.Bindings) {
+		actions, ok := keyScopeActions(scope)
+		if !ok {
+			result.Errors = append(result.Errors, fmt.Sprintf("unknown view %q (valid: %s)", scope, strings.Join(KeyScopes(), ", ")))
+			continue
+		}
+
+		bound := make(map[string]string) // key -> action within this scope
+		for _, action := range sortedKeys(cfg.Bindings[scope]) {
+			key := cfg.Bindings[scope][action]
+			defaultKey, ok := actions[action]
+			if !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: unknown action %q (valid: %s)", scope, action, strings.Join(sortedKeys(actions), ", ")))
+				continue
+			}
+			name, valid := parseKeyName(key)
+			if !valid {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s.%s: invalid key %q (use a single character, space, enter, tab, f1-f12 or ctrl-<letter>)", scope, action, key))
+				continue
+			}
+			if other, dup := bound[name]; dup {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: key %q is bound to both %s and %s", scope, name, other, action))
+				continue
+			}
+			bound[name] = action
+
+			// A view key that a global action also uses hides the global action
+			if scope != globalKeyScope {
+				if globalActions := actionsOnKey(globalKeyScope, name); len(globalActions) > 0 {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s.%s: key %q conflicts with global action %s", scope, action, name, globalActions[0]))
+					continue
+				}
+			}
+
+			// Taking another action's default key makes that action unreachable
+			if shadowed := slices.DeleteFunc(actionsOnKey(scope, name), func(a string) bool { return a == action }); len(shadowed) > 0 {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s.%s: key %q is already used by %s", scope, action, name, shadowed[0]))
+				continue
+			}
+
+			result.Bindings = append(result.Bindings, fmt.Sprintf("%s.%s: %s -> %s", scope, action, defaultKey, name))
+		}
+	}
+
+	for _, alias := range sortedKeys(cfg.Aliases) {
+		target := strings.TrimSpace(cfg.Aliases[alias])
+		switch {
+		case target == "":
+			result.Errors = append(result.Errors, fmt.Sprintf("alias %q has no target command", alias))
+		case registry.Get(alias) != nil:
+			result.Errors = append(result.Errors, fmt.Sprintf("alias %q conflicts with built-in command :%s", alias, registry.Get(alias).Name))
+		default:
+			base := strings.Fields(target)[0]
+			if registry.Get(target) == nil && registry.Get(base) == nil && !hasPlugin(cfg.Plugins, target) {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("alias %q points to unknown command %q", alias, target))
+			}
+			result.Aliases = append(result.Aliases, fmt.Sprintf(":%s -> :%s", alias, target))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, plugin := range cfg.Plugins {
+		label := plugin.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i+1)
+		}
+		switch {
+		case plugin.Name == "":
+			result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: name is required", label))
+			continue
+		case plugin.Command == "":
+			result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: command is required", label))
+			continue
+		case seen[plugin.Name]:
+			result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: duplicate name", label))
+			continue
+		case registry.Get(plugin.Name) != nil:
+			result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: name conflicts with built-in command :%s", label, registry.Get(plugin.Name).Name))
+			continue
+		case cfg.Aliases[plugin.Name] != "":
+			result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: name conflicts with alias :%s", label, plugin.Name))
+			continue
+		}
+		seen[plugin.Name] = true
+
+		if _, err := lookPath(plugin.Command); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("plugin %s: command %q not found in PATH", label, plugin.Command))
+		}
+
+		views := plugin.Views
+		for _, view := range views {
+			if _, ok := keyScopeActions(view); !ok && !isPluginOnlyView(view) {
+				result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: unknown view %q", label, view))
+			}
+		}
+
+		if plugin.Key != "" {
+			name, ok := parseKeyName(plugin.Key)
+			if !ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: invalid key %q", label, plugin.Key))
+				continue
+			}
+			if len(views) == 0 {
+				views = KeyScopes()
+			}
+			conflict := ""
+			if actions := actionsOnKey(globalKeyScope, name); len(actions) > 0 {
+				conflict = "global action " + actions[0]
+			}
+			for _, view := range views {
+				if actions := actionsOnKey(view, name); len(actions) > 0 && conflict == "" {
+					conflict = view + " action " + actions[0]
+				}
+			}
+			for _, other := range cfg.Plugins[:i] {
+				if otherKey, _ := parseKeyName(other.Key); otherKey == name && conflict == "" {
+					conflict = "plugin " + other.Name
+				}
+			}
+			if conflict != "" {
+				result.Errors = append(result.Errors, fmt.Sprintf("plugin %s: key %q conflicts with %s", label, name, conflict))
+				continue
+			}
+		}
+
+		desc := fmt.Sprintf(":%s -> %s", plugin.Name, strings.Join(append([]string{plugin.Command}, plugin.Args...), " "))
+		if plugin.Key != "" {
+			desc += fmt.Sprintf(" (key %s)", plugin.Key)
+		}
+		result.Plugins = append(result.Plugins, desc)
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+// isPluginOnlyView reports whether a view has no remappable actions but can
+// still host plugins.
+func isPluginOnlyView(view string) bool {
+	switch view {
+	case "dashboard", "grants", "inbound", "availability", "webhook-server":
+		return true
+	}
+	return false
+}
+
+func hasPlugin(plugins []PluginConfig, name string) bool {
+	for _, p := range plugins {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CreateDefaultKeysFile writes a commented starter keys.yaml.
+func CreateDefaultKeysFile(path string) error {
+	const defaultKeys = `# Nylas TUI key bindings
+# Place this file at ~/.config/nylas/tui/keys.yaml
+# Check it with: nylas tui keys validate
+
+# Remap actions per view. Keys are a single character, "space", "enter",
+# "tab", "f1"-"f12" or "ctrl-<letter>". Default keys keep working unless
+# another action takes them over.
+bindings:
+  global:
+    down: ctrl-n     # emacs-style movement
+    up: ctrl-p
+  messages:
+    archive: e
+    reply-all: a
+
+# Extra command names for the : prompt
+aliases:
+  mail: messages
+  convo: threads
+
+# External programs that receive the selected item as JSON on stdin.
+# NYLAS_GRANT_ID, NYLAS_VIEW and NYLAS_RESOURCE_ID are set in the environment.
+plugins:
+  - name: inspect
+    description: Show the selected item as JSON
+    key: ctrl-o
+    command: sh
+    args: ["-c", "jq -C . | less -R"]
+  - name: wordcount
+    description: Count words in the selected item
+    views: [messages, threads]
+    command: wc
+    args: ["-w"]
+    background: true
+`
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(defaultKeys), 0600)
+}
@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseKeyName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"s", "s", true},
+		{" ", "space", true},
+		{"Space", "space", true},
+		{"ctrl-n", "ctrl-n", true},
+		{"Ctrl-N", "ctrl-n", true},
+		{"F5", "f5", true},
+		{"enter", "enter", true},
+		{"ctrl-", "", false},
+		{"alt-x", "", false},
+		{"ss", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseKeyName(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseKeyName(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestEventKeyNameRoundTrip(t *testing.T) {
+	for _, name := range []string{"s", "space", "ctrl-n", "enter", "tab", "f5"} {
+		if got := eventKeyName(keyEvent(name)); got != name {
+			t.Errorf("eventKeyName(keyEvent(%q)) = %q", name, got)
+		}
+	}
+}
+
+func TestKeymap_Translate(t *testing.T) {
+	keymap := NewKeymap(&KeyConfig{
+		Bindings: map[string]map[string]string{
+			"global":   {"down": "ctrl-n"},
+			"messages": {"archive": "e", "star": "S"},
+		},
+	})
+
+	tests := []struct {
+		name string
+		view string
+		in   *tcell.EventKey
+		want string
+	}{
+		{"view binding", "messages", keyEvent("e"), "a"},
+		{"threads share messages bindings", "threads", keyEvent("S"), "s"},
+		{"global binding", "contacts", keyEvent("ctrl-n"), "j"},
+		{"view binding not applied elsewhere", "contacts", keyEvent("e"), "e"},
+		{"default key still works", "messages", keyEvent("a"), "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventKeyName(keymap.Translate(tt.view, tt.in)); got != tt.want {
+				t.Errorf("Translate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeymap_Hints(t *testing.T) {
+	keymap := NewKeymap(&KeyConfig{
+		Bindings: map[string]map[string]string{"messages": {"archive": "e"}},
+	})
+
+	hints := keymap.Hints("messages", []Hint{{Key: "a", Desc: "archive"}, {Key: "s", Desc: "star"}})
+	if hints[0].Key != "e" || hints[1].Key != "s" {
+		t.Errorf("Hints() = %+v, want archive on e and star unchanged", hints)
+	}
+}
+
+func TestKeymap_AliasesAndPlugins(t *testing.T) {
+	keymap := NewKeymap(&KeyConfig{
+		Aliases: map[string]string{"Mail": "messages"},
+		Plugins: []PluginConfig{
+			{Name: "inspect", Key: "ctrl-o", Command: "cat", Views: []string{"messages"}},
+			{Name: "broken", Key: "ctrl-p"}, // No command
+		},
+	})
+
+	if target, ok := keymap.Alias("mail"); !ok || target != "messages" {
+		t.Errorf("Alias(mail) = %q, %v; want messages", target, ok)
+	}
+	if keymap.Plugin("broken") != nil {
+		t.Error("plugins without a command should be skipped")
+	}
+	if p := keymap.PluginForKey("messages", keyEvent("ctrl-o")); p == nil || p.Name != "inspect" {
+		t.Errorf("PluginForKey(messages) = %v, want inspect", p)
+	}
+	if p := keymap.PluginForKey("events", keyEvent("ctrl-o")); p != nil {
+		t.Error("plugin should only apply to its views")
+	}
+}
+
+func TestValidateKeyConfig(t *testing.T) {
+	lookPath = func(file string) (string, error) { return "/bin/" + file, nil }
+	t.Cleanup(func() { lookPath = defaultLookPath })
+
+	tests := []struct {
+		name    string
+		cfg     KeyConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg: KeyConfig{
+				Bindings: map[string]map[string]string{"messages": {"archive": "e", "reply-all": "a"}},
+				Aliases:  map[string]string{"mail": "messages"},
+				Plugins:  []PluginConfig{{Name: "inspect", Key: "ctrl-o", Command: "jq"}},
+			},
+		},
+		{
+			name:    "unknown view",
+			cfg:     KeyConfig{Bindings: map[string]map[string]string{"nope": {"x": "y"}}},
+			wantErr: `unknown view "nope"`,
+		},
+		{
+			name:    "unknown action",
+			cfg:     KeyConfig{Bindings: map[string]map[string]string{"messages": {"fly": "y"}}},
+			wantErr: `unknown action "fly"`,
+		},
+		{
+			name:    "two actions on one key",
+			cfg:     KeyConfig{Bindings: map[string]map[string]string{"messages": {"archive": "e", "star": "e"}}},
+			wantErr: "is bound to both",
+		},
+		{
+			name:    "takes another action's key",
+			cfg:     KeyConfig{Bindings: map[string]map[string]string{"messages": {"archive": "s"}}},
+			wantErr: "already used by star",
+		},
+		{
+			name:    "conflicts with global key",
+			cfg:     KeyConfig{Bindings: map[string]map[string]string{"messages": {"archive": "r"}}},
+			wantErr: "conflicts with global action refresh",
+		},
+		{
+			name:    "alias shadows built-in",
+			cfg:     KeyConfig{Aliases: map[string]string{"m": "events"}},
+			wantErr: "conflicts with built-in command",
+		},
+		{
+			name:    "plugin key conflict",
+			cfg:     KeyConfig{Plugins: []PluginConfig{{Name: "p", Key: "s", Command: "cat", Views: []string{"messages"}}}},
+			wantErr: "conflicts with messages action star",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateKeyConfig(&tt.cfg)
+			if tt.wantErr == "" {
+				if !result.Valid {
+					t.Fatalf("ValidateKeyConfig() errors = %v, want valid", result.Errors)
+				}
+				return
+			}
+			if result.Valid {
+				t.Fatalf("ValidateKeyConfig() valid, want error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(strings.Join(result.Errors, "\n"), tt.wantErr) {
+				t.Errorf("ValidateKeyConfig() errors = %v, want %q", result.Errors, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadKeymap(t *testing.T) {
+	dir := t.TempDir()
+
+	keymap, err := LoadKeymap(filepath.Join(dir, "missing.yaml"))
+	if err != nil || keymap == nil {
+		t.Fatalf("LoadKeymap(missing) = %v, %v; want empty keymap", keymap, err)
+	}
+
+	path := filepath.Join(dir, "keys.yaml")
+	if err := CreateDefaultKeysFile(path); err != nil {
+		t.Fatalf("CreateDefaultKeysFile() error = %v", err)
+	}
+	keymap, err = LoadKeymap(path)
+	if err != nil {
+		t.Fatalf("LoadKeymap(default file) error = %v", err)
+	}
+	if keymap.Plugin("inspect") == nil {
+		t.Error("starter file should define the inspect plugin")
+	}
+
+	if err := os.WriteFile(path, []byte("bindings: [oops"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKeymap(path); err == nil {
+		t.Error("LoadKeymap() should report invalid YAML")
+	}
+}
@@ -0,0 +1,379 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
+	"github.com/rivo/tview"
+)
+
+const (
+	// notifyPollInterval is how often new mail is polled when no webhook server feeds the TUI.
+	notifyPollInterval = 30 * time.Second
+
+	// reminderRefreshInterval is how often upcoming events are re-fetched for reminders.
+	reminderRefreshInterval = 5 * time.Minute
+
+	// reminderWindow is how far ahead events are fetched for reminders.
+	reminderWindow = 24 * time.Hour
+
+	// defaultReminderMinutes applies to events that use the calendar's default reminder.
+	defaultReminderMinutes = 10
+
+	// notificationTTL is how long the latest notification stays in the bar.
+	notificationTTL = time.Minute
+
+	// maxNotifications bounds the notification history.
+	maxNotifications = 50
+)
+
+// NotificationKind says what a notification is about.
+type NotificationKind string
+
+const (
+	NotifyMail     NotificationKind = "mail"
+	NotifyReminder NotificationKind = "reminder"
+)
+
+// Notification is one entry in the notification area.
+type Notification struct {
+	Time time.Time
+	Kind NotificationKind
+	Text string
+}
+
+// Notifier watches for new mail and due event reminders and shows them in
+// a one-line bar above the content area.
+type Notifier struct {
+	app   *App
+	bar   *tview.TextView
+	items []Notification // Newest first
+	shown bool           // Whether the bar currently takes up a row
+
+	since      time.Time       // Only mail received after this is announced
+	seenMail   map[string]bool // Message IDs already announced
+	lastPoll   time.Time
+	webhooks   bool // Mail arrives by webhook, so don't poll for it
+	events     []domain.Event
+	eventsAt   time.Time
+	reminded   map[string]bool // Event ID + minutes already announced
+	polling    atomic.Bool
+	calendarID string // Calendar reminders come from, for calendarOf
+	calendarOf string // Grant calendarID belongs to
+}
+
+// NewNotifier creates a notifier for app.
+func NewNotifier(app *App) *Notifier {
+	n := &Notifier{
+		app:      app,
+		bar:      tview.NewTextView(),
+		since:    time.Now(),
+		seenMail: make(map[string]bool),
+		reminded: make(map[string]bool),
+	}
+	n.bar.SetDynamicColors(true)
+	n.bar.SetBackgroundColor(app.styles.BgColor)
+	n.bar.SetBorderPadding(0, 0, 1, 1)
+	return n
+}
+
+// Primitive returns the notification bar.
+func (n *Notifier) Primitive() tview.Primitive { return n.bar }
+
+// Items returns the notification history, newest first.
+func (n *Notifier) Items() []Notification { return n.items }
+
+// Push adds notifications and shows the newest in the bar.
+func (n *Notifier) Push(items ...Notification) {
+	for _, item := range items {
+		n.items = append([]Notification{item}, n.items...)
+	}
+	if len(n.items) > maxNotifications {
+		n.items = n.items[:maxNotifications]
+	}
+	n.render()
+}
+
+// Tick runs once a second on the UI goroutine. It fires due reminders,
+// hides stale notifications and starts background polls when needed.
+func (n *Notifier) Tick(now time.Time) {
+	if due := dueReminders(n.events, now, n.reminded); len(due) > 0 {
+		n.Push(due...)
+	}
+	if n.shown && (len(n.items) == 0 || now.Sub(n.items[0].Time) > notificationTTL) {
+		n.render()
+	}
+
+	pollMail := !n.webhooks && now.Sub(n.lastPoll) >= notifyPollInterval
+	pollEvents := now.Sub(n.eventsAt) >= reminderRefreshInterval
+	if (pollMail || pollEvents) && n.polling.CompareAndSwap(false, true) {
+		if pollMail {
+			n.lastPoll = now
+		}
+		go n.poll(pollMail, pollEvents)
+	}
+}
+
+// InvalidateEvents makes the next tick re-fetch events for reminders.
+func (n *Notifier) InvalidateEvents() {
+	n.eventsAt = time.Time{}
+}
+
+// Reset drops reminders for the previous grant after a grant switch.
+func (n *Notifier) Reset() {
+	n.events = nil
+	n.eventsAt = time.Time{}
+	n.lastPoll = time.Time{}
+}
+
+// poll fetches new mail and upcoming events off the UI goroutine.
+func (n *Notifier) poll(mail, events bool) {
+	defer n.polling.Store(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := n.app.config.Client
+	grantID := n.app.config.GrantID
+
+	var messages []domain.Message
+	if mail {
+		messages, _ = client.GetMessagesWithParams(ctx, grantID, &domain.MessageQueryParams{
+			Limit:         10,
+			In:            []string{"INBOX"},
+			ReceivedAfter: n.since.Unix(),
+		})
+	}
+
+	var upcoming []domain.Event
+	fetchedEvents := false
+	if events {
+		if n.calendarOf != grantID {
+			n.calendarID = primaryCalendarID(ctx, client, grantID)
+			n.calendarOf = grantID
+		}
+		if n.calendarID != "" {
+			now := time.Now()
+			list, err := client.GetEvents(ctx, grantID, n.calendarID, &domain.EventQueryParams{
+				Start:           now.Unix(),
+				End:             now.Add(reminderWindow).Unix(),
+				ExpandRecurring: true,
+				Limit:           100,
+			})
+			if err == nil {
+				upcoming, fetchedEvents = list, true
+			}
+		}
+	}
+
+	n.app.QueueUpdateDraw(func() {
+		if fresh := newMailNotifications(messages, n.seenMail); len(fresh) > 0 {
+			n.Push(fresh...)
+		}
+		if fetchedEvents {
+			n.events = upcoming
+			n.eventsAt = time.Now()
+		}
+	})
+}
+
+// NotifyMessage announces a message pushed by a webhook.
+func (n *Notifier) NotifyMessage(msg domain.Message) {
+	if fresh := newMailNotifications([]domain.Message{msg}, n.seenMail); len(fresh) > 0 {
+		n.Push(fresh...)
+	}
+}
+
+func (n *Notifier) render() {
+	n.bar.Clear()
+
+	visible := len(n.items) > 0 && time.Since(n.items[0].Time) <= notificationTTL
+	if visible != n.shown {
+		n.shown = visible
+		height := 0
+		if visible {
+			height = 1
+		}
+		n.app.main.ResizeItem(n.bar, height, 0)
+	}
+	if !visible {
+		return
+	}
+
+	st := n.app.styles
+	latest := n.items[0]
+	icon, color := "✉", st.Hex(st.InfoColor)
+	if latest.Kind == NotifyReminder {
+		icon, color = "⏰", st.Hex(st.WarnColor)
+	}
+
+	recent := 0
+	for _, item := range n.items[1:] {
+		if time.Since(item.Time) <= notificationTTL {
+			recent++
+		}
+	}
+	more := ""
+	if recent > 0 {
+		more = fmt.Sprintf(" [%s::d](+%d more, :notifications)[-::-]", st.Hex(st.BorderColor), recent)
+	}
+
+	_, _ = fmt.Fprintf(n.bar, "[%s::b]%s[-::-] [%s]%s[-]%s", color, icon, st.Hex(st.FgColor), tview.Escape(latest.Text), more)
+}
+
+// newMailNotifications returns notifications for messages not yet announced
+// and records them as seen. More than three new messages are summarized.
+func newMailNotifications(messages []domain.Message, seen map[string]bool) []Notification {
+	var fresh []domain.Message
+	for _, msg := range messages {
+		if msg.ID == "" || seen[msg.ID] {
+			continue
+		}
+		seen[msg.ID] = true
+		fresh = append(fresh, msg)
+	}
+
+	now := time.Now()
+	if len(fresh) > 3 {
+		return []Notification{{Time: now, Kind: NotifyMail, Text: fmt.Sprintf("%d new messages", len(fresh))}}
+	}
+
+	notifications := make([]Notification, 0, len(fresh))
+	for _, msg := range fresh {
+		from := "unknown sender"
+		if len(msg.From) > 0 {
+			from = msg.From[0].Name
+			if from == "" {
+				from = msg.From[0].Email
+			}
+		}
+		subject := msg.Subject
+		if subject == "" {
+			subject = "(no subject)"
+		}
+		notifications = append(notifications, Notification{
+			Time: now,
+			Kind: NotifyMail,
+			Text: fmt.Sprintf("New mail from %s: %s", from, subject),
+		})
+	}
+	return notifications
+}
+
+// reminderMinutes returns the minutes-before-start of an event's reminders.
+func reminderMinutes(event *domain.Event) []int {
+	if event.Reminders == nil {
+		return nil
+	}
+	if len(event.Reminders.Overrides) > 0 {
+		minutes := make([]int, 0, len(event.Reminders.Overrides))
+		for _, r := range event.Reminders.Overrides {
+			minutes = append(minutes, r.ReminderMinutes)
+		}
+		return minutes
+	}
+	if event.Reminders.UseDefault {
+		return []int{defaultReminderMinutes}
+	}
+	return nil
+}
+
+// dueReminders returns reminders that are due at now and not yet announced,
+// recording them in reminded. All-day and past events are skipped.
+func dueReminders(events []domain.Event, now time.Time, reminded map[string]bool) []Notification {
+	var due []Notification
+	for i := range events {
+		event := &events[i]
+		if event.When.IsAllDay() {
+			continue
+		}
+		start := event.When.StartDateTime()
+		if !start.After(now) {
+			continue
+		}
+		for _, minutes := range reminderMinutes(event) {
+			key := fmt.Sprintf("%s/%d", event.ID, minutes)
+			if reminded[key] || now.Before(start.Add(-time.Duration(minutes)*time.Minute)) {
+				continue
+			}
+			reminded[key] = true
+
+			title := event.Title
+			if title == "" {
+				title = "(no title)"
+			}
+			due = append(due, Notification{
+				Time: now,
+				Kind: NotifyReminder,
+				Text: fmt.Sprintf("%s starts in %s (%s)", title, formatUntil(start.Sub(now)), start.Local().Format("15:04")),
+			})
+		}
+	}
+	return due
+}
+
+// formatUntil formats the time until an event starts.
+func formatUntil(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	switch {
+	case minutes < 1:
+		return "less than a minute"
+	case minutes < 60:
+		return fmt.Sprintf("%d min", minutes)
+	default:
+		return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+	}
+}
+
+// primaryCalendarID returns the grant's primary calendar, or its first one.
+func primaryCalendarID(ctx context.Context, client ports.NylasClient, grantID string) string {
+	calendars, err := client.GetCalendars(ctx, grantID)
+	if err != nil || len(calendars) == 0 {
+		return ""
+	}
+	for _, cal := range calendars {
+		if cal.IsPrimary {
+			return cal.ID
+		}
+	}
+	return calendars[0].ID
+}
+
+// showNotifications shows the notification history.
+func (a *App) showNotifications() {
+	detail := tview.NewTextView()
+	detail.SetDynamicColors(true)
+	detail.SetBackgroundColor(a.styles.BgColor)
+	detail.SetBorder(true)
+	detail.SetBorderColor(a.styles.FocusColor)
+	detail.SetTitle(" Notifications ")
+	detail.SetTitleColor(a.styles.TitleFg)
+	detail.SetBorderPadding(1, 1, 2, 2)
+	detail.SetScrollable(true)
+
+	st := a.styles
+	muted := st.Hex(st.BorderColor)
+	value := st.Hex(st.FgColor)
+
+	items := a.notify.Items()
+	if len(items) == 0 {
+		_, _ = fmt.Fprintf(detail, "[%s]No notifications yet[-]\n", muted)
+	}
+	for _, item := range items {
+		kind := strings.ToUpper(string(item.Kind))
+		_, _ = fmt.Fprintf(detail, "[%s]%s  %-8s[-] [%s]%s[-]\n", muted, item.Time.Format("15:04:05"), kind, value, tview.Escape(item.Text))
+	}
+	_, _ = fmt.Fprintf(detail, "\n[%s::d]Press Esc to go back[-::-]", muted)
+
+	detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		return event
+	})
+
+	a.PushDetail("notifications", detail)
+}
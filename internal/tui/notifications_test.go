@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestDueReminders(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) domain.EventWhen {
+		return domain.EventWhen{StartTime: now.Add(d).Unix()}
+	}
+
+	events := []domain.Event{
+		{ID: "soon", Title: "Standup", When: at(5 * time.Minute), Reminders: &domain.Reminders{
+			Overrides: []domain.Reminder{{ReminderMinutes: 10}, {ReminderMinutes: 1}},
+		}},
+		{ID: "default", Title: "Review", When: at(8 * time.Minute), Reminders: &domain.Reminders{UseDefault: true}},
+		{ID: "later", Title: "Lunch", When: at(3 * time.Hour), Reminders: &domain.Reminders{UseDefault: true}},
+		{ID: "none", Title: "No reminders", When: at(time.Minute)},
+		{ID: "past", Title: "Started", When: at(-time.Minute), Reminders: &domain.Reminders{UseDefault: true}},
+		{ID: "allday", Title: "Holiday", When: domain.EventWhen{Date: "2026-03-02"}, Reminders: &domain.Reminders{UseDefault: true}},
+	}
+
+	reminded := map[string]bool{}
+	due := dueReminders(events, now, reminded)
+	if len(due) != 2 {
+		t.Fatalf("dueReminders() returned %d, want 2: %v", len(due), due)
+	}
+	if !strings.Contains(due[0].Text, "Standup starts in 5 min") {
+		t.Errorf("due[0].Text = %q", due[0].Text)
+	}
+	if !strings.Contains(due[1].Text, "Review") || due[1].Kind != NotifyReminder {
+		t.Errorf("due[1] = %+v", due[1])
+	}
+
+	if again := dueReminders(events, now, reminded); len(again) != 0 {
+		t.Errorf("reminders should fire once, got %v", again)
+	}
+
+	// The 1-minute override fires later.
+	if later := dueReminders(events, now.Add(4*time.Minute), reminded); len(later) != 1 {
+		t.Errorf("dueReminders() at +4m = %d, want 1", len(later))
+	}
+}
+
+func TestNewMailNotifications(t *testing.T) {
+	seen := map[string]bool{"old": true}
+	messages := []domain.Message{
+		{ID: "old", Subject: "Seen"},
+		{ID: "m1", Subject: "Hello", From: []domain.EmailParticipant{{Name: "Ann", Email: "ann@example.com"}}},
+		{ID: "m2", From: []domain.EmailParticipant{{Email: "bob@example.com"}}},
+	}
+
+	got := newMailNotifications(messages, seen)
+	if len(got) != 2 {
+		t.Fatalf("newMailNotifications() = %d, want 2", len(got))
+	}
+	if got[0].Text != "New mail from Ann: Hello" {
+		t.Errorf("got[0].Text = %q", got[0].Text)
+	}
+	if got[1].Text != "New mail from bob@example.com: (no subject)" {
+		t.Errorf("got[1].Text = %q", got[1].Text)
+	}
+	if len(newMailNotifications(messages, seen)) != 0 {
+		t.Error("messages should only be announced once")
+	}
+
+	many := []domain.Message{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	if got := newMailNotifications(many, seen); len(got) != 1 || got[0].Text != "4 new messages" {
+		t.Errorf("newMailNotifications(4) = %v, want one summary", got)
+	}
+}
+
+func TestNotifier_PushShowsBar(t *testing.T) {
+	app := createTestApp(t)
+
+	app.notify.Push(Notification{Time: time.Now(), Kind: NotifyMail, Text: "New mail from Ann: Hello"})
+	if !app.notify.shown {
+		t.Fatal("Push() should show the notification bar")
+	}
+	if text := app.notify.bar.GetText(true); !strings.Contains(text, "Hello") {
+		t.Errorf("bar text = %q", text)
+	}
+
+	app.notify.items[0].Time = time.Now().Add(-2 * notificationTTL)
+	app.notify.render()
+	if app.notify.shown {
+		t.Error("stale notifications should hide the bar")
+	}
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// pluginTimeout bounds background plugins, whose output is captured.
+const pluginTimeout = 2 * time.Minute
+
+// defaultLookPath finds plugin commands on PATH.
+var defaultLookPath = exec.LookPath
+
+// lookPath is defaultLookPath, replaceable in tests.
+var lookPath = defaultLookPath
+
+// selectionProvider is implemented by views that can report their selected item.
+type selectionProvider interface {
+	SelectedResource() (id string, resource any)
+}
+
+// SelectedResource returns the ID and data of the selected table row.
+func (v *BaseTableView) SelectedResource() (string, any) {
+	meta := v.table.SelectedMeta()
+	if meta == nil {
+		return "", nil
+	}
+	return meta.ID, meta.Data
+}
+
+// runPlugin runs a user plugin with the selected resource as JSON on stdin.
+func (a *App) runPlugin(plugin *PluginConfig) {
+	viewName := ""
+	var id string
+	var resource any
+	if view := a.getCurrentView(); view != nil {
+		viewName = view.Name()
+		if provider, ok := view.(selectionProvider); ok {
+			id, resource = provider.SelectedResource()
+		}
+	}
+
+	var payload []byte
+	if resource != nil {
+		data, err := json.MarshalIndent(resource, "", "  ")
+		if err != nil {
+			a.Flash(FlashError, "Plugin %s: %v", plugin.Name, err)
+			return
+		}
+		payload = append(data, '\n')
+	}
+
+	env := append(os.Environ(),
+		"NYLAS_GRANT_ID="+a.config.GrantID,
+		"NYLAS_VIEW="+viewName,
+		"NYLAS_RESOURCE_ID="+id,
+	)
+
+	if plugin.Background {
+		a.Flash(FlashInfo, "Running %s...", plugin.Name)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+			defer cancel()
+
+			// #nosec G204 -- plugins are commands the user configured in keys.yaml
+			cmd := exec.CommandContext(ctx, plugin.Command, plugin.Args...)
+			cmd.Env = env
+			cmd.Stdin = bytes.NewReader(payload)
+			out, err := cmd.CombinedOutput()
+
+			a.QueueUpdateDraw(func() {
+				output := strings.TrimSpace(string(out))
+				if err != nil {
+					a.Flash(FlashError, "Plugin %s failed: %v", plugin.Name, err)
+					if output != "" {
+						a.ShowErrorDialog(plugin.Name, output)
+					}
+					return
+				}
+				if output == "" {
+					a.Flash(FlashInfo, "Plugin %s finished", plugin.Name)
+					return
+				}
+				a.ShowInfoDialog(plugin.Name, output)
+			})
+		}()
+		return
+	}
+
+	// Foreground plugins get the terminal, like an editor or pager would
+	var runErr error
+	a.Suspend(func() {
+		// #nosec G204 -- plugins are commands the user configured in keys.yaml
+		cmd := exec.Command(plugin.Command, plugin.Args...)
+		cmd.Env = env
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if runErr = cmd.Run(); runErr != nil {
+			fmt.Fprintf(os.Stderr, "\nplugin %s: %v\nPress Enter to return...", plugin.Name, runErr)
+			_, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+		}
+	})
+	if runErr != nil {
+		a.Flash(FlashError, "Plugin %s failed: %v", plugin.Name, runErr)
+	}
+}
+
+// registerUserCommands adds keys.yaml aliases and plugins to the command palette.
+func (a *App) registerUserCommands() {
+	for _, alias := range a.keys.Aliases() {
+		if a.cmdRegistry.Get(alias) != nil {
+			continue
+		}
+		target, _ := a.keys.Alias(alias)
+		a.cmdRegistry.Register(Command{
+			Name:        alias,
+			Description: "Alias for :" + target,
+			Category:    CategoryPlugins,
+		})
+	}
+
+	for _, plugin := range a.keys.Plugins() {
+		if a.cmdRegistry.Get(plugin.Name) != nil {
+			continue
+		}
+		desc := plugin.Description
+		if desc == "" {
+			desc = "Run " + plugin.Command
+		}
+		a.cmdRegistry.Register(Command{
+			Name:        plugin.Name,
+			Description: desc,
+			Category:    CategoryPlugins,
+			Shortcut:    plugin.Key,
+		})
+	}
+}
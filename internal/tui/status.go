@@ -17,6 +17,7 @@ type StatusIndicator struct {
 	flashExpiry time.Time
 	refreshSec  int
 	isLive      bool
+	webhooks    bool // Updates are pushed by a webhook server instead of polled
 }
 
 // NewStatusIndicator creates a new status indicator.
@@ -38,13 +39,19 @@ func NewStatusIndicator(styles *Styles, config Config) *StatusIndicator {
 	return s
 }
 
-// Update refreshes the status display.
-func (s *StatusIndicator) Update() {
-	s.refreshSec--
-	if s.refreshSec <= 0 {
-		s.refreshSec = int(s.config.RefreshInterval.Seconds())
+// Update refreshes the status display. It returns true when the refresh
+// countdown wraps, i.e. when a live refresh is due.
+func (s *StatusIndicator) Update() bool {
+	due := false
+	if s.config.RefreshInterval > 0 {
+		s.refreshSec--
+		if s.refreshSec <= 0 {
+			s.refreshSec = int(s.config.RefreshInterval.Seconds())
+			due = true
+		}
 	}
 	s.render()
+	return due
 }
 
 // Flash shows a temporary message.
@@ -61,6 +68,17 @@ func (s *StatusIndicator) ToggleLive() {
 	s.render()
 }
 
+// IsLive reports whether live updates are on.
+func (s *StatusIndicator) IsLive() bool {
+	return s.isLive
+}
+
+// SetWebhooks shows that updates arrive from a webhook server.
+func (s *StatusIndicator) SetWebhooks(enabled bool) {
+	s.webhooks = enabled
+	s.render()
+}
+
 // UpdateGrant updates the displayed grant info.
 func (s *StatusIndicator) UpdateGrant(email, provider, grantID string) {
 	s.config.Email = email
@@ -103,6 +121,9 @@ func (s *StatusIndicator) render() {
 
 	// Refresh countdown
 	refreshStr := fmt.Sprintf("<%ds>", s.refreshSec)
+	if s.webhooks {
+		refreshStr = "<webhooks>"
+	}
 
 	// Live indicator - k9s style
 	var liveStr string
@@ -117,6 +138,11 @@ func (s *StatusIndicator) render() {
 	provider := s.config.Provider
 	grantID := s.config.GrantID
 
+	// Non-default profile, so staging and production sessions look different
+	if s.config.Profile != "" && s.config.Profile != "default" {
+		_, _ = fmt.Fprintf(s, "[%s::b]%s[-::-] [%s::d]│[-::-] ", highlight, s.config.Profile, muted)
+	}
+
 	// k9s style: info | section | section   time <refresh> live
 	_, _ = fmt.Fprintf(s, "[%s]%s[-] [%s::d]│[-::-] [%s]%s[-] [%s::d]│[-::-] [%s::d]%s[-::-]   [%s]%s[-] [%s]%s[-] %s",
 		info, email,
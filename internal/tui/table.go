@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -19,6 +21,10 @@ type Table struct {
 	columns       []Column
 	data          [][]string
 	rowMeta       []RowMeta
+	marked        map[string]bool      // Marked row IDs, kept across reloads
+	markAnchor    int                  // Last toggled row index, start of range marks
+	seen          map[string]bool      // Row IDs shown so far, to spot new rows
+	fresh         map[string]time.Time // New row IDs and when their highlight ends
 	onSelect      func(*RowMeta)
 	onDoubleClick func(*RowMeta)
 }
@@ -35,8 +41,12 @@ type RowMeta struct {
 // NewTable creates a new table component.
 func NewTable(styles *Styles) *Table {
 	t := &Table{
-		Table:  tview.NewTable(),
-		styles: styles,
+		Table:      tview.NewTable(),
+		styles:     styles,
+		marked:     make(map[string]bool),
+		markAnchor: -1,
+		seen:       make(map[string]bool),
+		fresh:      make(map[string]time.Time),
 	}
 
 	t.SetBackgroundColor(styles.BgColor)
@@ -105,11 +115,22 @@ func (t *Table) SetColumns(cols []Column) {
 	t.renderHeader()
 }
 
-// SetData sets the table data.
+// SetData sets the table data. Marks on rows that are no longer present are
+// dropped, the cursor stays on the same row if it's still there, and rows that
+// weren't there before are highlighted for a while.
 func (t *Table) SetData(data [][]string, meta []RowMeta) {
+	selectedID := ""
+	if selected := t.SelectedMeta(); selected != nil {
+		selectedID = selected.ID
+	}
+	prev := t.rowMeta
+
 	t.data = data
 	t.rowMeta = meta
+	t.pruneMarks()
+	t.trackFresh(prev)
 	t.render()
+	t.restoreSelection(selectedID)
 }
 
 // SelectedMeta returns the metadata for the selected row.
@@ -180,6 +201,17 @@ func (t *Table) render() {
 				tableCell = t.renderStatusCell(meta)
 			}
 
+			if t.IsFresh(meta.ID) && colIdx > 0 {
+				tableCell.SetTextColor(t.styles.HighlightColor)
+			}
+
+			if t.marked[meta.ID] {
+				tableCell.SetTextColor(t.styles.TableMarkColor)
+				if colIdx == 0 && t.columns[0].Width <= 4 {
+					tableCell.SetText("✓")
+				}
+			}
+
 			if t.columns[colIdx].Width > 0 {
 				tableCell.SetMaxWidth(t.columns[colIdx].Width)
 			}
@@ -0,0 +1,80 @@
+package tui
+
+import "time"
+
+// freshRowDuration is how long rows that appear on a refresh stay highlighted.
+const freshRowDuration = 30 * time.Second
+
+// maxSeenRows bounds the IDs a table remembers for spotting new rows.
+const maxSeenRows = 5000
+
+// trackFresh records which rows are new since the previous data. Rows only
+// count as new when the old and new data overlap, so switching folders or
+// calendars doesn't light up the whole table.
+func (t *Table) trackFresh(prev []RowMeta) {
+	now := time.Now()
+	for id, until := range t.fresh {
+		if now.After(until) {
+			delete(t.fresh, id)
+		}
+	}
+
+	overlap := false
+	if len(prev) > 0 {
+		prevIDs := make(map[string]bool, len(prev))
+		for _, meta := range prev {
+			prevIDs[meta.ID] = true
+		}
+		for _, meta := range t.rowMeta {
+			if prevIDs[meta.ID] {
+				overlap = true
+				break
+			}
+		}
+	}
+
+	if len(t.seen) > maxSeenRows {
+		t.seen = make(map[string]bool, len(t.rowMeta))
+		overlap = false
+	}
+	for _, meta := range t.rowMeta {
+		if meta.ID == "" {
+			continue
+		}
+		if overlap && !t.seen[meta.ID] {
+			t.fresh[meta.ID] = now.Add(freshRowDuration)
+		}
+		t.seen[meta.ID] = true
+	}
+}
+
+// IsFresh reports whether a row appeared on a recent refresh.
+func (t *Table) IsFresh(id string) bool {
+	until, ok := t.fresh[id]
+	return ok && time.Now().Before(until)
+}
+
+// FreshCount returns the number of highlighted new rows.
+func (t *Table) FreshCount() int {
+	count := 0
+	for _, meta := range t.rowMeta {
+		if t.IsFresh(meta.ID) {
+			count++
+		}
+	}
+	return count
+}
+
+// restoreSelection moves the cursor back to the row with id, if it's still
+// there, so refreshes don't jump to the top.
+func (t *Table) restoreSelection(id string) {
+	if id == "" {
+		return
+	}
+	for i, meta := range t.rowMeta {
+		if meta.ID == id {
+			t.Select(i+1, 0)
+			return
+		}
+	}
+}
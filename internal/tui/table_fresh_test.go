@@ -0,0 +1,50 @@
+package tui
+
+import "testing"
+
+func setRows(table *Table, ids ...string) {
+	data := make([][]string, len(ids))
+	meta := make([]RowMeta, len(ids))
+	for i, id := range ids {
+		data[i] = []string{id}
+		meta[i] = RowMeta{ID: id}
+	}
+	table.SetData(data, meta)
+}
+
+func TestTable_FreshRows(t *testing.T) {
+	table := newMarkTable("a", "b")
+	if got := table.FreshCount(); got != 0 {
+		t.Fatalf("FreshCount() after first load = %d, want 0", got)
+	}
+
+	setRows(table, "c", "a", "b")
+	if !table.IsFresh("c") {
+		t.Error("IsFresh(c) = false, want true for a row added on refresh")
+	}
+	if table.IsFresh("a") {
+		t.Error("IsFresh(a) = true, want false for a row already shown")
+	}
+
+	// A disjoint list (another folder) shouldn't light up.
+	setRows(table, "x", "y")
+	if table.IsFresh("x") || table.IsFresh("y") {
+		t.Error("rows from an unrelated list should not be fresh")
+	}
+}
+
+func TestTable_RefreshKeepsSelectionAndMarks(t *testing.T) {
+	table := newMarkTable("a", "b", "c")
+	table.Select(2, 0) // "b"
+	table.ToggleMark()
+	table.Select(2, 0)
+
+	setRows(table, "new", "a", "b", "c")
+
+	if meta := table.SelectedMeta(); meta == nil || meta.ID != "b" {
+		t.Errorf("selection after refresh = %v, want b", meta)
+	}
+	if !table.IsMarked("b") {
+		t.Error("marks should survive a refresh")
+	}
+}
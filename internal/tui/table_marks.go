@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// ToggleMark toggles the mark on the selected row and moves to the next row.
+func (t *Table) ToggleMark() {
+	idx := t.GetSelectedRow()
+	if idx < 0 || idx >= len(t.rowMeta) {
+		return
+	}
+
+	id := t.rowMeta[idx].ID
+	if t.marked[id] {
+		delete(t.marked, id)
+	} else {
+		t.marked[id] = true
+	}
+	t.markAnchor = idx
+
+	t.rerender()
+	if idx+1 < len(t.rowMeta) {
+		t.Select(idx+2, 0) // +1 for header, +1 for next row
+	}
+}
+
+// MarkRange marks every row between the last toggled row and the selected row.
+func (t *Table) MarkRange() {
+	idx := t.GetSelectedRow()
+	if idx < 0 || idx >= len(t.rowMeta) {
+		return
+	}
+
+	from := t.markAnchor
+	if from < 0 || from >= len(t.rowMeta) {
+		from = idx
+	}
+	if from > idx {
+		from, idx = idx, from
+	}
+	for i := from; i <= idx; i++ {
+		t.marked[t.rowMeta[i].ID] = true
+	}
+	t.rerender()
+}
+
+// MarkAll marks every visible row, i.e. all rows matching the current filter.
+func (t *Table) MarkAll() {
+	for _, meta := range t.rowMeta {
+		t.marked[meta.ID] = true
+	}
+	t.rerender()
+}
+
+// ClearMarks removes all marks.
+func (t *Table) ClearMarks() {
+	if len(t.marked) == 0 {
+		return
+	}
+	t.marked = make(map[string]bool)
+	t.markAnchor = -1
+	t.rerender()
+}
+
+// IsMarked reports whether the row with id is marked.
+func (t *Table) IsMarked(id string) bool {
+	return t.marked[id]
+}
+
+// MarkedCount returns the number of marked rows.
+func (t *Table) MarkedCount() int {
+	return len(t.marked)
+}
+
+// MarkedMeta returns the metadata of marked rows in display order.
+func (t *Table) MarkedMeta() []RowMeta {
+	var marked []RowMeta
+	for _, meta := range t.rowMeta {
+		if t.marked[meta.ID] {
+			marked = append(marked, meta)
+		}
+	}
+	return marked
+}
+
+// TargetMeta returns the rows a bulk action applies to: the marked rows,
+// or the selected row when nothing is marked.
+func (t *Table) TargetMeta() []RowMeta {
+	if marked := t.MarkedMeta(); len(marked) > 0 {
+		return marked
+	}
+	if meta := t.SelectedMeta(); meta != nil {
+		return []RowMeta{*meta}
+	}
+	return nil
+}
+
+// HandleMarkKey handles the marking keys shared by table views:
+// space toggles, V marks a range and * marks all rows matching the filter.
+// It returns true if the key was consumed.
+func (t *Table) HandleMarkKey(event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyRune {
+		return false
+	}
+	switch event.Rune() {
+	case ' ':
+		t.ToggleMark()
+	case 'V':
+		t.MarkRange()
+	case '*':
+		t.MarkAll()
+	default:
+		return false
+	}
+	return true
+}
+
+// pruneMarks drops marks for rows that are no longer loaded.
+func (t *Table) pruneMarks() {
+	if len(t.marked) == 0 {
+		return
+	}
+	present := make(map[string]bool, len(t.rowMeta))
+	for _, meta := range t.rowMeta {
+		present[meta.ID] = true
+	}
+	for id := range t.marked {
+		if !present[id] {
+			delete(t.marked, id)
+		}
+	}
+	if t.markAnchor >= len(t.rowMeta) {
+		t.markAnchor = -1
+	}
+}
+
+// rerender redraws rows while keeping the current selection.
+func (t *Table) rerender() {
+	row, col := t.GetSelection()
+	t.render()
+	if row > 0 && row <= len(t.data) {
+		t.Select(row, col)
+	}
+}
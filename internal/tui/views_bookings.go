@@ -0,0 +1,447 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/rivo/tview"
+)
+
+// rescheduleLayout is the date format of the reschedule form.
+const rescheduleLayout = "2006-01-02 15:04"
+
+// BookingsView displays scheduler configurations and, once one is opened,
+// its bookings.
+type BookingsView struct {
+	*BaseTableView
+	configs  []domain.SchedulerConfiguration
+	bookings []domain.Booking
+	config   *domain.SchedulerConfiguration // Open configuration, nil when listing configurations
+}
+
+// NewBookingsView creates a new bookings view.
+func NewBookingsView(app *App) *BookingsView {
+	v := &BookingsView{
+		BaseTableView: newBaseTableView(app, "bookings", "Bookings"),
+	}
+	v.showConfigs()
+	return v
+}
+
+// Hints returns the hints for the current level.
+func (v *BookingsView) Hints() []Hint {
+	if v.config == nil {
+		return []Hint{
+			{Key: "enter", Desc: "bookings"},
+			{Key: "/", Desc: "filter"},
+			{Key: "r", Desc: "refresh"},
+		}
+	}
+	return []Hint{
+		{Key: "enter", Desc: "view"},
+		{Key: "c", Desc: "confirm"},
+		{Key: "R", Desc: "reschedule"},
+		{Key: "space", Desc: "mark"},
+		{Key: "dd", Desc: "cancel"},
+		{Key: "esc", Desc: "configurations"},
+	}
+}
+
+// showConfigs switches the table to the configuration list.
+func (v *BookingsView) showConfigs() {
+	v.config = nil
+	v.bookings = nil
+	v.table.SetTitle("")
+	v.table.SetColumns([]Column{
+		{Title: "", Width: 3},
+		{Title: "NAME", Expand: true},
+		{Title: "SLUG", Width: 24},
+		{Title: "PARTICIPANTS", Width: 40},
+	})
+}
+
+// showBookings switches the table to the bookings of config.
+func (v *BookingsView) showBookings(config *domain.SchedulerConfiguration) {
+	v.config = config
+	v.table.ClearMarks()
+	v.table.SetTitle(fmt.Sprintf(" %s ", config.Name))
+	v.table.SetTitleColor(v.app.styles.TitleFg)
+	v.table.SetColumns([]Column{
+		{Title: "", Width: 3},
+		{Title: "STATUS", Width: 10},
+		{Title: "TITLE", Expand: true},
+		{Title: "START", Width: 20},
+		{Title: "DURATION", Width: 9},
+		{Title: "ORGANIZER", Width: 30},
+	})
+}
+
+// updateChrome refreshes the breadcrumbs and hints after changing level.
+func (v *BookingsView) updateChrome() {
+	path := v.Title()
+	if v.config != nil {
+		path += " > " + v.config.Name
+	}
+	v.app.crumbs.SetPath(path)
+	v.app.menu.SetHints(v.app.keys.Hints(v.Name(), v.Hints()))
+}
+
+func (v *BookingsView) Load() { loadNow(v.loader()) }
+
+func (v *BookingsView) loader() loader {
+	client, config := v.app.config.Client, v.config
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if config != nil {
+			bookings, err := client.ListBookings(ctx, config.ID)
+			return func() {
+				if err != nil {
+					v.app.Flash(FlashError, "Failed to load bookings: %v", err)
+					return
+				}
+				// The user may have gone back to the configurations meanwhile.
+				if v.config == config {
+					v.bookings = bookings
+					v.render()
+				}
+			}
+		}
+
+		configs, err := client.ListSchedulerConfigurations(ctx)
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load scheduler configurations: %v", err)
+				return
+			}
+			v.configs = configs
+			v.render()
+		}
+	}
+}
+
+func (v *BookingsView) Refresh() { v.Load() }
+
+func (v *BookingsView) render() {
+	var data [][]string
+	var meta []RowMeta
+	filter := strings.ToLower(v.filter)
+
+	if v.config == nil {
+		for _, cfg := range v.configs {
+			if filter != "" && !strings.Contains(strings.ToLower(cfg.Name+" "+cfg.Slug), filter) {
+				continue
+			}
+			emails := make([]string, 0, len(cfg.Participants))
+			for _, p := range cfg.Participants {
+				emails = append(emails, p.Email)
+			}
+			data = append(data, []string{"", cfg.Name, cfg.Slug, strings.Join(emails, ", ")})
+			meta = append(meta, RowMeta{ID: cfg.ID, Data: &cfg})
+		}
+		v.table.SetData(data, meta)
+		return
+	}
+
+	for _, b := range v.bookings {
+		if filter != "" && !strings.Contains(strings.ToLower(b.Title+" "+b.Status+" "+b.Organizer.Email), filter) {
+			continue
+		}
+		start, duration := "", ""
+		if !b.StartTime.IsZero() {
+			start = b.StartTime.Local().Format(common.DisplayDateTime)
+			if b.EndTime.After(b.StartTime) {
+				duration = b.EndTime.Sub(b.StartTime).String()
+			}
+		}
+		data = append(data, []string{"", b.Status, b.Title, start, duration, b.Organizer.Email})
+		meta = append(meta, RowMeta{
+			ID:     b.BookingID,
+			Data:   &b,
+			Unread: b.Status == "pending",
+			Error:  b.Status == "cancelled",
+		})
+	}
+	v.table.SetData(data, meta)
+}
+
+// HandleKey handles keyboard input for the bookings view.
+func (v *BookingsView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
+	if v.config == nil {
+		if event.Key() == tcell.KeyEnter {
+			if cfg, ok := metaData[*domain.SchedulerConfiguration](v.table.SelectedMeta()); ok {
+				v.openConfig(cfg)
+			}
+			return nil
+		}
+		return event
+	}
+
+	booking, _ := metaData[*domain.Booking](v.table.SelectedMeta())
+
+	switch event.Key() {
+	case tcell.KeyEscape:
+		v.showConfigs()
+		v.updateChrome()
+		v.render()
+		return nil
+
+	case tcell.KeyEnter:
+		if booking != nil {
+			v.showBookingDetail(booking)
+		}
+		return nil
+
+	case tcell.KeyRune:
+		if v.table.HandleMarkKey(event) {
+			return nil
+		}
+		switch event.Rune() {
+		case 'c':
+			if booking != nil {
+				v.confirmBooking(booking)
+			}
+			return nil
+		case 'R':
+			if booking != nil {
+				v.showRescheduleForm(booking)
+			}
+			return nil
+		}
+	}
+
+	return event
+}
+
+// openConfig shows the bookings of a configuration.
+func (v *BookingsView) openConfig(cfg *domain.SchedulerConfiguration) {
+	v.showBookings(cfg)
+	v.updateChrome()
+	v.render()
+	v.reload()
+}
+
+// reload refreshes the open bookings in the background.
+func (v *BookingsView) reload() {
+	v.app.reloadInBackground(v)
+}
+
+// DeleteSelected cancels the marked or selected bookings.
+func (v *BookingsView) DeleteSelected() {
+	if v.config == nil {
+		v.app.Flash(FlashWarn, "Open a configuration to cancel its bookings")
+		return
+	}
+	items := v.table.TargetMeta()
+	if len(items) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Cancel %d bookings?", len(items))
+	if len(items) == 1 {
+		message = fmt.Sprintf("Cancel booking %q?", rowLabel(items[0]))
+	}
+	v.app.ShowConfirmDialog("Cancel Bookings", message, func() {
+		v.app.runBulk("Cancelled", items, func(ctx context.Context, meta RowMeta) error {
+			return v.app.config.Client.CancelBooking(ctx, meta.ID, "")
+		}, func(BulkResult) {
+			v.table.ClearMarks()
+			v.reload()
+		})
+	})
+}
+
+func (v *BookingsView) confirmBooking(booking *domain.Booking) {
+	if booking.Status == "confirmed" {
+		v.app.Flash(FlashInfo, "Booking is already confirmed")
+		return
+	}
+
+	v.app.ShowConfirmDialog("Confirm Booking", fmt.Sprintf("Confirm %q?", booking.Title), func() {
+		id := booking.BookingID
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			_, err := v.app.config.Client.ConfirmBooking(ctx, id, &domain.ConfirmBookingRequest{Status: "confirmed"})
+			v.app.QueueUpdateDraw(func() {
+				if err != nil {
+					v.app.Flash(FlashError, "Failed to confirm booking: %v", err)
+					return
+				}
+				v.app.Flash(FlashInfo, "Booking confirmed")
+				v.reload()
+			})
+		}()
+	})
+}
+
+func (v *BookingsView) showRescheduleForm(booking *domain.Booking) {
+	loc := bookingLocation(booking)
+	current := ""
+	if !booking.StartTime.IsZero() {
+		current = booking.StartTime.In(loc).Format(rescheduleLayout)
+	}
+
+	v.app.ShowForm("Reschedule "+booking.Title, []FormField{
+		{
+			Key:         "start",
+			Label:       "New start",
+			Type:        FieldDateTime,
+			Placeholder: "YYYY-MM-DD HH:MM " + loc.String(),
+			Value:       current,
+			Required:    true,
+			Validator: func(s string) error {
+				_, err := time.ParseInLocation(rescheduleLayout, s, loc)
+				return err
+			},
+		},
+		{Key: "reason", Label: "Reason", Type: FieldText},
+	}, func(values map[string]string) {
+		req, err := rescheduleRequest(booking, strings.TrimSpace(values["start"]), strings.TrimSpace(values["reason"]))
+		if err != nil {
+			v.app.Flash(FlashError, "Invalid start time: %v", err)
+			return
+		}
+
+		id := booking.BookingID
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			_, err := v.app.config.Client.RescheduleBooking(ctx, id, req)
+			v.app.QueueUpdateDraw(func() {
+				if err != nil {
+					v.app.Flash(FlashError, "Failed to reschedule booking: %v", err)
+					return
+				}
+				v.app.Flash(FlashInfo, "Booking rescheduled to %s", values["start"])
+				v.reload()
+			})
+		}()
+	})
+}
+
+// rescheduleRequest moves a booking to start, keeping its duration.
+func rescheduleRequest(booking *domain.Booking, start, reason string) (*domain.RescheduleBookingRequest, error) {
+	loc := bookingLocation(booking)
+	startTime, err := time.ParseInLocation(rescheduleLayout, start, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := booking.EndTime.Sub(booking.StartTime)
+	if duration <= 0 {
+		duration = 30 * time.Minute
+	}
+
+	req := &domain.RescheduleBookingRequest{
+		StartTime: startTime.Unix(),
+		EndTime:   startTime.Add(duration).Unix(),
+		Reason:    reason,
+	}
+	if booking.Timezone != "" {
+		req.Timezone = booking.Timezone
+	}
+	return req, nil
+}
+
+// bookingLocation returns the booking's time zone, or the local one.
+func bookingLocation(booking *domain.Booking) *time.Location {
+	if booking.Timezone != "" {
+		if loc, err := time.LoadLocation(booking.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+func (v *BookingsView) showBookingDetail(booking *domain.Booking) {
+	detail := tview.NewTextView()
+	detail.SetDynamicColors(true)
+	detail.SetBackgroundColor(v.app.styles.BgColor)
+	detail.SetBorder(true)
+	detail.SetBorderColor(v.app.styles.FocusColor)
+	detail.SetTitle(fmt.Sprintf(" Booking: %s ", booking.Title))
+	detail.SetTitleColor(v.app.styles.TitleFg)
+	detail.SetBorderPadding(1, 1, 2, 2)
+	detail.SetScrollable(true)
+
+	st := v.app.styles
+	info := st.Hex(st.InfoColor)
+	value := st.Hex(st.InfoSectionFg)
+	muted := st.Hex(st.BorderColor)
+	success := st.Hex(st.SuccessColor)
+	errColor := st.Hex(st.ErrorColor)
+
+	// Status
+	_, _ = fmt.Fprintf(detail, "[%s::b]Status[-::-]\n", info)
+	statusColor := value
+	switch booking.Status {
+	case "confirmed":
+		statusColor = success
+	case "cancelled":
+		statusColor = errColor
+	}
+	_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", statusColor, booking.Status)
+
+	// When
+	if !booking.StartTime.IsZero() {
+		loc := bookingLocation(booking)
+		_, _ = fmt.Fprintf(detail, "[%s::b]When[-::-]\n", info)
+		_, _ = fmt.Fprintf(detail, "[%s]%s – %s (%s)[-]\n\n", value,
+			booking.StartTime.In(loc).Format(common.DisplayDateTime),
+			booking.EndTime.In(loc).Format("15:04"),
+			loc.String())
+	}
+
+	// Organizer and participants
+	_, _ = fmt.Fprintf(detail, "[%s::b]Organizer[-::-]\n", info)
+	_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, booking.Organizer.DisplayName())
+	if len(booking.Participants) > 0 {
+		_, _ = fmt.Fprintf(detail, "[%s::b]Participants[-::-]\n", info)
+		for _, p := range booking.Participants {
+			_, _ = fmt.Fprintf(detail, "[%s]  • %s[-]\n", value, p.DisplayName())
+		}
+		_, _ = fmt.Fprintln(detail)
+	}
+
+	if booking.Location != "" {
+		_, _ = fmt.Fprintf(detail, "[%s::b]Location[-::-]\n", info)
+		_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, booking.Location)
+	}
+	if booking.Description != "" {
+		_, _ = fmt.Fprintf(detail, "[%s::b]Description[-::-]\n", info)
+		_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, tview.Escape(booking.Description))
+	}
+
+	_, _ = fmt.Fprintf(detail, "[%s]ID:[-] [%s]%s[-]\n", muted, value, booking.BookingID)
+	_, _ = fmt.Fprintf(detail, "\n\n[%s::d]Press Esc to go back, 'c' to confirm, 'R' to reschedule, 'x' to cancel[-::-]", muted)
+
+	detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+		switch event.Rune() {
+		case 'c':
+			v.app.PopDetail()
+			v.confirmBooking(booking)
+			return nil
+		case 'R':
+			v.app.PopDetail()
+			v.showRescheduleForm(booking)
+			return nil
+		case 'x':
+			v.app.PopDetail()
+			v.DeleteSelected()
+			return nil
+		}
+		return event
+	})
+
+	v.app.PushDetail("booking-detail", detail)
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestBookingsView_Levels(t *testing.T) {
+	app := createTestApp(t)
+	view := NewBookingsView(app)
+
+	view.Load()
+	if got := view.table.GetRowCount(); got != 2 {
+		t.Fatalf("configurations = %d, want 2", got)
+	}
+
+	cfg, ok := metaData[*domain.SchedulerConfiguration](view.table.SelectedMeta())
+	if !ok {
+		t.Fatal("selected row should be a configuration")
+	}
+	view.showBookings(cfg)
+	view.Load()
+	if meta := view.table.SelectedMeta(); meta == nil || meta.ID != "booking-1" {
+		t.Fatalf("selected booking = %v, want booking-1", meta)
+	}
+
+	if view.HandleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)) != nil {
+		t.Error("Escape should be handled while showing bookings")
+	}
+	if view.config != nil {
+		t.Error("Escape should return to the configuration list")
+	}
+	if view.HandleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)) == nil {
+		t.Error("Escape on the configuration list should fall through to navigation")
+	}
+}
+
+func TestRescheduleRequest(t *testing.T) {
+	start := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+	booking := &domain.Booking{
+		StartTime: start,
+		EndTime:   start.Add(45 * time.Minute),
+		Timezone:  "America/New_York",
+	}
+
+	req, err := rescheduleRequest(booking, "2025-03-12 09:30", "Conflict")
+	if err != nil {
+		t.Fatalf("rescheduleRequest() error = %v", err)
+	}
+
+	ny, _ := time.LoadLocation("America/New_York")
+	wantStart := time.Date(2025, 3, 12, 9, 30, 0, 0, ny)
+	if req.StartTime != wantStart.Unix() {
+		t.Errorf("StartTime = %v, want %v", time.Unix(req.StartTime, 0), wantStart)
+	}
+	if req.EndTime-req.StartTime != int64((45 * time.Minute).Seconds()) {
+		t.Errorf("duration = %ds, want the original 45m", req.EndTime-req.StartTime)
+	}
+	if req.Timezone != "America/New_York" || req.Reason != "Conflict" {
+		t.Errorf("Timezone, Reason = %q, %q", req.Timezone, req.Reason)
+	}
+
+	if _, err := rescheduleRequest(booking, "next tuesday", ""); err == nil {
+		t.Error("rescheduleRequest() should reject unparseable times")
+	}
+}
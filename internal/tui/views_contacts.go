@@ -26,7 +26,8 @@ func NewContactsView(app *App) *ContactsView {
 		{Key: "enter", Desc: "view"},
 		{Key: "n", Desc: "new"},
 		{Key: "e", Desc: "edit"},
-		{Key: "d", Desc: "delete"},
+		{Key: "space", Desc: "mark"},
+		{Key: "dd", Desc: "delete"},
 		{Key: "r", Desc: "refresh"},
 	}
 
@@ -40,16 +41,23 @@ func NewContactsView(app *App) *ContactsView {
 	return v
 }
 
-func (v *ContactsView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	contacts, err := v.app.config.Client.GetContacts(ctx, v.app.config.GrantID, nil)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load contacts: %v", err)
-		return
+func (v *ContactsView) Load() { loadNow(v.loader()) }
+
+func (v *ContactsView) loader() loader {
+	client, grantID := v.app.config.Client, v.app.config.GrantID
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		contacts, err := client.GetContacts(ctx, grantID, nil)
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load contacts: %v", err)
+				return
+			}
+			v.contacts = contacts
+			v.render()
+		}
 	}
-	v.contacts = contacts
-	v.render()
 }
 
 func (v *ContactsView) Refresh() { v.Load() }
@@ -84,6 +92,13 @@ func (v *ContactsView) render() {
 // HandleKey handles keyboard input for contacts view.
 func (v *ContactsView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() {
+	case tcell.KeyEscape:
+		if v.table.MarkedCount() > 0 {
+			v.table.ClearMarks()
+			return nil
+		}
+		return event
+
 	case tcell.KeyEnter:
 		// View contact detail
 		if idx, _ := v.table.GetSelection(); idx > 0 && idx-1 < len(v.contacts) {
@@ -92,6 +107,9 @@ func (v *ContactsView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 
 	case tcell.KeyRune:
+		if v.table.HandleMarkKey(event) {
+			return nil
+		}
 		switch event.Rune() {
 		case 'n': // New contact
 			v.app.ShowContactForm(nil, func(contact *domain.Contact) {
@@ -107,21 +125,35 @@ func (v *ContactsView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 				})
 			}
 			return nil
-
-		case 'd': // Delete selected contact
-			if idx, _ := v.table.GetSelection(); idx > 0 && idx-1 < len(v.contacts) {
-				contact := v.contacts[idx-1]
-				v.app.DeleteContact(&contact, func() {
-					v.Refresh()
-				})
-			}
-			return nil
 		}
 	}
 
 	return event
 }
 
+// DeleteSelected deletes the marked contacts, or the selected contact, after confirmation.
+func (v *ContactsView) DeleteSelected() {
+	items := v.table.TargetMeta()
+	if len(items) == 0 {
+		return
+	}
+	if len(items) == 1 {
+		if contact, ok := items[0].Data.(*domain.Contact); ok {
+			v.app.DeleteContact(contact, func() { v.Refresh() })
+		}
+		return
+	}
+
+	v.app.ShowConfirmDialog("Delete Contacts", fmt.Sprintf("Delete %d contacts?", len(items)), func() {
+		v.app.runBulk("Deleted", items, func(ctx context.Context, meta RowMeta) error {
+			return v.app.config.Client.DeleteContact(ctx, v.app.config.GrantID, meta.ID)
+		}, func(BulkResult) {
+			v.table.ClearMarks()
+			v.app.reloadInBackground(v)
+		})
+	})
+}
+
 func (v *ContactsView) showContactDetail(contact *domain.Contact) {
 	detail := tview.NewTextView()
 	detail.SetDynamicColors(true)
@@ -65,11 +65,15 @@ func (v *DashboardView) Load() {
 		desc string
 	}{
 		{":m", "Messages", "Email messages"},
+		{":t", "Threads", "Email conversations"},
 		{":e", "Events", "Calendar events"},
 		{":c", "Contacts", "Contacts"},
 		{":i", "Inbound", "Inbound inboxes"},
 		{":w", "Webhooks", "Webhooks"},
 		{":ws", "Server", "Webhook server (local)"},
+		{":sl", "Slack", "Slack channels and messages"},
+		{":nt", "Notetakers", "Meeting notetakers"},
+		{":bk", "Bookings", "Scheduler bookings"},
 		{":g", "Grants", "Connected accounts"},
 	}
 
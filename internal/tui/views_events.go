@@ -8,6 +8,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
 	"github.com/rivo/tview"
 )
 
@@ -21,15 +22,19 @@ type EventsView struct {
 	calendars    []domain.Calendar
 	name         string
 	title        string
-	focusedPanel int // 0 = calendar, 1 = events list
+	focusedPanel int                     // 0 = calendar, 1 = events list
+	filter       string                  // Filter text used by mark-all
+	marked       map[string]domain.Event // Marked events by ID
+	markAnchor   time.Time               // Last toggled day, start of range marks
 }
 
 // NewEventsView creates a new calendar-style events view.
 func NewEventsView(app *App) *EventsView {
 	v := &EventsView{
-		app:   app,
-		name:  "events",
-		title: "Calendar",
+		app:    app,
+		name:   "events",
+		title:  "Calendar",
+		marked: make(map[string]domain.Event),
 	}
 
 	// Create calendar view
@@ -58,7 +63,11 @@ func NewEventsView(app *App) *EventsView {
 func (v *EventsView) Name() string               { return v.name }
 func (v *EventsView) Title() string              { return v.title }
 func (v *EventsView) Primitive() tview.Primitive { return v.layout }
-func (v *EventsView) Filter(string)              {}
+
+// Filter sets the text that mark-all (*) matches against.
+func (v *EventsView) Filter(f string) {
+	v.filter = f
+}
 
 func (v *EventsView) Hints() []Hint {
 	return []Hint{
@@ -69,35 +78,55 @@ func (v *EventsView) Hints() []Hint {
 		{Key: "w", Desc: "week"},
 		{Key: "a", Desc: "agenda"},
 		{Key: "t", Desc: "today"},
+		{Key: "space", Desc: "mark day"},
+		{Key: "dd", Desc: "delete"},
 		{Key: "H/L", Desc: "±month"},
 		{Key: "r", Desc: "refresh"},
 	}
 }
 
-func (v *EventsView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func (v *EventsView) Load() { loadNow(v.loader()) }
 
-	// Get calendars first
-	calendars, err := v.app.config.Client.GetCalendars(ctx, v.app.config.GrantID)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load calendars: %v", err)
-		return
-	}
+func (v *EventsView) loader() loader {
+	client, grantID := v.app.config.Client, v.app.config.GrantID
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
-	v.calendars = calendars
-	v.calendar.SetCalendars(calendars)
+		// Get calendars first
+		calendars, err := client.GetCalendars(ctx, grantID)
+		if err != nil {
+			return func() { v.app.Flash(FlashError, "Failed to load calendars: %v", err) }
+		}
+		if len(calendars) == 0 {
+			return func() {
+				v.calendars = calendars
+				v.calendar.SetCalendars(calendars)
+				v.app.Flash(FlashWarn, "No calendars found")
+			}
+		}
 
-	if len(calendars) == 0 {
-		v.app.Flash(FlashWarn, "No calendars found")
-		return
+		// Load events for the calendar SetCalendars will select
+		showEvents := v.fetchEvents(client, grantID, defaultCalendarID(calendars))
+		return func() {
+			v.calendars = calendars
+			v.calendar.SetCalendars(calendars)
+			showEvents()
+		}
 	}
+}
 
-	// Load events for the current calendar
-	v.loadEventsForCalendar(v.calendar.GetCurrentCalendarID())
+// eventsLoader loads the events of one calendar.
+func (v *EventsView) eventsLoader(calendarID string) loader {
+	client, grantID := v.app.config.Client, v.app.config.GrantID
+	return func() func() {
+		return v.fetchEvents(client, grantID, calendarID)
+	}
 }
 
-func (v *EventsView) loadEventsForCalendar(calendarID string) {
+// fetchEvents fetches a calendar's events and returns the function that
+// shows them. Only the returned function touches the view.
+func (v *EventsView) fetchEvents(client ports.NylasClient, grantID, calendarID string) func() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -106,35 +135,54 @@ func (v *EventsView) loadEventsForCalendar(calendarID string) {
 	startTime := now.AddDate(0, -1, 0).Unix()
 	endTime := now.AddDate(0, 2, 0).Unix()
 
-	events, err := v.app.config.Client.GetEvents(ctx, v.app.config.GrantID, calendarID, &domain.EventQueryParams{
+	events, err := client.GetEvents(ctx, grantID, calendarID, &domain.EventQueryParams{
 		Start:           startTime,
 		End:             endTime,
 		ExpandRecurring: true,
 		Limit:           200,
 	})
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load events: %v", err)
-		return
-	}
+	return func() {
+		if err != nil {
+			v.app.Flash(FlashError, "Failed to load events: %v", err)
+			return
+		}
+
+		v.events = events
+		v.pruneMarks()
+		v.calendar.SetEvents(events)
+		v.updateEventsList(v.calendar.GetSelectedDate())
 
-	v.events = events
-	v.calendar.SetEvents(events)
-	v.updateEventsList(v.calendar.GetSelectedDate())
+		// Show calendar name in flash
+		if cal := v.calendar.GetCurrentCalendar(); cal != nil {
+			v.app.Flash(FlashInfo, "Calendar: %s (%d events)", cal.Name, len(events))
+		}
+	}
+}
 
-	// Show calendar name in flash
-	if cal := v.calendar.GetCurrentCalendar(); cal != nil {
-		v.app.Flash(FlashInfo, "Calendar: %s (%d events)", cal.Name, len(events))
+// defaultCalendarID returns the calendar SetCalendars selects: the primary
+// calendar, or the first one.
+func defaultCalendarID(calendars []domain.Calendar) string {
+	for _, cal := range calendars {
+		if cal.IsPrimary {
+			return cal.ID
+		}
 	}
+	return calendars[0].ID
+}
+
+// reloadEvents reloads a calendar's events in the background.
+func (v *EventsView) reloadEvents(calendarID string) {
+	load := v.eventsLoader(calendarID)
+	go func() {
+		v.app.QueueUpdateDraw(load())
+	}()
 }
 
 func (v *EventsView) Refresh() { v.Load() }
 
 func (v *EventsView) onCalendarChange(calendarID string) {
 	// Reload events for the new calendar
-	go func() {
-		v.loadEventsForCalendar(calendarID)
-		v.app.QueueUpdateDraw(func() {})
-	}()
+	v.reloadEvents(calendarID)
 }
 
 func (v *EventsView) onDateSelect(date time.Time) {
@@ -163,6 +211,12 @@ func (v *EventsView) updateEventsList(date time.Time) {
 	}
 
 	for i, evt := range events {
+		// Marked events get a check in front of the time
+		mark := ""
+		if _, ok := v.marked[evt.ID]; ok {
+			mark = fmt.Sprintf("[%s::b]✓ [-::-]", s.Hex(s.TableMarkColor))
+		}
+
 		// Time
 		timeStr := "All day"
 		if !evt.When.IsAllDay() {
@@ -172,7 +226,7 @@ func (v *EventsView) updateEventsList(date time.Time) {
 		}
 
 		// Event entry
-		_, _ = fmt.Fprintf(v.eventsList, "[%s]%s[-]\n", info, timeStr)
+		_, _ = fmt.Fprintf(v.eventsList, "%s[%s]%s[-]\n", mark, info, timeStr)
 
 		// Title with recurring indicator
 		title := evt.Title
@@ -206,6 +260,10 @@ func (v *EventsView) updateEventsList(date time.Time) {
 func (v *EventsView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() {
 	case tcell.KeyEscape:
+		if len(v.marked) > 0 {
+			v.clearMarks()
+			return nil
+		}
 		// Let the app handle Escape for navigation
 		return event
 
@@ -232,6 +290,15 @@ func (v *EventsView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 		case 'C': // Show calendar list
 			v.showCalendarList()
 			return nil
+		case ' ': // Mark all events on the selected day
+			v.toggleDayMarks()
+			return nil
+		case 'V': // Mark events from the last marked day to the selected day
+			v.markDayRange()
+			return nil
+		case '*': // Mark all loaded events matching the filter
+			v.markAllMatching()
+			return nil
 		}
 	}
 
@@ -333,9 +400,6 @@ func (v *EventsView) createNewEvent() {
 
 	v.app.ShowEventForm(calendarID, nil, func(event *domain.Event) {
 		// Refresh events after creation
-		go func() {
-			v.loadEventsForCalendar(calendarID)
-			v.app.QueueUpdateDraw(func() {})
-		}()
+		v.reloadEvents(calendarID)
 	})
 }
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// toggleDayMarks marks every event on the selected day, or unmarks them if all are marked.
+func (v *EventsView) toggleDayMarks() {
+	date := v.calendar.GetSelectedDate()
+	events := v.calendar.GetEventsForDate(date)
+	if len(events) == 0 {
+		v.app.Flash(FlashWarn, "No events on %s", date.Format("Jan 2"))
+		return
+	}
+
+	allMarked := true
+	for _, evt := range events {
+		if _, ok := v.marked[evt.ID]; !ok {
+			allMarked = false
+			break
+		}
+	}
+	for _, evt := range events {
+		if allMarked {
+			delete(v.marked, evt.ID)
+		} else {
+			v.marked[evt.ID] = evt
+		}
+	}
+	v.markAnchor = date
+	v.refreshMarks()
+}
+
+// markDayRange marks all events between the last toggled day and the selected day.
+func (v *EventsView) markDayRange() {
+	end := v.calendar.GetSelectedDate()
+	start := v.markAnchor
+	if start.IsZero() {
+		start = end
+	}
+	if start.After(end) {
+		start, end = end, start
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		for _, evt := range v.calendar.GetEventsForDate(day) {
+			v.marked[evt.ID] = evt
+		}
+	}
+	v.refreshMarks()
+}
+
+// markAllMatching marks every loaded event whose title, location or
+// description contains the current filter text.
+func (v *EventsView) markAllMatching() {
+	needle := strings.ToLower(v.filter)
+	for _, evt := range v.events {
+		if needle == "" ||
+			strings.Contains(strings.ToLower(evt.Title), needle) ||
+			strings.Contains(strings.ToLower(evt.Location), needle) ||
+			strings.Contains(strings.ToLower(evt.Description), needle) {
+			v.marked[evt.ID] = evt
+		}
+	}
+	v.refreshMarks()
+}
+
+// clearMarks removes all event marks.
+func (v *EventsView) clearMarks() {
+	v.marked = make(map[string]domain.Event)
+	v.markAnchor = time.Time{}
+	v.refreshMarks()
+}
+
+// pruneMarks drops marks for events that are no longer loaded.
+func (v *EventsView) pruneMarks() {
+	present := make(map[string]bool, len(v.events))
+	for _, evt := range v.events {
+		present[evt.ID] = true
+	}
+	for id := range v.marked {
+		if !present[id] {
+			delete(v.marked, id)
+		}
+	}
+}
+
+// refreshMarks redraws the events list and shows the mark count in its title.
+func (v *EventsView) refreshMarks() {
+	title := " Events "
+	if n := len(v.marked); n > 0 {
+		title = fmt.Sprintf(" Events [%d marked] ", n)
+	}
+	v.eventsList.SetTitle(title)
+	v.updateEventsList(v.calendar.GetSelectedDate())
+}
+
+// markedEvents returns marked events ordered by start time.
+func (v *EventsView) markedEvents() []RowMeta {
+	events := make([]domain.Event, 0, len(v.marked))
+	for _, evt := range v.marked {
+		events = append(events, evt)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].When.StartTime < events[j].When.StartTime })
+
+	items := make([]RowMeta, 0, len(events))
+	for i := range events {
+		items = append(items, RowMeta{ID: events[i].ID, Data: &events[i]})
+	}
+	return items
+}
+
+// DeleteSelected deletes the marked events, or the only event on the
+// selected day when nothing is marked.
+func (v *EventsView) DeleteSelected() {
+	calendarID := v.calendar.GetCurrentCalendarID()
+
+	if len(v.marked) == 0 {
+		events := v.calendar.GetEventsForDate(v.calendar.GetSelectedDate())
+		if len(events) != 1 {
+			v.app.Flash(FlashWarn, "Mark events with space to delete them")
+			return
+		}
+		evt := events[0]
+		v.app.DeleteEvent(calendarID, &evt, func() { v.reloadEvents(calendarID) })
+		return
+	}
+
+	items := v.markedEvents()
+	v.app.ShowConfirmDialog("Delete Events", fmt.Sprintf("Delete %d events?", len(items)), func() {
+		v.app.runBulk("Deleted", items, func(ctx context.Context, meta RowMeta) error {
+			evt := meta.Data.(*domain.Event)
+			calID := evt.CalendarID
+			if calID == "" {
+				calID = calendarID
+			}
+			return v.app.config.Client.DeleteEvent(ctx, v.app.config.GrantID, calID, evt.ID)
+		}, func(BulkResult) {
+			v.clearMarks()
+			v.reloadEvents(calendarID)
+		})
+	})
+}
@@ -42,16 +42,23 @@ func NewGrantsView(app *App) *GrantsView {
 	return v
 }
 
-func (v *GrantsView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	grants, err := v.app.config.Client.ListGrants(ctx)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load grants: %v", err)
-		return
+func (v *GrantsView) Load() { loadNow(v.loader()) }
+
+func (v *GrantsView) loader() loader {
+	client := v.app.config.Client
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		grants, err := client.ListGrants(ctx)
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load grants: %v", err)
+				return
+			}
+			v.grants = grants
+			v.render()
+		}
 	}
-	v.grants = grants
-	v.render()
 }
 
 func (v *GrantsView) Refresh() { v.Load() }
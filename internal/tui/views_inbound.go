@@ -10,6 +10,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/mqasimca/nylas/internal/cli/common"
 	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/ports"
 	"github.com/rivo/tview"
 )
 
@@ -90,30 +91,37 @@ func (v *InboundView) Hints() []Hint {
 	}
 }
 
-func (v *InboundView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func (v *InboundView) Load() { loadNow(v.loader()) }
 
-	// Load inbound inboxes
-	inboxes, err := v.app.config.Client.ListInboundInboxes(ctx)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load inboxes: %v", err)
-		return
-	}
+func (v *InboundView) loader() loader {
+	client := v.app.config.Client
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
-	v.inboxes = inboxes
-	v.renderInboxes()
+		// Load inbound inboxes
+		inboxes, err := client.ListInboundInboxes(ctx)
+		if err != nil {
+			return func() { v.app.Flash(FlashError, "Failed to load inboxes: %v", err) }
+		}
+		if len(inboxes) == 0 {
+			return func() {
+				v.inboxes = inboxes
+				v.renderInboxes()
+				v.app.Flash(FlashInfo, "No inbound inboxes found. Create one with: nylas inbound create <name>")
+			}
+		}
 
-	if len(inboxes) == 0 {
-		v.app.Flash(FlashInfo, "No inbound inboxes found. Create one with: nylas inbound create <name>")
-		return
+		// Select first inbox and load its messages
+		showMessages := v.fetchMessages(client, inboxes[0].ID)
+		return func() {
+			v.inboxes = inboxes
+			v.selectedInbox = &inboxes[0]
+			v.renderInboxes()
+			showMessages()
+			v.app.Flash(FlashInfo, "Found %d inbound inbox(es)", len(inboxes))
+		}
 	}
-
-	// Select first inbox and load its messages
-	v.selectedInbox = &inboxes[0]
-	v.loadMessages(inboxes[0].ID)
-
-	v.app.Flash(FlashInfo, "Found %d inbound inbox(es)", len(inboxes))
 }
 
 func (v *InboundView) Refresh() {
@@ -158,17 +166,25 @@ func (v *InboundView) renderInboxes() {
 }
 
 func (v *InboundView) loadMessages(inboxID string) {
+	v.fetchMessages(v.app.config.Client, inboxID)()
+}
+
+// fetchMessages fetches an inbox's messages and returns the function that
+// shows them. Only the returned function touches the view.
+func (v *InboundView) fetchMessages(client ports.NylasClient, inboxID string) func() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	messages, err := v.app.config.Client.GetInboundMessages(ctx, inboxID, &domain.MessageQueryParams{Limit: 50})
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load messages: %v", err)
-		return
-	}
+	messages, err := client.GetInboundMessages(ctx, inboxID, &domain.MessageQueryParams{Limit: 50})
+	return func() {
+		if err != nil {
+			v.app.Flash(FlashError, "Failed to load messages: %v", err)
+			return
+		}
 
-	v.messages = messages
-	v.renderMessages()
+		v.messages = messages
+		v.renderMessages()
+	}
 }
 
 func (v *InboundView) renderMessages() {
@@ -33,9 +33,15 @@ type MessagesView struct {
 
 // NewMessagesView creates a new messages view.
 func NewMessagesView(app *App) *MessagesView {
+	return newThreadListView(app, "messages", "Inbox")
+}
+
+// newThreadListView creates a conversation list with its folder panel, keys
+// and columns. The messages and threads views are both built on it.
+func newThreadListView(app *App, name, title string) *MessagesView {
 	v := &MessagesView{
-		BaseTableView:   newBaseTableView(app, "messages", "Inbox"),
-		currentFolder:   "Inbox",
+		BaseTableView:   newBaseTableView(app, name, title),
+		currentFolder:   title,
 		currentFolderID: "", // Will use INBOX by default in Load()
 	}
 
@@ -61,8 +67,12 @@ func NewMessagesView(app *App) *MessagesView {
 		{Key: "enter", Desc: "view"},
 		{Key: "n", Desc: "compose"},
 		{Key: "R", Desc: "reply"},
+		{Key: "space", Desc: "mark"},
 		{Key: "s", Desc: "star"},
-		{Key: "u", Desc: "unread"},
+		{Key: "u", Desc: "read/unread"},
+		{Key: "a", Desc: "archive"},
+		{Key: "M", Desc: "move"},
+		{Key: "dd", Desc: "delete"},
 		{Key: "F", Desc: "folders"},
 		{Key: "r", Desc: "refresh"},
 	}
@@ -85,13 +95,13 @@ func NewMessagesView(app *App) *MessagesView {
 	return v
 }
 
-func (v *MessagesView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func (v *MessagesView) Load() { loadNow(v.loader()) }
 
+func (v *MessagesView) loader() loader {
 	// Load folders if not already loaded
+	var folders loader
 	if len(v.folderPanel.folders) == 0 {
-		v.folderPanel.Load()
+		folders = v.folderPanel.loader()
 	}
 
 	// Build folder filter - use folder ID if set, otherwise default to INBOX
@@ -106,13 +116,28 @@ func (v *MessagesView) Load() {
 		Limit: 50,
 		In:    folderFilter,
 	}
-	threads, err := v.app.config.Client.GetThreads(ctx, v.app.config.GrantID, params)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load threads: %v", err)
-		return
+	client, grantID := v.app.config.Client, v.app.config.GrantID
+
+	return func() func() {
+		applyFolders := func() {}
+		if folders != nil {
+			applyFolders = folders()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		threads, err := client.GetThreads(ctx, grantID, params)
+
+		return func() {
+			applyFolders()
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load threads: %v", err)
+				return
+			}
+			v.threads = threads
+			v.render()
+		}
 	}
-	v.threads = threads
-	v.render()
 }
 
 // updateLayout rebuilds the layout based on folder panel visibility.
@@ -210,6 +235,11 @@ func (v *MessagesView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 			v.closeDetail()
 			return nil
 		}
+		// Clear marks before navigating away
+		if v.table.MarkedCount() > 0 {
+			v.table.ClearMarks()
+			return nil
+		}
 		// Otherwise, let app handle the Escape
 		return event
 
@@ -231,6 +261,9 @@ func (v *MessagesView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 
 	case tcell.KeyRune:
+		if v.table.HandleMarkKey(event) {
+			return nil
+		}
 		switch event.Rune() {
 		case 'n':
 			// New compose
@@ -248,11 +281,23 @@ func (v *MessagesView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
 				v.showCompose(ComposeModeReplyAll, v.currentMessage)
 			}
 			return nil
+		case 'f':
+			// Forward the current message
+			if v.showingDetail && v.currentMessage != nil {
+				v.showCompose(ComposeModeForward, v.currentMessage)
+			}
+			return nil
 		case 's':
 			v.toggleStar()
 			return nil
 		case 'u':
-			v.markUnread()
+			v.toggleRead()
+			return nil
+		case 'a':
+			v.archiveSelected()
+			return nil
+		case 'M':
+			v.showMoveDialog()
 			return nil
 		case 'F':
 			// Toggle folder panel
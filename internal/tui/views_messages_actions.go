@@ -14,74 +14,13 @@ import (
 	"github.com/rivo/tview"
 )
 
-func (v *MessagesView) toggleStar() {
-	meta := v.table.SelectedMeta()
-	if meta == nil {
-		return
-	}
-
-	thread, ok := meta.Data.(*domain.Thread)
-	if !ok {
-		return
-	}
-
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		newStarred := !thread.Starred
-		_, err := v.app.config.Client.UpdateThread(ctx, v.app.config.GrantID, thread.ID, &domain.UpdateMessageRequest{
-			Starred: &newStarred,
-		})
-		if err != nil {
-			v.app.Flash(FlashError, "Failed to update: %v", err)
-			return
-		}
-		v.app.Flash(FlashInfo, "Thread starred")
-		v.app.QueueUpdateDraw(func() {
-			v.Load()
-		})
-	}()
-}
-
-func (v *MessagesView) markUnread() {
-	meta := v.table.SelectedMeta()
-	if meta == nil {
-		return
-	}
-
-	thread, ok := meta.Data.(*domain.Thread)
-	if !ok {
-		return
-	}
-
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		unread := true
-		_, err := v.app.config.Client.UpdateThread(ctx, v.app.config.GrantID, thread.ID, &domain.UpdateMessageRequest{
-			Unread: &unread,
-		})
-		if err != nil {
-			v.app.Flash(FlashError, "Failed to update: %v", err)
-			return
-		}
-		v.app.Flash(FlashInfo, "Marked as unread")
-		v.app.QueueUpdateDraw(func() {
-			v.Load()
-		})
-	}()
-}
-
 func (v *MessagesView) showCompose(mode ComposeMode, replyTo *domain.Message) {
 	compose := NewComposeView(v.app, mode, replyTo)
 
 	compose.SetOnSent(func() {
 		v.app.PopDetail()
 		// Refresh messages to show the sent message
-		go func() {
-			v.Load()
-			v.app.QueueUpdateDraw(func() {})
-		}()
+		v.app.reloadInBackground(v)
 	})
 
 	compose.SetOnCancel(func() {
@@ -218,6 +157,11 @@ func (v *MessagesView) downloadAttachment(messageID, attachmentID, filename stri
 }
 
 func (v *MessagesView) getUniqueFilename(path string) string {
+	return uniqueFilename(path)
+}
+
+// uniqueFilename returns path, or a numbered variant if path already exists.
+func uniqueFilename(path string) string {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return path
 	}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+// targetThreads returns the marked threads, or the selected thread if none are marked.
+func (v *MessagesView) targetThreads() []RowMeta {
+	return v.table.TargetMeta()
+}
+
+// updateThreads applies an update to the target threads and reloads.
+func (v *MessagesView) updateThreads(verb string, items []RowMeta, req func(*domain.Thread) (*domain.UpdateMessageRequest, error)) {
+	v.app.runBulk(verb, items, func(ctx context.Context, meta RowMeta) error {
+		thread, ok := meta.Data.(*domain.Thread)
+		if !ok {
+			return fmt.Errorf("not a thread")
+		}
+		update, err := req(thread)
+		if err != nil {
+			return err
+		}
+		_, err = v.app.config.Client.UpdateThread(ctx, v.app.config.GrantID, thread.ID, update)
+		return err
+	}, v.afterBulk)
+}
+
+// afterBulk clears marks and reloads the list.
+func (v *MessagesView) afterBulk(result BulkResult) {
+	v.table.ClearMarks()
+	v.app.reloadInBackground(v)
+}
+
+// toggleStar stars the target threads, or unstars them if all are already starred.
+func (v *MessagesView) toggleStar() {
+	items := v.targetThreads()
+	starred := !allThreads(items, func(t *domain.Thread) bool { return t.Starred })
+
+	verb := "Starred"
+	if !starred {
+		verb = "Unstarred"
+	}
+	v.updateThreads(verb, items, func(*domain.Thread) (*domain.UpdateMessageRequest, error) {
+		return &domain.UpdateMessageRequest{Starred: &starred}, nil
+	})
+}
+
+// toggleRead marks the target threads unread, or read if all are already unread.
+func (v *MessagesView) toggleRead() {
+	items := v.targetThreads()
+	unread := !allThreads(items, func(t *domain.Thread) bool { return t.Unread })
+
+	verb := "Marked as unread"
+	if !unread {
+		verb = "Marked as read"
+	}
+	v.updateThreads(verb, items, func(*domain.Thread) (*domain.UpdateMessageRequest, error) {
+		return &domain.UpdateMessageRequest{Unread: &unread}, nil
+	})
+}
+
+// archiveSelected moves the target threads out of the inbox.
+func (v *MessagesView) archiveSelected() {
+	archive := v.folderPanel.GetFolderBySystemName("archive")
+	var archiveID string
+	if archive != nil {
+		archiveID = archive.ID
+	}
+
+	v.updateThreads("Archived", v.targetThreads(), func(thread *domain.Thread) (*domain.UpdateMessageRequest, error) {
+		folders := archiveFolders(thread.FolderIDs, archiveID)
+		if len(folders) == 0 {
+			return nil, fmt.Errorf("no archive folder and no other labels to keep")
+		}
+		return &domain.UpdateMessageRequest{Folders: folders}, nil
+	})
+}
+
+// archiveFolders returns the folders a thread should have once archived.
+// Providers with an archive folder move the thread there; label-based
+// providers such as Gmail just drop the inbox label.
+func archiveFolders(current []string, archiveID string) []string {
+	if archiveID != "" {
+		return []string{archiveID}
+	}
+	return slices.DeleteFunc(slices.Clone(current), func(id string) bool {
+		return id == "INBOX" || id == "inbox"
+	})
+}
+
+// showMoveDialog asks for a folder and moves the target threads into it.
+func (v *MessagesView) showMoveDialog() {
+	items := v.targetThreads()
+	if len(items) == 0 {
+		v.app.Flash(FlashWarn, "Nothing selected")
+		return
+	}
+	if len(v.folderPanel.folders) == 0 {
+		v.app.Flash(FlashWarn, "No folders loaded")
+		return
+	}
+
+	list := NewStyledList(v.app.styles, ListViewConfig{
+		Title:             fmt.Sprintf("Move %d thread(s) to", len(items)),
+		ShowSecondaryText: false,
+		HighlightFullLine: true,
+		UseTableSelectBg:  true,
+	})
+	list.SetBorderColor(v.app.styles.FocusColor)
+
+	for _, folder := range v.folderPanel.folders {
+		name := folder.Name
+		if folder.SystemFolder != "" {
+			name = v.folderPanel.getSystemFolderName(folder.SystemFolder)
+		}
+		folderID, folderName := folder.ID, name
+		list.AddItem(name, "", 0, func() {
+			v.app.PopDetail()
+			v.app.SetFocus(v.table)
+			v.updateThreads("Moved to "+folderName, items, func(*domain.Thread) (*domain.UpdateMessageRequest, error) {
+				return &domain.UpdateMessageRequest{Folders: []string{folderID}}, nil
+			})
+		})
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.app.PopDetail()
+			v.app.SetFocus(v.table)
+			return nil
+		}
+		return event
+	})
+
+	v.app.PushDetail("move-dialog", list)
+	v.app.SetFocus(list)
+}
+
+// DeleteSelected deletes the marked threads, or the selected thread, after confirmation.
+func (v *MessagesView) DeleteSelected() {
+	items := v.targetThreads()
+	if len(items) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("Delete %d thread(s)?", len(items))
+	if len(items) == 1 {
+		msg = fmt.Sprintf("Delete thread %q?", rowLabel(items[0]))
+	}
+	v.app.ShowConfirmDialog("Delete", msg, func() {
+		v.app.runBulk("Deleted", items, func(ctx context.Context, meta RowMeta) error {
+			return v.app.config.Client.DeleteThread(ctx, v.app.config.GrantID, meta.ID)
+		}, v.afterBulk)
+	})
+}
+
+// allThreads reports whether pred holds for every thread in items.
+func allThreads(items []RowMeta, pred func(*domain.Thread) bool) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, meta := range items {
+		thread, ok := meta.Data.(*domain.Thread)
+		if !ok || !pred(thread) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,15 +13,118 @@ import (
 	"github.com/rivo/tview"
 )
 
+// conversation is the state of an open thread detail view.
+type conversation struct {
+	thread     *domain.Thread
+	messages   []*domain.Message
+	selected   int
+	expanded   map[string]bool // Message ID -> body shown
+	showQuoted map[string]bool // Message ID -> quoted history shown
+	loading    bool
+	lastKey    rune
+	lastKeyAt  time.Time
+}
+
+// selectedMessage returns the message under the cursor.
+func (c *conversation) selectedMessage() *domain.Message {
+	if c.selected < 0 || c.selected >= len(c.messages) {
+		return nil
+	}
+	return c.messages[c.selected]
+}
+
+// showDetail opens a thread as a conversation: every message in order, with
+// the latest and unread messages expanded and quoted history collapsed.
 func (v *MessagesView) showDetail(thread *domain.Thread) {
 	v.currentThread = thread
+	conv := &conversation{
+		thread:     thread,
+		expanded:   make(map[string]bool),
+		showQuoted: make(map[string]bool),
+		loading:    true,
+	}
 
 	detail := tview.NewTextView()
 	detail.SetDynamicColors(true)
+	detail.SetRegions(true)
 	detail.SetBackgroundColor(v.app.styles.BgColor)
 	detail.SetBorderPadding(1, 1, 2, 2)
 	detail.SetScrollable(true)
 
+	v.renderConversation(detail, conv)
+
+	// Fetch all messages in the thread asynchronously
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		messages := v.fetchThreadMessages(ctx, thread)
+
+		v.app.QueueUpdateDraw(func() {
+			conv.messages = messages
+			conv.loading = false
+			conv.selected = len(messages) - 1
+			for i, msg := range messages {
+				if msg.Unread || i == len(messages)-1 {
+					conv.expanded[msg.ID] = true
+				}
+			}
+			v.currentMessage = conv.selectedMessage()
+			v.renderConversation(detail, conv)
+			detail.ScrollToHighlight()
+		})
+	}()
+
+	detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		return v.handleConversationKey(detail, conv, event)
+	})
+
+	// Push detail onto the page stack
+	v.app.PushDetail("thread-detail", detail)
+	v.showingDetail = true
+}
+
+// threadMessagesPageSize is how many messages of a thread are fetched per request.
+const threadMessagesPageSize = 50
+
+// fetchThreadMessages returns the thread's messages oldest first.
+func (v *MessagesView) fetchThreadMessages(ctx context.Context, thread *domain.Thread) []*domain.Message {
+	var messages []*domain.Message
+
+	// Page through the thread, so long conversations aren't cut off
+	params := &domain.MessageQueryParams{ThreadID: thread.ID, Limit: threadMessagesPageSize}
+	for {
+		page, err := v.app.config.Client.GetMessagesWithCursor(ctx, v.app.config.GrantID, params)
+		if err != nil {
+			break
+		}
+		for i := range page.Data {
+			messages = append(messages, &page.Data[i])
+		}
+		if page.Pagination.NextCursor == "" || page.Pagination.NextCursor == params.PageToken {
+			break
+		}
+		params.PageToken = page.Pagination.NextCursor
+	}
+
+	// Fall back to fetching by ID when the thread filter isn't supported
+	if len(messages) == 0 {
+		for _, msgID := range thread.MessageIDs {
+			if msg, err := v.app.config.Client.GetMessage(ctx, v.app.config.GrantID, msgID); err == nil {
+				messages = append(messages, msg)
+			}
+		}
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].Date.Before(messages[j].Date) })
+	return messages
+}
+
+// renderConversation redraws the conversation and highlights the selected message.
+func (v *MessagesView) renderConversation(detail *tview.TextView, conv *conversation) {
+	detail.Clear()
+	v.attachments = nil
+
 	// k9s style colors - use cached Hex() method
 	s := v.app.styles
 	title := s.Hex(s.TitleFg)
@@ -29,137 +133,264 @@ func (v *MessagesView) showDetail(thread *domain.Thread) {
 	muted := s.Hex(s.BorderColor)
 	hint := s.Hex(s.InfoColor)
 
-	// Format participants
+	thread := conv.thread
 	var participants []string
 	for _, p := range thread.Participants {
 		participants = append(participants, p.String())
 	}
 
-	// Show loading state first
-	_, _ = fmt.Fprintf(detail, "[%s::b]%s[-::-]\n", title, thread.Subject)
-	_, _ = fmt.Fprintf(detail, "[%s]Participants:[-] [%s]%s[-]\n", key, value, strings.Join(participants, ", "))
+	state := ""
+	if thread.Starred {
+		state += " ★"
+	}
+	if thread.Unread {
+		state += " ●"
+	}
+	_, _ = fmt.Fprintf(detail, "[%s::b]%s[-::-][%s]%s[-]\n", title, tview.Escape(thread.Subject), hint, state)
+	_, _ = fmt.Fprintf(detail, "[%s]Participants:[-] [%s]%s[-]\n", key, value, tview.Escape(strings.Join(participants, ", ")))
 	_, _ = fmt.Fprintf(detail, "[%s]Messages:[-] [%s]%d[-]\n\n", key, value, len(thread.MessageIDs))
-	_, _ = fmt.Fprintf(detail, "[%s]────────────────────────────────────────[-]\n\n", muted)
-	_, _ = fmt.Fprintf(detail, "[%s]Loading messages...[-]\n\n", muted)
 
-	// Fetch all messages in the thread asynchronously
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	switch {
+	case conv.loading:
+		_, _ = fmt.Fprintf(detail, "[%s]Loading messages...[-]\n\n", muted)
+	case len(conv.messages) == 0:
+		_, _ = fmt.Fprintf(detail, "[%s]────────────────────────────────────────[-]\n\n", muted)
+		_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, tview.Escape(thread.Snippet))
+	}
 
-		// Fetch each message in the thread
-		var messages []*domain.Message
-		for _, msgID := range thread.MessageIDs {
-			msg, err := v.app.config.Client.GetMessage(ctx, v.app.config.GrantID, msgID)
-			if err == nil {
-				messages = append(messages, msg)
+	for i, msg := range conv.messages {
+		from := ""
+		if len(msg.From) > 0 {
+			from = msg.From[0].String()
+		}
+
+		marker := "▸"
+		if conv.expanded[msg.ID] {
+			marker = "▾"
+		}
+		unread := ""
+		if msg.Unread {
+			unread = " ●"
+		}
+
+		_, _ = fmt.Fprintf(detail, "[%s]════════════════════════════════════════[-]\n", muted)
+		_, _ = fmt.Fprintf(detail, "[\"msg-%d\"][%s::b]%s %s[-::-][\"\"] [%s]%s[-][%s]%s[-]\n",
+			i, key, marker, tview.Escape(from), muted, msg.Date.Format(common.DisplayWeekdayComma), hint, unread)
+
+		// Attachments are listed for every message so they can be downloaded
+		// without expanding it
+		var files []string
+		for _, att := range msg.Attachments {
+			if att.IsInline {
+				continue // Skip inline attachments (images in HTML)
 			}
+			v.attachments = append(v.attachments, AttachmentInfo{MessageID: msg.ID, Attachment: att})
+			files = append(files, fmt.Sprintf("[%d] %s (%s)", len(v.attachments), att.Filename, formatFileSize(att.Size)))
+		}
+		if len(files) > 0 {
+			_, _ = fmt.Fprintf(detail, "[%s]Attachments:[-] [%s]%s[-]\n", key, hint, tview.Escape(strings.Join(files, "  ")))
 		}
 
-		v.app.QueueUpdateDraw(func() {
-			detail.Clear()
-
-			// Clear attachments list
-			v.attachments = nil
-
-			_, _ = fmt.Fprintf(detail, "[%s::b]%s[-::-]\n", title, thread.Subject)
-			_, _ = fmt.Fprintf(detail, "[%s]Participants:[-] [%s]%s[-]\n", key, value, strings.Join(participants, ", "))
-			_, _ = fmt.Fprintf(detail, "[%s]Messages:[-] [%s]%d[-]\n\n", key, value, len(thread.MessageIDs))
-
-			if len(messages) == 0 {
-				_, _ = fmt.Fprintf(detail, "[%s]────────────────────────────────────────[-]\n\n", muted)
-				_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, thread.Snippet)
-			} else {
-				// Display all messages in chronological order
-				for i, msg := range messages {
-					_, _ = fmt.Fprintf(detail, "[%s]════════════════════════════════════════[-]\n", muted)
-
-					from := ""
-					if len(msg.From) > 0 {
-						from = msg.From[0].String()
-					}
-
-					_, _ = fmt.Fprintf(detail, "[%s]From:[-] [%s]%s[-]\n", key, value, from)
-					_, _ = fmt.Fprintf(detail, "[%s]Date:[-] [%s]%s[-]\n", key, value, msg.Date.Format(common.DisplayWeekdayComma))
-
-					// Display attachments if any
-					if len(msg.Attachments) > 0 {
-						_, _ = fmt.Fprintf(detail, "[%s]Attachments:[-]", key)
-						for _, att := range msg.Attachments {
-							if att.IsInline {
-								continue // Skip inline attachments (images in HTML)
-							}
-							// Track attachment with its message ID
-							attachmentIdx := len(v.attachments)
-							v.attachments = append(v.attachments, AttachmentInfo{
-								MessageID:  msg.ID,
-								Attachment: att,
-							})
-							sizeStr := formatFileSize(att.Size)
-							_, _ = fmt.Fprintf(detail, " [%s][%d] %s (%s)[-]", hint, attachmentIdx+1, att.Filename, sizeStr)
-						}
-						_, _ = fmt.Fprintln(detail)
-					}
-					_, _ = fmt.Fprintln(detail)
-
-					// Use full body, strip HTML for terminal display
-					body := msg.Body
-					if body == "" {
-						body = msg.Snippet
-					}
-					body = stripHTMLForTUI(body)
-					_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, tview.Escape(body))
-
-					// Store the last message for reply
-					if i == len(messages)-1 {
-						v.currentMessage = msg
-					}
-				}
+		if !conv.expanded[msg.ID] {
+			_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", muted, tview.Escape(truncateStr(msg.Snippet, 100)))
+			continue
+		}
+		_, _ = fmt.Fprintln(detail)
+
+		// Use full body, strip HTML for terminal display
+		body := msg.Body
+		if body == "" {
+			body = msg.Snippet
+		}
+		for _, seg := range splitQuoted(stripHTMLForTUI(body)) {
+			if seg.Quoted && !conv.showQuoted[msg.ID] {
+				_, _ = fmt.Fprintf(detail, "[%s]··· %d quoted line(s) hidden (z to show)[-]\n", muted, seg.Lines)
+				continue
 			}
+			color := value
+			if seg.Quoted {
+				color = muted
+			}
+			_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n", color, tview.Escape(seg.Text))
+		}
+		_, _ = fmt.Fprintln(detail)
+	}
+
+	// Build help line based on available actions
+	helpLine := fmt.Sprintf("[%s]j/k[-][%s::d]=message  [-::-][%s]Enter[-][%s::d]=expand  [-::-][%s]z[-][%s::d]=quoted  [-::-]",
+		hint, muted, hint, muted, hint, muted)
+	helpLine += fmt.Sprintf("[%s]R[-][%s::d]=reply  [-::-][%s]A[-][%s::d]=reply all  [-::-][%s]f[-][%s::d]=forward  [-::-]",
+		hint, muted, hint, muted, hint, muted)
+	helpLine += fmt.Sprintf("[%s]s/u/a[-][%s::d]=star/unread/archive  [-::-][%s]dd[-][%s::d]=delete  [-::-]", hint, muted, hint, muted)
+	if len(v.attachments) > 0 {
+		helpLine += fmt.Sprintf("[%s]D[-][%s::d]=download  [-::-]", hint, muted)
+	}
+	helpLine += fmt.Sprintf("[%s]Esc[-][%s::d]=back[-::-]", hint, muted)
+	_, _ = fmt.Fprint(detail, helpLine)
+
+	if len(conv.messages) > 0 {
+		detail.Highlight(fmt.Sprintf("msg-%d", conv.selected))
+	}
+}
+
+// handleConversationKey handles keys in the conversation detail view.
+func (v *MessagesView) handleConversationKey(detail *tview.TextView, conv *conversation, event *tcell.EventKey) *tcell.EventKey {
+	selectMessage := func(idx int) {
+		if idx < 0 || idx >= len(conv.messages) {
+			return
+		}
+		conv.selected = idx
+		v.currentMessage = conv.selectedMessage()
+		v.renderConversation(detail, conv)
+		detail.ScrollToHighlight()
+	}
+
+	switch event.Key() {
+	case tcell.KeyEscape:
+		v.closeDetail()
+		return nil
+	case tcell.KeyEnter:
+		if msg := conv.selectedMessage(); msg != nil {
+			conv.expanded[msg.ID] = !conv.expanded[msg.ID]
+			selectMessage(conv.selected)
+		}
+		return nil
+	case tcell.KeyRune:
+	default:
+		return event
+	}
+
+	r := event.Rune()
+	isDelete := r == 'd' && conv.lastKey == 'd' && time.Since(conv.lastKeyAt) < 500*time.Millisecond
+	conv.lastKey, conv.lastKeyAt = r, time.Now()
+
+	switch r {
+	case 'j', 'n':
+		selectMessage(conv.selected + 1)
+	case 'k', 'p':
+		selectMessage(conv.selected - 1)
+	case ' ', 'o':
+		if msg := conv.selectedMessage(); msg != nil {
+			conv.expanded[msg.ID] = !conv.expanded[msg.ID]
+			selectMessage(conv.selected)
+		}
+	case 'E':
+		// Expand all, or collapse all when everything is already expanded
+		expand := false
+		for _, msg := range conv.messages {
+			if !conv.expanded[msg.ID] {
+				expand = true
+			}
+		}
+		for _, msg := range conv.messages {
+			conv.expanded[msg.ID] = expand
+		}
+		selectMessage(conv.selected)
+	case 'z':
+		if msg := conv.selectedMessage(); msg != nil {
+			conv.showQuoted[msg.ID] = !conv.showQuoted[msg.ID]
+			conv.expanded[msg.ID] = true
+			selectMessage(conv.selected)
+		}
+	case 'R':
+		if msg := conv.selectedMessage(); msg != nil {
+			v.showCompose(ComposeModeReply, msg)
+		}
+	case 'A':
+		if msg := conv.selectedMessage(); msg != nil {
+			v.showCompose(ComposeModeReplyAll, msg)
+		}
+	case 'f':
+		if msg := conv.selectedMessage(); msg != nil {
+			v.showCompose(ComposeModeForward, msg)
+		}
+	case 'D':
+		if len(v.attachments) > 0 {
+			v.showDownloadDialog()
+		}
+	case 's':
+		starred := !conv.thread.Starred
+		v.updateOpenThread(detail, conv, &domain.UpdateMessageRequest{Starred: &starred}, false)
+	case 'u':
+		unread := !conv.thread.Unread
+		v.updateOpenThread(detail, conv, &domain.UpdateMessageRequest{Unread: &unread}, false)
+	case 'a':
+		v.archiveOpenThread(detail, conv)
+	case 'd':
+		if isDelete {
+			conv.lastKey = 0
+			v.deleteOpenThread(conv)
+		}
+	default:
+		return event
+	}
+	return nil
+}
 
-			// Build help line based on available actions
-			helpLine := fmt.Sprintf("[%s]R[-][%s::d]=reply  [-::-][%s]A[-][%s::d]=reply all  [-::-]", hint, muted, hint, muted)
-			if len(v.attachments) > 0 {
-				helpLine += fmt.Sprintf("[%s]D[-][%s::d]=download  [-::-]", hint, muted)
+// updateOpenThread applies a thread-level update from the conversation view.
+// When closeAfter is set the detail view is closed once the update succeeds.
+func (v *MessagesView) updateOpenThread(detail *tview.TextView, conv *conversation, req *domain.UpdateMessageRequest, closeAfter bool) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		updated, err := v.app.config.Client.UpdateThread(ctx, v.app.config.GrantID, conv.thread.ID, req)
+		v.app.QueueUpdateDraw(func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to update thread: %v", err)
+				return
 			}
-			helpLine += fmt.Sprintf("[%s]Esc[-][%s::d]=back[-::-]", hint, muted)
-			_, _ = fmt.Fprint(detail, helpLine)
+			if updated != nil {
+				conv.thread.Starred = updated.Starred
+				conv.thread.Unread = updated.Unread
+				conv.thread.FolderIDs = updated.FolderIDs
+			}
+			if closeAfter {
+				v.app.Flash(FlashInfo, "Thread archived")
+				v.closeDetail()
+				v.app.reloadInBackground(v)
+				return
+			}
+			v.renderConversation(detail, conv)
+			v.render()
 		})
 	}()
+}
 
-	_, _ = fmt.Fprintf(detail, "[%s]R[-][%s::d]=reply  [-::-][%s]A[-][%s::d]=reply all  [-::-][%s]Esc[-][%s::d]=back[-::-]", hint, muted, hint, muted, hint, muted)
+// archiveOpenThread archives the open thread and returns to the list.
+func (v *MessagesView) archiveOpenThread(detail *tview.TextView, conv *conversation) {
+	var archiveID string
+	if archive := v.folderPanel.GetFolderBySystemName("archive"); archive != nil {
+		archiveID = archive.ID
+	}
+	folders := archiveFolders(conv.thread.FolderIDs, archiveID)
+	if len(folders) == 0 {
+		v.app.Flash(FlashWarn, "No archive folder and no other labels to keep")
+		return
+	}
+	v.updateOpenThread(detail, conv, &domain.UpdateMessageRequest{Folders: folders}, true)
+}
 
-	// Handle key events for reply actions in detail view
-	detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyEscape:
-			v.closeDetail()
-			return nil
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'R':
-				if v.currentMessage != nil {
-					v.showCompose(ComposeModeReply, v.currentMessage)
-				}
-				return nil
-			case 'A':
-				if v.currentMessage != nil {
-					v.showCompose(ComposeModeReplyAll, v.currentMessage)
-				}
-				return nil
-			case 'D':
-				if len(v.attachments) > 0 {
-					v.showDownloadDialog()
+// deleteOpenThread deletes the open thread after confirmation.
+func (v *MessagesView) deleteOpenThread(conv *conversation) {
+	thread := conv.thread
+	v.app.ShowConfirmDialog("Delete", fmt.Sprintf("Delete thread %q?", thread.Subject), func() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			err := v.app.config.Client.DeleteThread(ctx, v.app.config.GrantID, thread.ID)
+			v.app.QueueUpdateDraw(func() {
+				if err != nil {
+					v.app.Flash(FlashError, "Failed to delete thread: %v", err)
+					return
 				}
-				return nil
-			}
-		}
-		return event
+				v.app.Flash(FlashInfo, "Thread deleted")
+				v.closeDetail()
+				v.app.reloadInBackground(v)
+			})
+		}()
 	})
-
-	// Push detail onto the page stack
-	v.app.PushDetail("thread-detail", detail)
-	v.showingDetail = true
 }
 
 func (v *MessagesView) closeDetail() {
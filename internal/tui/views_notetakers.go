@@ -0,0 +1,381 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/cli/common"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/mqasimca/nylas/internal/httputil"
+	"github.com/rivo/tview"
+)
+
+// NotetakersView displays meeting notetakers.
+type NotetakersView struct {
+	*BaseTableView
+	notetakers []domain.Notetaker
+}
+
+// NewNotetakersView creates a new notetakers view.
+func NewNotetakersView(app *App) *NotetakersView {
+	v := &NotetakersView{
+		BaseTableView: newBaseTableView(app, "notetakers", "Notetakers"),
+	}
+
+	v.hints = []Hint{
+		{Key: "enter", Desc: "view"},
+		{Key: "D", Desc: "download"},
+		{Key: "space", Desc: "mark"},
+		{Key: "dd", Desc: "cancel"},
+		{Key: "/", Desc: "filter"},
+		{Key: "r", Desc: "refresh"},
+	}
+
+	v.table.SetColumns([]Column{
+		{Title: "", Width: 3},
+		{Title: "STATE", Width: 18},
+		{Title: "MEETING", Expand: true},
+		{Title: "JOIN TIME", Width: 18},
+		{Title: "LINK", Width: 40},
+	})
+
+	return v
+}
+
+func (v *NotetakersView) Load() { loadNow(v.loader()) }
+
+func (v *NotetakersView) loader() loader {
+	client, grantID := v.app.config.Client, v.app.config.GrantID
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		notetakers, err := client.ListNotetakers(ctx, grantID, &domain.NotetakerQueryParams{Limit: 100})
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load notetakers: %v", err)
+				return
+			}
+			v.notetakers = notetakers
+			v.render()
+		}
+	}
+}
+
+func (v *NotetakersView) Refresh() { v.Load() }
+
+func (v *NotetakersView) render() {
+	var data [][]string
+	var meta []RowMeta
+
+	filter := strings.ToLower(v.filter)
+	for _, nt := range v.notetakers {
+		title := notetakerTitle(&nt)
+		if filter != "" && !strings.Contains(strings.ToLower(title+" "+nt.State), filter) {
+			continue
+		}
+
+		joinTime := ""
+		if !nt.JoinTime.IsZero() {
+			joinTime = nt.JoinTime.Local().Format(common.DisplayDateTime)
+		}
+		data = append(data, []string{
+			"",
+			nt.State,
+			title,
+			joinTime,
+			nt.MeetingLink,
+		})
+		meta = append(meta, RowMeta{
+			ID:    nt.ID,
+			Data:  &nt,
+			Error: nt.State == domain.NotetakerStateFailed,
+		})
+	}
+
+	v.table.SetData(data, meta)
+}
+
+// HandleKey handles keyboard input for the notetakers view.
+func (v *NotetakersView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
+	notetaker, _ := metaData[*domain.Notetaker](v.table.SelectedMeta())
+
+	switch event.Key() {
+	case tcell.KeyEnter:
+		if notetaker != nil {
+			v.showNotetakerDetail(notetaker)
+		}
+		return nil
+
+	case tcell.KeyRune:
+		if v.table.HandleMarkKey(event) {
+			return nil
+		}
+		if event.Rune() == 'D' && notetaker != nil {
+			v.downloadMedia(notetaker)
+			return nil
+		}
+	}
+
+	return event
+}
+
+// DeleteSelected cancels the marked or selected notetakers.
+func (v *NotetakersView) DeleteSelected() {
+	items := v.table.TargetMeta()
+	if len(items) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Cancel %d notetakers?", len(items))
+	if len(items) == 1 {
+		message = fmt.Sprintf("Cancel notetaker for %s?", rowLabel(items[0]))
+	}
+	v.app.ShowConfirmDialog("Cancel Notetakers", message, func() {
+		v.app.runBulk("Cancelled", items, func(ctx context.Context, meta RowMeta) error {
+			return v.app.config.Client.DeleteNotetaker(ctx, v.app.config.GrantID, meta.ID)
+		}, func(BulkResult) {
+			v.table.ClearMarks()
+			v.app.reloadInBackground(v)
+		})
+	})
+}
+
+func (v *NotetakersView) showNotetakerDetail(notetaker *domain.Notetaker) {
+	detail := tview.NewTextView()
+	detail.SetDynamicColors(true)
+	detail.SetBackgroundColor(v.app.styles.BgColor)
+	detail.SetBorder(true)
+	detail.SetBorderColor(v.app.styles.FocusColor)
+	detail.SetTitle(fmt.Sprintf(" Notetaker: %s ", notetakerTitle(notetaker)))
+	detail.SetTitleColor(v.app.styles.TitleFg)
+	detail.SetBorderPadding(1, 1, 2, 2)
+	detail.SetScrollable(true)
+
+	v.renderNotetakerDetail(detail, notetaker, nil)
+
+	// Media is only available once processing has finished
+	if notetaker.State == domain.NotetakerStateComplete {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			media, err := v.app.config.Client.GetNotetakerMedia(ctx, v.app.config.GrantID, notetaker.ID)
+			v.app.QueueUpdateDraw(func() {
+				if err != nil {
+					v.app.Flash(FlashError, "Failed to load media: %v", err)
+					return
+				}
+				v.renderNotetakerDetail(detail, notetaker, media)
+			})
+		}()
+	}
+
+	detail.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == 'D' {
+			v.downloadMedia(notetaker)
+			return nil
+		}
+		return event
+	})
+
+	v.app.PushDetail("notetaker-detail", detail)
+}
+
+func (v *NotetakersView) renderNotetakerDetail(detail *tview.TextView, notetaker *domain.Notetaker, media *domain.MediaData) {
+	st := v.app.styles
+	info := st.Hex(st.InfoColor)
+	value := st.Hex(st.InfoSectionFg)
+	muted := st.Hex(st.BorderColor)
+	success := st.Hex(st.SuccessColor)
+	errColor := st.Hex(st.ErrorColor)
+
+	detail.Clear()
+
+	// State
+	_, _ = fmt.Fprintf(detail, "[%s::b]State[-::-]\n", info)
+	stateColor := value
+	switch notetaker.State {
+	case domain.NotetakerStateComplete:
+		stateColor = success
+	case domain.NotetakerStateFailed:
+		stateColor = errColor
+	}
+	_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", stateColor, notetaker.State)
+
+	// Meeting
+	if notetaker.MeetingLink != "" {
+		_, _ = fmt.Fprintf(detail, "[%s::b]Meeting Link[-::-]\n", info)
+		_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, notetaker.MeetingLink)
+	}
+	if notetaker.MeetingInfo != nil && notetaker.MeetingInfo.Provider != "" {
+		_, _ = fmt.Fprintf(detail, "[%s::b]Provider[-::-]\n", info)
+		_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, notetaker.MeetingInfo.Provider)
+	}
+	if !notetaker.JoinTime.IsZero() {
+		_, _ = fmt.Fprintf(detail, "[%s::b]Join Time[-::-]\n", info)
+		_, _ = fmt.Fprintf(detail, "[%s]%s[-]\n\n", value, notetaker.JoinTime.Local().Format(common.DisplayDateTime))
+	}
+
+	// Media
+	_, _ = fmt.Fprintf(detail, "[%s::b]Media[-::-]\n", info)
+	switch {
+	case media != nil:
+		for _, file := range []struct {
+			name string
+			file *domain.MediaFile
+		}{
+			{"Recording", media.Recording},
+			{"Transcript", media.Transcript},
+		} {
+			if file.file == nil {
+				continue
+			}
+			_, _ = fmt.Fprintf(detail, "[%s]  • %s (%s, %s)[-]\n", value, file.name, file.file.ContentType, formatFileSize(file.file.Size))
+			if file.file.ExpiresAt > 0 {
+				_, _ = fmt.Fprintf(detail, "[%s]    expires %s[-]\n", muted, time.Unix(file.file.ExpiresAt, 0).Local().Format(common.DisplayDateTime))
+			}
+		}
+	case notetaker.State == domain.NotetakerStateComplete:
+		_, _ = fmt.Fprintf(detail, "[%s]  Loading...[-]\n", muted)
+	default:
+		_, _ = fmt.Fprintf(detail, "[%s]  Available once the meeting is processed[-]\n", muted)
+	}
+	_, _ = fmt.Fprintln(detail)
+
+	if !notetaker.CreatedAt.IsZero() {
+		_, _ = fmt.Fprintf(detail, "[%s]Created:[-] [%s]%s[-]\n", muted, value, notetaker.CreatedAt.Format(common.DisplayDateTime))
+	}
+	_, _ = fmt.Fprintf(detail, "[%s]ID:[-] [%s]%s[-]\n", muted, value, notetaker.ID)
+
+	_, _ = fmt.Fprintf(detail, "\n\n[%s::d]Press Esc to go back, 'D' to download media[-::-]", muted)
+}
+
+// downloadMedia saves a notetaker's recording and transcript to ~/Downloads.
+func (v *NotetakersView) downloadMedia(notetaker *domain.Notetaker) {
+	if notetaker.State != domain.NotetakerStateComplete {
+		v.app.Flash(FlashWarn, "Media is not ready (state: %s)", notetaker.State)
+		return
+	}
+
+	v.app.Flash(FlashInfo, "Downloading media for %s...", notetakerTitle(notetaker))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		media, err := v.app.config.Client.GetNotetakerMedia(ctx, v.app.config.GrantID, notetaker.ID)
+		if err != nil {
+			v.app.QueueUpdateDraw(func() {
+				v.app.Flash(FlashError, "Failed to get media: %v", err)
+			})
+			return
+		}
+
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			v.app.QueueUpdateDraw(func() {
+				v.app.Flash(FlashError, "Cannot find home directory: %v", err)
+			})
+			return
+		}
+		downloadDir := filepath.Join(homeDir, "Downloads")
+		if err := os.MkdirAll(downloadDir, 0750); err != nil {
+			v.app.QueueUpdateDraw(func() {
+				v.app.Flash(FlashError, "Cannot create Downloads directory: %v", err)
+			})
+			return
+		}
+
+		var saved []string
+		for kind, file := range map[string]*domain.MediaFile{
+			"recording":  media.Recording,
+			"transcript": media.Transcript,
+		} {
+			if file == nil || file.URL == "" {
+				continue
+			}
+			destPath := uniqueFilename(filepath.Join(downloadDir, mediaFilename(notetaker, kind, file)))
+			if err := downloadURL(ctx, file.URL, destPath); err != nil {
+				v.app.QueueUpdateDraw(func() {
+					v.app.Flash(FlashError, "Download of %s failed: %v", kind, err)
+				})
+				return
+			}
+			saved = append(saved, filepath.Base(destPath))
+		}
+
+		v.app.QueueUpdateDraw(func() {
+			if len(saved) == 0 {
+				v.app.Flash(FlashWarn, "No media available for %s", notetakerTitle(notetaker))
+				return
+			}
+			v.app.Flash(FlashInfo, "Downloaded %s to %s", strings.Join(saved, ", "), downloadDir)
+		})
+	}()
+}
+
+// downloadURL saves the body of a GET request to destPath.
+func downloadURL(ctx context.Context, rawURL, destPath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httputil.NewClient(0).Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	// #nosec G304 -- destPath is built by uniqueFilename() inside the Downloads directory
+	file, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	_, err = io.Copy(file, resp.Body)
+	return err
+}
+
+// mediaFilename names a downloaded media file after its meeting, e.g.
+// "Weekly-Sync-recording.mp4".
+func mediaFilename(notetaker *domain.Notetaker, kind string, file *domain.MediaFile) string {
+	base := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, notetakerTitle(notetaker))
+
+	ext := ""
+	if u, err := url.Parse(file.URL); err == nil {
+		ext = path.Ext(u.Path)
+	}
+	if ext == "" && file.ContentType != "" {
+		if exts, _ := mime.ExtensionsByType(file.ContentType); len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+	return base + "-" + kind + ext
+}
+
+// notetakerTitle returns the meeting title, or the notetaker ID if it has none.
+func notetakerTitle(notetaker *domain.Notetaker) string {
+	if notetaker.MeetingTitle != "" {
+		return notetaker.MeetingTitle
+	}
+	return notetaker.ID
+}
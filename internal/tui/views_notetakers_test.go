@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mqasimca/nylas/internal/domain"
+)
+
+func TestNotetakersView_Load(t *testing.T) {
+	app := createTestApp(t)
+	view := NewNotetakersView(app)
+
+	view.Load()
+	meta := view.table.SelectedMeta()
+	if meta == nil || meta.ID != "notetaker-1" {
+		t.Fatalf("selected row = %v, want notetaker-1", meta)
+	}
+	if rowLabel(*meta) != "Test Meeting" {
+		t.Errorf("rowLabel() = %q, want the meeting title", rowLabel(*meta))
+	}
+}
+
+func TestMediaFilename(t *testing.T) {
+	tests := []struct {
+		name      string
+		notetaker domain.Notetaker
+		kind      string
+		file      domain.MediaFile
+		want      string
+	}{
+		{
+			name:      "extension from URL",
+			notetaker: domain.Notetaker{ID: "nt-1", MeetingTitle: "Weekly Sync"},
+			kind:      "recording",
+			file:      domain.MediaFile{URL: "https://storage.example.com/rec.mp4?sig=abc"},
+			want:      "Weekly-Sync-recording.mp4",
+		},
+		{
+			name:      "extension from content type",
+			notetaker: domain.Notetaker{ID: "nt-1"},
+			kind:      "transcript",
+			file:      domain.MediaFile{URL: "https://storage.example.com/t", ContentType: "application/json"},
+			want:      "nt-1-transcript.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mediaFilename(&tt.notetaker, tt.kind, &tt.file); got != tt.want {
+				t.Errorf("mediaFilename() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("transcript"))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "transcript.txt")
+	if err := downloadURL(context.Background(), server.URL+"/ok", dest); err != nil {
+		t.Fatalf("downloadURL() error = %v", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "transcript" {
+		t.Errorf("downloaded %q, want transcript", data)
+	}
+
+	if err := downloadURL(context.Background(), server.URL+"/missing", dest); err == nil {
+		t.Error("downloadURL() should fail on a 404")
+	}
+}
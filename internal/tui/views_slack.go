@@ -0,0 +1,468 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/rivo/tview"
+)
+
+// slackPageSize is how many messages a channel timeline fetches per page.
+const slackPageSize = 50
+
+// slackUserResolver is implemented by Slack clients that can fill in
+// usernames for messages (the API only returns user IDs).
+type slackUserResolver interface {
+	GetUsersForMessages(ctx context.Context, messages []domain.SlackMessage) error
+}
+
+// SlackView displays Slack channels.
+type SlackView struct {
+	*BaseTableView
+	channels []domain.SlackChannel
+}
+
+// NewSlackView creates a new Slack view.
+func NewSlackView(app *App) *SlackView {
+	v := &SlackView{
+		BaseTableView: newBaseTableView(app, "slack", "Slack"),
+	}
+
+	v.hints = []Hint{
+		{Key: "enter", Desc: "open"},
+		{Key: "n", Desc: "send"},
+		{Key: "/", Desc: "filter"},
+		{Key: "r", Desc: "refresh"},
+	}
+
+	v.table.SetColumns([]Column{
+		{Title: "", Width: 3},
+		{Title: "CHANNEL", Width: 28},
+		{Title: "TYPE", Width: 10},
+		{Title: "MEMBERS", Width: 8},
+		{Title: "TOPIC", Expand: true},
+	})
+
+	return v
+}
+
+func (v *SlackView) Load() { loadNow(v.loader()) }
+
+func (v *SlackView) loader() loader {
+	slack := v.app.config.Slack
+	return func() func() {
+		if slack == nil {
+			return func() {
+				v.channels = nil
+				v.render()
+				v.app.Flash(FlashWarn, "Slack not configured. Run: nylas slack auth set --token YOUR_TOKEN")
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		resp, err := slack.ListMyChannels(ctx, &domain.SlackChannelQueryParams{
+			ExcludeArchived: true,
+			Limit:           200,
+		})
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load Slack channels: %v", err)
+				return
+			}
+			v.channels = resp.Channels
+			v.render()
+		}
+	}
+}
+
+func (v *SlackView) Refresh() { v.Load() }
+
+func (v *SlackView) render() {
+	var data [][]string
+	var meta []RowMeta
+
+	filter := strings.ToLower(v.filter)
+	for _, ch := range v.channels {
+		name := ch.ChannelDisplayName()
+		if filter != "" && !strings.Contains(strings.ToLower(name+" "+ch.Topic), filter) {
+			continue
+		}
+
+		members := ""
+		if ch.MemberCount > 0 {
+			members = fmt.Sprintf("%d", ch.MemberCount)
+		}
+		data = append(data, []string{
+			"",
+			name,
+			ch.ChannelType(),
+			members,
+			ch.Topic,
+		})
+		meta = append(meta, RowMeta{
+			ID:   ch.ID,
+			Data: &ch,
+		})
+	}
+
+	v.table.SetData(data, meta)
+}
+
+// HandleKey handles keyboard input for the Slack view.
+func (v *SlackView) HandleKey(event *tcell.EventKey) *tcell.EventKey {
+	meta := v.table.SelectedMeta()
+	channel, _ := metaData[*domain.SlackChannel](meta)
+
+	switch event.Key() {
+	case tcell.KeyEnter:
+		if channel != nil {
+			newSlackTimeline(v.app, *channel).open()
+		}
+		return nil
+
+	case tcell.KeyRune:
+		if event.Rune() == 'n' && channel != nil {
+			newSlackTimeline(v.app, *channel).showSendForm()
+			return nil
+		}
+	}
+
+	return event
+}
+
+// metaData returns a row's data as T, or false if the row holds something else.
+func metaData[T any](meta *RowMeta) (T, bool) {
+	var zero T
+	if meta == nil {
+		return zero, false
+	}
+	data, ok := meta.Data.(T)
+	return data, ok
+}
+
+// slackTimeline shows a channel's messages, or the replies of one thread.
+type slackTimeline struct {
+	app        *App
+	channel    domain.SlackChannel
+	parent     *domain.SlackMessage // Set when showing a thread
+	messages   []domain.SlackMessage
+	nextCursor string
+	hasMore    bool
+	table      *Table
+	text       *tview.TextView
+}
+
+func newSlackTimeline(app *App, channel domain.SlackChannel) *slackTimeline {
+	return &slackTimeline{app: app, channel: channel}
+}
+
+// newSlackThread creates a timeline for the replies to parent.
+func newSlackThread(app *App, channel domain.SlackChannel, parent domain.SlackMessage) *slackTimeline {
+	return &slackTimeline{app: app, channel: channel, parent: &parent}
+}
+
+func (t *slackTimeline) title() string {
+	if t.parent != nil {
+		return fmt.Sprintf(" Thread in %s ", t.channel.ChannelDisplayName())
+	}
+	return fmt.Sprintf(" %s ", t.channel.ChannelDisplayName())
+}
+
+// open pushes the timeline and loads its messages in the background.
+func (t *slackTimeline) open() {
+	var page tview.Primitive
+	if t.parent != nil {
+		t.text = tview.NewTextView()
+		t.text.SetDynamicColors(true)
+		t.text.SetScrollable(true)
+		t.text.SetWrap(true)
+		t.text.SetBackgroundColor(t.app.styles.BgColor)
+		t.text.SetBorder(true)
+		t.text.SetBorderColor(t.app.styles.FocusColor)
+		t.text.SetBorderPadding(1, 1, 2, 2)
+		t.text.SetTitle(t.title())
+		t.text.SetTitleColor(t.app.styles.TitleFg)
+		t.text.SetInputCapture(t.handleKey)
+		page = t.text
+	} else {
+		t.table = NewTable(t.app.styles)
+		t.table.SetColumns([]Column{
+			{Title: "", Width: 3},
+			{Title: "TIME", Width: 12},
+			{Title: "FROM", Width: 20},
+			{Title: "MESSAGE", Expand: true},
+			{Title: "REPLIES", Width: 8},
+		})
+		t.table.SetTitle(t.title())
+		t.table.SetTitleColor(t.app.styles.TitleFg)
+		t.table.SetBorderColor(t.app.styles.FocusColor)
+		t.table.SetInputCapture(t.handleKey)
+		t.table.SetOnDoubleClick(func(meta *RowMeta) {
+			if msg, ok := metaData[*domain.SlackMessage](meta); ok {
+				newSlackThread(t.app, t.channel, *msg).open()
+			}
+		})
+		page = t.table
+	}
+
+	t.app.PushDetail("slack-"+t.channel.ID, page)
+	t.reload()
+}
+
+// slackPage is one fetch of timeline messages, oldest first.
+type slackPage struct {
+	messages   []domain.SlackMessage
+	nextCursor string
+	hasMore    bool
+}
+
+// reload fetches the newest page in the background and re-renders.
+func (t *slackTimeline) reload() {
+	t.app.Flash(FlashInfo, "Loading %s...", t.channel.ChannelDisplayName())
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		page, err := t.fetch(ctx, "")
+		t.app.QueueUpdateDraw(func() {
+			if err != nil {
+				t.app.Flash(FlashError, "Failed to load messages: %v", err)
+				return
+			}
+			t.apply(page, false)
+			t.app.Flash(FlashInfo, "Loaded %d messages", len(t.messages))
+		})
+	}()
+}
+
+// loadOlder fetches the page before the oldest loaded message.
+func (t *slackTimeline) loadOlder() {
+	if t.parent != nil || !t.hasMore {
+		t.app.Flash(FlashInfo, "No older messages")
+		return
+	}
+	cursor := t.nextCursor
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		page, err := t.fetch(ctx, cursor)
+		t.app.QueueUpdateDraw(func() {
+			if err != nil {
+				t.app.Flash(FlashError, "Failed to load older messages: %v", err)
+				return
+			}
+			t.apply(page, true)
+		})
+	}()
+}
+
+// fetch loads a page of the channel, or the whole thread, oldest first.
+func (t *slackTimeline) fetch(ctx context.Context, cursor string) (*slackPage, error) {
+	client := t.app.config.Slack
+	if client == nil {
+		return nil, domain.ErrSlackNotConfigured
+	}
+
+	page := &slackPage{}
+	if t.parent != nil {
+		replies, err := client.GetThreadReplies(ctx, t.channel.ID, t.parent.ID, 200)
+		if err != nil {
+			return nil, err
+		}
+		page.messages = replies
+	} else {
+		resp, err := client.GetMessages(ctx, &domain.SlackMessageQueryParams{
+			ChannelID: t.channel.ID,
+			Limit:     slackPageSize,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Slack returns newest first
+		page.messages = make([]domain.SlackMessage, len(resp.Messages))
+		for i, msg := range resp.Messages {
+			page.messages[len(resp.Messages)-1-i] = msg
+		}
+		page.nextCursor, page.hasMore = resp.NextCursor, resp.HasMore
+	}
+
+	if resolver, ok := client.(slackUserResolver); ok {
+		_ = resolver.GetUsersForMessages(ctx, page.messages)
+	}
+	return page, nil
+}
+
+// apply shows a fetched page. Older pages go before the loaded messages;
+// otherwise the page replaces them.
+func (t *slackTimeline) apply(page *slackPage, older bool) {
+	if older {
+		t.messages = append(page.messages, t.messages...)
+	} else {
+		t.messages = page.messages
+	}
+	if t.parent == nil {
+		t.nextCursor, t.hasMore = page.nextCursor, page.hasMore
+	}
+	t.render()
+
+	// Keep the cursor on the message that was oldest before the fetch
+	if older && t.table != nil {
+		t.table.Select(len(page.messages)+1, 0)
+	}
+}
+
+func (t *slackTimeline) render() {
+	if t.text != nil {
+		t.renderThread()
+		return
+	}
+
+	data := make([][]string, 0, len(t.messages))
+	meta := make([]RowMeta, 0, len(t.messages))
+	for i := range t.messages {
+		msg := &t.messages[i]
+		replies := ""
+		if msg.ReplyCount > 0 {
+			replies = fmt.Sprintf("%d", msg.ReplyCount)
+		}
+		text, _, _ := strings.Cut(msg.Text, "\n")
+		data = append(data, []string{
+			"",
+			formatSlackTime(msg.Timestamp),
+			slackSender(msg),
+			tview.Escape(text),
+			replies,
+		})
+		meta = append(meta, RowMeta{ID: msg.ID, Data: msg})
+	}
+	t.table.SetData(data, meta)
+
+	// Start at the newest message, like a chat client
+	if len(data) > 0 {
+		t.table.Select(len(data), 0)
+	}
+}
+
+func (t *slackTimeline) renderThread() {
+	st := t.app.styles
+	info := st.Hex(st.InfoColor)
+	value := st.Hex(st.InfoSectionFg)
+	muted := st.Hex(st.BorderColor)
+
+	t.text.Clear()
+	for i, msg := range t.messages {
+		if i == 1 {
+			_, _ = fmt.Fprintf(t.text, "[%s]%s[-]\n\n", muted, strings.Repeat("─", 40))
+		}
+		_, _ = fmt.Fprintf(t.text, "[%s::b]%s[-::-]  [%s]%s[-]\n", info, tview.Escape(slackSender(&msg)), muted, formatSlackTime(msg.Timestamp))
+		_, _ = fmt.Fprintf(t.text, "[%s]%s[-]\n\n", value, tview.Escape(msg.Text))
+	}
+	if len(t.messages) <= 1 {
+		_, _ = fmt.Fprintf(t.text, "[%s::d]No replies yet[-::-]\n\n", muted)
+	}
+	_, _ = fmt.Fprintf(t.text, "[%s::d]n reply · r refresh · Esc back[-::-]", muted)
+	t.text.ScrollToEnd()
+}
+
+func (t *slackTimeline) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEnter:
+		if t.table != nil {
+			if msg, ok := metaData[*domain.SlackMessage](t.table.SelectedMeta()); ok {
+				newSlackThread(t.app, t.channel, *msg).open()
+			}
+			return nil
+		}
+
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'n', 'R':
+			t.showSendForm()
+			return nil
+		case 'r':
+			t.reload()
+			return nil
+		case 'o':
+			t.loadOlder()
+			return nil
+		}
+	}
+	return event
+}
+
+// showSendForm asks for a message and sends it to the channel, or as a
+// reply when the timeline shows a thread.
+func (t *slackTimeline) showSendForm() {
+	if t.app.config.Slack == nil {
+		t.app.Flash(FlashWarn, "Slack not configured. Run: nylas slack auth set --token YOUR_TOKEN")
+		return
+	}
+
+	title := "Send to " + t.channel.ChannelDisplayName()
+	if t.parent != nil {
+		title = "Reply in thread"
+	}
+
+	t.app.ShowForm(title, []FormField{
+		{Key: "text", Label: "Message", Type: FieldTextArea, Required: true},
+	}, func(values map[string]string) {
+		t.send(strings.TrimSpace(values["text"]))
+	})
+}
+
+func (t *slackTimeline) send(text string) {
+	req := &domain.SlackSendMessageRequest{
+		ChannelID: t.channel.ID,
+		Text:      text,
+	}
+	if t.parent != nil {
+		req.ThreadTS = t.parent.ID
+	}
+
+	t.app.Flash(FlashInfo, "Sending...")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_, err := t.app.config.Slack.SendMessage(ctx, req)
+		t.app.QueueUpdateDraw(func() {
+			if err != nil {
+				t.app.Flash(FlashError, "Send failed: %v", err)
+				return
+			}
+			t.app.Flash(FlashInfo, "Sent to %s", t.channel.ChannelDisplayName())
+			// Only refresh timelines that are on screen
+			if t.table != nil || t.text != nil {
+				t.reload()
+			}
+		})
+	}()
+}
+
+// slackSender returns the best name for a message's author.
+func slackSender(msg *domain.SlackMessage) string {
+	if msg.Username != "" {
+		return msg.Username
+	}
+	return msg.UserID
+}
+
+// formatSlackTime shows the time for today's messages and the date otherwise.
+func formatSlackTime(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	local := ts.Local()
+	now := time.Now()
+	if local.Year() == now.Year() && local.YearDay() == now.YearDay() {
+		return local.Format("15:04")
+	}
+	if local.Year() == now.Year() {
+		return local.Format("Jan 2 15:04")
+	}
+	return local.Format("Jan 2, 2006")
+}
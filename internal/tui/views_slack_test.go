@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	slackadapter "github.com/mqasimca/nylas/internal/adapters/slack"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/rivo/tview"
+)
+
+func TestSlackView_NotConfigured(t *testing.T) {
+	app := createTestApp(t)
+	view := NewSlackView(app)
+
+	view.Load()
+	if got := view.table.GetRowCount(); got != 0 {
+		t.Errorf("row count = %d, want 0", got)
+	}
+}
+
+func TestSlackView_Load(t *testing.T) {
+	app := createTestApp(t)
+	mock := slackadapter.NewMockClient()
+	mock.ListMyChannelsFunc = func(ctx context.Context, params *domain.SlackChannelQueryParams) (*domain.SlackChannelListResponse, error) {
+		return &domain.SlackChannelListResponse{Channels: []domain.SlackChannel{
+			{ID: "C1", Name: "general", IsChannel: true, Topic: "Company news"},
+			{ID: "C2", Name: "random", IsChannel: true},
+		}}, nil
+	}
+	app.config.Slack = mock
+
+	view := NewSlackView(app)
+	view.Load()
+	if got := view.table.GetRowCount(); got != 2 {
+		t.Fatalf("row count = %d, want 2", got)
+	}
+
+	view.Filter("news")
+	view.render()
+	if meta := view.table.SelectedMeta(); view.table.GetRowCount() != 1 || meta == nil || meta.ID != "C1" {
+		t.Errorf("filter should keep only #general, got %d rows", view.table.GetRowCount())
+	}
+}
+
+func TestSlackTimeline_FetchAndPaging(t *testing.T) {
+	app := createTestApp(t)
+	now := time.Now()
+	mock := slackadapter.NewMockClient()
+	mock.GetMessagesFunc = func(ctx context.Context, params *domain.SlackMessageQueryParams) (*domain.SlackMessageListResponse, error) {
+		// Newest first, like the Slack API
+		if params.Cursor == "" {
+			return &domain.SlackMessageListResponse{
+				Messages:   []domain.SlackMessage{{ID: "3", Timestamp: now}, {ID: "2", Timestamp: now.Add(-time.Minute)}},
+				HasMore:    true,
+				NextCursor: "older",
+			}, nil
+		}
+		return &domain.SlackMessageListResponse{
+			Messages: []domain.SlackMessage{{ID: "1", Timestamp: now.Add(-time.Hour)}},
+		}, nil
+	}
+	app.config.Slack = mock
+
+	timeline := newSlackTimeline(app, domain.SlackChannel{ID: "C1", Name: "general"})
+	timeline.table = NewTable(app.styles)
+
+	page, err := timeline.fetch(context.Background(), "")
+	if err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+	timeline.apply(page, false)
+	if !timeline.hasMore || timeline.nextCursor != "older" {
+		t.Errorf("paging state = %v, %q; want more from cursor older", timeline.hasMore, timeline.nextCursor)
+	}
+
+	page, err = timeline.fetch(context.Background(), timeline.nextCursor)
+	if err != nil {
+		t.Fatalf("fetch(older) error = %v", err)
+	}
+	timeline.apply(page, true)
+
+	var ids []string
+	for _, msg := range timeline.messages {
+		ids = append(ids, msg.ID)
+	}
+	if !slices.Equal(ids, []string{"1", "2", "3"}) {
+		t.Errorf("messages = %v, want oldest first [1 2 3]", ids)
+	}
+	if timeline.hasMore {
+		t.Error("hasMore should be false after the last page")
+	}
+}
+
+func TestSlackTimeline_FetchThread(t *testing.T) {
+	app := createTestApp(t)
+	mock := slackadapter.NewMockClient()
+	var gotTS string
+	mock.GetThreadRepliesFunc = func(ctx context.Context, channelID, threadTS string, limit int) ([]domain.SlackMessage, error) {
+		gotTS = threadTS
+		return []domain.SlackMessage{{ID: threadTS}, {ID: "reply"}}, nil
+	}
+	app.config.Slack = mock
+
+	thread := newSlackThread(app, domain.SlackChannel{ID: "C1"}, domain.SlackMessage{ID: "1700000000.000100", ReplyCount: 1})
+	page, err := thread.fetch(context.Background(), "")
+	if err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+	if gotTS != "1700000000.000100" || len(page.messages) != 2 {
+		t.Errorf("fetch() asked for thread %q and got %d messages", gotTS, len(page.messages))
+	}
+}
+
+func TestShowForm_ClosesBackToDetailPage(t *testing.T) {
+	app := createTestApp(t)
+	page := tview.NewTextView()
+	app.PushDetail("slack-C1", page)
+
+	app.ShowForm("Reply in thread", []FormField{{Key: "text", Label: "Message"}}, nil)
+	_, front := app.content.GetFrontPage()
+	form, ok := front.(*Form)
+	if !ok {
+		t.Fatalf("front page = %T, want *Form", front)
+	}
+
+	form.handleInput(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	if got := app.GetFocus(); got != page {
+		t.Errorf("focus after closing the form = %T, want the detail page", got)
+	}
+}
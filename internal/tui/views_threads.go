@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NewThreadsView creates the threads view. It lists conversations like the
+// messages view and opens them in the conversation detail view.
+func NewThreadsView(app *App) *MessagesView {
+	return newThreadListView(app, "threads", "Threads")
+}
+
+// quoteSegment is a run of body lines that is either new text or quoted history.
+type quoteSegment struct {
+	Text   string
+	Quoted bool
+	Lines  int
+}
+
+// attributionPattern matches reply attribution lines such as
+// "On Mon, Jan 2, 2025 at 10:00 AM Jane <jane@example.com> wrote:".
+var attributionPattern = regexp.MustCompile(`(?i)^on\s.+\swrote:$`)
+
+// splitQuoted splits a plain-text body into new text and quoted history.
+// Runs of ">" lines are quoted, as is everything from a reply attribution
+// or "Original Message" separator to the end of the body. A "Forwarded
+// message" separator is not a quote.
+func splitQuoted(body string) []quoteSegment {
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+
+	var segments []quoteSegment
+	var current []string
+	quoted := false
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		text := strings.Join(current, "\n")
+		if strings.TrimSpace(text) != "" {
+			segments = append(segments, quoteSegment{Text: text, Quoted: quoted, Lines: len(current)})
+		}
+		current = nil
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if isQuoteHeader(trimmed) {
+			flush()
+			quoted = true
+			current = lines[i:]
+			flush()
+			return segments
+		}
+
+		isQuote := strings.HasPrefix(trimmed, ">")
+		if isQuote != quoted && trimmed != "" {
+			flush()
+			quoted = isQuote
+		}
+		current = append(current, line)
+	}
+	flush()
+	return segments
+}
+
+// isQuoteHeader reports whether a line starts quoted history. Forwarded
+// messages are the content of the message, not history, so they stay visible.
+func isQuoteHeader(line string) bool {
+	if attributionPattern.MatchString(line) {
+		return true
+	}
+	return strings.EqualFold(strings.Trim(line, "- "), "original message")
+}
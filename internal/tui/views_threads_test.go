@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mqasimca/nylas/internal/adapters/nylas"
+	"github.com/mqasimca/nylas/internal/domain"
+	"github.com/rivo/tview"
+)
+
+func TestNewThreadsView(t *testing.T) {
+	app := createTestApp(t)
+	view := NewThreadsView(app)
+
+	if view.Name() != "threads" {
+		t.Errorf("Name() = %q, want threads", view.Name())
+	}
+	if view.Title() != "Threads" {
+		t.Errorf("Title() = %q, want Threads", view.Title())
+	}
+}
+
+func TestSplitQuoted(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []quoteSegment
+	}{
+		{
+			name: "no quotes",
+			body: "Hello\nThanks",
+			want: []quoteSegment{{Text: "Hello\nThanks", Lines: 2}},
+		},
+		{
+			name: "attribution hides the rest",
+			body: "Sounds good\n\nOn Mon, Jan 6, 2025 at 9:00 AM Jane <jane@example.com> wrote:\n> Lunch?\n> Jane",
+			want: []quoteSegment{
+				{Text: "Sounds good\n", Lines: 2},
+				{Text: "On Mon, Jan 6, 2025 at 9:00 AM Jane <jane@example.com> wrote:\n> Lunch?\n> Jane", Quoted: true, Lines: 3},
+			},
+		},
+		{
+			name: "inline quote runs",
+			body: "> Can you make it?\nYes\n> And Friday?\nNo",
+			want: []quoteSegment{
+				{Text: "> Can you make it?", Quoted: true, Lines: 1},
+				{Text: "Yes", Lines: 1},
+				{Text: "> And Friday?", Quoted: true, Lines: 1},
+				{Text: "No", Lines: 1},
+			},
+		},
+		{
+			name: "original message separator",
+			body: "FYI\n-----Original Message-----\nFrom: Bob",
+			want: []quoteSegment{
+				{Text: "FYI", Lines: 1},
+				{Text: "-----Original Message-----\nFrom: Bob", Quoted: true, Lines: 2},
+			},
+		},
+		{
+			name: "forwarded message stays visible",
+			body: "See below\n---------- Forwarded message ----------\nFrom: Bob\nHi all",
+			want: []quoteSegment{
+				{Text: "See below\n---------- Forwarded message ----------\nFrom: Bob\nHi all", Lines: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitQuoted(tt.body)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitQuoted() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("segment %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFetchThreadMessages_Pages(t *testing.T) {
+	app := createTestApp(t)
+	client := app.config.Client.(*nylas.MockClient)
+	client.GetMessagesWithCursorFunc = func(ctx context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+		if params.PageToken == "" {
+			return &domain.MessageListResponse{
+				Data:       []domain.Message{{ID: "msg-2", Date: time.Unix(200, 0)}},
+				Pagination: domain.Pagination{NextCursor: "page-2", HasMore: true},
+			}, nil
+		}
+		return &domain.MessageListResponse{Data: []domain.Message{{ID: "msg-1", Date: time.Unix(100, 0)}}}, nil
+	}
+
+	view := NewThreadsView(app)
+	messages := view.fetchThreadMessages(context.Background(), &domain.Thread{ID: "thread-1"})
+
+	if len(messages) != 2 || messages[0].ID != "msg-1" || messages[1].ID != "msg-2" {
+		t.Fatalf("fetchThreadMessages() = %d messages, want msg-1 and msg-2 from both pages", len(messages))
+	}
+}
+
+func TestConversationKeys(t *testing.T) {
+	app := createTestApp(t)
+	view := NewThreadsView(app)
+
+	conv := &conversation{
+		thread: &domain.Thread{ID: "thread-1", Subject: "Lunch"},
+		messages: []*domain.Message{
+			{ID: "msg-1", Body: "Lunch?"},
+			{ID: "msg-2", Body: "Sure\n\nOn Mon, Jan 6, 2025 at 9:00 AM Jane wrote:\n> Lunch?"},
+		},
+		selected:   1,
+		expanded:   map[string]bool{"msg-2": true},
+		showQuoted: make(map[string]bool),
+	}
+	detail := tview.NewTextView()
+	view.renderConversation(detail, conv)
+
+	key := func(r rune) {
+		view.handleConversationKey(detail, conv, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+
+	key('k')
+	if conv.selected != 0 || view.currentMessage.ID != "msg-1" {
+		t.Fatalf("k should select the previous message, selected = %d", conv.selected)
+	}
+
+	key(' ')
+	if !conv.expanded["msg-1"] {
+		t.Error("space should expand the selected message")
+	}
+
+	key('j')
+	key('z')
+	if !conv.showQuoted["msg-2"] {
+		t.Error("z should show quoted text of the selected message")
+	}
+
+	key('E')
+	if conv.expanded["msg-1"] || conv.expanded["msg-2"] {
+		t.Error("E on a fully expanded thread should collapse all messages")
+	}
+
+	key('E')
+	if !conv.expanded["msg-1"] || !conv.expanded["msg-2"] {
+		t.Error("E should expand all messages")
+	}
+}
@@ -42,16 +42,23 @@ func NewWebhooksView(app *App) *WebhooksView {
 	return v
 }
 
-func (v *WebhooksView) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	webhooks, err := v.app.config.Client.ListWebhooks(ctx)
-	if err != nil {
-		v.app.Flash(FlashError, "Failed to load webhooks: %v", err)
-		return
+func (v *WebhooksView) Load() { loadNow(v.loader()) }
+
+func (v *WebhooksView) loader() loader {
+	client := v.app.config.Client
+	return func() func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		webhooks, err := client.ListWebhooks(ctx)
+		return func() {
+			if err != nil {
+				v.app.Flash(FlashError, "Failed to load webhooks: %v", err)
+				return
+			}
+			v.webhooks = webhooks
+			v.render()
+		}
 	}
-	v.webhooks = webhooks
-	v.render()
 }
 
 func (v *WebhooksView) Refresh() { v.Load() }